@@ -35,18 +35,34 @@ func ParsePatientRefreshToken(tokenString string) (patientId int, err error) {
 	if err != nil {
 		return -1, err
 	}
-	if !token.Valid {
+	if !token.Valid || claims.PatientId == -1 { // caregiver refresh token has no patientId
 		return -1, errors.New("invalid token")
 	}
 	return claims.PatientId, nil
 }
 
+// PatientId is the active patient, a caregiver token also has the caregiver and their permissions for that patient
 type PatientAccessClaims struct {
-	PatientId int `json:"patientId"`
-	DeviceId  int `json:"deviceId"`
+	PatientId   int                         `json:"patientId"`
+	DeviceId    int                         `json:"deviceId"`
+	CaregiverId int                         `json:"caregiverId,omitempty"`
+	Permissions []model.CaregiverPermission `json:"permissions,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// patients may do everything for themselves, caregivers only what the link allows
+func (c *PatientAccessClaims) Can(permission model.CaregiverPermission) bool {
+	if c.CaregiverId == 0 {
+		return true
+	}
+	for _, p := range c.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
 func GeneratePatientAccessToken(patientId int, deviceId int) (string, error) {
 	expirationTime := time.Now().Add(24 * time.Hour)
 	claims := &PatientAccessClaims{
@@ -60,6 +76,52 @@ func GeneratePatientAccessToken(patientId int, deviceId int) (string, error) {
 	return token.SignedString([]byte(config.AppConfig.JWT_KEY))
 }
 
+func GenerateCaregiverAccessToken(caregiverId int, patientId int, deviceId int, permissions []model.CaregiverPermission) (string, error) {
+	expirationTime := time.Now().Add(24 * time.Hour)
+	claims := &PatientAccessClaims{
+		PatientId:   patientId,
+		DeviceId:    deviceId,
+		CaregiverId: caregiverId,
+		Permissions: permissions,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(config.AppConfig.JWT_KEY))
+}
+
+type CaregiverRefreshClaims struct {
+	CaregiverId int `json:"caregiverId"`
+	jwt.RegisteredClaims
+}
+
+func GenerateCaregiverRefreshToken(caregiverId int) (string, error) {
+	expirationTime := time.Now().Add(30 * 24 * time.Hour)
+	claims := &CaregiverRefreshClaims{
+		CaregiverId: caregiverId,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(config.AppConfig.JWT_REFRESH_KEY))
+}
+
+func ParseCaregiverRefreshToken(tokenString string) (caregiverId int, err error) {
+	claims := &CaregiverRefreshClaims{CaregiverId: -1}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(config.AppConfig.JWT_REFRESH_KEY), nil
+	})
+	if err != nil {
+		return -1, err
+	}
+	if !token.Valid || claims.CaregiverId == -1 { // patient refresh token has no caregiverId
+		return -1, errors.New("invalid token")
+	}
+	return claims.CaregiverId, nil
+}
+
 type DoctorClaims struct {
 	DoctorId int        `json:"doctorId"`
 	Role     model.Role `json:"role"`
@@ -87,3 +149,50 @@ func HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	return string(bytes), err
 }
+
+// owner of calendar feed
+type CalendarOwner string
+
+const (
+	CALENDAR_DOCTOR  CalendarOwner = "doctor"
+	CALENDAR_PATIENT CalendarOwner = "patient"
+)
+
+type CalendarFeedClaims struct {
+	Owner   CalendarOwner `json:"owner"`
+	OwnerId int           `json:"ownerId"`
+	Version int           `json:"version"`
+	jwt.RegisteredClaims
+}
+
+/*
+feed token doesn't expire since calendar apps keep subscribed url, rotate the owner's feed version to revoke
+the owner's tokens or rotate CALENDAR_FEED_KEY to revoke all tokens
+*/
+func GenerateCalendarFeedToken(owner CalendarOwner, ownerId int, version int) (string, error) {
+	claims := &CalendarFeedClaims{
+		Owner:   owner,
+		OwnerId: ownerId,
+		Version: version,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(config.AppConfig.CALENDAR_FEED_KEY))
+}
+
+// the version must be checked against the owner's current feed version
+func ParseCalendarFeedToken(tokenString string, owner CalendarOwner) (ownerId int, version int, err error) {
+	claims := &CalendarFeedClaims{OwnerId: -1}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(config.AppConfig.CALENDAR_FEED_KEY), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return -1, 0, err
+	}
+	if !token.Valid || claims.Owner != owner {
+		return -1, 0, errors.New("invalid token")
+	}
+	return claims.OwnerId, claims.Version, nil
+}
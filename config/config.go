@@ -16,13 +16,33 @@ type config struct {
 	JWT_KEY                string
 	JWT_REFRESH_KEY        string
 	MAX_DEVICE             int
-	NOTIFY_IN_RANGE        int
 	NOTIFY_SECRET          string
 	ENABLE_CRON            bool
 	CORS_ALLOW             []string
 	REQUIRE_MOBILE_VERSION string
 	ANDROID_STORE_LINK     string
 	IOS_STORE_LINK         string
+	CLINIC_TIMEZONE        string
+	CALENDAR_FEED_KEY      string
+	REMINDER_CRON_SPEC     string
+	OUTBOX_CRON_SPEC       string
+	VACCINE_CRON_SPEC      string
+	OUTBOX_MAX_ATTEMPTS    int
+	EXPO_HOST              string
+	EXPO_ACCESS_TOKEN      string
+	SMTP_HOST              string
+	SMTP_PORT              int
+	SMTP_USERNAME          string
+	SMTP_PASSWORD          string
+	SMTP_FROM              string
+	SMS_GATEWAY_URL        string
+	SMS_GATEWAY_TOKEN      string
+	NOTIFICATION_FALLBACK  []string
+	GCS_PRIVATE_BUCKET     string
+	MAX_ATTACHMENT_SIZE_MB int
+	ATTACHMENT_URL_TTL     int
+	QUESTION_SLA_HOURS     int
+	REQUIRE_INVITATION     bool
 }
 
 // shared config across packages
@@ -33,13 +53,33 @@ var defaultConfig = config{
 	JWT_KEY:                "SAMPLE_KEY",
 	JWT_REFRESH_KEY:        "REFRESH_KEY",
 	MAX_DEVICE:             3,
-	NOTIFY_IN_RANGE:        3,
 	NOTIFY_SECRET:          "SAMPLE_SECRET",
 	ENABLE_CRON:            false,
 	CORS_ALLOW:             []string{"http://localhost:5173", "http://localhost:4173", "https://duchenne-web.onrender.com"},
 	REQUIRE_MOBILE_VERSION: "0.0.0",
 	ANDROID_STORE_LINK:     "https://play.google.com",
 	IOS_STORE_LINK:         "https://apps.apple.com/",
+	CLINIC_TIMEZONE:        "Asia/Bangkok",
+	CALENDAR_FEED_KEY:      "CALENDAR_KEY",
+	REMINDER_CRON_SPEC:     "0 */15 * * * *",
+	OUTBOX_CRON_SPEC:       "30 * * * * *",
+	VACCINE_CRON_SPEC:      "0 0 9 * * *", // once a day, doses are due by the date
+	OUTBOX_MAX_ATTEMPTS:    5,
+	EXPO_HOST:              "https://exp.host",
+	EXPO_ACCESS_TOKEN:      "",
+	SMTP_HOST:              "", // empty disables email channel
+	SMTP_PORT:              587,
+	SMTP_USERNAME:          "",
+	SMTP_PASSWORD:          "",
+	SMTP_FROM:              "",
+	SMS_GATEWAY_URL:        "", // empty disables sms channel
+	SMS_GATEWAY_TOKEN:      "",
+	NOTIFICATION_FALLBACK:  []string{"appointment=expo,sms,email", "reminder=expo,sms", "question=expo,email", "content=expo", "general=expo", "medication=expo", "vaccine=expo,sms,email", "account=expo,sms,email"},
+	GCS_PRIVATE_BUCKET:     "dmd-we-care-private", // not publicly readable, files are read by signed urls
+	MAX_ATTACHMENT_SIZE_MB: 10,
+	ATTACHMENT_URL_TTL:     300, // seconds
+	QUESTION_SLA_HOURS:     24,  // questions waiting for a doctor reply longer than this are flagged
+	REQUIRE_INVITATION:     false,
 }
 
 func LoadConfig() {
@@ -86,6 +126,10 @@ func LoadConfig() {
 			fmt.Printf("\t%-15s\t=>\t%-10v\n", fieldName, f.Field(i).Interface())
 		})
 	}
+	// anyone could sign feed tokens with the key from the source code
+	if AppConfig.MODE != "dev" && AppConfig.CALENDAR_FEED_KEY == defaultConfig.CALENDAR_FEED_KEY {
+		panic("CALENDAR_FEED_KEY must be set outside dev mode")
+	}
 	configLogger.Printf("config loaded\n")
 }
 
@@ -0,0 +1,64 @@
+package common
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/auth"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/calendar"
+	"github.com/gin-gonic/gin"
+)
+
+// past appointments in the feed, older ones are already settled in subscribed calendars
+const CALENDAR_FEED_HISTORY = 90 * 24 * 60 * 60
+
+func (c *CommonHandler) GetDoctorCalendarFeed(ctx *gin.Context) {
+	c.calendarFeed(ctx, auth.CALENDAR_DOCTOR)
+}
+
+func (c *CommonHandler) GetPatientCalendarFeed(ctx *gin.Context) {
+	c.calendarFeed(ctx, auth.CALENDAR_PATIENT)
+}
+
+func (c *CommonHandler) calendarFeed(ctx *gin.Context, owner auth.CalendarOwner) {
+	token := strings.TrimSuffix(ctx.Param("token"), ".ics")
+	ownerId, version, err := auth.ParseCalendarFeedToken(token, owner)
+	if err != nil {
+		ctx.Status(http.StatusUnauthorized)
+		return
+	}
+	current, err := c.Repo.GetCalendarFeedVersion(string(owner), ownerId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	// the owner rotated the feed after this url was given
+	if version != current {
+		ctx.Status(http.StatusUnauthorized)
+		return
+	}
+	ownerCriteria := repository.Criteria{QueryCriteria: repository.DOCTORID, Value: ownerId}
+	if owner == auth.CALENDAR_PATIENT {
+		ownerCriteria = repository.Criteria{QueryCriteria: repository.PATIENTID, Value: ownerId}
+	}
+	// cancelled appointments are included so subscribed calendars remove them, limit = -1 means no limit
+	aps, err := c.Repo.GetAllAppointment(-1, 0,
+		ownerCriteria,
+		repository.Criteria{QueryCriteria: repository.DATE_GREATERTHAN, Value: int(time.Now().Unix()) - CALENDAR_FEED_HISTORY},
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	events := []calendar.Event{}
+	for _, ap := range aps {
+		summary := calendar.SummaryForDoctor(ap)
+		if owner == auth.CALENDAR_PATIENT {
+			summary = calendar.SummaryForPatient(ap)
+		}
+		events = append(events, calendar.AppointmentEvent(ap, summary))
+	}
+	ctx.Data(http.StatusOK, calendar.CONTENT_TYPE, []byte(calendar.Build("DMD We Care", calendar.METHOD_PUBLISH, events)))
+}
@@ -0,0 +1,94 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/PhasitWo/duchenne-server/middleware"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/chart"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// chart series of the patient's own measures
+func (c *CommonHandler) GetPatientChart(ctx *gin.Context) {
+	i, exists := ctx.Get("patientId")
+	if !exists {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	c.measurementChart(ctx, i.(int))
+}
+
+// chart series of measures of the patient in the url
+func (c *CommonHandler) GetDoctorChart(ctx *gin.Context) {
+	patientId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !middleware.CanViewPatient(ctx, c.Repo, patientId) {
+		return
+	}
+	c.measurementChart(ctx, patientId)
+}
+
+/*
+query 'type' can be repeated for several measures, all measures by default,
+'from' and 'to' limit the range, 'reference=true' adds reference percentiles
+*/
+func (c *CommonHandler) measurementChart(ctx *gin.Context, patientId int) {
+	types := map[model.MeasurementType]bool{}
+	for _, t := range ctx.QueryArray("type") {
+		if !model.MeasurementType(t).IsValid() {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid type value"})
+			return
+		}
+		types[model.MeasurementType(t)] = true
+	}
+	criteriaList := []repository.Criteria{}
+	if f, exist := ctx.GetQuery("from"); exist {
+		from, err := strconv.Atoi(f)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse from value"})
+			return
+		}
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.MEASURED_AFTER, Value: from - 1})
+	}
+	if t, exist := ctx.GetQuery("to"); exist {
+		to, err := strconv.Atoi(t)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse to value"})
+			return
+		}
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.MEASURED_BEFORE, Value: to + 1})
+	}
+	withReference := ctx.Query("reference") == "true"
+	patient, err := c.Repo.GetPatientById(patientId)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	measurements, err := c.Repo.GetAllMeasurement(patientId, criteriaList...)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(types) > 0 {
+		selected := []model.Measurement{}
+		for _, m := range measurements {
+			if types[m.Type] {
+				selected = append(selected, m)
+			}
+		}
+		measurements = selected
+	}
+	ctx.JSON(http.StatusOK, chart.NewCharts(patient.BirthDate, measurements, withReference))
+}
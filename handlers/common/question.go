@@ -0,0 +1,166 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/config"
+	"github.com/PhasitWo/duchenne-server/middleware"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// photo or document of the patient's own question, uploaded as multipart field 'file'
+func (c *CommonHandler) UploadQuestionAttachment(ctx *gin.Context) {
+	i, exists := ctx.Get("patientId")
+	if !exists {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	patientId := i.(int)
+	q, err := c.Repo.GetQuestion(ctx.Param("id"))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if patientId != q.PatientID {
+		ctx.Status(http.StatusUnauthorized)
+		return
+	}
+	if q.ClosedAt != nil {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "question is closed"})
+		return
+	}
+	maxSize := int64(config.AppConfig.MAX_ATTACHMENT_SIZE_MB) * 1024 * 1024
+	// stop reading huge bodies early, leave room for the other multipart parts
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxSize+1024*1024)
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("file is exceeding %d MB", config.AppConfig.MAX_ATTACHMENT_SIZE_MB)})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if file.Size > maxSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("file is exceeding %d MB", config.AppConfig.MAX_ATTACHMENT_SIZE_MB)})
+		return
+	}
+	// trust the content, not the name or header from the phone
+	contentType, err := detectContentType(file)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ext, ok := model.ATTACHMENT_CONTENT_TYPES[contentType]
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid content type: %v", contentType)})
+		return
+	}
+	objectName := fmt.Sprintf("questions/%d/%d%s", q.ID, time.Now().UnixNano(), ext)
+	if err := c.CloudStorageService.UploadPrivateFile(file, objectName, contentType); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("error uploading file: %v", err.Error())})
+		return
+	}
+	insertedId, err := c.Repo.CreateQuestionAttachment(model.QuestionAttachment{
+		QuestionID:   q.ID,
+		UploaderType: model.ACTOR_PATIENT,
+		UploaderID:   patientId,
+		FileName:     file.Filename,
+		ContentType:  contentType,
+		Size:         file.Size,
+		ObjectName:   objectName,
+	})
+	if err != nil {
+		// don't keep a file that nobody can find
+		c.CloudStorageService.DeletePrivateFile(objectName)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"id": insertedId})
+}
+
+// download url of an attachment of the patient's own question
+func (c *CommonHandler) GetPatientQuestionAttachmentURL(ctx *gin.Context) {
+	i, exists := ctx.Get("patientId")
+	if !exists {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	q, err := c.Repo.GetQuestion(ctx.Param("id"))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if i.(int) != q.PatientID {
+		ctx.Status(http.StatusUnauthorized)
+		return
+	}
+	c.attachmentURL(ctx)
+}
+
+// download url of an attachment for doctors who can see the patient of the question
+func (c *CommonHandler) GetDoctorQuestionAttachmentURL(ctx *gin.Context) {
+	q, err := c.Repo.GetQuestion(ctx.Param("id"))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !middleware.CanViewPatient(ctx, c.Repo, q.PatientID) {
+		return
+	}
+	c.attachmentURL(ctx)
+}
+
+func (c *CommonHandler) attachmentURL(ctx *gin.Context) {
+	attachment, err := c.Repo.GetQuestionAttachment(ctx.Param("id"), ctx.Param("attachmentId"))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	expires := time.Now().Add(time.Duration(config.AppConfig.ATTACHMENT_URL_TTL) * time.Second)
+	url, err := c.CloudStorageService.SignedURL(attachment.ObjectName, expires)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, model.AttachmentURLResponse{URL: url, ExpireAt: int(expires.Unix())})
+}
+
+func detectContentType(file *multipart.FileHeader) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", errors.New("error opening file")
+	}
+	defer src.Close()
+	head := make([]byte, 512)
+	n, err := src.Read(head)
+	if err != nil && n == 0 {
+		return "", errors.New("error reading file")
+	}
+	contentType, _, _ := strings.Cut(http.DetectContentType(head[:n]), ";")
+	return contentType, nil
+}
@@ -0,0 +1,76 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/middleware"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/schedule"
+	"github.com/PhasitWo/duchenne-server/services/vaccination"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// overdue vaccines of the patient and the ones due in the next 'within' days
+func (c *CommonHandler) GetPatientDueVaccine(ctx *gin.Context) {
+	i, exists := ctx.Get("patientId")
+	if !exists {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	c.dueVaccine(ctx, i.(int))
+}
+
+// overdue vaccines of the patient in the url and the ones due in the next 'within' days
+func (c *CommonHandler) GetDoctorDueVaccine(ctx *gin.Context) {
+	patientId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !middleware.CanViewPatient(ctx, c.Repo, patientId) {
+		return
+	}
+	c.dueVaccine(ctx, patientId)
+}
+
+func (c *CommonHandler) dueVaccine(ctx *gin.Context, patientId int) {
+	within := 90
+	if w, exist := ctx.GetQuery("within"); exist {
+		var err error
+		within, err = strconv.Atoi(w)
+		if err != nil || within < 0 || within > 365 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid within value"})
+			return
+		}
+	}
+	patient, err := c.Repo.GetPatientById(patientId)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	rules, err := c.Repo.GetAllVaccineRule(repository.Criteria{QueryCriteria: repository.IS_ENABLED, Value: true})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	records, err := c.Repo.GetAllVaccination(patientId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	regimens, err := c.Repo.GetAllMedicationRegimen(patientId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	now := int(time.Now().Unix())
+	ctx.JSON(http.StatusOK, vaccination.DueVaccines(patient, rules, records, regimens, now, within*24*60*60, schedule.Location()))
+}
@@ -2,11 +2,15 @@ package mobile
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/PhasitWo/duchenne-server/model"
 	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/calendar"
+	"github.com/PhasitWo/duchenne-server/services/schedule"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
@@ -59,6 +63,35 @@ func (m *MobileHandler) GetAppointment(c *gin.Context) {
 	c.JSON(http.StatusOK, ap)
 }
 
+// single appointment file for adding to phone calendar, cancelled appointment is sent as METHOD:CANCEL
+func (m *MobileHandler) GetAppointmentICS(c *gin.Context) {
+	i, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	patientId := i.(int)
+	id := c.Param("id")
+	ap, err := m.Repo.GetAppointment(id)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	// check if this appointment belongs to the patient
+	if patientId != ap.Patient.ID {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	event := calendar.AppointmentEvent(ap, calendar.SummaryForPatient(ap))
+	ics := calendar.Build("DMD We Care", calendar.AppointmentMethod(ap), []calendar.Event{event})
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=appointment-%d.ics", ap.ID))
+	c.Data(http.StatusOK, calendar.CONTENT_TYPE, []byte(ics))
+}
+
 func (m *MobileHandler) CreateAppointment(c *gin.Context) {
 	// get patientId from auth header
 	i, exists := c.Get("patientId")
@@ -79,17 +112,54 @@ func (m *MobileHandler) CreateAppointment(c *gin.Context) {
 		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "'Date' is before current time"})
 		return
 	}
+	// input.date must match a free slot of the doctor
+	slots, err := m.getFreeSlots(input.DoctorId, input.Date, input.Date+24*60*60)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid doctorId"})
+			return
+		}
+		if err == errDoctorCannotBeAppointed {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	slot, found := schedule.FindSlot(slots, input.Date)
+	if !found {
+		c.JSON(http.StatusConflict, gin.H{"error": "'Date' doesn't match any free slot of this doctor"})
+		return
+	}
 	// create new appointment
 	insertedId, err := m.Repo.CreateAppointment(model.Appointment{
-		Date: input.Date,
+		Date:      input.Date,
+		Duration:  (slot.End - slot.Start) / 60,
 		PatientID: patientId,
-		DoctorID: input.DoctorId,
+		DoctorID:  input.DoctorId,
+		Status:    model.REQUESTED,
 		ApproveAt: nil,
 	})
 	if err != nil {
+		var conflictErr *repository.ErrAppointmentConflict
+		if errors.As(err, &conflictErr) {
+			// don't expose appointments of other patients
+			conflict := model.AppointmentConflict{
+				Date:     conflictErr.Appointment.Date,
+				Duration: conflictErr.Appointment.Duration,
+				DoctorID: conflictErr.Appointment.DoctorID,
+			}
+			if conflictErr.Appointment.PatientID == patientId {
+				conflict.ID = conflictErr.Appointment.ID
+				conflict.PatientID = patientId
+			}
+			c.JSON(http.StatusConflict, gin.H{"error": "appointment overlaps another appointment", "conflict": conflict})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	go m.NotiService.NotifyCareTeam(patientId, model.AppointmentLink(insertedId))
 	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
 }
 
@@ -116,11 +186,95 @@ func (m *MobileHandler) DeleteAppointment(c *gin.Context) {
 		c.Status(http.StatusUnauthorized)
 		return
 	}
-	// delete appointment
-	err = m.Repo.DeleteAppointment(id)
+	// cancel appointment, the record is kept for reporting
+	err = m.Repo.ChangeAppointmentStatus(ap.ID, model.AppointmentStatusChange{
+		Status:    model.CANCELLED_BY_PATIENT,
+		ActorType: model.ACTOR_PATIENT,
+		ActorID:   &patientId,
+	})
 	if err != nil {
+		if errors.Unwrap(err) == repository.ErrInvalidStatusTransition {
+			c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("can't cancel %v appointment", ap.Status)})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
+
+func (m *MobileHandler) RequestReschedule(c *gin.Context) {
+	// prepare param from url and auth middleware
+	i, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	patientId := i.(int)
+	var input model.PatientRescheduleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// validate input.date
+	now := int(time.Now().Add(3 * time.Minute).Unix())
+	if input.Date < now {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "'Date' is before current time"})
+		return
+	}
+	// check if this appointment belongs to the patient
+	ap, err := m.Repo.GetAppointment(id)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if patientId != ap.Patient.ID {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+	if !ap.Status.CanTransitionTo(model.RESCHEDULED) {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("can't reschedule %v appointment", ap.Status)})
+		return
+	}
+	if input.Date == ap.Date {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "'Date' is the same as current appointment date"})
+		return
+	}
+	// the proposed date must match a free slot of the doctor
+	slots, err := m.getFreeSlots(ap.DoctorID, input.Date, input.Date+24*60*60)
+	if err != nil {
+		if err == errDoctorCannotBeAppointed {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if _, found := schedule.FindSlot(slots, input.Date); !found {
+		c.JSON(http.StatusConflict, gin.H{"error": "'Date' doesn't match any free slot of this doctor"})
+		return
+	}
+	insertedId, err := m.Repo.CreateRescheduleRequest(model.RescheduleRequest{
+		AppointmentID: ap.ID,
+		ProposedDate:  input.Date,
+		Reason:        input.Reason,
+	})
+	if err != nil {
+		if errors.Unwrap(err) == repository.ErrDuplicateEntry {
+			c.JSON(http.StatusConflict, gin.H{"error": "this appointment already has a pending reschedule request"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
+}
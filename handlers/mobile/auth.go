@@ -37,16 +37,24 @@ func (m *MobileHandler) Refresh(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	// checking
-	if !storedPatient.Verified {
-		c.JSON(http.StatusForbidden, gin.H{"error": "unverified account"})
-		return
-	}
 	// verify password
 	if err := auth.VerifyPassword(storedPatient.Password, input.Password); err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credential"})
 		return
 	}
+	// checking, the reject reason is only shown to the applicant
+	switch storedPatient.VerificationStatus {
+	case model.SIGNUP_PENDING:
+		c.JSON(http.StatusForbidden, gin.H{"error": "signup is waiting for verification", "verificationStatus": storedPatient.VerificationStatus})
+		return
+	case model.SIGNUP_REJECTED:
+		c.JSON(http.StatusForbidden, gin.H{"error": "signup was rejected", "verificationStatus": storedPatient.VerificationStatus, "reason": storedPatient.RejectReason})
+		return
+	}
+	if !storedPatient.Verified {
+		c.JSON(http.StatusForbidden, gin.H{"error": "unverified account"})
+		return
+	}
 	// generate refresh token
 	token, err := auth.GeneratePatientRefreshToken(storedPatient.ID)
 	if err != nil {
@@ -90,9 +98,11 @@ func (m *MobileHandler) Login(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credential"})
 		return
 	}
-	// save this device for notification stuff
-	criteria := repository.Criteria{QueryCriteria: repository.PATIENTID, Value: patientId}
-	devices, err := m.Repo.GetAllDevice(criteria)
+	// save this device for notification stuff, caregiver devices of the patient are not counted
+	devices, err := m.Repo.GetAllDevice(
+		repository.Criteria{QueryCriteria: repository.PATIENTID, Value: patientId},
+		repository.Criteria{QueryCriteria: repository.CAREGIVERID_ISNULL},
+	)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -160,6 +170,8 @@ type signupRequest struct {
 	Email      *string `json:"email"`
 	BirthDate  int     `json:"birthDate"`
 	Pin        string  `json:"pin" binding:"required,len=6"`
+	// one-time code from the clinic, required when REQUIRE_INVITATION is set
+	InvitationCode string `json:"invitationCode" binding:"omitempty,max=16"`
 }
 
 func (m *MobileHandler) Signup(c *gin.Context) {
@@ -168,6 +180,10 @@ func (m *MobileHandler) Signup(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if s.InvitationCode == "" && config.AppConfig.REQUIRE_INVITATION {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invitation code is required"})
+		return
+	}
 	hashedPassword, err := auth.HashPassword(s.Password)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -178,33 +194,57 @@ func (m *MobileHandler) Signup(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	// save new patient to database
-	newId, err := m.Repo.CreatePatient(model.Patient{
-		NID:            s.NID,
-		Password:       hashedPassword,
-		Hn:             s.Hn,
-		Pin:            hashedPin,
-		FirstName:      s.FirstName,
-		MiddleName:     s.MiddleName,
-		LastName:       s.LastName,
-		Phone:          s.Phone,
-		Email:          s.Email,
-		Weight:         nil,
-		Height:         nil,
-		BirthDate:      s.BirthDate,
-		VaccineHistory: nil,
-		Medicine:       nil,
-		Verified:       true,
-	})
+	// save new patient to database, staff verify the signup before the patient can log in
+	newPatient := model.Patient{
+		NID:                s.NID,
+		Password:           hashedPassword,
+		Hn:                 s.Hn,
+		Pin:                hashedPin,
+		FirstName:          s.FirstName,
+		MiddleName:         s.MiddleName,
+		LastName:           s.LastName,
+		Phone:              s.Phone,
+		Email:              s.Email,
+		Weight:             nil,
+		Height:             nil,
+		BirthDate:          s.BirthDate,
+		VaccineHistory:     nil,
+		Medicine:           nil,
+		Verified:           false,
+		VerificationStatus: model.SIGNUP_PENDING,
+	}
+	var newId int
+	if s.InvitationCode != "" {
+		var invitation model.InvitationCode
+		invitation, err = m.Repo.GetInvitationCode(s.InvitationCode)
+		if err != nil {
+			if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+				c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid invitation code"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !invitation.Valid(s.Hn, int(time.Now().Unix())) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid invitation code"})
+			return
+		}
+		newId, err = m.Repo.CreatePatientWithInvitation(newPatient, invitation.ID)
+	} else {
+		newId, err = m.Repo.CreatePatient(newPatient)
+	}
 	if err != nil {
-		if errors.Unwrap(err) == repository.ErrDuplicateEntry {
+		switch errors.Unwrap(err) {
+		case repository.ErrDuplicateEntry:
 			c.JSON(http.StatusConflict, gin.H{"error": "duplicate HN or NID"})
-			return
+		case repository.ErrInvitationUsed:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid invitation code"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusCreated, gin.H{"id": newId})
+	c.JSON(http.StatusCreated, gin.H{"id": newId, "verificationStatus": model.SIGNUP_PENDING})
 }
 
 func (m *MobileHandler) Logout(c *gin.Context) {
@@ -0,0 +1,354 @@
+package mobile
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/auth"
+	"github.com/PhasitWo/duchenne-server/config"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func (m *MobileHandler) CaregiverSignup(c *gin.Context) {
+	var s model.CaregiverSignupRequest
+	if err := c.ShouldBindJSON(&s); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	hashedPassword, err := auth.HashPassword(s.Password)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	hashedPin, err := auth.HashPassword(s.Pin)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	newId, err := m.Repo.CreateCaregiver(model.Caregiver{
+		NID:        s.NID,
+		Password:   hashedPassword,
+		Pin:        hashedPin,
+		FirstName:  s.FirstName,
+		MiddleName: s.MiddleName,
+		LastName:   s.LastName,
+		Phone:      s.Phone,
+		Email:      s.Email,
+	})
+	if err != nil {
+		if errors.Unwrap(err) == repository.ErrDuplicateEntry {
+			c.JSON(http.StatusConflict, gin.H{"error": "duplicate NID"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": newId})
+}
+
+func (m *MobileHandler) CaregiverRefresh(c *gin.Context) {
+	var input refreshRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	caregiver, err := m.Repo.GetCaregiverByNID(input.NID)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := auth.VerifyPassword(caregiver.Password, input.Password); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credential"})
+		return
+	}
+	token, err := auth.GenerateCaregiverRefreshToken(caregiver.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"refreshToken": token})
+}
+
+type caregiverLoginRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+	Pin          string `json:"pin" binding:"required,len=6"`
+	DeviceName   string `json:"deviceName" binding:"required"`
+	ExpoToken    string `json:"expoToken" binding:"required"`
+	PatientID    int    `json:"patientId"` // optional, the first linked patient by default
+}
+
+// login of a caregiver on a device, the access token acts for one linked patient at a time
+func (m *MobileHandler) CaregiverLogin(c *gin.Context) {
+	var input caregiverLoginRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	caregiverId, err := auth.ParseCaregiverRefreshToken(input.RefreshToken)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	caregiver, err := m.Repo.GetCaregiverById(caregiverId)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := auth.VerifyPassword(caregiver.Pin, input.Pin); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credential"})
+		return
+	}
+	links, err := m.Repo.GetAllCaregiverPatient(caregiverId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(links) == 0 {
+		c.JSON(http.StatusForbidden, gin.H{"error": "no linked patient"})
+		return
+	}
+	link := links[0].CaregiverLink
+	if input.PatientID != 0 {
+		found := false
+		for _, l := range links {
+			if l.PatientID == input.PatientID {
+				link, found = l.CaregiverLink, true
+				break
+			}
+		}
+		if !found {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "patient isn't linked to this caregiver"})
+			return
+		}
+	}
+	// save this device for notification stuff, devices are counted per caregiver
+	devices, err := m.Repo.GetAllDevice(repository.Criteria{QueryCriteria: repository.CAREGIVERID, Value: caregiverId})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	newDevice := model.Device{
+		LoginAt:     int(time.Now().Unix()),
+		DeviceName:  input.DeviceName,
+		ExpoToken:   input.ExpoToken,
+		PatientId:   link.PatientID,
+		CaregiverID: &caregiverId,
+	}
+	tx := m.DBConn.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	repoWithTx := m.Repo.New(tx)
+	if len(devices) >= config.AppConfig.MAX_DEVICE {
+		// remove the oldest login device
+		err = repoWithTx.DeleteDevice(devices[0].ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	deviceId, err := repoWithTx.CreateDevice(newDevice)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	accessToken, err := auth.GenerateCaregiverAccessToken(caregiverId, link.PatientID, deviceId, link.Permissions)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	refreshToken, err := auth.GenerateCaregiverRefreshToken(caregiverId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Tx can't commit"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"accessToken": accessToken, "refreshToken": refreshToken, "patientId": link.PatientID})
+}
+
+// patients the caregiver can switch to
+func (m *MobileHandler) GetCaregiverPatient(c *gin.Context) {
+	cg, exists := c.Get("caregiverId")
+	if !exists {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only for caregivers"})
+		return
+	}
+	links, err := m.Repo.GetAllCaregiverPatient(cg.(int))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, links)
+}
+
+// issue an access token for another linked patient, the device follows the active patient
+func (m *MobileHandler) SwitchPatient(c *gin.Context) {
+	cg, exists := c.Get("caregiverId")
+	if !exists {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only for caregivers"})
+		return
+	}
+	d, exists := c.Get("deviceId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'deviceId' from auth middleware"})
+		return
+	}
+	caregiverId, deviceId := cg.(int), d.(int)
+	var input model.SwitchPatientRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	link, err := m.Repo.GetCaregiverLink(caregiverId, input.PatientID)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // not linked
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := m.Repo.UpdateDevice(model.Device{ID: deviceId, PatientId: link.PatientID}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	accessToken, err := auth.GenerateCaregiverAccessToken(caregiverId, link.PatientID, deviceId, link.Permissions)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"accessToken": accessToken, "patientId": link.PatientID})
+}
+
+// caregivers linked to the patient
+func (m *MobileHandler) GetAllCaregiver(c *gin.Context) {
+	i, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	links, err := m.Repo.GetAllCaregiverLink(i.(int))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, links)
+}
+
+// the patient account links a caregiver by their NID
+func (m *MobileHandler) LinkCaregiver(c *gin.Context) {
+	i, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	patientId := i.(int)
+	var input model.CaregiverLinkRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	caregiver, err := m.Repo.GetCaregiverByNID(input.NID)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "caregiver doesn't exist"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	insertedId, err := m.Repo.CreateCaregiverLink(model.CaregiverLink{
+		CaregiverID:  caregiver.ID,
+		PatientID:    patientId,
+		Relationship: input.Relationship,
+		Permissions:  caregiverPermissions(input.Permissions),
+	})
+	if err != nil {
+		if errors.Unwrap(err) == repository.ErrDuplicateEntry {
+			c.JSON(http.StatusConflict, gin.H{"error": "this caregiver is already linked"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
+}
+
+func (m *MobileHandler) UpdateCaregiverLink(c *gin.Context) {
+	var input model.UpdateCaregiverLinkRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	link, ok := m.patientCaregiverLink(c)
+	if !ok {
+		return
+	}
+	link.Relationship = input.Relationship
+	link.Permissions = caregiverPermissions(input.Permissions)
+	if err := m.Repo.UpdateCaregiverLink(link); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (m *MobileHandler) UnlinkCaregiver(c *gin.Context) {
+	link, ok := m.patientCaregiverLink(c)
+	if !ok {
+		return
+	}
+	if err := m.Repo.DeleteCaregiverLink(link); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// link of the caregiver in the url to the patient of the token
+func (m *MobileHandler) patientCaregiverLink(c *gin.Context) (model.CaregiverLink, bool) {
+	i, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return model.CaregiverLink{}, false
+	}
+	link, err := m.Repo.GetCaregiverLink(c.Param("id"), i.(int))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return link, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return link, false
+	}
+	return link, true
+}
+
+// no permission is stored as an empty list instead of null
+func caregiverPermissions(permissions []model.CaregiverPermission) []model.CaregiverPermission {
+	if permissions == nil {
+		return []model.CaregiverPermission{}
+	}
+	return permissions
+}
@@ -18,8 +18,15 @@ func (m *MobileHandler) GetAllDevice(c *gin.Context) {
 		return
 	}
 	id := i.(int)
-	criteria := repository.Criteria{QueryCriteria: repository.PATIENTID, Value: id}
-	dv, err := m.Repo.GetAllDevice(criteria)
+	// patients see their own devices, caregivers see theirs
+	criteria := []repository.Criteria{
+		{QueryCriteria: repository.PATIENTID, Value: id},
+		{QueryCriteria: repository.CAREGIVERID_ISNULL},
+	}
+	if cg, isCaregiver := c.Get("caregiverId"); isCaregiver {
+		criteria = []repository.Criteria{{QueryCriteria: repository.CAREGIVERID, Value: cg.(int)}}
+	}
+	dv, err := m.Repo.GetAllDevice(criteria...)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -52,8 +59,25 @@ func (m *MobileHandler) CreateDevice(c *gin.Context) {
 		ExpoToken:  dv.ExpoToken,
 		PatientId:  id,
 	}
-	criteria := repository.Criteria{QueryCriteria: repository.PATIENTID, Value: id}
-	devices, err := m.Repo.GetAllDevice(criteria)
+	criteria := []repository.Criteria{
+		{QueryCriteria: repository.PATIENTID, Value: id},
+		{QueryCriteria: repository.CAREGIVERID_ISNULL},
+	}
+	// a caregiver device is counted per caregiver and keeps a caregiver token
+	var link model.CaregiverLink
+	cg, isCaregiver := c.Get("caregiverId")
+	if isCaregiver {
+		caregiverId := cg.(int)
+		var err error
+		link, err = m.Repo.GetCaregiverLink(caregiverId, id)
+		if err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": "patient isn't linked to this caregiver"})
+			return
+		}
+		newDevice.CaregiverID = &caregiverId
+		criteria = []repository.Criteria{{QueryCriteria: repository.CAREGIVERID, Value: caregiverId}}
+	}
+	devices, err := m.Repo.GetAllDevice(criteria...)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -85,12 +109,18 @@ func (m *MobileHandler) CreateDevice(c *gin.Context) {
 		return
 	}
 	// generate token
-	accessToken, err := auth.GeneratePatientAccessToken(id, deviceId)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+	var accessToken, refreshToken string
+	if isCaregiver {
+		accessToken, err = auth.GenerateCaregiverAccessToken(link.CaregiverID, id, deviceId, link.Permissions)
+		if err == nil {
+			refreshToken, err = auth.GenerateCaregiverRefreshToken(link.CaregiverID)
+		}
+	} else {
+		accessToken, err = auth.GeneratePatientAccessToken(id, deviceId)
+		if err == nil {
+			refreshToken, err = auth.GeneratePatientRefreshToken(id)
+		}
 	}
-	refreshToken, err := auth.GeneratePatientRefreshToken(id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -4,15 +4,17 @@ import (
 	// "database/sql"
 
 	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/notification"
 	_ "github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
 )
 
 type MobileHandler struct {
-	Repo   repository.IRepo
-	DBConn repository.IGorm
+	Repo        repository.IRepo
+	DBConn      repository.IGorm
+	NotiService notification.INotificationService
 }
 
 func Init(db *gorm.DB) *MobileHandler {
-	return &MobileHandler{Repo: repository.New(db), DBConn: db}
+	return &MobileHandler{Repo: repository.New(db), DBConn: db, NotiService: notification.NewService(db)}
 }
@@ -0,0 +1,32 @@
+package mobile
+
+import (
+	"net/http"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// trends of the patient's own clinical measures
+func (m *MobileHandler) GetMeasurementTrend(c *gin.Context) {
+	id, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	criteriaList := []repository.Criteria{}
+	if t, exist := c.GetQuery("type"); exist {
+		if !model.MeasurementType(t).IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid type value"})
+			return
+		}
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.TYPE, Value: t})
+	}
+	measurements, err := m.Repo.GetAllMeasurement(id.(int), criteriaList...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.NewMeasurementTrends(measurements))
+}
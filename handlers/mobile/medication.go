@@ -0,0 +1,155 @@
+package mobile
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/medication"
+	"github.com/PhasitWo/duchenne-server/services/schedule"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// medicines the patient is taking now
+func (m *MobileHandler) GetActiveMedication(c *gin.Context) {
+	id, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	regimens, err := m.Repo.GetAllMedicationRegimen(id.(int), repository.Criteria{QueryCriteria: repository.ENDDATE_ISNULL})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, regimens)
+}
+
+// scheduled doses with their logged status, default range is today in clinic timezone
+func (m *MobileHandler) GetMedicationDoses(c *gin.Context) {
+	id, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	now := time.Now().In(schedule.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, schedule.Location())
+	from := int(today.Unix())
+	to := int(today.AddDate(0, 0, 1).Unix())
+	var err error
+	if f, exist := c.GetQuery("from"); exist {
+		from, err = strconv.Atoi(f)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse from value"})
+			return
+		}
+	}
+	if t, exist := c.GetQuery("to"); exist {
+		to, err = strconv.Atoi(t)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse to value"})
+			return
+		}
+	}
+	if to <= from || to-from > medication.MAX_DOSE_RANGE {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from/to range"})
+		return
+	}
+	regimens, err := m.Repo.GetAllMedicationRegimen(id.(int))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	logged, err := m.Repo.GetAllMedicationDose(id.(int), from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, medication.WithStatus(medication.ScheduledDoses(regimens, from, to, schedule.Location()), logged))
+}
+
+// mark a dose of the patient's medicine as taken or skipped, logging it again changes the status
+func (m *MobileHandler) LogMedicationDose(c *gin.Context) {
+	regimen, ok := m.ownMedication(c)
+	if !ok {
+		return
+	}
+	var input model.LogDoseRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	now := int(time.Now().Unix())
+	// allow logging a little early, the phone clock may be off
+	if input.ScheduledAt > now+60*60 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "can't log a future dose"})
+		return
+	}
+	if !medication.IsDoseTime(regimen, input.ScheduledAt, schedule.Location()) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "no dose of this medicine at scheduledAt"})
+		return
+	}
+	status := input.Status
+	err := m.Repo.LogMedicationDose(model.MedicationDose{
+		RegimenID:   regimen.ID,
+		PatientID:   regimen.PatientID,
+		ScheduledAt: input.ScheduledAt,
+		Status:      &status,
+		LogAt:       &now,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// turn reminders of the medicine on or off and set their times, empty times use the schedule's default
+func (m *MobileHandler) UpdateMedicationReminder(c *gin.Context) {
+	regimen, ok := m.ownMedication(c)
+	if !ok {
+		return
+	}
+	var input model.UpdateMedicationReminderRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(input.Times) > 0 && medication.DoseTimes(regimen) == nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "this medicine isn't taken by the clock"})
+		return
+	}
+	if err := m.Repo.UpdateMedicationReminder(regimen.ID, input.Disabled, input.Times); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// regimen of the url param that belongs to the patient
+func (m *MobileHandler) ownMedication(c *gin.Context) (model.MedicationRegimen, bool) {
+	id, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return model.MedicationRegimen{}, false
+	}
+	regimenId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return model.MedicationRegimen{}, false
+	}
+	regimen, err := m.Repo.GetMedicationRegimen(id.(int), regimenId)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return regimen, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return regimen, false
+	}
+	return regimen, true
+}
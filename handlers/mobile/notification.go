@@ -0,0 +1,143 @@
+package mobile
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// inbox of the patient newest first, unread=true lists only unread notifications
+func (m *MobileHandler) GetAllNotification(c *gin.Context) {
+	i, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	id := i.(int)
+	limit, offset, err := utils.Paging(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	criteriaList := []repository.Criteria{{QueryCriteria: repository.PATIENTID, Value: id}}
+	if c.Query("unread") == "true" {
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.READAT_ISNULL})
+	}
+	notifications, err := m.Repo.GetAllNotification(limit, offset, criteriaList...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, notifications)
+}
+
+func (m *MobileHandler) GetUnreadNotificationCount(c *gin.Context) {
+	i, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	id := i.(int)
+	count, err := m.Repo.CountNotification(
+		repository.Criteria{QueryCriteria: repository.PATIENTID, Value: id},
+		repository.Criteria{QueryCriteria: repository.READAT_ISNULL},
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.UnreadNotificationCount{Count: count})
+}
+
+func (m *MobileHandler) ReadNotification(c *gin.Context) {
+	i, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	patientId := i.(int)
+	err := m.Repo.ReadNotification(patientId, c.Param("id"))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // not found or belongs to other patient
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (m *MobileHandler) ReadAllNotification(c *gin.Context) {
+	i, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	patientId := i.(int)
+	_, err := m.Repo.ReadAllNotification(patientId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// preference of the patient, default preference when the patient never set one
+func (m *MobileHandler) GetNotificationPreference(c *gin.Context) {
+	i, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	patientId := i.(int)
+	pref, err := m.Repo.GetNotificationPreference(patientId)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusOK, model.NotificationPreference{PatientID: patientId, MutedCategories: []model.NotificationCategory{}})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, pref)
+}
+
+func (m *MobileHandler) UpdateNotificationPreference(c *gin.Context) {
+	i, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	patientId := i.(int)
+	var input model.UpdateNotificationPreferenceRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if (input.QuietStartMinute == nil) != (input.QuietEndMinute == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "quietStartMinute and quietEndMinute must be set together"})
+		return
+	}
+	muted := input.MutedCategories
+	if muted == nil {
+		muted = []model.NotificationCategory{}
+	}
+	err := m.Repo.UpsertNotificationPreference(model.NotificationPreference{
+		PatientID:        patientId,
+		MutedCategories:  muted,
+		QuietStartMinute: input.QuietStartMinute,
+		QuietEndMinute:   input.QuietEndMinute,
+		Timezone:         input.Timezone,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
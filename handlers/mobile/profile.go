@@ -4,6 +4,8 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/PhasitWo/duchenne-server/auth"
+	"github.com/PhasitWo/duchenne-server/model"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
@@ -26,3 +28,61 @@ func (m *MobileHandler) GetProfile(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, p)
 }
+
+// language of notifications sent to the patient
+func (m *MobileHandler) UpdateLanguage(c *gin.Context) {
+	i, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	var input model.UpdateLanguageRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := m.Repo.UpdatePatientLanguage(i.(int), input.Language); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// url of the patient's calendar feed for subscribing in phone calendar
+func (m *MobileHandler) GetCalendarFeed(c *gin.Context) {
+	i, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	version, err := m.Repo.GetCalendarFeedVersion(string(auth.CALENDAR_PATIENT), i.(int))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	writeCalendarFeedUrl(c, i.(int), version)
+}
+
+// revoke the patient's calendar feed url and give a new one
+func (m *MobileHandler) RotateCalendarFeed(c *gin.Context) {
+	i, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	version, err := m.Repo.RotateCalendarFeed(string(auth.CALENDAR_PATIENT), i.(int))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	writeCalendarFeedUrl(c, i.(int), version)
+}
+
+func writeCalendarFeedUrl(c *gin.Context, patientId int, version int) {
+	token, err := auth.GenerateCalendarFeedToken(auth.CALENDAR_PATIENT, patientId, version)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"url": "/calendar/patient/" + token + ".ics"})
+}
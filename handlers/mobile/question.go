@@ -86,6 +86,7 @@ func (m *MobileHandler) CreateQuestion(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	go m.NotiService.NotifyCareTeam(patientId, model.QuestionLink(insertedId))
 	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
 }
 
@@ -118,3 +119,88 @@ func (m *MobileHandler) DeleteQuestion(c *gin.Context) {
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// follow-up of the patient in the question thread
+func (m *MobileHandler) CreateQuestionMessage(c *gin.Context) {
+	q, ok := m.patientQuestion(c)
+	if !ok {
+		return
+	}
+	var input model.QuestionMessageRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	insertedId, err := m.Repo.CreateQuestionMessage(model.QuestionMessage{
+		QuestionID: q.ID,
+		AuthorType: model.ACTOR_PATIENT,
+		AuthorID:   q.PatientID,
+		Message:    input.Message,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrQuestionClosed) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
+}
+
+// mark doctor messages of the question as read by the patient
+func (m *MobileHandler) ReadQuestion(c *gin.Context) {
+	q, ok := m.patientQuestion(c)
+	if !ok {
+		return
+	}
+	if err := m.Repo.ReadQuestion(q.ID, model.ACTOR_PATIENT); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (m *MobileHandler) CloseQuestion(c *gin.Context) {
+	m.setQuestionClosed(c, true)
+}
+
+func (m *MobileHandler) ReopenQuestion(c *gin.Context) {
+	m.setQuestionClosed(c, false)
+}
+
+func (m *MobileHandler) setQuestionClosed(c *gin.Context, closed bool) {
+	q, ok := m.patientQuestion(c)
+	if !ok {
+		return
+	}
+	if err := m.Repo.SetQuestionClosed(q.ID, closed); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// question of the path id, write the error response when it doesn't belong to the patient
+func (m *MobileHandler) patientQuestion(c *gin.Context) (model.SafeQuestion, bool) {
+	i, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return model.SafeQuestion{}, false
+	}
+	patientId := i.(int)
+	q, err := m.Repo.GetQuestion(c.Param("id"))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return q, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return q, false
+	}
+	if patientId != q.PatientID {
+		c.Status(http.StatusUnauthorized)
+		return q, false
+	}
+	return q, true
+}
@@ -0,0 +1,103 @@
+package mobile
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/schedule"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var errDoctorCannotBeAppointed = errors.New("this doctor can't be appointed")
+
+func (m *MobileHandler) GetDoctorSlots(c *gin.Context) {
+	i := c.Param("id")
+	doctorId, err := strconv.Atoi(i)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// get url query param, default range is the next 14 days
+	from := int(time.Now().Unix())
+	to := from + 14*24*60*60
+	if f, exist := c.GetQuery("from"); exist {
+		from, err = strconv.Atoi(f)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse from value"})
+			return
+		}
+	}
+	if t, exist := c.GetQuery("to"); exist {
+		to, err = strconv.Atoi(t)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse to value"})
+			return
+		}
+	}
+	if to <= from || to-from > schedule.MAX_SLOT_RANGE {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from/to range"})
+		return
+	}
+	// can't book the past
+	now := int(time.Now().Unix())
+	if from < now {
+		from = now
+	}
+	slots, err := m.getFreeSlots(doctorId, from, to)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		if err == errDoctorCannotBeAppointed {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, slots)
+}
+
+// free slots of the doctor in [from, to)
+func (m *MobileHandler) getFreeSlots(doctorId int, from int, to int) ([]model.Slot, error) {
+	doctor, err := m.Repo.GetDoctorById(doctorId)
+	if err != nil {
+		return nil, err
+	}
+	if !doctor.CanBeAppointed {
+		return nil, errDoctorCannotBeAppointed
+	}
+	schedules, err := m.Repo.GetDoctorSchedule(doctorId)
+	if err != nil {
+		return nil, err
+	}
+	exceptions, err := m.Repo.GetAllScheduleException(
+		repository.Criteria{QueryCriteria: repository.DOCTORID_OR_CLINIC, Value: doctorId},
+		repository.Criteria{QueryCriteria: repository.STARTAT_LESSTHAN, Value: to},
+		repository.Criteria{QueryCriteria: repository.ENDAT_GREATERTHAN, Value: from},
+	)
+	if err != nil {
+		return nil, err
+	}
+	// limit = -1 means no limit, appointments that start before 'from' may still overlap it
+	aps, err := m.Repo.GetAllAppointment(-1, 0,
+		repository.Criteria{QueryCriteria: repository.DOCTORID, Value: doctorId},
+		repository.Criteria{QueryCriteria: repository.DATE_GREATERTHAN, Value: from - model.MAX_APPOINTMENT_DURATION*60},
+		repository.Criteria{QueryCriteria: repository.DATE_LESSTHAN, Value: to},
+		repository.Criteria{QueryCriteria: repository.STATUS_ACTIVE, Value: nil},
+	)
+	if err != nil {
+		return nil, err
+	}
+	booked := []model.Slot{}
+	for _, ap := range aps {
+		booked = append(booked, model.Slot{Start: ap.Date, End: ap.EndDate()})
+	}
+	return schedule.GenerateSlots(schedules, exceptions, booked, from, to, schedule.Location()), nil
+}
@@ -0,0 +1,22 @@
+package mobile
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// vaccines the patient got, oldest first
+func (m *MobileHandler) GetVaccination(c *gin.Context) {
+	id, exists := c.Get("patientId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'patientId' from auth middleware"})
+		return
+	}
+	vaccinations, err := m.Repo.GetAllVaccination(id.(int))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, vaccinations)
+}
@@ -2,6 +2,7 @@ package web
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -49,6 +50,29 @@ func (w *WebHandler) GetAllAppointment(c *gin.Context) {
 			return
 		}
 	}
+	if st, exist := c.GetQuery("status"); exist {
+		status := model.AppointmentStatus(st)
+		if !status.IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status value"})
+			return
+		}
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.STATUS, Value: status})
+	}
+	if p, exist := c.GetQuery("pendingReschedule"); exist {
+		pending, err := strconv.ParseBool(p)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse pendingReschedule value"})
+			return
+		}
+		if pending {
+			criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.PENDING_RESCHEDULE})
+		}
+	}
+	scope, ok := myPatientScope(c, repository.MY_PATIENTID)
+	if !ok {
+		return
+	}
+	criteriaList = append(criteriaList, scope...)
 	// query
 	aps, err := w.Repo.GetAllAppointment(limit, offset, criteriaList...)
 	if err != nil {
@@ -69,6 +93,9 @@ func (w *WebHandler) GetAppointment(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if !w.canViewPatient(c, apm.PatientID) {
+		return
+	}
 	c.JSON(http.StatusOK, apm)
 }
 
@@ -87,20 +114,31 @@ func (w *WebHandler) CreateAppointment(c *gin.Context) {
 	}
 	// create new appointment
 	var approveAt *int = nil
+	status := model.REQUESTED
 	if input.Approve {
 		approveAt = &now
+		status = model.APPROVED
+	}
+	duration := input.Duration
+	if duration == 0 {
+		duration = model.DEFAULT_APPOINTMENT_DURATION
 	}
 	insertedId, err := w.Repo.CreateAppointment(model.Appointment{
 		Date:      input.Date,
+		Duration:  duration,
 		PatientID: input.PatientId,
 		DoctorID:  input.DoctorId,
+		Status:    status,
 		ApproveAt: approveAt,
 	})
 	if err != nil {
+		if writeAppointmentConflict(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	go w.NotiService.SendNotiByPatientId(input.PatientId, "คุณมีนัดหมายใหม่!", "ดูข้อมูลในแอปพลิเคชัน")
+	go w.NotiService.SendTemplateByPatientId(input.PatientId, model.TEMPLATE_APPOINTMENT_CREATED, nil, model.AppointmentLink(insertedId))
 	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
 }
 
@@ -122,23 +160,110 @@ func (w *WebHandler) UpdateAppointment(c *gin.Context) {
 		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "'Date' is before current time"})
 		return
 	}
+	ap, err := w.Repo.GetAppointment(id)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !ap.Status.IsActive() {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("can't update %v appointment", ap.Status)})
+		return
+	}
+	// approving a request or moving an approved appointment is a status change
+	nextStatus := ap.Status
+	if input.Approve && ap.Status == model.REQUESTED {
+		nextStatus = model.APPROVED
+	} else if input.Date != ap.Date && ap.Status != model.REQUESTED {
+		nextStatus = model.RESCHEDULED
+	}
+	// the status change and the update are saved together
+	tx := w.DBConn.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	repoWithTx := w.Repo.New(tx)
+	if nextStatus != ap.Status {
+		err = w.changeAppointmentStatus(c, repoWithTx, id, model.AppointmentStatusChange{Status: nextStatus, Date: &input.Date})
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
 	// update
-	var approveAt *int = nil
-	if input.Approve {
-		approveAt = &now
+	duration := input.Duration
+	if duration == 0 {
+		duration = ap.Duration
 	}
-	err = w.Repo.UpdateAppointment(model.Appointment{
+	err = repoWithTx.UpdateAppointment(model.Appointment{
 		ID:        id,
 		Date:      input.Date,
+		Duration:  duration,
 		PatientID: input.PatientId,
 		DoctorID:  input.DoctorId,
-		ApproveAt: approveAt,
 	})
 	if err != nil {
+		tx.Rollback()
+		if writeAppointmentConflict(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	go w.NotiService.SendNotiByPatientId(input.PatientId, "นัดหมายของคุณมีการเปลี่ยนแปลง!", "เช็คสถานะในแอปพลิเคชัน")
+	if err := tx.Commit().Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Tx can't commit"})
+		return
+	}
+	go w.NotiService.SendTemplateByPatientId(input.PatientId, model.TEMPLATE_APPOINTMENT_UPDATED, nil, model.AppointmentLink(id))
+	c.Status(http.StatusOK)
+}
+
+func (w *WebHandler) UpdateAppointmentStatus(c *gin.Context) {
+	var input model.UpdateAppointmentStatusRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	i := c.Param("id")
+	id, err := strconv.Atoi(i)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Status == model.REJECTED && (input.Reason == nil || *input.Reason == "") {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "'Reason' is required for rejecting"})
+		return
+	}
+	ap, err := w.Repo.GetAppointment(id)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	err = w.changeAppointmentStatus(c, w.Repo, id, model.AppointmentStatusChange{Status: input.Status, Reason: input.Reason})
+	if err != nil {
+		return
+	}
+	switch input.Status {
+	case model.APPROVED:
+		go w.NotiService.SendTemplateByPatientId(ap.PatientID, model.TEMPLATE_APPOINTMENT_APPROVED, nil, model.AppointmentLink(id))
+	case model.REJECTED:
+		go w.NotiService.SendTemplateByPatientId(ap.PatientID, model.TEMPLATE_APPOINTMENT_REJECTED, reasonParams(input.Reason), model.AppointmentLink(id))
+	case model.CANCELLED_BY_STAFF:
+		go w.NotiService.SendTemplateByPatientId(ap.PatientID, model.TEMPLATE_APPOINTMENT_CANCELLED, reasonParams(input.Reason), model.AppointmentLink(id))
+	}
 	c.Status(http.StatusOK)
 }
 
@@ -153,12 +278,178 @@ func (w *WebHandler) DeleteAppointment(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	// delete appointment
-	err = w.Repo.DeleteAppointment(id)
+	// cancel appointment, the record is kept for reporting
+	var reason *string
+	if r, exist := c.GetQuery("reason"); exist && r != "" {
+		reason = &r
+	}
+	err = w.changeAppointmentStatus(c, w.Repo, apm.ID, model.AppointmentStatusChange{Status: model.CANCELLED_BY_STAFF, Reason: reason})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	go w.NotiService.SendNotiByPatientId(apm.PatientID, "นัดหมายของคุณถูกยกเลิก!", "เจ้าหน้าที่ยกเลิกนัดหมายของคุณ")
+	go w.NotiService.SendTemplateByPatientId(apm.PatientID, model.TEMPLATE_APPOINTMENT_CANCELLED, reasonParams(reason), model.AppointmentLink(apm.ID))
 	c.Status(http.StatusNoContent)
 }
+
+// accept or decline pending reschedule request from the patient
+func (w *WebHandler) ResolveRescheduleRequest(c *gin.Context) {
+	var input model.ResolveRescheduleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	doctorId := dId.(int)
+	ap, err := w.Repo.GetAppointment(id)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	_, err = w.Repo.ResolveRescheduleRequest(id, input.Accept, input.Reason, doctorId)
+	if err != nil {
+		if writeAppointmentConflict(c, err) {
+			return
+		}
+		switch errors.Unwrap(err) {
+		case gorm.ErrRecordNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "no pending reschedule request"})
+		case repository.ErrInvalidStatusTransition:
+			c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("can't reschedule %v appointment", ap.Status)})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	if input.Accept {
+		go w.NotiService.SendTemplateByPatientId(ap.PatientID, model.TEMPLATE_RESCHEDULE_ACCEPTED, nil, model.AppointmentLink(id))
+	} else {
+		go w.NotiService.SendTemplateByPatientId(ap.PatientID, model.TEMPLATE_RESCHEDULE_DECLINED, reasonParams(input.Reason), model.AppointmentLink(id))
+	}
+	c.Status(http.StatusOK)
+}
+
+func (w *WebHandler) GetAppointmentHistory(c *gin.Context) {
+	id := c.Param("id")
+	apm, err := w.Repo.GetAppointment(id)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !w.canViewPatient(c, apm.PatientID) {
+		return
+	}
+	history, err := w.Repo.GetAppointmentHistory(apm.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
+// count appointments by status e.g. for no-show report
+func (w *WebHandler) GetAppointmentReport(c *gin.Context) {
+	criteriaList := []repository.Criteria{}
+	if d, exist := c.GetQuery("doctorId"); exist {
+		doctorId, err := strconv.Atoi(d)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse doctorId value"})
+			return
+		}
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.DOCTORID, Value: doctorId})
+	}
+	if f, exist := c.GetQuery("from"); exist {
+		from, err := strconv.Atoi(f)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse from value"})
+			return
+		}
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.DATE_GREATERTHAN, Value: from})
+	}
+	if t, exist := c.GetQuery("to"); exist {
+		to, err := strconv.Atoi(t)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse to value"})
+			return
+		}
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.DATE_LESSTHAN, Value: to})
+	}
+	counts, err := w.Repo.GetAppointmentStatusCount(criteriaList...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, counts)
+}
+
+// change status on behalf of the doctor in auth middleware, the response is written when error occurs
+func (w *WebHandler) changeAppointmentStatus(c *gin.Context, repo repository.IRepo, appointmentId int, change model.AppointmentStatusChange) error {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return errors.New("no 'doctorId' from auth middleware")
+	}
+	doctorId := dId.(int)
+	change.ActorType = model.ACTOR_DOCTOR
+	change.ActorID = &doctorId
+	err := repo.ChangeAppointmentStatus(appointmentId, change)
+	if err != nil {
+		if writeAppointmentConflict(c, err) {
+			return err
+		}
+		switch errors.Unwrap(err) {
+		case gorm.ErrRecordNotFound:
+			c.Status(http.StatusNotFound)
+		case repository.ErrInvalidStatusTransition:
+			c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("can't change appointment status to %v", change.Status)})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return err
+	}
+	return nil
+}
+
+// write 409 with the clashing appointment, return false if err is not appointment conflict
+func writeAppointmentConflict(c *gin.Context, err error) bool {
+	var conflictErr *repository.ErrAppointmentConflict
+	if !errors.As(err, &conflictErr) {
+		return false
+	}
+	conflict := conflictErr.Appointment
+	c.JSON(http.StatusConflict, gin.H{
+		"error": "appointment overlaps another appointment of this doctor or patient",
+		"conflict": model.AppointmentConflict{
+			ID:        conflict.ID,
+			Date:      conflict.Date,
+			Duration:  conflict.Duration,
+			DoctorID:  conflict.DoctorID,
+			PatientID: conflict.PatientID,
+		},
+	})
+	return true
+}
+
+// reason placeholder of the notification, nil when there is no reason
+func reasonParams(reason *string) model.TemplateParams {
+	if reason == nil {
+		return nil
+	}
+	return model.TemplateParams{"reason": *reason}
+}
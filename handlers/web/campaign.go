@@ -0,0 +1,163 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// number of patients in the segment right now
+func (w *WebHandler) PreviewCampaign(c *gin.Context) {
+	var input model.CampaignPreviewRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !validSegment(c, input.Segment) {
+		return
+	}
+	ids, err := w.Repo.GetSegmentPatientIds(input.Segment, int(time.Now().Unix()))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(ids)})
+}
+
+func (w *WebHandler) GetAllCampaign(c *gin.Context) {
+	limit, offset, err := utils.Paging(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	criteriaList := []repository.Criteria{}
+	if status, exist := c.GetQuery("status"); exist && status != "" {
+		switch model.CampaignStatus(status) {
+		case model.CAMPAIGN_SCHEDULED, model.CAMPAIGN_SENDING, model.CAMPAIGN_SENT, model.CAMPAIGN_CANCELLED:
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+			return
+		}
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.STATUS, Value: status})
+	}
+	campaigns, err := w.Repo.GetAllCampaign(limit, offset, criteriaList...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, campaigns)
+}
+
+func (w *WebHandler) GetCampaign(c *gin.Context) {
+	campaign, err := w.Repo.GetCampaign(c.Param("id"))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, campaign)
+}
+
+func (w *WebHandler) GetCampaignStats(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	campaign, err := w.Repo.GetCampaign(id)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	stats, err := w.Repo.GetCampaignStats(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	stats.RecipientCount = campaign.RecipientCount
+	c.JSON(http.StatusOK, stats)
+}
+
+// schedule a campaign, campaigns without send time or with past send time are sent right away
+func (w *WebHandler) CreateCampaign(c *gin.Context) {
+	var input model.CreateCampaignRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !validSegment(c, input.Segment) {
+		return
+	}
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	now := int(time.Now().Unix())
+	scheduledAt := now
+	if input.ScheduledAt != nil && *input.ScheduledAt > now {
+		scheduledAt = *input.ScheduledAt
+	}
+	insertedId, err := w.Repo.CreateCampaign(model.Campaign{
+		Title:       input.Title,
+		Body:        input.Body,
+		Segment:     datatypes.NewJSONType(input.Segment),
+		Status:      model.CAMPAIGN_SCHEDULED,
+		ScheduledAt: scheduledAt,
+		CreateByID:  dId.(int),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if scheduledAt == now {
+		go w.NotiService.SendDueCampaigns()
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
+}
+
+// cancel a scheduled campaign
+func (w *WebHandler) CancelCampaign(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	err = w.Repo.CancelCampaign(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, repository.ErrInvalidStatusTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": "campaign is already sent"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func validSegment(c *gin.Context, segment model.CampaignSegment) bool {
+	if segment.MinAge != nil && segment.MaxAge != nil && *segment.MinAge > *segment.MaxAge {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "minAge must not be greater than maxAge"})
+		return false
+	}
+	return true
+}
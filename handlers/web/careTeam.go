@@ -0,0 +1,148 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/PhasitWo/duchenne-server/middleware"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// care team of the patient with their doctors
+func (w *WebHandler) GetPatientCareTeam(c *gin.Context) {
+	patientId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !w.canViewPatient(c, patientId) {
+		return
+	}
+	members, err := w.Repo.GetAllCareTeamMember(patientId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, members)
+}
+
+func (w *WebHandler) AddPatientCareTeamMember(c *gin.Context) {
+	patientId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var input model.CareTeamMemberRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	patient, err := w.Repo.GetPatientById(patientId) // check if this id exist
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if patient.VerificationStatus != model.SIGNUP_APPROVED {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "patient signup is not approved"})
+		return
+	}
+	insertedId, err := w.Repo.CreateCareTeamMember(model.CareTeamMember{PatientID: patientId, DoctorID: input.DoctorID, Role: input.Role})
+	if err != nil {
+		switch errors.Unwrap(err) {
+		case repository.ErrDuplicateEntry:
+			c.JSON(http.StatusConflict, gin.H{"error": "this doctor is already in the care team"})
+		case repository.ErrForeignKeyFail:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "doctor doesn't exist"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
+}
+
+// change the role of the doctor in the care team
+func (w *WebHandler) UpdatePatientCareTeamMember(c *gin.Context) {
+	var input model.UpdateCareTeamMemberRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	member, ok := w.careTeamMember(c)
+	if !ok {
+		return
+	}
+	member.Role = input.Role
+	if err := w.Repo.UpdateCareTeamMember(member); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (w *WebHandler) RemovePatientCareTeamMember(c *gin.Context) {
+	member, ok := w.careTeamMember(c)
+	if !ok {
+		return
+	}
+	if err := w.Repo.DeleteCareTeamMember(member.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (w *WebHandler) careTeamMember(c *gin.Context) (model.CareTeamMember, bool) {
+	member, err := w.Repo.GetCareTeamMember(c.Param("id"), c.Param("doctorId"))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return member, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return member, false
+	}
+	return member, true
+}
+
+/*
+criteria of the listing scope, by default only patients whose care team has the doctor,
+scope=all lists every patient and needs ViewAllPatientPermission
+*/
+func myPatientScope(c *gin.Context, criteria repository.ColumnCriteria) ([]repository.Criteria, bool) {
+	switch c.DefaultQuery("scope", "mine") {
+	case "mine":
+		dId, exists := c.Get("doctorId")
+		if !exists {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+			return nil, false
+		}
+		return []repository.Criteria{{QueryCriteria: criteria, Value: dId.(int)}}, true
+	case "all":
+		r, exists := c.Get("doctorRole")
+		if !exists {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorRole' from auth middleware"})
+			return nil, false
+		}
+		if !middleware.HasPermission(r.(model.Role), middleware.ViewAllPatientPermission) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			return nil, false
+		}
+		return nil, true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid scope value"})
+		return nil, false
+	}
+}
+
+func (w *WebHandler) canViewPatient(c *gin.Context, patientId int) bool {
+	return middleware.CanViewPatient(c, w.Repo, patientId)
+}
@@ -0,0 +1,67 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// caregivers linked to the patient with their permissions
+func (w *WebHandler) GetPatientCaregiver(c *gin.Context) {
+	patientId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !w.canViewPatient(c, patientId) {
+		return
+	}
+	links, err := w.Repo.GetAllCaregiverLink(patientId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, links)
+}
+
+func (w *WebHandler) RemovePatientCaregiver(c *gin.Context) {
+	link, err := w.Repo.GetCaregiverLink(c.Param("caregiverId"), c.Param("id"))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := w.Repo.DeleteCaregiverLink(link); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// actions done by the caregiver on the mobile app, newest first
+func (w *WebHandler) GetCaregiverActivity(c *gin.Context) {
+	caregiverId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	limit, offset, err := utils.Paging(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	logs, err := w.Repo.GetAllActivityLog(limit, offset, repository.Criteria{QueryCriteria: repository.CAREGIVERID, Value: caregiverId})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, logs)
+}
@@ -11,7 +11,7 @@ import (
 
 type WebHandler struct {
 	Repo   repository.IRepo
-	DBConn *gorm.DB
+	DBConn repository.IGorm
 	NotiService notification.INotificationService
 }
 
@@ -0,0 +1,164 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// measurements of the patient, oldest first
+func (w *WebHandler) GetAllPatientMeasurement(c *gin.Context) {
+	patientId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !w.canViewPatient(c, patientId) {
+		return
+	}
+	criteriaList := []repository.Criteria{}
+	if t, exist := c.GetQuery("type"); exist {
+		if !model.MeasurementType(t).IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid type value"})
+			return
+		}
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.TYPE, Value: t})
+	}
+	if f, exist := c.GetQuery("from"); exist {
+		from, err := strconv.Atoi(f)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse from value"})
+			return
+		}
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.MEASURED_AFTER, Value: from - 1})
+	}
+	if t, exist := c.GetQuery("to"); exist {
+		to, err := strconv.Atoi(t)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse to value"})
+			return
+		}
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.MEASURED_BEFORE, Value: to + 1})
+	}
+	measurements, err := w.Repo.GetAllMeasurement(patientId, criteriaList...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, measurements)
+}
+
+func (w *WebHandler) CreatePatientMeasurement(c *gin.Context) {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	patientId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var input model.MeasurementRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !input.Type.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid type value"})
+		return
+	}
+	unit, ok := measurementUnit(c, input.Type, input.Unit)
+	if !ok {
+		return
+	}
+	_, err = w.Repo.GetPatientById(patientId) // check if this id exist
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	insertedId, err := w.Repo.CreateMeasurement(model.Measurement{
+		PatientID:  patientId,
+		Type:       input.Type,
+		Value:      input.Value,
+		Unit:       unit,
+		MeasuredAt: input.MeasuredAt,
+		DoctorID:   dId.(int),
+		Note:       input.Note,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
+}
+
+func (w *WebHandler) UpdatePatientMeasurement(c *gin.Context) {
+	var input model.UpdateMeasurementRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	measurement, ok := w.patientMeasurement(c)
+	if !ok {
+		return
+	}
+	unit, ok := measurementUnit(c, measurement.Type, input.Unit)
+	if !ok {
+		return
+	}
+	measurement.Value = input.Value
+	measurement.Unit = unit
+	measurement.MeasuredAt = input.MeasuredAt
+	measurement.Note = input.Note
+	if err := w.Repo.UpdateMeasurement(measurement); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (w *WebHandler) DeletePatientMeasurement(c *gin.Context) {
+	measurement, ok := w.patientMeasurement(c)
+	if !ok {
+		return
+	}
+	if err := w.Repo.DeleteMeasurement(measurement); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (w *WebHandler) patientMeasurement(c *gin.Context) (model.Measurement, bool) {
+	measurement, err := w.Repo.GetMeasurement(c.Param("id"), c.Param("measurementId"))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return measurement, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return measurement, false
+	}
+	return measurement, true
+}
+
+// empty unit means the unit of the type, any other unit isn't converted
+func measurementUnit(c *gin.Context, measurementType model.MeasurementType, unit string) (string, bool) {
+	expected := model.MEASUREMENT_UNITS[measurementType]
+	if unit != "" && unit != expected {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": fmt.Sprintf("unit of %v must be %v", measurementType, expected)})
+		return "", false
+	}
+	return expected, true
+}
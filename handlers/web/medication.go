@@ -0,0 +1,228 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/medication"
+	"github.com/PhasitWo/duchenne-server/services/schedule"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// medication history of the patient, latest start first
+func (w *WebHandler) GetAllPatientMedication(c *gin.Context) {
+	patientId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !w.canViewPatient(c, patientId) {
+		return
+	}
+	criteriaList := []repository.Criteria{}
+	if status, exist := c.GetQuery("status"); exist {
+		switch status {
+		case "active":
+			criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.ENDDATE_ISNULL})
+		case "ended":
+			criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.ENDDATE_ISNOTNULL})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status value"})
+			return
+		}
+	}
+	regimens, err := w.Repo.GetAllMedicationRegimen(patientId, criteriaList...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, regimens)
+}
+
+func (w *WebHandler) CreatePatientMedication(c *gin.Context) {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	patientId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var input model.CreateMedicationRegimenRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	_, err = w.Repo.GetPatientById(patientId) // check if this id exist
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	prescriberId := dId.(int)
+	insertedId, err := w.Repo.CreateMedicationRegimen(model.MedicationRegimen{
+		PatientID:    patientId,
+		MedicineName: input.MedicineName,
+		Dose:         input.Dose,
+		DoseUnit:     input.DoseUnit,
+		Schedule:     input.Schedule,
+		Instruction:  input.Instruction,
+		StartDate:    &input.StartDate,
+		Reason:       input.Reason,
+		PrescriberID: &prescriberId,
+		Note:         input.Note,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
+}
+
+// new dose or schedule of the same medicine, the current regimen ends when the new one starts
+func (w *WebHandler) ChangePatientMedication(c *gin.Context) {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	var input model.ChangeMedicationRegimenRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	current, ok := w.patientMedication(c)
+	if !ok {
+		return
+	}
+	if current.StartDate != nil && input.StartDate < *current.StartDate {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "startDate is before the start of the current regimen"})
+		return
+	}
+	prescriberId := dId.(int)
+	insertedId, err := w.Repo.ChangeMedicationRegimen(current.ID, model.MedicationRegimen{
+		PatientID:    current.PatientID,
+		MedicineName: current.MedicineName,
+		Dose:         input.Dose,
+		DoseUnit:     input.DoseUnit,
+		Schedule:     input.Schedule,
+		Instruction:  input.Instruction,
+		StartDate:    &input.StartDate,
+		Reason:       &input.Reason,
+		PrescriberID: &prescriberId,
+		Note:         input.Note,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrRegimenEnded) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
+}
+
+func (w *WebHandler) StopPatientMedication(c *gin.Context) {
+	var input model.StopMedicationRegimenRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	current, ok := w.patientMedication(c)
+	if !ok {
+		return
+	}
+	if current.StartDate != nil && input.EndDate < *current.StartDate {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "endDate is before the start of the regimen"})
+		return
+	}
+	err := w.Repo.StopMedicationRegimen(current.ID, input.EndDate, input.Reason)
+	if err != nil {
+		if errors.Is(err, repository.ErrRegimenEnded) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// remove a regimen entered by mistake
+func (w *WebHandler) DeletePatientMedication(c *gin.Context) {
+	regimen, ok := w.patientMedication(c)
+	if !ok {
+		return
+	}
+	if err := w.Repo.DeleteMedicationRegimen(regimen); err != nil {
+		switch errors.Unwrap(err) {
+		case repository.ErrRegimenReplaced:
+			c.JSON(http.StatusConflict, gin.H{"error": "only the latest regimen of a change can be deleted"})
+		case repository.ErrRegimenHasDoses:
+			c.JSON(http.StatusConflict, gin.H{"error": "regimen has logged doses, stop it instead"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// percent of expected doses the patient took in each of the last weeks, oldest week first
+func (w *WebHandler) GetPatientAdherence(c *gin.Context) {
+	patientId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !w.canViewPatient(c, patientId) {
+		return
+	}
+	weeks := 8
+	if wk, exist := c.GetQuery("weeks"); exist {
+		weeks, err = strconv.Atoi(wk)
+		if err != nil || weeks < 1 || weeks > 52 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid weeks value"})
+			return
+		}
+	}
+	regimens, err := w.Repo.GetAllMedicationRegimen(patientId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	now := time.Now()
+	// a week before weekStart so the monday of the first week is covered
+	from := int(now.AddDate(0, 0, -7*weeks).Unix())
+	logged, err := w.Repo.GetAllMedicationDose(patientId, from, int(now.Unix())+1)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	weekStart := int(now.AddDate(0, 0, -7*(weeks-1)).Unix())
+	c.JSON(http.StatusOK, medication.WeeklyAdherence(regimens, logged, weekStart, weeks, int(now.Unix()), schedule.Location()))
+}
+
+func (w *WebHandler) patientMedication(c *gin.Context) (model.MedicationRegimen, bool) {
+	regimen, err := w.Repo.GetMedicationRegimen(c.Param("id"), c.Param("regimenId"))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return regimen, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return regimen, false
+	}
+	return regimen, true
+}
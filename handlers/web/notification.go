@@ -1,36 +1,131 @@
 package web
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/PhasitWo/duchenne-server/config"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/utils"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-func (w *WebHandler) SendDailyNotifications(c *gin.Context) {
-	// get url query param
+// trigger reminders from external scheduler when cron is disabled
+func (w *WebHandler) SendReminders(c *gin.Context) {
 	secret, exist := c.GetQuery("secret")
 	if !exist {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "require secret"})
 		return
 	}
 	if secret != config.AppConfig.NOTIFY_SECRET {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid secret", "received": secret})
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid secret"})
 		return
 	}
-	var dayRange *int
-	if l, exist := c.GetQuery("day_range"); exist {
-		converted, err := strconv.Atoi(l)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse day_range value"})
+	err := w.NotiService.SendReminders()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// send due campaigns, retry outbox messages and poll receipts from external scheduler when cron is disabled
+func (w *WebHandler) ProcessOutbox(c *gin.Context) {
+	secret, exist := c.GetQuery("secret")
+	if !exist {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "require secret"})
+		return
+	}
+	if secret != config.AppConfig.NOTIFY_SECRET {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid secret"})
+		return
+	}
+	if err := w.NotiService.SendDueCampaigns(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := w.NotiService.ProcessOutbox(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := w.NotiService.CheckReceipts(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// inbox of the doctor newest first, unread=true lists only unread notifications
+func (w *WebHandler) GetAllDoctorNotification(c *gin.Context) {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	limit, offset, err := utils.Paging(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	criteriaList := []repository.Criteria{{QueryCriteria: repository.DOCTORID, Value: dId.(int)}}
+	if c.Query("unread") == "true" {
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.READAT_ISNULL})
+	}
+	notifications, err := w.Repo.GetAllDoctorNotification(limit, offset, criteriaList...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, notifications)
+}
+
+func (w *WebHandler) GetDoctorUnreadNotificationCount(c *gin.Context) {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	count, err := w.Repo.CountDoctorNotification(
+		repository.Criteria{QueryCriteria: repository.DOCTORID, Value: dId.(int)},
+		repository.Criteria{QueryCriteria: repository.READAT_ISNULL},
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.UnreadNotificationCount{Count: count})
+}
+
+func (w *WebHandler) ReadDoctorNotification(c *gin.Context) {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	err := w.Repo.ReadDoctorNotification(dId.(int), c.Param("id"))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // not found or belongs to other doctor
+			c.Status(http.StatusNotFound)
 			return
 		}
-		dayRange = &converted
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (w *WebHandler) ReadAllDoctorNotification(c *gin.Context) {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
 	}
-	err := w.NotiService.SendDailyNotifications(dayRange)
+	_, err := w.Repo.ReadAllDoctorNotification(dId.(int))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
-	c.Status(200)
+	c.Status(http.StatusNoContent)
 }
@@ -23,11 +23,15 @@ func (w *WebHandler) GetPatient(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if !w.canViewPatient(c, patient.ID) {
+		return
+	}
 	c.JSON(http.StatusOK, patient)
 }
 
 func (w *WebHandler) GetAllPatient(c *gin.Context) {
-	criteriaList := []repository.Criteria{}
+	// signups waiting for review or rejected are listed in signups only
+	criteriaList := []repository.Criteria{{QueryCriteria: repository.VERIFICATION, Value: model.SIGNUP_APPROVED}}
 	limit, offset, err := utils.Paging(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -38,6 +42,11 @@ func (w *WebHandler) GetAllPatient(c *gin.Context) {
 			criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.PATIENT_SEARCH, Value: search})
 		}
 	}
+	scope, ok := myPatientScope(c, repository.MY_PATIENT)
+	if !ok {
+		return
+	}
+	criteriaList = append(criteriaList, scope...)
 	patients, err := w.Repo.GetAllPatient(limit, offset, criteriaList...)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -110,6 +119,7 @@ func (w *WebHandler) UpdatePatient(c *gin.Context) {
 		Weight:     input.Weight,
 		Height:     input.Height,
 		BirthDate:  input.BirthDate,
+		Language:   input.Language,
 	})
 	if err != nil {
 		if errors.Unwrap(err) == repository.ErrDuplicateEntry {
@@ -138,60 +148,30 @@ func (w *WebHandler) DeletePatient(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// vaccines are recorded as vaccinations now, old clients must not overwrite the list that vaccinations were migrated from
 func (w *WebHandler) UpdatePatientVaccineHistory(c *gin.Context) {
-	var input model.UpdateVaccineHistoryRequest
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	i := c.Param("id")
-	id, err := strconv.Atoi(i)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	_, err = w.Repo.GetPatientById(id) // check if this id exist
-	if err != nil {
-		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
-			c.Status(http.StatusNotFound)
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-	err = w.Repo.UpdatePatientVaccineHistory(id, input.Data)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-	c.Status(http.StatusOK)
+	c.JSON(http.StatusGone, gin.H{"error": "vaccine history is replaced by vaccinations, use /patient/:id/vaccination"})
 }
 
+// medicines are recorded as regimens now, old clients must not overwrite the list that regimens were migrated from
 func (w *WebHandler) UpdatePatientMedicine(c *gin.Context) {
-	var input model.UpdateMedicineRequest
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	i := c.Param("id")
-	id, err := strconv.Atoi(i)
+	c.JSON(http.StatusGone, gin.H{"error": "medicine list is replaced by medication regimens, use /patient/:id/medication"})
+}
+
+// devices removed by the server, support uses this to explain why a phone stopped getting notifications
+func (w *WebHandler) GetPatientDeviceRemoval(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	_, err = w.Repo.GetPatientById(id) // check if this id exist
-	if err != nil {
-		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
-			c.Status(http.StatusNotFound)
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !w.canViewPatient(c, id) {
 		return
 	}
-	err = w.Repo.UpdatePatientMedicine(id, input.Data)
+	removals, err := w.Repo.GetAllDeviceRemoval(repository.Criteria{QueryCriteria: repository.PATIENTID, Value: id})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.Status(http.StatusOK)
+	c.JSON(http.StatusOK, removals)
 }
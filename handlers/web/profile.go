@@ -80,3 +80,42 @@ func (w *WebHandler) UpdateProfile(c *gin.Context) {
 	}
 	c.Status(http.StatusOK)
 }
+
+// url of the doctor's calendar feed for subscribing in calendar apps
+func (w *WebHandler) GetCalendarFeed(c *gin.Context) {
+	i, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	version, err := w.Repo.GetCalendarFeedVersion(string(auth.CALENDAR_DOCTOR), i.(int))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	writeCalendarFeedUrl(c, i.(int), version)
+}
+
+// revoke the doctor's calendar feed url and give a new one
+func (w *WebHandler) RotateCalendarFeed(c *gin.Context) {
+	i, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	version, err := w.Repo.RotateCalendarFeed(string(auth.CALENDAR_DOCTOR), i.(int))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	writeCalendarFeedUrl(c, i.(int), version)
+}
+
+func writeCalendarFeedUrl(c *gin.Context, doctorId int, version int) {
+	token, err := auth.GenerateCalendarFeedToken(auth.CALENDAR_DOCTOR, doctorId, version)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"url": "/calendar/doctor/" + token + ".ics"})
+}
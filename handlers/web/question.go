@@ -4,7 +4,9 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/PhasitWo/duchenne-server/config"
 	"github.com/PhasitWo/duchenne-server/model"
 	"github.com/PhasitWo/duchenne-server/repository"
 	"github.com/PhasitWo/duchenne-server/utils"
@@ -48,20 +50,112 @@ func (w *WebHandler) GetAllQuestion(c *gin.Context) {
 			return
 		}
 	}
+	if status, exist := c.GetQuery("status"); exist {
+		switch status {
+		case "open":
+			criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.CLOSEDAT_ISNULL})
+		case "closed":
+			criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.CLOSEDAT_ISNOTNULL})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status value"})
+			return
+		}
+	}
+	if c.Query("unread") == "true" {
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.DOCTOR_UNREAD})
+	}
+	if category, exist := c.GetQuery("category"); exist {
+		switch model.QuestionCategory(category) {
+		case model.QUESTION_RESPIRATORY, model.QUESTION_CARDIAC, model.QUESTION_MEDICATION, model.QUESTION_MOBILITY, model.QUESTION_OTHER:
+			criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.CATEGORY, Value: category})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category value"})
+			return
+		}
+	}
+	if priority, exist := c.GetQuery("priority"); exist {
+		switch model.QuestionPriority(priority) {
+		case model.PRIORITY_LOW, model.PRIORITY_NORMAL, model.PRIORITY_HIGH, model.PRIORITY_URGENT:
+			criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.PRIORITY, Value: priority})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid priority value"})
+			return
+		}
+	}
+	if a, exist := c.GetQuery("assigneeId"); exist {
+		switch a {
+		case "unassigned":
+			criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.ASSIGNEEID_ISNULL})
+		case "me":
+			dId, exists := c.Get("doctorId")
+			if !exists {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+				return
+			}
+			criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.ASSIGNEEID, Value: dId.(int)})
+		default:
+			assigneeId, err := strconv.Atoi(a)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse assigneeId value"})
+				return
+			}
+			criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.ASSIGNEEID, Value: assigneeId})
+		}
+	}
+	now := int(time.Now().Unix())
+	if c.Query("overdue") == "true" {
+		criteriaList = append(criteriaList,
+			repository.Criteria{QueryCriteria: repository.CLOSEDAT_ISNULL},
+			repository.Criteria{QueryCriteria: repository.AWAITING_LESSTHAN, Value: now - config.AppConfig.QUESTION_SLA_HOURS*60*60},
+		)
+	}
 	if search, exist := c.GetQuery("search"); exist {
 		if search != "" {
 			criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.QUESTION_SEARCH, Value: search})
 		}
 	}
+	scope, ok := myPatientScope(c, repository.MY_PATIENTID)
+	if !ok {
+		return
+	}
+	criteriaList = append(criteriaList, scope...)
 	// query
 	qs, err := w.Repo.GetAllQuestion(limit, offset, criteriaList...)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	markSLABreached(qs, now)
+	c.JSON(http.StatusOK, qs)
+}
+
+// open questions assigned to the doctor, most urgent first
+func (w *WebHandler) GetQuestionQueue(c *gin.Context) {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	limit, offset, err := utils.Paging(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	qs, err := w.Repo.GetQuestionQueue(dId.(int), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	markSLABreached(qs, int(time.Now().Unix()))
 	c.JSON(http.StatusOK, qs)
 }
 
+func markSLABreached(qs []model.QuestionTopic, now int) {
+	for i := range qs {
+		qs[i].SLABreached = qs[i].IsOverdue(now, config.AppConfig.QUESTION_SLA_HOURS)
+	}
+}
+
 func (w *WebHandler) GetQuestion(c *gin.Context) {
 	id := c.Param("id")
 	q, err := w.Repo.GetQuestion(id)
@@ -73,6 +167,9 @@ func (w *WebHandler) GetQuestion(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if !w.canViewPatient(c, q.PatientID) {
+		return
+	}
 	c.JSON(http.StatusOK, q)
 }
 
@@ -92,6 +189,9 @@ func (w *WebHandler) AnswerQuestion(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if !w.canViewPatient(c, q.PatientID) {
+		return
+	}
 	// check question status
 	if q.AnswerAt != nil {
 		c.JSON(http.StatusConflict, gin.H{"error": "this question has been replied"})
@@ -109,12 +209,249 @@ func (w *WebHandler) AnswerQuestion(c *gin.Context) {
 		return
 	}
 	doctorId := dId.(int)
+	if !assignedToDoctor(q, doctorId) {
+		c.JSON(http.StatusForbidden, gin.H{"error": repository.ErrQuestionAssigned.Error()})
+		return
+	}
 	// query
 	err = w.Repo.UpdateQuestionAnswer(questionId, input.Answer, doctorId)
 	if err != nil {
+		if errors.Is(err, repository.ErrQuestionClosed) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	w.countSnippetUsage(input.SnippetID)
+	go w.NotiService.SendTemplateByPatientId(q.PatientID, model.TEMPLATE_QUESTION_ANSWERED, nil, model.QuestionLink(questionId))
+	c.Status(http.StatusOK)
+}
+
+// follow-up of the doctor in the question thread
+func (w *WebHandler) CreateQuestionMessage(c *gin.Context) {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	questionId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	var input model.DoctorQuestionMessageRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	q, err := w.Repo.GetQuestion(questionId)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !w.canViewPatient(c, q.PatientID) {
+		return
+	}
+	if !assignedToDoctor(q, dId.(int)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": repository.ErrQuestionAssigned.Error()})
+		return
+	}
+	insertedId, err := w.Repo.CreateQuestionMessage(model.QuestionMessage{
+		QuestionID: questionId,
+		AuthorType: model.ACTOR_DOCTOR,
+		AuthorID:   dId.(int),
+		Message:    input.Message,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrQuestionClosed) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	w.countSnippetUsage(input.SnippetID)
+	go w.NotiService.SendTemplateByPatientId(q.PatientID, model.TEMPLATE_QUESTION_MESSAGE, nil, model.QuestionLink(questionId))
+	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
+}
+
+// mark patient messages of the question as read by the doctors
+func (w *WebHandler) ReadQuestion(c *gin.Context) {
+	questionId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	q, err := w.Repo.GetQuestion(questionId)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !w.canViewPatient(c, q.PatientID) {
+		return
+	}
+	if err := w.Repo.ReadQuestion(questionId, model.ACTOR_DOCTOR); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (w *WebHandler) CloseQuestion(c *gin.Context) {
+	w.setQuestionClosed(c, true)
+}
+
+func (w *WebHandler) ReopenQuestion(c *gin.Context) {
+	w.setQuestionClosed(c, false)
+}
+
+func (w *WebHandler) setQuestionClosed(c *gin.Context, closed bool) {
+	questionId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	q, err := w.Repo.GetQuestion(questionId)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !w.canViewPatient(c, q.PatientID) {
+		return
+	}
+	if err := w.Repo.SetQuestionClosed(questionId, closed); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// unassigned questions can be answered by any doctor
+func assignedToDoctor(q model.SafeQuestion, doctorId int) bool {
+	return q.AssigneeID == nil || *q.AssigneeID == doctorId
+}
+
+func (w *WebHandler) TriageQuestion(c *gin.Context) {
+	questionId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	var input model.TriageQuestionRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	_, err = w.Repo.GetQuestion(questionId)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := w.Repo.UpdateQuestionTriage(questionId, input.Category, input.Priority); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// assign the question to a doctor or back to unassigned
+func (w *WebHandler) AssignQuestion(c *gin.Context) {
+	questionId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	var input model.AssignQuestionRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	_, err = w.Repo.GetQuestion(questionId)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if input.AssigneeID != nil {
+		_, err := w.Repo.GetDoctorById(*input.AssigneeID)
+		if err != nil {
+			if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+				c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "assignee doesn't exist"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if err := w.Repo.AssignQuestion(questionId, input.AssigneeID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// take an unassigned question into the doctor's queue
+func (w *WebHandler) ClaimQuestion(c *gin.Context) {
+	w.setQuestionAssignee(c, true)
+}
+
+// put the doctor's question back to unassigned
+func (w *WebHandler) ReleaseQuestion(c *gin.Context) {
+	w.setQuestionAssignee(c, false)
+}
+
+func (w *WebHandler) setQuestionAssignee(c *gin.Context, claim bool) {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	questionId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	_, err = w.Repo.GetQuestion(questionId)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if claim {
+		err = w.Repo.ClaimQuestion(questionId, dId.(int))
+	} else {
+		err = w.Repo.ReleaseQuestion(questionId, dId.(int))
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrQuestionAssigned) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	go w.NotiService.SendNotiByPatientId(q.PatientID, "แพทย์ตอบคำถามของคุณแล้ว!", "ดูคำตอบในแอปพลิเคชัน")
 	c.Status(http.StatusOK)
 }
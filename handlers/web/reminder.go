@@ -0,0 +1,81 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+)
+
+func (w *WebHandler) GetAllReminderRule(c *gin.Context) {
+	rules, err := w.Repo.GetAllReminderRule()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rules)
+}
+
+func (w *WebHandler) CreateReminderRule(c *gin.Context) {
+	var input model.ReminderRuleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	insertedId, err := w.Repo.CreateReminderRule(model.ReminderRule{
+		Name:          input.Name,
+		OffsetMinutes: input.OffsetMinutes,
+		Enabled:       input.Enabled,
+	})
+	if err != nil {
+		if errors.Unwrap(err) == repository.ErrDuplicateEntry {
+			c.JSON(http.StatusConflict, gin.H{"error": "reminder rule with this offset already exists"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
+}
+
+func (w *WebHandler) UpdateReminderRule(c *gin.Context) {
+	var input model.ReminderRuleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	i := c.Param("id")
+	id, err := strconv.Atoi(i)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	err = w.Repo.UpdateReminderRule(model.ReminderRule{
+		ID:            id,
+		Name:          input.Name,
+		OffsetMinutes: input.OffsetMinutes,
+		Enabled:       input.Enabled,
+	})
+	if err != nil {
+		if errors.Unwrap(err) == repository.ErrDuplicateEntry {
+			c.JSON(http.StatusConflict, gin.H{"error": "reminder rule with this offset already exists"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (w *WebHandler) DeleteReminderRule(c *gin.Context) {
+	id := c.Param("id")
+	err := w.Repo.DeleteReminderRule(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
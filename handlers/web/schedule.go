@@ -0,0 +1,151 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func (w *WebHandler) GetDoctorSchedule(c *gin.Context) {
+	i := c.Param("id")
+	id, err := strconv.Atoi(i)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	schedules, err := w.Repo.GetDoctorSchedule(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	// upcoming exceptions of this doctor including clinic holidays
+	exceptions, err := w.Repo.GetAllScheduleException(
+		repository.Criteria{QueryCriteria: repository.DOCTORID_OR_CLINIC, Value: id},
+		repository.Criteria{QueryCriteria: repository.ENDAT_GREATERTHAN, Value: int(time.Now().Unix())},
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.DoctorScheduleResponse{Schedules: schedules, Exceptions: exceptions})
+}
+
+func (w *WebHandler) UpdateDoctorSchedule(c *gin.Context) {
+	var input model.UpdateDoctorScheduleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	i := c.Param("id")
+	id, err := strconv.Atoi(i)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	_, err = w.Repo.GetDoctorById(id) // check if this id exist
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	schedules := []model.DoctorSchedule{}
+	for _, s := range input.Data {
+		schedules = append(schedules, model.DoctorSchedule{
+			DoctorID:    id,
+			Weekday:     s.Weekday,
+			StartMinute: s.StartMinute,
+			EndMinute:   s.EndMinute,
+			SlotMinutes: s.SlotMinutes,
+		})
+	}
+	err = w.Repo.ReplaceDoctorSchedule(id, schedules)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (w *WebHandler) GetAllScheduleException(c *gin.Context) {
+	criteriaList := []repository.Criteria{}
+	if d, exist := c.GetQuery("doctorId"); exist {
+		doctorId, err := strconv.Atoi(d)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse doctorId value"})
+			return
+		}
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.DOCTORID_OR_CLINIC, Value: doctorId})
+	}
+	if f, exist := c.GetQuery("from"); exist {
+		from, err := strconv.Atoi(f)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse from value"})
+			return
+		}
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.ENDAT_GREATERTHAN, Value: from})
+	}
+	if t, exist := c.GetQuery("to"); exist {
+		to, err := strconv.Atoi(t)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse to value"})
+			return
+		}
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.STARTAT_LESSTHAN, Value: to})
+	}
+	exceptions, err := w.Repo.GetAllScheduleException(criteriaList...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, exceptions)
+}
+
+func (w *WebHandler) CreateScheduleException(c *gin.Context) {
+	var input model.CreateScheduleExceptionRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// doctorId = nil means clinic holiday
+	if input.DoctorId != nil {
+		_, err := w.Repo.GetDoctorById(*input.DoctorId)
+		if err != nil {
+			if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+				c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid doctorId"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	insertedId, err := w.Repo.CreateScheduleException(model.ScheduleException{
+		DoctorID: input.DoctorId,
+		StartAt:  input.StartAt,
+		EndAt:    input.EndAt,
+		Reason:   input.Reason,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
+}
+
+func (w *WebHandler) DeleteScheduleException(c *gin.Context) {
+	id := c.Param("id")
+	err := w.Repo.DeleteScheduleException(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,186 @@
+package web
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// patient signups by review state, pending by default
+func (w *WebHandler) GetAllSignup(c *gin.Context) {
+	limit, offset, err := utils.Paging(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	status := model.VerificationStatus(c.DefaultQuery("status", string(model.SIGNUP_PENDING)))
+	switch status {
+	case model.SIGNUP_PENDING, model.SIGNUP_APPROVED, model.SIGNUP_REJECTED:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be pending, approved or rejected"})
+		return
+	}
+	patients, err := w.Repo.GetAllPatient(limit, offset, repository.Criteria{QueryCriteria: repository.VERIFICATION, Value: status})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, patients)
+}
+
+func (w *WebHandler) ApproveSignup(c *gin.Context) {
+	var input model.ApproveSignupRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	patient, doctorId, ok := w.signupPatient(c)
+	if !ok {
+		return
+	}
+	if err := w.Repo.ApproveSignup(patient.ID, input.Hn, doctorId); err != nil {
+		writeSignupError(c, patient, err)
+		return
+	}
+	params := model.TemplateParams{"firstName": patient.FirstName, "hn": input.Hn}
+	go w.NotiService.SendTemplateByPatientId(patient.ID, model.TEMPLATE_SIGNUP_APPROVED, params, model.NotificationLink{})
+	c.Status(http.StatusOK)
+}
+
+func (w *WebHandler) RejectSignup(c *gin.Context) {
+	var input model.RejectSignupRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	patient, doctorId, ok := w.signupPatient(c)
+	if !ok {
+		return
+	}
+	if err := w.Repo.RejectSignup(patient.ID, input.Reason, doctorId); err != nil {
+		writeSignupError(c, patient, err)
+		return
+	}
+	params := model.TemplateParams{"firstName": patient.FirstName, "reason": input.Reason}
+	go w.NotiService.SendTemplateByPatientId(patient.ID, model.TEMPLATE_SIGNUP_REJECTED, params, model.NotificationLink{})
+	c.Status(http.StatusOK)
+}
+
+// patient of the signup in the url and the reviewing doctor
+func (w *WebHandler) signupPatient(c *gin.Context) (model.Patient, int, bool) {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return model.Patient{}, 0, false
+	}
+	patient, err := w.Repo.GetPatientById(c.Param("id"))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return patient, 0, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return patient, 0, false
+	}
+	return patient, dId.(int), true
+}
+
+func writeSignupError(c *gin.Context, patient model.Patient, err error) {
+	switch errors.Unwrap(err) {
+	case repository.ErrInvalidStatusTransition:
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("signup is already %v", patient.VerificationStatus)})
+	case repository.ErrDuplicateEntry:
+		c.JSON(http.StatusConflict, gin.H{"error": "duplicate HN"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// invitation codes newest first, unused=true lists codes that can still be used
+func (w *WebHandler) GetAllInvitationCode(c *gin.Context) {
+	criteriaList := []repository.Criteria{}
+	limit, offset, err := utils.Paging(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if u, exist := c.GetQuery("unused"); exist {
+		unused, err := strconv.ParseBool(u)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot parse unused value"})
+			return
+		}
+		if unused {
+			criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.USEDBYID_ISNULL})
+		}
+	}
+	codes, err := w.Repo.GetAllInvitationCode(limit, offset, criteriaList...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, codes)
+}
+
+func (w *WebHandler) CreateInvitationCode(c *gin.Context) {
+	var input model.CreateInvitationCodeRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	invitation := model.InvitationCode{Hn: input.Hn, Note: input.Note, CreatedByID: dId.(int), ExpireAt: input.ExpireAt}
+	// retry on the unlikely collision with an existing code
+	var err error
+	for range 3 {
+		invitation.Code, err = newInvitationCode()
+		if err != nil {
+			break
+		}
+		invitation.ID, err = w.Repo.CreateInvitationCode(invitation)
+		if errors.Unwrap(err) != repository.ErrDuplicateEntry {
+			break
+		}
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": invitation.ID, "code": invitation.Code})
+}
+
+func (w *WebHandler) DeleteInvitationCode(c *gin.Context) {
+	if err := w.Repo.DeleteInvitationCode(c.Param("id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// letters and digits that can't be misread when typed from paper e.g. no 0/O or 1/I
+const invitationAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+func newInvitationCode() (string, error) {
+	code := make([]byte, 8)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(invitationAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = invitationAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
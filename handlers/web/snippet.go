@@ -0,0 +1,191 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// own and shared snippets of the doctor, most used first
+func (w *WebHandler) GetAllAnswerSnippet(c *gin.Context) {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	limit, offset, err := utils.Paging(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	criteriaList := []repository.Criteria{{QueryCriteria: repository.SNIPPET_VISIBLE, Value: dId.(int)}}
+	if scope, exist := c.GetQuery("scope"); exist {
+		switch scope {
+		case "mine":
+			criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.OWNERID, Value: dId.(int)})
+		case "shared":
+			criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.IS_SHARED, Value: true})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid scope value"})
+			return
+		}
+	}
+	// popular answers that have no article yet
+	if c.Query("hasContent") == "false" {
+		criteriaList = append(criteriaList, repository.Criteria{QueryCriteria: repository.CONTENTID_ISNULL})
+	}
+	snippets, err := w.Repo.GetAllAnswerSnippet(limit, offset, c.Query("search"), criteriaList...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, snippets)
+}
+
+func (w *WebHandler) GetAnswerSnippet(c *gin.Context) {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	snippet, err := w.Repo.GetAnswerSnippet(c.Param("id"))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	// personal snippets of other doctors don't exist for this doctor
+	if !snippet.Shared && snippet.OwnerID != dId.(int) {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	c.JSON(http.StatusOK, snippet)
+}
+
+func (w *WebHandler) CreateAnswerSnippet(c *gin.Context) {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	var input model.AnswerSnippetRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !w.validSnippetContent(c, input.ContentID) {
+		return
+	}
+	insertedId, err := w.Repo.CreateAnswerSnippet(model.AnswerSnippet{
+		Title:     input.Title,
+		Body:      input.Body,
+		Shared:    input.Shared,
+		OwnerID:   dId.(int),
+		ContentID: input.ContentID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
+}
+
+func (w *WebHandler) UpdateAnswerSnippet(c *gin.Context) {
+	snippetId, ok := w.ownSnippet(c)
+	if !ok {
+		return
+	}
+	var input model.AnswerSnippetRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !w.validSnippetContent(c, input.ContentID) {
+		return
+	}
+	err := w.Repo.UpdateAnswerSnippet(model.AnswerSnippet{
+		ID:        snippetId,
+		Title:     input.Title,
+		Body:      input.Body,
+		Shared:    input.Shared,
+		ContentID: input.ContentID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (w *WebHandler) DeleteAnswerSnippet(c *gin.Context) {
+	snippetId, ok := w.ownSnippet(c)
+	if !ok {
+		return
+	}
+	if err := w.Repo.DeleteAnswerSnippet(snippetId); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// only the owner can change a snippet, even a shared one
+func (w *WebHandler) ownSnippet(c *gin.Context) (int, bool) {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return 0, false
+	}
+	snippetId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return 0, false
+	}
+	snippet, err := w.Repo.GetAnswerSnippet(snippetId)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return 0, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return 0, false
+	}
+	if snippet.OwnerID != dId.(int) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the owner can change this snippet"})
+		return 0, false
+	}
+	return snippetId, true
+}
+
+// linked content must exist
+func (w *WebHandler) validSnippetContent(c *gin.Context, contentId *int) bool {
+	if contentId == nil {
+		return true
+	}
+	_, err := w.Repo.GetContent(*contentId)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "content doesn't exist"})
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
+// the answer is already sent, a missed count isn't worth failing the request
+func (w *WebHandler) countSnippetUsage(snippetId *int) {
+	if snippetId != nil {
+		w.Repo.IncreaseSnippetUsage(*snippetId)
+	}
+}
@@ -0,0 +1,99 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/services/notification"
+	"github.com/gin-gonic/gin"
+)
+
+// every notification template with its text in each language, edited text replaces the built-in text
+func (w *WebHandler) GetAllNotificationTemplate(c *gin.Context) {
+	overrides, err := w.Repo.GetAllNotificationTemplate()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	edited := map[model.TemplateKey]map[model.Language]model.NotificationTemplate{}
+	for _, t := range overrides {
+		if edited[t.Key] == nil {
+			edited[t.Key] = map[model.Language]model.NotificationTemplate{}
+		}
+		edited[t.Key][t.Language] = t
+	}
+	res := []model.NotificationTemplateResponse{}
+	for _, def := range notification.TemplateDefinitions {
+		texts := []model.TemplateText{}
+		for _, language := range model.SUPPORTED_LANGUAGES {
+			text := def.Defaults[language]
+			if t, ok := edited[def.Key][language]; ok {
+				text = model.TemplateText{Language: language, Title: t.Title, Body: t.Body}
+			}
+			texts = append(texts, text)
+		}
+		res = append(res, model.NotificationTemplateResponse{
+			Key:          def.Key,
+			Category:     def.Category,
+			Placeholders: def.Placeholders,
+			Texts:        texts,
+		})
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WebHandler) UpdateNotificationTemplate(c *gin.Context) {
+	def, language, ok := templateParams(c)
+	if !ok {
+		return
+	}
+	var input model.UpdateNotificationTemplateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	for _, text := range []string{input.Title, input.Body} {
+		if err := def.Validate(text); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	err := w.Repo.UpsertNotificationTemplate(model.NotificationTemplate{
+		Key:      def.Key,
+		Language: language,
+		Title:    input.Title,
+		Body:     input.Body,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// reset the template in the language to the built-in text
+func (w *WebHandler) ResetNotificationTemplate(c *gin.Context) {
+	def, language, ok := templateParams(c)
+	if !ok {
+		return
+	}
+	if err := w.Repo.DeleteNotificationTemplate(def.Key, language); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func templateParams(c *gin.Context) (notification.TemplateDefinition, model.Language, bool) {
+	def, err := notification.GetTemplateDefinition(model.TemplateKey(c.Param("key")))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return def, "", false
+	}
+	language := model.Language(c.Param("language"))
+	if !language.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid language"})
+		return def, "", false
+	}
+	return def, language, true
+}
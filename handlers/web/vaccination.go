@@ -0,0 +1,306 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/vaccination"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func (w *WebHandler) GetAllVaccine(c *gin.Context) {
+	vaccines, err := w.Repo.GetAllVaccine()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, vaccines)
+}
+
+func (w *WebHandler) CreateVaccine(c *gin.Context) {
+	var input model.VaccineRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	insertedId, err := w.Repo.CreateVaccine(model.Vaccine{Name: input.Name, Live: input.Live, Note: input.Note})
+	if err != nil {
+		if errors.Unwrap(err) == repository.ErrDuplicateEntry {
+			c.JSON(http.StatusConflict, gin.H{"error": "vaccine with this name already exists"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
+}
+
+// recorded vaccinations keep the name they were given with
+func (w *WebHandler) UpdateVaccine(c *gin.Context) {
+	var input model.VaccineRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	err = w.Repo.UpdateVaccine(model.Vaccine{ID: id, Name: input.Name, Live: input.Live, Note: input.Note})
+	if err != nil {
+		if errors.Unwrap(err) == repository.ErrDuplicateEntry {
+			c.JSON(http.StatusConflict, gin.H{"error": "vaccine with this name already exists"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (w *WebHandler) DeleteVaccine(c *gin.Context) {
+	err := w.Repo.DeleteVaccine(c.Param("id"))
+	if err != nil {
+		if errors.Unwrap(err) == repository.ErrForeignKeyFail {
+			c.JSON(http.StatusConflict, gin.H{"error": "vaccine is used by a schedule rule"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (w *WebHandler) GetAllVaccineRule(c *gin.Context) {
+	rules, err := w.Repo.GetAllVaccineRule()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rules)
+}
+
+func (w *WebHandler) CreateVaccineRule(c *gin.Context) {
+	var input model.VaccineRuleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !validVaccineSeries(c, input) {
+		return
+	}
+	insertedId, err := w.Repo.CreateVaccineRule(vaccineRule(input))
+	if err != nil {
+		w.vaccineRuleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
+}
+
+func (w *WebHandler) UpdateVaccineRule(c *gin.Context) {
+	var input model.VaccineRuleRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !validVaccineSeries(c, input) {
+		return
+	}
+	rule := vaccineRule(input)
+	rule.ID = id
+	if err := w.Repo.UpdateVaccineRule(rule); err != nil {
+		w.vaccineRuleError(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (w *WebHandler) DeleteVaccineRule(c *gin.Context) {
+	err := w.Repo.DeleteVaccineRule(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func vaccineRule(input model.VaccineRuleRequest) model.VaccineRule {
+	return model.VaccineRule{
+		VaccineID:      input.VaccineID,
+		MinAgeMonths:   input.MinAgeMonths,
+		Doses:          input.Doses,
+		IntervalMonths: input.IntervalMonths,
+		SteroidOnly:    input.SteroidOnly,
+		Enabled:        input.Enabled,
+	}
+}
+
+// every series except a single dose needs the interval between doses
+func validVaccineSeries(c *gin.Context, input model.VaccineRuleRequest) bool {
+	if input.Doses != 1 && input.IntervalMonths == 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "intervalMonths is required for more than one dose"})
+		return false
+	}
+	return true
+}
+
+func (w *WebHandler) vaccineRuleError(c *gin.Context, err error) {
+	switch errors.Unwrap(err) {
+	case repository.ErrDuplicateEntry:
+		c.JSON(http.StatusConflict, gin.H{"error": "this vaccine already has a schedule rule"})
+	case repository.ErrForeignKeyFail:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "vaccine doesn't exist"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// vaccinations of the patient, oldest first
+func (w *WebHandler) GetAllPatientVaccination(c *gin.Context) {
+	patientId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !w.canViewPatient(c, patientId) {
+		return
+	}
+	vaccinations, err := w.Repo.GetAllVaccination(patientId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, vaccinations)
+}
+
+func (w *WebHandler) CreatePatientVaccination(c *gin.Context) {
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return
+	}
+	patientId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var input model.VaccinationRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	patient, err := w.Repo.GetPatientById(patientId) // check if this id exist
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	vaccine, ok := w.catalogVaccine(c, input.VaccineID)
+	if !ok {
+		return
+	}
+	if vaccine.Live && !input.SteroidOverride {
+		regimens, err := w.Repo.GetAllMedicationRegimen(patientId)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if vaccination.OnHighDoseSteroid(regimens, patient.Weight, input.VaccinatedAt) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "live vaccine while on high dose steroids, set steroidOverride to record it"})
+			return
+		}
+	}
+	doctorId := dId.(int)
+	insertedId, err := w.Repo.CreateVaccination(model.Vaccination{
+		PatientID:    patientId,
+		VaccineID:    &vaccine.ID,
+		VaccineName:  vaccine.Name,
+		VaccinatedAt: input.VaccinatedAt,
+		Location:     input.Location,
+		Complication: input.Complication,
+		DoctorID:     &doctorId,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": insertedId})
+}
+
+func (w *WebHandler) UpdatePatientVaccination(c *gin.Context) {
+	var input model.VaccinationRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	vaccination, ok := w.patientVaccination(c)
+	if !ok {
+		return
+	}
+	vaccine, ok := w.catalogVaccine(c, input.VaccineID)
+	if !ok {
+		return
+	}
+	vaccination.VaccineID = &vaccine.ID
+	vaccination.VaccineName = vaccine.Name
+	vaccination.VaccinatedAt = input.VaccinatedAt
+	vaccination.Location = input.Location
+	vaccination.Complication = input.Complication
+	if err := w.Repo.UpdateVaccination(vaccination); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (w *WebHandler) DeletePatientVaccination(c *gin.Context) {
+	vaccination, ok := w.patientVaccination(c)
+	if !ok {
+		return
+	}
+	if err := w.Repo.DeleteVaccination(vaccination.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (w *WebHandler) patientVaccination(c *gin.Context) (model.Vaccination, bool) {
+	vaccination, err := w.Repo.GetVaccination(c.Param("id"), c.Param("vaccinationId"))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.Status(http.StatusNotFound)
+			return vaccination, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return vaccination, false
+	}
+	return vaccination, true
+}
+
+// vaccine of the request must be in the catalog
+func (w *WebHandler) catalogVaccine(c *gin.Context, vaccineId int) (model.Vaccine, bool) {
+	vaccine, err := w.Repo.GetVaccine(vaccineId)
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // no rows found
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "vaccine doesn't exist"})
+			return vaccine, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return vaccine, false
+	}
+	return vaccine, true
+}
@@ -70,29 +70,71 @@ func attachHandler(r *gin.Engine, m *mobile.MobileHandler, w *web.WebHandler, c
 			mobileAuth.POST("/login", m.Login)
 			mobileAuth.POST("/signup", m.Signup)
 			mobileAuth.POST("/logout", middleware.MobileAuthMiddleware, m.Logout)
+			mobileAuth.POST("/caregiver/signup", m.CaregiverSignup)
+			mobileAuth.POST("/caregiver/refresh", m.CaregiverRefresh)
+			mobileAuth.POST("/caregiver/login", m.CaregiverLogin)
 		}
 		mobileProtected := mobile.Group("/api")
 		mobileProtected.Use(middleware.MobileAuthMiddleware)
+		mobileProtected.Use(middleware.CaregiverLinkMiddleware(m.Repo))
 		mobileProtected.Use(a.ActivityLog)
 		{
 			mobileProtected.GET("/profile", m.GetProfile)
+			mobileProtected.GET("/profile/calendar", m.GetCalendarFeed)
+			mobileProtected.POST("/profile/calendar/rotate", middleware.MobilePermissionMiddleware(model.CAREGIVER_PROFILE), m.RotateCalendarFeed)
+			mobileProtected.PUT("/profile/language", middleware.MobilePermissionMiddleware(model.CAREGIVER_PROFILE), m.UpdateLanguage)
+			mobileProtected.GET("/measurement", m.GetMeasurementTrend)
+			mobileProtected.GET("/chart", c.GetPatientChart)
+			mobileProtected.GET("/medication", m.GetActiveMedication)
+			mobileProtected.GET("/medication/dose", m.GetMedicationDoses)
+			mobileProtected.POST("/medication/:id/dose", middleware.MobilePermissionMiddleware(model.CAREGIVER_MEDICATION), m.LogMedicationDose)
+			mobileProtected.PUT("/medication/:id/reminder", middleware.MobilePermissionMiddleware(model.CAREGIVER_MEDICATION), m.UpdateMedicationReminder)
+			mobileProtected.GET("/vaccination", m.GetVaccination)
+			mobileProtected.GET("/vaccination/due", c.GetPatientDueVaccine)
 			mobileProtected.GET("/appointment", m.GetAllPatientAppointment)
 			mobileProtected.GET("/appointment/:id", m.GetAppointment)
-			mobileProtected.POST("/appointment", m.CreateAppointment)
-			mobileProtected.DELETE("/appointment/:id", m.DeleteAppointment)
+			mobileProtected.GET("/appointment/:id/ics", m.GetAppointmentICS)
+			mobileProtected.POST("/appointment", middleware.MobilePermissionMiddleware(model.CAREGIVER_APPOINTMENT), m.CreateAppointment)
+			mobileProtected.DELETE("/appointment/:id", middleware.MobilePermissionMiddleware(model.CAREGIVER_APPOINTMENT), m.DeleteAppointment)
+			mobileProtected.POST("/appointment/:id/reschedule", middleware.MobilePermissionMiddleware(model.CAREGIVER_APPOINTMENT), m.RequestReschedule)
 			mobileProtected.GET("/question", m.GetAllPatientQuestion)
 			mobileProtected.GET("/question/:id", m.GetQuestion)
-			mobileProtected.POST("/question", m.CreateQuestion)
-			mobileProtected.DELETE("/question/:id", m.DeleteQuestion)
+			mobileProtected.POST("/question", middleware.MobilePermissionMiddleware(model.CAREGIVER_QUESTION), m.CreateQuestion)
+			mobileProtected.DELETE("/question/:id", middleware.MobilePermissionMiddleware(model.CAREGIVER_QUESTION), m.DeleteQuestion)
+			mobileProtected.POST("/question/:id/message", middleware.MobilePermissionMiddleware(model.CAREGIVER_QUESTION), m.CreateQuestionMessage)
+			mobileProtected.PUT("/question/:id/read", m.ReadQuestion)
+			mobileProtected.PUT("/question/:id/close", middleware.MobilePermissionMiddleware(model.CAREGIVER_QUESTION), m.CloseQuestion)
+			mobileProtected.PUT("/question/:id/reopen", middleware.MobilePermissionMiddleware(model.CAREGIVER_QUESTION), m.ReopenQuestion)
+			mobileProtected.POST("/question/:id/attachment", middleware.MobilePermissionMiddleware(model.CAREGIVER_QUESTION), c.UploadQuestionAttachment)
+			mobileProtected.GET("/question/:id/attachment/:attachmentId/url", c.GetPatientQuestionAttachmentURL)
 			mobileProtected.GET("/doctor", m.GetAllDoctor)
+			mobileProtected.GET("/doctor/:id/slots", m.GetDoctorSlots)
 			mobileProtected.GET("/device", m.GetAllDevice)
 			mobileProtected.POST("/device", m.CreateDevice)
-			mobileProtected.POST("/reset-password", m.ResetPassword)
-			mobileProtected.POST("/reset-pin", m.ResetPin)
+			mobileProtected.GET("/notification", m.GetAllNotification)
+			mobileProtected.GET("/notification/unreadCount", m.GetUnreadNotificationCount)
+			mobileProtected.PUT("/notification/read", m.ReadAllNotification)
+			mobileProtected.GET("/notification/preference", m.GetNotificationPreference)
+			mobileProtected.PUT("/notification/preference", middleware.MobilePermissionMiddleware(model.CAREGIVER_PROFILE), m.UpdateNotificationPreference)
+			mobileProtected.PUT("/notification/:id/read", m.ReadNotification)
+			mobileProtected.POST("/reset-password", middleware.MobilePatientOnlyMiddleware, m.ResetPassword)
+			mobileProtected.POST("/reset-pin", middleware.MobilePatientOnlyMiddleware, m.ResetPin)
+			mobileProtected.GET("/caregiver", m.GetAllCaregiver)
+			mobileProtected.POST("/caregiver", middleware.MobilePatientOnlyMiddleware, m.LinkCaregiver)
+			mobileProtected.PUT("/caregiver/:id", middleware.MobilePatientOnlyMiddleware, m.UpdateCaregiverLink)
+			mobileProtected.DELETE("/caregiver/:id", middleware.MobilePatientOnlyMiddleware, m.UnlinkCaregiver)
+			mobileProtected.GET("/caregiver/patient", m.GetCaregiverPatient)
+			mobileProtected.POST("/caregiver/switch", m.SwitchPatient)
 			mobileProtected.GET("/content", c.GetAllContent)
 			mobileProtected.GET("/content/:id", c.GetOneContent)
 		}
 	}
+	calendar := r.Group("/calendar")
+	{
+		// protected by signed token in url, calendar apps can't send auth header
+		calendar.GET("/doctor/:token", c.GetDoctorCalendarFeed)
+		calendar.GET("/patient/:token", c.GetPatientCalendarFeed)
+	}
 	web := r.Group("/web")
 	// r.Static("/static", "./assets")
 	// r.GET("/", func(c *gin.Context) {
@@ -102,7 +144,10 @@ func attachHandler(r *gin.Engine, m *mobile.MobileHandler, w *web.WebHandler, c
 		c.JSON(http.StatusOK, "DMD We Care API")
 	})
 	{
-		web.POST("/sendDailyNotifications", w.SendDailyNotifications)
+		// old name of the reminder trigger, external schedulers may still call it
+		web.POST("/sendDailyNotifications", w.SendReminders)
+		web.POST("/sendReminders", w.SendReminders)
+		web.POST("/processOutbox", w.ProcessOutbox)
 		webAuth := web.Group("/auth")
 		{
 			webAuth.POST("/login", w.Login)
@@ -114,31 +159,112 @@ func attachHandler(r *gin.Engine, m *mobile.MobileHandler, w *web.WebHandler, c
 			webProtected.GET("/userData", w.GetUserData)
 			webProtected.GET("/profile", w.GetProfile)
 			webProtected.PUT("/profile", w.UpdateProfile)
+			webProtected.GET("/profile/calendar", w.GetCalendarFeed)
+			webProtected.POST("/profile/calendar/rotate", w.RotateCalendarFeed)
+			webProtected.GET("/notification", w.GetAllDoctorNotification)
+			webProtected.GET("/notification/unreadCount", w.GetDoctorUnreadNotificationCount)
+			webProtected.PUT("/notification/read", w.ReadAllDoctorNotification)
+			webProtected.PUT("/notification/:id/read", w.ReadDoctorNotification)
 			webProtected.GET("/doctor", w.GetAllDoctor)
 			webProtected.POST("/doctor", middleware.WebRBACMiddleware(middleware.CreateDoctorPermission), w.CreateDoctor)
 			webProtected.GET("/doctor/:id", w.GetDoctor)
 			webProtected.PUT("/doctor/:id", middleware.WebRBACMiddleware(middleware.UpdateDoctorPermission), w.UpdateDoctor)
 			webProtected.DELETE("/doctor/:id", middleware.WebRBACMiddleware(middleware.DeleteDoctorPermission), w.DeleteDoctor)
+			webProtected.GET("/doctor/:id/schedule", w.GetDoctorSchedule)
+			webProtected.PUT("/doctor/:id/schedule", middleware.WebRBACMiddleware(middleware.ManageSchedulePermission), w.UpdateDoctorSchedule)
+			webProtected.GET("/scheduleException", w.GetAllScheduleException)
+			webProtected.POST("/scheduleException", middleware.WebRBACMiddleware(middleware.ManageSchedulePermission), w.CreateScheduleException)
+			webProtected.DELETE("/scheduleException/:id", middleware.WebRBACMiddleware(middleware.ManageSchedulePermission), w.DeleteScheduleException)
 			webProtected.GET("/patient", w.GetAllPatient)
 			// webProtected.POST("/patient", middleware.WebRBACMiddleware(middleware.CreatePatientPermission), w.CreatePatient)
 			webProtected.GET("/patient/:id", w.GetPatient)
+			webProtected.GET("/patient/:id/deviceRemoval", w.GetPatientDeviceRemoval)
 			webProtected.PUT("/patient/:id", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.UpdatePatient)
 			webProtected.PUT("/patient/:id/vaccineHistory", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.UpdatePatientVaccineHistory)
 			webProtected.PUT("/patient/:id/medicine", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.UpdatePatientMedicine)
 			webProtected.DELETE("/patient/:id", middleware.WebRBACMiddleware(middleware.DeletePatientPermission), w.DeletePatient)
+			webProtected.GET("/patient/:id/measurement", w.GetAllPatientMeasurement)
+			webProtected.POST("/patient/:id/measurement", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.CreatePatientMeasurement)
+			webProtected.PUT("/patient/:id/measurement/:measurementId", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.UpdatePatientMeasurement)
+			webProtected.DELETE("/patient/:id/measurement/:measurementId", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.DeletePatientMeasurement)
+			webProtected.GET("/patient/:id/chart", c.GetDoctorChart)
+			webProtected.GET("/patient/:id/medication", w.GetAllPatientMedication)
+			webProtected.POST("/patient/:id/medication", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.CreatePatientMedication)
+			webProtected.POST("/patient/:id/medication/:regimenId/change", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.ChangePatientMedication)
+			webProtected.PUT("/patient/:id/medication/:regimenId/stop", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.StopPatientMedication)
+			webProtected.DELETE("/patient/:id/medication/:regimenId", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.DeletePatientMedication)
+			webProtected.GET("/patient/:id/adherence", w.GetPatientAdherence)
+			webProtected.GET("/patient/:id/vaccination", w.GetAllPatientVaccination)
+			webProtected.GET("/patient/:id/vaccination/due", c.GetDoctorDueVaccine)
+			webProtected.POST("/patient/:id/vaccination", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.CreatePatientVaccination)
+			webProtected.PUT("/patient/:id/vaccination/:vaccinationId", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.UpdatePatientVaccination)
+			webProtected.DELETE("/patient/:id/vaccination/:vaccinationId", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.DeletePatientVaccination)
+			webProtected.GET("/patient/:id/careTeam", w.GetPatientCareTeam)
+			webProtected.POST("/patient/:id/careTeam", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.AddPatientCareTeamMember)
+			webProtected.PUT("/patient/:id/careTeam/:doctorId", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.UpdatePatientCareTeamMember)
+			webProtected.DELETE("/patient/:id/careTeam/:doctorId", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.RemovePatientCareTeamMember)
+			webProtected.GET("/patient/:id/caregiver", w.GetPatientCaregiver)
+			webProtected.DELETE("/patient/:id/caregiver/:caregiverId", middleware.WebRBACMiddleware(middleware.UpdatePatientPermission), w.RemovePatientCaregiver)
+			webProtected.GET("/caregiver/:id/activity", middleware.WebRBACMiddleware(middleware.ViewActivityPermission), w.GetCaregiverActivity)
+			webProtected.GET("/signup", middleware.WebRBACMiddleware(middleware.VerifySignupPermission), w.GetAllSignup)
+			webProtected.PUT("/signup/:id/approve", middleware.WebRBACMiddleware(middleware.VerifySignupPermission), w.ApproveSignup)
+			webProtected.PUT("/signup/:id/reject", middleware.WebRBACMiddleware(middleware.VerifySignupPermission), w.RejectSignup)
+			webProtected.GET("/invitationCode", middleware.WebRBACMiddleware(middleware.VerifySignupPermission), w.GetAllInvitationCode)
+			webProtected.POST("/invitationCode", middleware.WebRBACMiddleware(middleware.VerifySignupPermission), w.CreateInvitationCode)
+			webProtected.DELETE("/invitationCode/:id", middleware.WebRBACMiddleware(middleware.VerifySignupPermission), w.DeleteInvitationCode)
+			webProtected.GET("/vaccine", w.GetAllVaccine)
+			webProtected.POST("/vaccine", middleware.WebRBACMiddleware(middleware.ManageVaccinePermission), w.CreateVaccine)
+			webProtected.PUT("/vaccine/:id", middleware.WebRBACMiddleware(middleware.ManageVaccinePermission), w.UpdateVaccine)
+			webProtected.DELETE("/vaccine/:id", middleware.WebRBACMiddleware(middleware.ManageVaccinePermission), w.DeleteVaccine)
+			webProtected.GET("/vaccineRule", w.GetAllVaccineRule)
+			webProtected.POST("/vaccineRule", middleware.WebRBACMiddleware(middleware.ManageVaccinePermission), w.CreateVaccineRule)
+			webProtected.PUT("/vaccineRule/:id", middleware.WebRBACMiddleware(middleware.ManageVaccinePermission), w.UpdateVaccineRule)
+			webProtected.DELETE("/vaccineRule/:id", middleware.WebRBACMiddleware(middleware.ManageVaccinePermission), w.DeleteVaccineRule)
 			webProtected.GET("/appointment", w.GetAllAppointment)
+			webProtected.GET("/appointment/report", w.GetAppointmentReport)
 			webProtected.GET("/appointment/:id", w.GetAppointment)
+			webProtected.GET("/appointment/:id/history", w.GetAppointmentHistory)
+			webProtected.PUT("/appointment/:id/status", w.UpdateAppointmentStatus)
+			webProtected.PUT("/appointment/:id/reschedule", w.ResolveRescheduleRequest)
 			webProtected.POST("/appointment", w.CreateAppointment)
 			webProtected.PUT("/appointment/:id", w.UpdateAppointment)
 			webProtected.DELETE("/appointment/:id", w.DeleteAppointment)
+			webProtected.GET("/reminderRule", w.GetAllReminderRule)
+			webProtected.POST("/reminderRule", middleware.WebRBACMiddleware(middleware.ManageReminderPermission), w.CreateReminderRule)
+			webProtected.PUT("/reminderRule/:id", middleware.WebRBACMiddleware(middleware.ManageReminderPermission), w.UpdateReminderRule)
+			webProtected.DELETE("/reminderRule/:id", middleware.WebRBACMiddleware(middleware.ManageReminderPermission), w.DeleteReminderRule)
+			webProtected.GET("/campaign", w.GetAllCampaign)
+			webProtected.GET("/campaign/:id", w.GetCampaign)
+			webProtected.GET("/campaign/:id/stats", w.GetCampaignStats)
+			webProtected.POST("/campaign/preview", middleware.WebRBACMiddleware(middleware.ManageCampaignPermission), w.PreviewCampaign)
+			webProtected.POST("/campaign", middleware.WebRBACMiddleware(middleware.ManageCampaignPermission), w.CreateCampaign)
+			webProtected.DELETE("/campaign/:id", middleware.WebRBACMiddleware(middleware.ManageCampaignPermission), w.CancelCampaign)
+			webProtected.GET("/notificationTemplate", w.GetAllNotificationTemplate)
+			webProtected.PUT("/notificationTemplate/:key/:language", middleware.WebRBACMiddleware(middleware.ManageTemplatePermission), w.UpdateNotificationTemplate)
+			webProtected.DELETE("/notificationTemplate/:key/:language", middleware.WebRBACMiddleware(middleware.ManageTemplatePermission), w.ResetNotificationTemplate)
 			webProtected.GET("/question", w.GetAllQuestion)
+			webProtected.GET("/question/queue", w.GetQuestionQueue)
 			webProtected.GET("/question/:id", w.GetQuestion)
 			webProtected.PUT("/question/:id/answer", w.AnswerQuestion)
+			webProtected.POST("/question/:id/message", w.CreateQuestionMessage)
+			webProtected.PUT("/question/:id/read", w.ReadQuestion)
+			webProtected.PUT("/question/:id/close", w.CloseQuestion)
+			webProtected.PUT("/question/:id/reopen", w.ReopenQuestion)
+			webProtected.PUT("/question/:id/claim", w.ClaimQuestion)
+			webProtected.PUT("/question/:id/release", w.ReleaseQuestion)
+			webProtected.PUT("/question/:id/triage", middleware.WebRBACMiddleware(middleware.TriageQuestionPermission), w.TriageQuestion)
+			webProtected.PUT("/question/:id/assign", middleware.WebRBACMiddleware(middleware.TriageQuestionPermission), w.AssignQuestion)
+			webProtected.GET("/question/:id/attachment/:attachmentId/url", c.GetDoctorQuestionAttachmentURL)
 			webProtected.GET("/content", c.GetAllContent)
 			webProtected.GET("/content/:id", c.GetOneContent)
 			webProtected.POST("/content", w.CreateContent)
 			webProtected.PUT("/content/:id", w.UpdateContent)
 			webProtected.DELETE("/content/:id", w.DeleteContent)
+			webProtected.GET("/snippet", w.GetAllAnswerSnippet)
+			webProtected.GET("/snippet/:id", w.GetAnswerSnippet)
+			webProtected.POST("/snippet", w.CreateAnswerSnippet)
+			webProtected.PUT("/snippet/:id", w.UpdateAnswerSnippet)
+			webProtected.DELETE("/snippet/:id", w.DeleteAnswerSnippet)
 			webProtected.POST("/image/upload", c.UploadImage)
 			webProtected.GET("/consent/:id", c.GetConsentById)
 			webProtected.GET("/consent/slug/:slug", c.GetConsentBySlug)
@@ -188,12 +314,47 @@ func setupDB() *gorm.DB {
 		&model.ActivityLog{},
 		&model.Appointment{},
 		&model.Device{},
+		&model.DeviceRemoval{},
+		&model.Notification{},
+		&model.NotificationPreference{},
+		&model.NotificationTemplate{},
+		&model.Campaign{},
 		&model.Doctor{},
 		&model.Patient{},
 		&model.Question{},
+		&model.QuestionMessage{},
+		&model.QuestionAttachment{},
 		&model.Content{},
+		&model.Measurement{},
+		&model.MedicationRegimen{},
+		&model.MedicationDose{},
+		&model.Vaccine{},
+		&model.VaccineRule{},
+		&model.Vaccination{},
+		&model.VaccineReminderLog{},
+		&model.CareTeamMember{},
+		&model.DoctorNotification{},
+		&model.Caregiver{},
+		&model.CaregiverLink{},
+		&model.InvitationCode{},
+		&model.AnswerSnippet{},
 		&model.Consent{},
+		&model.DoctorSchedule{},
+		&model.ScheduleException{},
+		&model.AppointmentHistory{},
+		&model.RescheduleRequest{},
+		&model.ReminderRule{},
+		&model.ReminderLog{},
+		&model.NotificationOutbox{},
+		&model.CalendarFeed{},
 	)
+	migrateAppointmentStatus(db)
+	migrateQuestionMessages(db)
+	migrateQuestionAwaiting(db)
+	migrateMedicationRegimens(db)
+	seedReminderRules(db)
+	seedVaccines(db)
+	migrateVaccinations(db)
 
 	mainLogger.Println("connected to the database")
 	return db
@@ -216,11 +377,31 @@ func setupRouter() *gin.Engine {
 
 func InitCronScheduler(service notification.INotificationService) *cron.Cron {
 	c := cron.New()
-	// everyday on 10.00 (GMT +7) -> spec : "00 00 03 * * *"
-	c.AddFunc("00 00 03 * * *", func() {
-		mainLogger.Println("executing Push Notifications..")
-		service.SendDailyNotifications(nil)
+	// every 15 minutes by default, often enough for hour-level reminder rules
+	err := c.AddFunc(config.AppConfig.REMINDER_CRON_SPEC, func() {
+		mainLogger.Println("executing reminder notifications..")
+		service.SendReminders()
+		service.SendMedicationReminders()
+	})
+	if err != nil {
+		mainLogger.Panicf("invalid REMINDER_CRON_SPEC : %v", err.Error())
+	}
+	err = c.AddFunc(config.AppConfig.VACCINE_CRON_SPEC, func() {
+		mainLogger.Println("executing vaccine reminders..")
+		service.SendVaccineReminders()
 	})
+	if err != nil {
+		mainLogger.Panicf("invalid VACCINE_CRON_SPEC : %v", err.Error())
+	}
+	// retry pending messages and poll delivery receipts
+	err = c.AddFunc(config.AppConfig.OUTBOX_CRON_SPEC, func() {
+		service.SendDueCampaigns()
+		service.ProcessOutbox()
+		service.CheckReceipts()
+	})
+	if err != nil {
+		mainLogger.Panicf("invalid OUTBOX_CRON_SPEC : %v", err.Error())
+	}
 	c.Start()
 	mainLogger.Println("cron scheduler initialized")
 	return c
@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 
+	"github.com/PhasitWo/duchenne-server/auth"
 	"github.com/PhasitWo/duchenne-server/model"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -50,6 +51,11 @@ func (a *ActivityLogMiddleware) ActivityLog(c *gin.Context) {
 			Data:       data,
 			Status:     status,
 		}
+		// caregiver who acted for the patient, so their actions can be told apart
+		if pc, ok := clm.(*auth.PatientAccessClaims); ok && pc.CaregiverId != 0 {
+			caregiverId := pc.CaregiverId
+			l.CaregiverID = &caregiverId
+		}
 		go func(log *model.ActivityLog) {
 			err := a.DB.Create(l).Error
 			if err != nil {
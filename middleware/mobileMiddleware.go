@@ -1,12 +1,16 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/PhasitWo/duchenne-server/auth"
 	"github.com/PhasitWo/duchenne-server/config"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
+	"gorm.io/gorm"
 )
 
 func MobileAuthMiddleware(c *gin.Context) {
@@ -40,5 +44,84 @@ func MobileAuthMiddleware(c *gin.Context) {
 	c.Set("claims", claims)
 	c.Set("patientId", claims.PatientId)
 	c.Set("deviceId", claims.DeviceId)
+	if claims.CaregiverId != 0 {
+		c.Set("caregiverId", claims.CaregiverId)
+	}
+	c.Next()
+}
+
+/*
+caregiver tokens are valid only while the link to the active patient and the device still exist,
+permissions are read from the link so that changes apply before the token expires, patients pass
+*/
+func CaregiverLinkMiddleware(repo repository.IRepo) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clm, exists := c.Get("claims")
+		if !exists {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'claims' from auth middleware"})
+			c.Abort()
+			return
+		}
+		claims := clm.(*auth.PatientAccessClaims)
+		if claims.CaregiverId == 0 {
+			c.Next()
+			return
+		}
+		link, err := repo.GetCaregiverLink(claims.CaregiverId, claims.PatientId)
+		if err != nil {
+			if errors.Unwrap(err) == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Caregiver is no longer linked to the patient"})
+				c.Abort()
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+		devices, err := repo.GetAllDevice(
+			repository.Criteria{QueryCriteria: repository.ID, Value: claims.DeviceId},
+			repository.Criteria{QueryCriteria: repository.CAREGIVERID, Value: claims.CaregiverId},
+			repository.Criteria{QueryCriteria: repository.PATIENTID, Value: claims.PatientId},
+		)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+		if len(devices) == 0 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Device is logged out"})
+			c.Abort()
+			return
+		}
+		claims.Permissions = link.Permissions
+		c.Next()
+	}
+}
+
+// caregivers need the permission on their link to the active patient, patients pass
+func MobilePermissionMiddleware(requiredPermission model.CaregiverPermission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clm, exists := c.Get("claims")
+		if !exists {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'claims' from auth middleware"})
+			c.Abort()
+			return
+		}
+		if !clm.(*auth.PatientAccessClaims).Can(requiredPermission) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// only the patient account itself, e.g. credentials and caregiver links
+func MobilePatientOnlyMiddleware(c *gin.Context) {
+	if _, exists := c.Get("caregiverId"); exists {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not allowed for caregivers"})
+		c.Abort()
+		return
+	}
 	c.Next()
 }
@@ -1,14 +1,17 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/PhasitWo/duchenne-server/auth"
 	"github.com/PhasitWo/duchenne-server/config"
 	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
+	"gorm.io/gorm"
 )
 
 func WebAuthMiddleware(c *gin.Context) {
@@ -61,19 +64,65 @@ func WebAuthMiddleware(c *gin.Context) {
 type permission string
 
 const (
-	CreateDoctorPermission  permission = "createDoctorPermission"
-	UpdateDoctorPermission  permission = "updateDoctorPermission"
-	DeleteDoctorPermission  permission = "deleteDoctorPermission"
-	CreatePatientPermission permission = "createPatientPermission"
-	UpdatePatientPermission permission = "updatePatientPermission"
-	DeletePatientPermission permission = "deletePatientPermission"
-	ManageConsentPermission permission = "manageConsentPermission"
+	CreateDoctorPermission   permission = "createDoctorPermission"
+	UpdateDoctorPermission   permission = "updateDoctorPermission"
+	DeleteDoctorPermission   permission = "deleteDoctorPermission"
+	CreatePatientPermission  permission = "createPatientPermission"
+	UpdatePatientPermission  permission = "updatePatientPermission"
+	DeletePatientPermission  permission = "deletePatientPermission"
+	ManageConsentPermission  permission = "manageConsentPermission"
+	ManageSchedulePermission permission = "manageSchedulePermission"
+	ManageReminderPermission permission = "manageReminderPermission"
+	ManageCampaignPermission permission = "manageCampaignPermission"
+	ManageTemplatePermission permission = "manageTemplatePermission"
+	TriageQuestionPermission permission = "triageQuestionPermission"
+	ManageVaccinePermission  permission = "manageVaccinePermission"
+	ViewActivityPermission   permission = "viewActivityPermission"
+	VerifySignupPermission   permission = "verifySignupPermission"
+	ViewAllPatientPermission permission = "viewAllPatientPermission"
 )
 
 var rolePermissionsMap = map[model.Role][]permission{
 	model.USER:  {},
-	model.ADMIN: {CreatePatientPermission, UpdatePatientPermission, DeletePatientPermission},
-	model.ROOT:  {CreatePatientPermission, UpdatePatientPermission, DeletePatientPermission, CreateDoctorPermission, UpdateDoctorPermission, DeleteDoctorPermission, ManageConsentPermission},
+	model.ADMIN: {CreatePatientPermission, UpdatePatientPermission, DeletePatientPermission, ManageSchedulePermission, ManageReminderPermission, ManageCampaignPermission, ManageTemplatePermission, TriageQuestionPermission, ManageVaccinePermission, ViewActivityPermission, VerifySignupPermission, ViewAllPatientPermission},
+	model.ROOT:  {CreatePatientPermission, UpdatePatientPermission, DeletePatientPermission, CreateDoctorPermission, UpdateDoctorPermission, DeleteDoctorPermission, ManageConsentPermission, ManageSchedulePermission, ManageReminderPermission, ManageCampaignPermission, ManageTemplatePermission, TriageQuestionPermission, ManageVaccinePermission, ViewActivityPermission, VerifySignupPermission, ViewAllPatientPermission},
+}
+
+// role has the permission, for checks that depend on the request e.g. listing scope
+func HasPermission(role model.Role, requiredPermission permission) bool {
+	for _, permission := range rolePermissionsMap[role] {
+		if permission == requiredPermission {
+			return true
+		}
+	}
+	return false
+}
+
+// doctors without ViewAllPatientPermission only see patients whose care team has them, others get 404
+func CanViewPatient(c *gin.Context, repo repository.IRepo, patientId int) bool {
+	r, exists := c.Get("doctorRole")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorRole' from auth middleware"})
+		return false
+	}
+	if HasPermission(r.(model.Role), ViewAllPatientPermission) {
+		return true
+	}
+	dId, exists := c.Get("doctorId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "no 'doctorId' from auth middleware"})
+		return false
+	}
+	_, err := repo.GetCareTeamMember(patientId, dId.(int))
+	if err != nil {
+		if errors.Unwrap(err) == gorm.ErrRecordNotFound { // not in the care team
+			c.Status(http.StatusNotFound)
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
 }
 
 func WebRBACMiddleware(requiredPermission permission) gin.HandlerFunc {
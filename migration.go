@@ -0,0 +1,237 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"gorm.io/gorm"
+)
+
+// appointments created before status lifecycle only have approve_at, safe to run on every startup
+func migrateAppointmentStatus(db *gorm.DB) {
+	err := db.Exec("UPDATE appointments SET status = 'approved' WHERE approve_at IS NOT NULL AND status = 'requested'").Error
+	if err != nil {
+		mainLogger.Printf("can't migrate appointment status : %v", err.Error())
+	}
+}
+
+/*
+questions created before threads only have a question and an answer, copy them into the thread,
+each side is copied only once so it's safe to run on every startup
+*/
+func migrateQuestionMessages(db *gorm.DB) {
+	queries := []string{
+		`INSERT INTO question_messages (question_id, author_type, author_id, message, create_at)
+		SELECT q.id, 'patient', q.patient_id, q.question, q.create_at FROM questions q
+		WHERE NOT EXISTS (SELECT 1 FROM question_messages m WHERE m.question_id = q.id AND m.author_type = 'patient')`,
+		`INSERT INTO question_messages (question_id, author_type, author_id, message, create_at)
+		SELECT q.id, 'doctor', q.doctor_id, q.answer, q.answer_at FROM questions q
+		WHERE q.answer IS NOT NULL AND q.answer_at IS NOT NULL AND q.doctor_id IS NOT NULL
+		AND NOT EXISTS (SELECT 1 FROM question_messages m WHERE m.question_id = q.id AND m.author_type = 'doctor')`,
+		// unanswered questions are still waiting for the doctors
+		`UPDATE questions SET last_message_at = COALESCE(answer_at, create_at), doctor_unread = IF(answer_at IS NULL, 1, 0)
+		WHERE last_message_at = 0`,
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, query := range queries {
+			if err := tx.Exec(query).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		mainLogger.Printf("can't migrate question messages : %v", err.Error())
+	}
+}
+
+// questions created before triage have no waiting time for the SLA, unanswered ones are waiting since created
+func migrateQuestionAwaiting(db *gorm.DB) {
+	err := db.Exec("UPDATE questions SET awaiting_since = create_at WHERE answer_at IS NULL AND awaiting_since IS NULL AND closed_at IS NULL").Error
+	if err != nil {
+		mainLogger.Printf("can't migrate question awaiting time : %v", err.Error())
+	}
+}
+
+/*
+medicines of the old json list become regimens with unknown start date, patients who already have
+regimens are skipped so it's safe to run on every startup
+*/
+func migrateMedicationRegimens(db *gorm.DB) {
+	var patients []model.Patient
+	err := db.Select("id", "medicine").
+		Where("medicine IS NOT NULL AND NOT EXISTS (SELECT 1 FROM medication_regimens m WHERE m.patient_id = patients.id)").
+		Find(&patients).Error
+	if err != nil {
+		mainLogger.Printf("can't migrate medication regimens : %v", err.Error())
+		return
+	}
+	regimens := []model.MedicationRegimen{}
+	for _, p := range patients {
+		for _, medicine := range p.Medicine {
+			regimens = append(regimens, legacyRegimen(p.ID, medicine))
+		}
+	}
+	if len(regimens) == 0 {
+		return
+	}
+	if err := db.CreateInBatches(&regimens, 100).Error; err != nil {
+		mainLogger.Printf("can't migrate medication regimens : %v", err.Error())
+		return
+	}
+	mainLogger.Printf("migrated %d medicines of %d patients to regimens", len(regimens), len(patients))
+}
+
+var legacyDosePattern = regexp.MustCompile(`^\s*(\d+(?:\.\d+)?)\s*([a-zA-Z]*)`)
+
+var legacyDoseUnits = map[string]model.DoseUnit{
+	"mg": model.DOSE_MG, "mcg": model.DOSE_MCG, "g": model.DOSE_G, "ml": model.DOSE_ML, "iu": model.DOSE_IU,
+	"tab": model.DOSE_TABLET, "tablet": model.DOSE_TABLET, "tablets": model.DOSE_TABLET,
+	"cap": model.DOSE_CAPSULE, "capsule": model.DOSE_CAPSULE, "capsules": model.DOSE_CAPSULE,
+	"puff": model.DOSE_PUFF, "puffs": model.DOSE_PUFF,
+}
+
+var legacySchedules = map[int]model.MedicationSchedule{
+	1: model.SCHEDULE_ONCE_DAILY,
+	2: model.SCHEDULE_TWICE_DAILY,
+	3: model.SCHEDULE_THREE_DAILY,
+	4: model.SCHEDULE_FOUR_DAILY,
+}
+
+// free text that can't be parsed is kept in the note, dose 0 means unknown
+func legacyRegimen(patientId int, medicine model.Medicine) model.MedicationRegimen {
+	regimen := model.MedicationRegimen{
+		PatientID:    patientId,
+		MedicineName: medicine.MedicineName,
+		Schedule:     model.SCHEDULE_OTHER,
+		Instruction:  medicine.Instruction,
+	}
+	text := func(s *string) string {
+		if s == nil {
+			return "-"
+		}
+		return *s
+	}
+	if medicine.Dose != nil {
+		if match := legacyDosePattern.FindStringSubmatch(*medicine.Dose); match != nil {
+			if unit, ok := legacyDoseUnits[strings.ToLower(match[2])]; ok {
+				regimen.Dose, _ = strconv.ParseFloat(match[1], 64)
+				regimen.DoseUnit = unit
+			}
+		}
+	}
+	if medicine.FrequencyPerDay != nil {
+		if n, err := strconv.Atoi(strings.TrimSpace(*medicine.FrequencyPerDay)); err == nil {
+			if schedule, ok := legacySchedules[n]; ok {
+				regimen.Schedule = schedule
+			}
+		}
+	}
+	note := fmt.Sprintf("migrated from the old medicine list, dose: %v, frequency per day: %v, quantity: %v",
+		text(medicine.Dose), text(medicine.FrequencyPerDay), text(medicine.Quantity))
+	regimen.Note = &note
+	return regimen
+}
+
+// default reminder stages, admin can change them later
+func seedReminderRules(db *gorm.DB) {
+	var cnt int64
+	if err := db.Model(&model.ReminderRule{}).Count(&cnt).Error; err != nil || cnt > 0 {
+		return
+	}
+	rules := []model.ReminderRule{
+		{Name: "7 วันก่อนนัด", OffsetMinutes: 7 * 24 * 60, Enabled: true},
+		{Name: "1 วันก่อนนัด", OffsetMinutes: 24 * 60, Enabled: true},
+		{Name: "2 ชั่วโมงก่อนนัด", OffsetMinutes: 2 * 60, Enabled: true},
+	}
+	if err := db.Create(&rules).Error; err != nil {
+		mainLogger.Printf("can't seed reminder rules : %v", err.Error())
+	}
+}
+
+// default vaccine catalog and schedule rules for patients with DMD, admin can change them later
+func seedVaccines(db *gorm.DB) {
+	var cnt int64
+	if err := db.Model(&model.Vaccine{}).Count(&cnt).Error; err != nil || cnt > 0 {
+		return
+	}
+	rules := []model.VaccineRule{
+		{Vaccine: model.Vaccine{Name: "Influenza"}, MinAgeMonths: 6, Doses: 0, IntervalMonths: 12, Enabled: true},
+		{Vaccine: model.Vaccine{Name: "Pneumococcal conjugate (PCV13)"}, MinAgeMonths: 24, Doses: 1, SteroidOnly: true, Enabled: true},
+		{Vaccine: model.Vaccine{Name: "Pneumococcal polysaccharide (PPSV23)"}, MinAgeMonths: 24, Doses: 2, IntervalMonths: 60, SteroidOnly: true, Enabled: true},
+		{Vaccine: model.Vaccine{Name: "MMR", Live: true}, MinAgeMonths: 9, Doses: 2, IntervalMonths: 9, Enabled: true},
+		{Vaccine: model.Vaccine{Name: "Varicella", Live: true}, MinAgeMonths: 12, Doses: 2, IntervalMonths: 36, Enabled: true},
+	}
+	if err := db.Create(&rules).Error; err != nil {
+		mainLogger.Printf("can't seed vaccines : %v", err.Error())
+	}
+}
+
+/*
+vaccines of the old json history become vaccinations, names that match the catalog are linked to it,
+patients who already have vaccinations are skipped so it's safe to run on every startup
+*/
+func migrateVaccinations(db *gorm.DB) {
+	var patients []model.Patient
+	err := db.Select("id", "vaccine_history").
+		Where("vaccine_history IS NOT NULL AND NOT EXISTS (SELECT 1 FROM vaccinations v WHERE v.patient_id = patients.id)").
+		Find(&patients).Error
+	if err != nil {
+		mainLogger.Printf("can't migrate vaccinations : %v", err.Error())
+		return
+	}
+	var vaccines []model.Vaccine
+	if err := db.Find(&vaccines).Error; err != nil {
+		mainLogger.Printf("can't migrate vaccinations : %v", err.Error())
+		return
+	}
+	catalog := map[string]int{}
+	for _, v := range vaccines {
+		catalog[strings.ToLower(v.Name)] = v.ID
+	}
+	vaccinations := []model.Vaccination{}
+	for _, p := range patients {
+		for _, history := range p.VaccineHistory {
+			vaccinations = append(vaccinations, legacyVaccination(p.ID, history, catalog))
+		}
+	}
+	if len(vaccinations) == 0 {
+		return
+	}
+	if err := db.CreateInBatches(&vaccinations, 100).Error; err != nil {
+		mainLogger.Printf("can't migrate vaccinations : %v", err.Error())
+		return
+	}
+	mainLogger.Printf("migrated %d vaccines of %d patients to vaccinations", len(vaccinations), len(patients))
+}
+
+// common names in the old history of the seeded vaccines
+var legacyVaccineNames = map[string]string{
+	"flu":        "influenza",
+	"pcv":        "pneumococcal conjugate (pcv13)",
+	"pcv13":      "pneumococcal conjugate (pcv13)",
+	"ppsv":       "pneumococcal polysaccharide (ppsv23)",
+	"ppsv23":     "pneumococcal polysaccharide (ppsv23)",
+	"chickenpox": "varicella",
+}
+
+func legacyVaccination(patientId int, history model.VaccineHistory, catalog map[string]int) model.Vaccination {
+	vaccination := model.Vaccination{
+		PatientID:    patientId,
+		VaccineName:  history.VaccineName,
+		VaccinatedAt: history.VaccineAt,
+		Location:     history.VaccineLocation,
+		Complication: history.Complication,
+	}
+	name := strings.ToLower(strings.TrimSpace(history.VaccineName))
+	if alias, ok := legacyVaccineNames[name]; ok {
+		name = alias
+	}
+	if id, ok := catalog[name]; ok {
+		vaccination.VaccineID = &id
+	}
+	return vaccination
+}
@@ -2,17 +2,71 @@ package model
 
 import "gorm.io/plugin/soft_delete"
 
+// Appointment states
+type AppointmentStatus string
+
+const (
+	REQUESTED            AppointmentStatus = "requested"
+	APPROVED             AppointmentStatus = "approved"
+	REJECTED             AppointmentStatus = "rejected"
+	RESCHEDULED          AppointmentStatus = "rescheduled"
+	CANCELLED_BY_PATIENT AppointmentStatus = "cancelled_by_patient"
+	CANCELLED_BY_STAFF   AppointmentStatus = "cancelled_by_staff"
+	COMPLETED            AppointmentStatus = "completed"
+	NO_SHOW              AppointmentStatus = "no_show"
+)
+
+// allowed transitions, states that are not listed are final
+var appointmentTransitions = map[AppointmentStatus][]AppointmentStatus{
+	REQUESTED:   {APPROVED, REJECTED, CANCELLED_BY_PATIENT, CANCELLED_BY_STAFF},
+	APPROVED:    {RESCHEDULED, CANCELLED_BY_PATIENT, CANCELLED_BY_STAFF, COMPLETED, NO_SHOW},
+	RESCHEDULED: {RESCHEDULED, CANCELLED_BY_PATIENT, CANCELLED_BY_STAFF, COMPLETED, NO_SHOW},
+}
+
+func (s AppointmentStatus) CanTransitionTo(next AppointmentStatus) bool {
+	for _, allowed := range appointmentTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// requested, approved or rescheduled appointment still occupies the doctor's time
+func (s AppointmentStatus) IsActive() bool {
+	return s == REQUESTED || s == APPROVED || s == RESCHEDULED
+}
+
+func (s AppointmentStatus) IsValid() bool {
+	switch s {
+	case REQUESTED, APPROVED, REJECTED, RESCHEDULED, CANCELLED_BY_PATIENT, CANCELLED_BY_STAFF, COMPLETED, NO_SHOW:
+		return true
+	}
+	return false
+}
+
+// appointment duration in minutes, default is used when the duration is not specified
+const (
+	DEFAULT_APPOINTMENT_DURATION = 30
+	MAX_APPOINTMENT_DURATION     = 480
+)
+
 type Appointment struct {
-	ID        int     `json:"id"`
-	CreateAt  int     `json:"createAt" gorm:"not null"`
-	UpdateAt  int     `json:"updateAt" gorm:"autoUpdateTime;not null"`
-	Date      int     `json:"date" gorm:"not null"`
-	PatientID int     `json:"-" gorm:"not null"`
-	Patient   Patient `json:"patient"`
-	DoctorID  int     `json:"-" gorm:"not null"`
-	Doctor    Doctor  `json:"doctor"`
-	ApproveAt *int    `json:"approveAt"` // nullable
-	DeletedAt soft_delete.DeletedAt
+	ID           int               `json:"id"`
+	CreateAt     int               `json:"createAt" gorm:"not null"`
+	UpdateAt     int               `json:"updateAt" gorm:"autoUpdateTime;not null"`
+	Date         int               `json:"date" gorm:"not null"`
+	Duration     int               `json:"duration" gorm:"not null;default:30"` // minutes
+	PatientID    int               `json:"-" gorm:"not null"`
+	Patient      Patient           `json:"patient"`
+	DoctorID     int               `json:"-" gorm:"not null"`
+	Doctor       Doctor            `json:"doctor"`
+	Status       AppointmentStatus `json:"status" gorm:"type:varchar(30);not null;default:'requested';index"`
+	StatusReason *string           `json:"statusReason"` // nullable
+	ApproveAt    *int              `json:"approveAt"`    // nullable, kept for older mobile clients
+	DeletedAt    soft_delete.DeletedAt
+	// pending reschedule request of the patient, nil if none
+	PendingReschedule *RescheduleRequest `json:"pendingReschedule" gorm:"foreignKey:AppointmentID"`
 }
 
 type SafeAppointment struct {
@@ -20,8 +74,58 @@ type SafeAppointment struct {
 	Doctor TrimDoctor `json:"doctor"`
 }
 
+// end of the appointment in unix time
+func (a Appointment) EndDate() int {
+	return a.Date + a.Duration*60
+}
+
+// details of the clashing appointment in conflict response
+type AppointmentConflict struct {
+	ID        int `json:"id,omitempty"`
+	Date      int `json:"date"`
+	Duration  int `json:"duration"`
+	DoctorID  int `json:"doctorId"`
+	PatientID int `json:"patientId,omitempty"`
+}
+
+// Who changes the appointment
+type ActorType string
+
+const (
+	ACTOR_PATIENT ActorType = "patient"
+	ACTOR_DOCTOR  ActorType = "doctor"
+	ACTOR_SYSTEM  ActorType = "system"
+)
+
+type AppointmentHistory struct {
+	ID            int               `json:"id"`
+	AppointmentID int               `json:"appointmentId" gorm:"not null;index"`
+	FromStatus    AppointmentStatus `json:"fromStatus" gorm:"type:varchar(30);not null"`
+	ToStatus      AppointmentStatus `json:"toStatus" gorm:"type:varchar(30);not null"`
+	Reason        *string           `json:"reason"`   // nullable
+	FromDate      *int              `json:"fromDate"` // nullable, set when the date is changed
+	ToDate        *int              `json:"toDate"`   // nullable
+	ActorType     ActorType         `json:"actorType" gorm:"type:varchar(20);not null"`
+	ActorID       *int              `json:"actorId"` // nullable for system
+	CreateAt      int               `json:"createAt" gorm:"autoCreateTime;not null"`
+}
+
+type AppointmentStatusChange struct {
+	Status    AppointmentStatus
+	Reason    *string
+	Date      *int // new date, only when rescheduling
+	ActorType ActorType
+	ActorID   *int
+}
+
+type AppointmentStatusCount struct {
+	Status AppointmentStatus `json:"status"`
+	Count  int               `json:"count"`
+}
+
 type CreateAppointmentRequest struct {
 	Date      int  `json:"date" binding:"required"`
+	Duration  int  `json:"duration" binding:"omitempty,min=5,max=480"` // minutes
 	PatientId int  `json:"patientId" binding:"required"`
 	DoctorId  int  `json:"doctorId" binding:"required"`
 	Approve   bool `json:"approve"`
@@ -30,4 +134,9 @@ type CreateAppointmentRequest struct {
 type PatientCreateAppointmentRequest struct {
 	Date     int `json:"date" binding:"required"`
 	DoctorId int `json:"doctorId" binding:"required"`
-}
\ No newline at end of file
+}
+
+type UpdateAppointmentStatusRequest struct {
+	Status AppointmentStatus `json:"status" binding:"required,oneof=approved rejected cancelled_by_staff completed no_show"`
+	Reason *string           `json:"reason" binding:"omitempty,max=500"`
+}
@@ -0,0 +1,8 @@
+package model
+
+// version of the calendar feed url of a doctor or patient, increased to revoke urls given before
+type CalendarFeed struct {
+	Owner   string `json:"owner" gorm:"type:varchar(10);primaryKey"`
+	OwnerID int    `json:"ownerId" gorm:"primaryKey;autoIncrement:false"`
+	Version int    `json:"version" gorm:"not null;default:0"`
+}
@@ -0,0 +1,58 @@
+package model
+
+import "gorm.io/datatypes"
+
+// Campaign states
+type CampaignStatus string
+
+const (
+	CAMPAIGN_SCHEDULED CampaignStatus = "scheduled" // waiting for send time
+	CAMPAIGN_SENDING   CampaignStatus = "sending"   // claimed by a worker
+	CAMPAIGN_SENT      CampaignStatus = "sent"      // all messages are in the outbox
+	CAMPAIGN_CANCELLED CampaignStatus = "cancelled"
+)
+
+// recipients of a campaign, every filter is optional and all of them must match, no filter means all patients
+type CampaignSegment struct {
+	DoctorID     *int `json:"doctorId" binding:"omitempty,min=1"`             // patients who have an appointment with this doctor
+	UpcomingDays *int `json:"upcomingDays" binding:"omitempty,min=1,max=365"` // patients with an approved or rescheduled appointment in the next n days
+	MinAge       *int `json:"minAge" binding:"omitempty,min=0,max=150"`       // age in years from BirthDate
+	MaxAge       *int `json:"maxAge" binding:"omitempty,min=0,max=150"`
+}
+
+// push announcement from staff to a segment of patients
+type Campaign struct {
+	ID             int                                 `json:"id"`
+	Title          string                              `json:"title" gorm:"not null"`
+	Body           string                              `json:"body" gorm:"type:text;not null"`
+	Segment        datatypes.JSONType[CampaignSegment] `json:"segment"`
+	Status         CampaignStatus                      `json:"status" gorm:"type:varchar(20);not null;default:'scheduled';index:idx_campaigns_due,priority:1"`
+	ScheduledAt    int                                 `json:"scheduledAt" gorm:"not null;index:idx_campaigns_due,priority:2"`
+	RecipientCount int                                 `json:"recipientCount" gorm:"not null;default:0"` // patients matched when sending
+	CreateByID     int                                 `json:"createById" gorm:"not null"`               // doctor who created the campaign
+	CreateAt       int                                 `json:"createAt" gorm:"autoCreateTime;not null"`
+	SentAt         *int                                `json:"sentAt"` // nullable
+}
+
+type OutboxStatusCount struct {
+	Status OutboxStatus `json:"status"`
+	Count  int          `json:"count"`
+}
+
+// delivery stats of a campaign, pushes of muted patients are not counted in outbox
+type CampaignStats struct {
+	RecipientCount int                 `json:"recipientCount"`
+	ReadCount      int                 `json:"readCount"`
+	Outbox         []OutboxStatusCount `json:"outbox"`
+}
+
+type CampaignPreviewRequest struct {
+	Segment CampaignSegment `json:"segment"`
+}
+
+type CreateCampaignRequest struct {
+	Title       string          `json:"title" binding:"required,max=100"`
+	Body        string          `json:"body" binding:"required,max=1000"`
+	Segment     CampaignSegment `json:"segment"`
+	ScheduledAt *int            `json:"scheduledAt"` // nil means now
+}
@@ -0,0 +1,61 @@
+package model
+
+// what a doctor does in the care team of a patient
+type CareTeamRole string
+
+const (
+	CARE_NEUROLOGIST     CareTeamRole = "neurologist"
+	CARE_CARDIOLOGIST    CareTeamRole = "cardiologist"
+	CARE_PULMONOLOGIST   CareTeamRole = "pulmonologist"
+	CARE_PHYSIOTHERAPIST CareTeamRole = "physiotherapist"
+	CARE_COORDINATOR     CareTeamRole = "coordinator"
+)
+
+// doctor in the care team of a patient, a doctor is in the team at most once
+type CareTeamMember struct {
+	ID        int          `json:"id"`
+	PatientID int          `json:"patientId" gorm:"not null;uniqueIndex:idx_care_team_members_pair,priority:1"`
+	Patient   Patient      `json:"-"`
+	DoctorID  int          `json:"doctorId" gorm:"not null;uniqueIndex:idx_care_team_members_pair,priority:2;index"`
+	Doctor    Doctor       `json:"-"`
+	Role      CareTeamRole `json:"role" gorm:"type:varchar(20);not null"`
+	CreateAt  int          `json:"createAt" gorm:"autoCreateTime;not null"`
+	UpdateAt  int          `json:"updateAt" gorm:"autoUpdateTime;not null"`
+}
+
+type SafeCareTeamMember struct {
+	CareTeamMember
+	Doctor TrimDoctor `json:"doctor"`
+}
+
+type CareTeamMemberRequest struct {
+	DoctorID int          `json:"doctorId" binding:"required"`
+	Role     CareTeamRole `json:"role" binding:"required,oneof=neurologist cardiologist pulmonologist physiotherapist coordinator"`
+}
+
+type UpdateCareTeamMemberRequest struct {
+	Role CareTeamRole `json:"role" binding:"required,oneof=neurologist cardiologist pulmonologist physiotherapist coordinator"`
+}
+
+// inbox entry of a doctor about a patient of their care team
+type DoctorNotification struct {
+	ID        int              `json:"id"`
+	DoctorID  int              `json:"-" gorm:"not null;index:idx_doctor_notifications_doctor,priority:1"`
+	PatientID int              `json:"patientId" gorm:"not null"`
+	Title     string           `json:"title" gorm:"not null"`
+	Body      string           `json:"body" gorm:"type:text;not null"`
+	Type      NotificationType `json:"type" gorm:"type:varchar(20);not null;default:'general'"`
+	RefID     *int             `json:"refId"`  // nullable, appointment id or question id depending on type
+	ReadAt    *int             `json:"readAt"` // nullable
+	CreateAt  int              `json:"createAt" gorm:"autoCreateTime;not null;index:idx_doctor_notifications_doctor,priority:2"`
+}
+
+func NewDoctorNotification(doctorId int, patientId int, title string, body string, link NotificationLink) DoctorNotification {
+	n := DoctorNotification{DoctorID: doctorId, PatientID: patientId, Title: title, Body: body, Type: NOTIFICATION_GENERAL}
+	if link.Type != "" {
+		refId := link.ID
+		n.Type = link.Type
+		n.RefID = &refId
+	}
+	return n
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"gorm.io/datatypes"
+	"gorm.io/plugin/soft_delete"
+)
+
+// what a caregiver may change for a linked patient, reading is always allowed
+type CaregiverPermission string
+
+const (
+	CAREGIVER_APPOINTMENT CaregiverPermission = "appointment" // request, cancel and reschedule appointments
+	CAREGIVER_QUESTION    CaregiverPermission = "question"    // ask doctors and reply in questions
+	CAREGIVER_MEDICATION  CaregiverPermission = "medication"  // log doses and set medication reminders
+	CAREGIVER_PROFILE     CaregiverPermission = "profile"     // language and notification settings
+)
+
+type CaregiverRelationship string
+
+const (
+	RELATIONSHIP_PARENT   CaregiverRelationship = "parent"
+	RELATIONSHIP_GUARDIAN CaregiverRelationship = "guardian"
+	RELATIONSHIP_RELATIVE CaregiverRelationship = "relative"
+	RELATIONSHIP_OTHER    CaregiverRelationship = "other"
+)
+
+// person who looks after one or more patients with their own login
+type Caregiver struct {
+	ID         int                   `json:"id"`
+	NID        string                `json:"nid" gorm:"type:varchar(13);uniqueIndex:idx_caregivers_n_id;not null;column:nid"`
+	Password   string                `json:"-" gorm:"not null"`
+	Pin        string                `json:"-" gorm:"not null"`
+	FirstName  string                `json:"firstName" gorm:"not null"`
+	MiddleName *string               `json:"middleName"` // nullable
+	LastName   string                `json:"lastName" gorm:"not null"`
+	Phone      *string               `json:"phone"` // nullable
+	Email      *string               `json:"email"` // nullable
+	CreateAt   int                   `json:"createAt" gorm:"autoCreateTime;not null"`
+	UpdateAt   int                   `json:"updateAt" gorm:"autoUpdateTime;not null"`
+	DeletedAt  soft_delete.DeletedAt `json:"-" gorm:"default:0"`
+}
+
+/*
+patient that the caregiver looks after, the link is read again on every request
+so changed permissions and unlinking apply right away
+*/
+type CaregiverLink struct {
+	ID           int                                      `json:"id"`
+	CaregiverID  int                                      `json:"caregiverId" gorm:"not null;uniqueIndex:idx_caregiver_links_pair,priority:1"`
+	Caregiver    Caregiver                                `json:"-"`
+	PatientID    int                                      `json:"patientId" gorm:"not null;uniqueIndex:idx_caregiver_links_pair,priority:2;index"`
+	Patient      Patient                                  `json:"-"`
+	Relationship CaregiverRelationship                    `json:"relationship" gorm:"type:varchar(20);not null"`
+	Permissions  datatypes.JSONSlice[CaregiverPermission] `json:"permissions"`
+	CreateAt     int                                      `json:"createAt" gorm:"autoCreateTime;not null"`
+	UpdateAt     int                                      `json:"updateAt" gorm:"autoUpdateTime;not null"`
+}
+
+// link with the caregiver, listed to the patient
+type SafeCaregiverLink struct {
+	CaregiverLink
+	Caregiver Caregiver `json:"caregiver"`
+}
+
+// link with the patient, listed to the caregiver for switching patients
+type CaregiverPatient struct {
+	CaregiverLink
+	Patient Patient `json:"patient"`
+}
+
+type CaregiverSignupRequest struct {
+	NID        string  `json:"nid" binding:"required,min=13,max=13"`
+	Password   string  `json:"password" binding:"required,min=8,max=30"`
+	FirstName  string  `json:"firstName" binding:"required"`
+	MiddleName *string `json:"middleName"`
+	LastName   string  `json:"lastName" binding:"required"`
+	Phone      *string `json:"phone" binding:"required"`
+	Email      *string `json:"email"`
+	Pin        string  `json:"pin" binding:"required,len=6"`
+}
+
+type CaregiverLinkRequest struct {
+	NID          string                `json:"nid" binding:"required,min=13,max=13"` // nid of the caregiver
+	Relationship CaregiverRelationship `json:"relationship" binding:"required,oneof=parent guardian relative other"`
+	Permissions  []CaregiverPermission `json:"permissions" binding:"dive,oneof=appointment question medication profile"`
+}
+
+type UpdateCaregiverLinkRequest struct {
+	Relationship CaregiverRelationship `json:"relationship" binding:"required,oneof=parent guardian relative other"`
+	Permissions  []CaregiverPermission `json:"permissions" binding:"dive,oneof=appointment question medication profile"`
+}
+
+type SwitchPatientRequest struct {
+	PatientID int `json:"patientId" binding:"required"`
+}
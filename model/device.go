@@ -1,18 +1,23 @@
 package model
 
 type Device struct {
-	ID         int    `json:"id"`
-	LoginAt    int    `json:"loginAt"`
-	DeviceName string `json:"deviceName"`
-	ExpoToken  string `json:"expoToken"`
-	PatientId  int    `json:"patientId"`
+	ID          int    `json:"id"`
+	LoginAt     int    `json:"loginAt"`
+	DeviceName  string `json:"deviceName"`
+	ExpoToken   string `json:"expoToken"`
+	PatientId   int    `json:"patientId"`                // patient of the device, the active patient for caregivers
+	CaregiverID *int   `json:"caregiverId" gorm:"index"` // nullable, set when a caregiver logged in on the device
 }
 
-type AppointmentDevice struct {
-	AppointmentId int    `json:"appointment_id"`
-	Date          int    `json:"date"`
-	DeviceId      int    `json:"device_id"`
-	DeviceName    string `json:"device_name"`
-	ExpoToken     string `json:"expoToken"`
-	PatientId     int    `json:"patient_id"`
+// a device removed by the server, kept so support can see why a phone stopped getting notifications
+type DeviceRemoval struct {
+	ID         int     `json:"id"`
+	PatientID  int     `json:"patientId" gorm:"index;not null"`
+	DeviceID   int     `json:"deviceId" gorm:"not null"`
+	DeviceName string  `json:"deviceName"`
+	ExpoToken  string  `json:"expoToken" gorm:"not null"`
+	Reason     string  `json:"reason" gorm:"type:varchar(64);not null"` // Expo error code e.g. DeviceNotRegistered
+	Detail     *string `json:"detail"`                                  // nullable, message from Expo
+	OutboxID   *int    `json:"outboxId"`                                // nullable, the message that revealed the dead token
+	RemoveAt   int     `json:"removeAt" gorm:"autoCreateTime;not null"`
 }
@@ -0,0 +1,139 @@
+package model
+
+import "gorm.io/plugin/soft_delete"
+
+// clinical measures followed over time
+type MeasurementType string
+
+const (
+	MEASURE_WEIGHT          MeasurementType = "weight"
+	MEASURE_HEIGHT          MeasurementType = "height"
+	MEASURE_BMI             MeasurementType = "bmi"
+	MEASURE_SIX_MINUTE_WALK MeasurementType = "sixMinuteWalk" // 6-minute walk distance
+	MEASURE_NSAA            MeasurementType = "nsaa"          // North Star Ambulatory Assessment score
+	MEASURE_TIMED_RISE      MeasurementType = "timedRise"     // time to rise from the floor
+	MEASURE_FVC             MeasurementType = "fvcPercent"    // forced vital capacity, percent predicted
+	MEASURE_LVEF            MeasurementType = "lvef"          // left ventricular ejection fraction from echo
+	MEASURE_CK              MeasurementType = "ck"            // creatine kinase level
+)
+
+// unit of each measure, every entry of the type is stored in this unit
+var MEASUREMENT_UNITS = map[MeasurementType]string{
+	MEASURE_WEIGHT:          "kg",
+	MEASURE_HEIGHT:          "cm",
+	MEASURE_BMI:             "kg/m2",
+	MEASURE_SIX_MINUTE_WALK: "m",
+	MEASURE_NSAA:            "score",
+	MEASURE_TIMED_RISE:      "s",
+	MEASURE_FVC:             "%",
+	MEASURE_LVEF:            "%",
+	MEASURE_CK:              "U/L",
+}
+
+// types in display order
+var MEASUREMENT_TYPES = []MeasurementType{
+	MEASURE_WEIGHT, MEASURE_HEIGHT, MEASURE_BMI, MEASURE_SIX_MINUTE_WALK, MEASURE_NSAA,
+	MEASURE_TIMED_RISE, MEASURE_FVC, MEASURE_LVEF, MEASURE_CK,
+}
+
+func (t MeasurementType) IsValid() bool {
+	_, ok := MEASUREMENT_UNITS[t]
+	return ok
+}
+
+// one clinical measure of the patient at a date
+type Measurement struct {
+	ID         int                   `json:"id"`
+	PatientID  int                   `json:"patientId" gorm:"not null;index:idx_measurements_patient,priority:1"`
+	Type       MeasurementType       `json:"type" gorm:"type:varchar(20);not null;index:idx_measurements_patient,priority:2"`
+	Value      float64               `json:"value" gorm:"not null"`
+	Unit       string                `json:"unit" gorm:"type:varchar(10);not null"`
+	MeasuredAt int                   `json:"measuredAt" gorm:"not null;index:idx_measurements_patient,priority:3"`
+	DoctorID   int                   `json:"doctorId" gorm:"not null"` // doctor who recorded it
+	Note       *string               `json:"note"`                     // nullable
+	CreateAt   int                   `json:"createAt" gorm:"autoCreateTime;not null"`
+	UpdateAt   int                   `json:"updateAt" gorm:"autoUpdateTime;not null"`
+	DeletedAt  soft_delete.DeletedAt `json:"-"`
+}
+
+// unit is optional, it must be the unit of the type when given
+type MeasurementRequest struct {
+	Type       MeasurementType `json:"type" binding:"required"`
+	Value      float64         `json:"value" binding:"gte=0"`
+	Unit       string          `json:"unit"`
+	MeasuredAt int             `json:"measuredAt" binding:"required"`
+	Note       *string         `json:"note" binding:"omitempty,max=500"`
+}
+
+// type of a measure can't be changed, delete and record it again
+type UpdateMeasurementRequest struct {
+	Value      float64 `json:"value" binding:"gte=0"`
+	Unit       string  `json:"unit"`
+	MeasuredAt int     `json:"measuredAt" binding:"required"`
+	Note       *string `json:"note" binding:"omitempty,max=500"`
+}
+
+type MeasurementPoint struct {
+	ID         int     `json:"id"`
+	Value      float64 `json:"value"`
+	MeasuredAt int     `json:"measuredAt"`
+}
+
+// points of one measure, oldest first
+type MeasurementTrend struct {
+	Type   MeasurementType    `json:"type"`
+	Unit   string             `json:"unit"`
+	Points []MeasurementPoint `json:"points"`
+	Latest MeasurementPoint   `json:"latest"`
+	Change *float64           `json:"change"` // nullable, latest minus the point before it
+}
+
+// group measurements into trends in MEASUREMENT_TYPES order, measurements must be oldest first
+func NewMeasurementTrends(measurements []Measurement) []MeasurementTrend {
+	points := map[MeasurementType][]MeasurementPoint{}
+	for _, m := range measurements {
+		points[m.Type] = append(points[m.Type], MeasurementPoint{ID: m.ID, Value: m.Value, MeasuredAt: m.MeasuredAt})
+	}
+	res := []MeasurementTrend{}
+	for _, t := range MEASUREMENT_TYPES {
+		p := points[t]
+		if len(p) == 0 {
+			continue
+		}
+		trend := MeasurementTrend{Type: t, Unit: MEASUREMENT_UNITS[t], Points: p, Latest: p[len(p)-1]}
+		if len(p) > 1 {
+			change := p[len(p)-1].Value - p[len(p)-2].Value
+			trend.Change = &change
+		}
+		res = append(res, trend)
+	}
+	return res
+}
+
+// measurement at the patient's age
+type ChartPoint struct {
+	ID         int     `json:"id"`
+	Value      float64 `json:"value"`
+	MeasuredAt int     `json:"measuredAt"`
+	AgeYears   float64 `json:"ageYears"`
+}
+
+type ReferencePoint struct {
+	AgeYears float64 `json:"ageYears"`
+	Value    float64 `json:"value"`
+}
+
+// one percentile line of the reference population e.g. 50 for the median
+type ReferenceCurve struct {
+	Percentile int              `json:"percentile"`
+	Points     []ReferencePoint `json:"points"`
+}
+
+// chart-ready series of one measure, oldest first
+type MeasurementChart struct {
+	Type        MeasurementType  `json:"type"`
+	Unit        string           `json:"unit"`
+	Points      []ChartPoint     `json:"points"`
+	RatePerYear *float64         `json:"ratePerYear"` // nullable, points don't span long enough
+	Reference   []ReferenceCurve `json:"reference"`   // nullable, not requested or no reference for the type
+}
@@ -0,0 +1,141 @@
+package model
+
+import "gorm.io/datatypes"
+
+type DoseUnit string
+
+const (
+	DOSE_MG      DoseUnit = "mg"
+	DOSE_MCG     DoseUnit = "mcg"
+	DOSE_G       DoseUnit = "g"
+	DOSE_ML      DoseUnit = "ml"
+	DOSE_IU      DoseUnit = "iu"
+	DOSE_TABLET  DoseUnit = "tablet"
+	DOSE_CAPSULE DoseUnit = "capsule"
+	DOSE_PUFF    DoseUnit = "puff"
+)
+
+type MedicationSchedule string
+
+const (
+	SCHEDULE_ONCE_DAILY  MedicationSchedule = "onceDaily"
+	SCHEDULE_TWICE_DAILY MedicationSchedule = "twiceDaily"
+	SCHEDULE_THREE_DAILY MedicationSchedule = "threeTimesDaily"
+	SCHEDULE_FOUR_DAILY  MedicationSchedule = "fourTimesDaily"
+	SCHEDULE_ALTERNATE   MedicationSchedule = "alternateDay"
+	SCHEDULE_WEEKEND     MedicationSchedule = "weekend" // high dose on saturday and sunday
+	SCHEDULE_WEEKLY      MedicationSchedule = "weekly"
+	SCHEDULE_AS_NEEDED   MedicationSchedule = "asNeeded"
+	SCHEDULE_OTHER       MedicationSchedule = "other" // described in instruction
+)
+
+// clock times of each dose in clinic timezone, schedules not in this map have no reminders
+var DEFAULT_DOSE_TIMES = map[MedicationSchedule][]string{
+	SCHEDULE_ONCE_DAILY:  {"08:00"},
+	SCHEDULE_TWICE_DAILY: {"08:00", "20:00"},
+	SCHEDULE_THREE_DAILY: {"08:00", "13:00", "19:00"},
+	SCHEDULE_FOUR_DAILY:  {"08:00", "12:00", "16:00", "20:00"},
+	SCHEDULE_ALTERNATE:   {"08:00"},
+	SCHEDULE_WEEKEND:     {"08:00"},
+	SCHEDULE_WEEKLY:      {"08:00"},
+}
+
+/*
+one period of a medicine at the same dose and schedule, a dose change ends the regimen
+and starts a new one pointing back to it, so the rows of a medicine are its history
+*/
+type MedicationRegimen struct {
+	ID               int                         `json:"id"`
+	PatientID        int                         `json:"patientId" gorm:"not null;index"`
+	MedicineName     string                      `json:"medicineName" gorm:"not null"`
+	Dose             float64                     `json:"dose" gorm:"not null"`
+	DoseUnit         DoseUnit                    `json:"doseUnit" gorm:"type:varchar(10);not null"`
+	Schedule         MedicationSchedule          `json:"schedule" gorm:"type:varchar(20);not null"`
+	Instruction      *string                     `json:"instruction"`   // nullable
+	StartDate        *int                        `json:"startDate"`     // nullable, unknown for medicines migrated from the old list
+	EndDate          *int                        `json:"endDate"`       // nullable, still taken
+	Reason           *string                     `json:"reason"`        // nullable, why it was started or changed
+	EndReason        *string                     `json:"endReason"`     // nullable, why it was stopped or changed
+	PreviousID       *int                        `json:"previousId"`    // nullable, regimen replaced by this dose change
+	PrescriberID     *int                        `json:"prescriberId"`  // nullable, doctor who prescribed it
+	Note             *string                     `json:"note"`          // nullable
+	ReminderTimes    datatypes.JSONSlice[string] `json:"reminderTimes"` // nullable, "15:04" in clinic timezone, default times of the schedule when empty
+	ReminderDisabled bool                        `json:"reminderDisabled" gorm:"not null;default:false"`
+	CreateAt         int                         `json:"createAt" gorm:"autoCreateTime;not null"`
+	UpdateAt         int                         `json:"updateAt" gorm:"autoUpdateTime;not null"`
+}
+
+type CreateMedicationRegimenRequest struct {
+	MedicineName string             `json:"medicineName" binding:"required,max=100"`
+	Dose         float64            `json:"dose" binding:"required,gt=0"`
+	DoseUnit     DoseUnit           `json:"doseUnit" binding:"required,oneof=mg mcg g ml iu tablet capsule puff"`
+	Schedule     MedicationSchedule `json:"schedule" binding:"required,oneof=onceDaily twiceDaily threeTimesDaily fourTimesDaily alternateDay weekend weekly asNeeded other"`
+	Instruction  *string            `json:"instruction" binding:"omitempty,max=500"`
+	StartDate    int                `json:"startDate" binding:"required"`
+	Reason       *string            `json:"reason" binding:"omitempty,max=500"`
+	Note         *string            `json:"note" binding:"omitempty,max=500"`
+}
+
+// new dose or schedule from startDate, the current regimen ends at the same date
+type ChangeMedicationRegimenRequest struct {
+	Dose        float64            `json:"dose" binding:"required,gt=0"`
+	DoseUnit    DoseUnit           `json:"doseUnit" binding:"required,oneof=mg mcg g ml iu tablet capsule puff"`
+	Schedule    MedicationSchedule `json:"schedule" binding:"required,oneof=onceDaily twiceDaily threeTimesDaily fourTimesDaily alternateDay weekend weekly asNeeded other"`
+	Instruction *string            `json:"instruction" binding:"omitempty,max=500"`
+	StartDate   int                `json:"startDate" binding:"required"`
+	Reason      string             `json:"reason" binding:"required,max=500"`
+	Note        *string            `json:"note" binding:"omitempty,max=500"`
+}
+
+type StopMedicationRegimenRequest struct {
+	EndDate int    `json:"endDate" binding:"required"`
+	Reason  string `json:"reason" binding:"required,max=500"`
+}
+
+type DoseStatus string
+
+const (
+	DOSE_TAKEN   DoseStatus = "taken"
+	DOSE_SKIPPED DoseStatus = "skipped"
+)
+
+// ledger of a scheduled dose, created by its reminder or when the patient logs it, whichever comes first
+type MedicationDose struct {
+	ID          int         `json:"id"`
+	RegimenID   int         `json:"regimenId" gorm:"not null;uniqueIndex:idx_medication_doses_once,priority:1"`
+	PatientID   int         `json:"patientId" gorm:"not null;index:idx_medication_doses_patient,priority:1"`
+	ScheduledAt int         `json:"scheduledAt" gorm:"not null;uniqueIndex:idx_medication_doses_once,priority:2;index:idx_medication_doses_patient,priority:2"`
+	Status      *DoseStatus `json:"status" gorm:"type:varchar(10)"` // nullable, not logged yet
+	LogAt       *int        `json:"logAt"`                          // nullable
+	RemindAt    *int        `json:"remindAt"`                       // nullable, reminder not sent
+}
+
+// dose of the schedule with its logged status
+type ScheduledDose struct {
+	RegimenID    int         `json:"regimenId"`
+	MedicineName string      `json:"medicineName"`
+	Dose         float64     `json:"dose"`
+	DoseUnit     DoseUnit    `json:"doseUnit"`
+	ScheduledAt  int         `json:"scheduledAt"`
+	Status       *DoseStatus `json:"status"` // nullable, not logged yet
+}
+
+// doses of a week from monday in clinic timezone, only doses due by now are expected
+type WeeklyAdherence struct {
+	WeekStart int      `json:"weekStart"`
+	Expected  int      `json:"expected"`
+	Taken     int      `json:"taken"`
+	Skipped   int      `json:"skipped"`
+	Percent   *float64 `json:"percent"` // nullable, no dose expected
+}
+
+// scheduledAt of as-needed medicines is the time it was taken
+type LogDoseRequest struct {
+	ScheduledAt int        `json:"scheduledAt" binding:"required"`
+	Status      DoseStatus `json:"status" binding:"required,oneof=taken skipped"`
+}
+
+type UpdateMedicationReminderRequest struct {
+	Disabled bool     `json:"disabled"`
+	Times    []string `json:"times" binding:"max=6,dive,datetime=15:04"` // empty means default times of the schedule
+}
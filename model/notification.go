@@ -0,0 +1,136 @@
+package model
+
+import (
+	"strconv"
+
+	"gorm.io/datatypes"
+)
+
+// what a notification is about, used by the app to open the related screen
+type NotificationType string
+
+const (
+	NOTIFICATION_GENERAL     NotificationType = "general"
+	NOTIFICATION_APPOINTMENT NotificationType = "appointment"
+	NOTIFICATION_QUESTION    NotificationType = "question"
+	NOTIFICATION_MEDICATION  NotificationType = "medication"
+	NOTIFICATION_VACCINE     NotificationType = "vaccine"
+)
+
+// group of notifications that patients can mute
+type NotificationCategory string
+
+const (
+	CATEGORY_GENERAL     NotificationCategory = "general"
+	CATEGORY_APPOINTMENT NotificationCategory = "appointment" // appointment created, changed or cancelled
+	CATEGORY_REMINDER    NotificationCategory = "reminder"    // upcoming appointment reminders
+	CATEGORY_QUESTION    NotificationCategory = "question"
+	CATEGORY_CONTENT     NotificationCategory = "content"    // announcements and new content
+	CATEGORY_MEDICATION  NotificationCategory = "medication" // time to take a medicine
+	CATEGORY_VACCINE     NotificationCategory = "vaccine"    // vaccine due
+	CATEGORY_ACCOUNT     NotificationCategory = "account"    // signup approved or rejected, can't be muted
+)
+
+// how a notification reaches the patient
+type NotificationChannel string
+
+const (
+	CHANNEL_EXPO  NotificationChannel = "expo" // push to registered devices
+	CHANNEL_EMAIL NotificationChannel = "email"
+	CHANNEL_SMS   NotificationChannel = "sms"
+)
+
+// deep link of a notification, zero value links to nothing
+type NotificationLink struct {
+	Type NotificationType
+	ID   int
+}
+
+func AppointmentLink(appointmentId int) NotificationLink {
+	return NotificationLink{Type: NOTIFICATION_APPOINTMENT, ID: appointmentId}
+}
+
+func QuestionLink(questionId int) NotificationLink {
+	return NotificationLink{Type: NOTIFICATION_QUESTION, ID: questionId}
+}
+
+func MedicationLink(regimenId int) NotificationLink {
+	return NotificationLink{Type: NOTIFICATION_MEDICATION, ID: regimenId}
+}
+
+func VaccineLink(vaccineId int) NotificationLink {
+	return NotificationLink{Type: NOTIFICATION_VACCINE, ID: vaccineId}
+}
+
+// persisted copy of a push, so patients can read it later in the app
+type Notification struct {
+	ID         int                  `json:"id"`
+	PatientID  int                  `json:"-" gorm:"not null;index:idx_notifications_patient,priority:1"`
+	Title      string               `json:"title" gorm:"not null"`
+	Body       string               `json:"body" gorm:"type:text;not null"`
+	Type       NotificationType     `json:"type" gorm:"type:varchar(20);not null;default:'general'"`
+	Category   NotificationCategory `json:"category" gorm:"type:varchar(20);not null;default:'general'"`
+	RefID      *int                 `json:"refId"`          // nullable, appointment id or question id depending on type
+	ReadAt     *int                 `json:"readAt"`         // nullable
+	CampaignID *int                 `json:"-" gorm:"index"` // nullable, set when sent by a campaign
+	CreateAt   int                  `json:"createAt" gorm:"autoCreateTime;not null;index:idx_notifications_patient,priority:2"`
+}
+
+func NewNotification(patientId int, category NotificationCategory, title string, body string, link NotificationLink) Notification {
+	n := Notification{PatientID: patientId, Title: title, Body: body, Type: NOTIFICATION_GENERAL, Category: category}
+	if link.Type != "" {
+		refId := link.ID
+		n.Type = link.Type
+		n.RefID = &refId
+	}
+	return n
+}
+
+// payload attached to the push, the app opens the linked screen and marks the notification read
+func (n *Notification) PushData() map[string]string {
+	data := map[string]string{
+		"notificationId": strconv.Itoa(n.ID),
+		"type":           string(n.Type),
+	}
+	if n.RefID != nil {
+		data["id"] = strconv.Itoa(*n.RefID)
+	}
+	return data
+}
+
+type UnreadNotificationCount struct {
+	Count int `json:"count"`
+}
+
+/*
+push settings of a patient, muted categories are still saved to the inbox but not pushed,
+pushes during quiet hours are deferred until the quiet hours end
+*/
+type NotificationPreference struct {
+	PatientID        int                                       `json:"-" gorm:"primaryKey;autoIncrement:false"`
+	MutedCategories  datatypes.JSONSlice[NotificationCategory] `json:"mutedCategories"`
+	QuietStartMinute *int                                      `json:"quietStartMinute"`                                     // nullable, minutes from midnight in Timezone
+	QuietEndMinute   *int                                      `json:"quietEndMinute"`                                       // nullable, may be less than start e.g. 22:00 - 07:00
+	Timezone         string                                    `json:"timezone" gorm:"type:varchar(64);not null;default:''"` // IANA name, empty means clinic timezone
+	UpdateAt         int                                       `json:"updateAt" gorm:"autoUpdateTime;not null"`
+}
+
+func (p *NotificationPreference) IsMuted(category NotificationCategory) bool {
+	for _, c := range p.MutedCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *NotificationPreference) HasQuietHours() bool {
+	return p.QuietStartMinute != nil && p.QuietEndMinute != nil && *p.QuietStartMinute != *p.QuietEndMinute
+}
+
+type UpdateNotificationPreferenceRequest struct {
+	MutedCategories  []NotificationCategory `json:"mutedCategories" binding:"dive,oneof=general appointment reminder question content medication vaccine"`
+	QuietStartMinute *int                   `json:"quietStartMinute" binding:"omitempty,min=0,max=1439"`
+	QuietEndMinute   *int                   `json:"quietEndMinute" binding:"omitempty,min=0,max=1439"`
+	Timezone         string                 `json:"timezone" binding:"omitempty,timezone"`
+}
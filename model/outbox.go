@@ -0,0 +1,37 @@
+package model
+
+import "gorm.io/datatypes"
+
+// Outbox message states
+type OutboxStatus string
+
+const (
+	OUTBOX_PENDING   OutboxStatus = "pending"   // waiting to be sent or retried
+	OUTBOX_SENT      OutboxStatus = "sent"      // accepted by Expo, waiting for receipt
+	OUTBOX_DELIVERED OutboxStatus = "delivered" // receipt is ok
+	OUTBOX_FAILED    OutboxStatus = "failed"    // gave up
+)
+
+// a message to one device, email address or phone number, each Expo message gets its own ticket
+type NotificationOutbox struct {
+	ID             int                                   `json:"id"`
+	PatientID      *int                                  `json:"patientId" gorm:"index"` // nullable
+	DeviceID       *int                                  `json:"deviceId"`               // nullable
+	Channel        NotificationChannel                   `json:"channel" gorm:"type:varchar(10);not null;default:'expo'"`
+	ExpoToken      string                                `json:"expoToken" gorm:"not null"`
+	Address        *string                               `json:"address"` // nullable, email address or phone number of email and sms messages
+	Title          string                                `json:"title" gorm:"not null"`
+	Body           string                                `json:"body" gorm:"type:text;not null"`
+	CampaignID     *int                                  `json:"campaignId" gorm:"index"` // nullable
+	NotificationID *int                                  `json:"notificationId"`          // nullable, inbox copy of this message
+	Data           datatypes.JSONType[map[string]string] `json:"data"`                    // deep link payload for the app
+	Status         OutboxStatus                          `json:"status" gorm:"type:varchar(20);not null;default:'pending';index:idx_notification_outboxes_due,priority:1"`
+	Attempts       int                                   `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  int                                   `json:"nextAttemptAt" gorm:"not null;index:idx_notification_outboxes_due,priority:2"`
+	TicketID       *string                               `json:"ticketId" gorm:"type:varchar(64);index"` // nullable, Expo ticket id for polling receipt
+	LastError      *string                               `json:"lastError"`                              // nullable
+	SentAt         *int                                  `json:"sentAt"`                                 // nullable
+	DeliveredAt    *int                                  `json:"deliveredAt"`                            // nullable
+	CreateAt       int                                   `json:"createAt" gorm:"autoCreateTime;not null"`
+	UpdateAt       int                                   `json:"updateAt" gorm:"autoUpdateTime;not null"`
+}
@@ -23,23 +23,28 @@ type Medicine struct {
 }
 
 type Patient struct {
-	ID             int                                 `json:"id"`
-	NID            string                              `json:"nid" gorm:"type:varchar(13);uniqueIndex:idx_patients_n_id;not null;column:nid"`
-	Password       string                              `json:"-" gorm:"not null"`
-	Hn             string                              `json:"hn" gorm:"type:varchar(20);uniqueIndex:idx_patients_hn;not null"`
-	Pin            string                              `json:"-" gorm:"not null"`
-	FirstName      string                              `json:"firstName" gorm:"not null"`
-	MiddleName     *string                             `json:"middleName"` // nullable
-	LastName       string                              `json:"lastName" gorm:"not null"`
-	Email          *string                             `json:"email"` // nullable
-	Phone          *string                             `json:"phone"` // nullable
-	Verified       bool                                `json:"verified" gorm:"not null;default:0"`
-	Weight         *float32                            `json:"weight"` // nullable
-	Height         *float32                            `json:"height"` // nullable
-	BirthDate      int                                 `json:"birthDate" gorm:"not null"`
-	VaccineHistory datatypes.JSONSlice[VaccineHistory] `json:"vaccineHistory"` // nullable
-	Medicine       datatypes.JSONSlice[Medicine]       `json:"medicine"`       // nullable
-	DeletedAt      soft_delete.DeletedAt               `json:"-" gorm:"default:0"`
+	ID                 int                                 `json:"id"`
+	NID                string                              `json:"nid" gorm:"type:varchar(13);uniqueIndex:idx_patients_n_id;not null;column:nid"`
+	Password           string                              `json:"-" gorm:"not null"`
+	Hn                 string                              `json:"hn" gorm:"type:varchar(20);uniqueIndex:idx_patients_hn;not null"`
+	Pin                string                              `json:"-" gorm:"not null"`
+	FirstName          string                              `json:"firstName" gorm:"not null"`
+	MiddleName         *string                             `json:"middleName"` // nullable
+	LastName           string                              `json:"lastName" gorm:"not null"`
+	Email              *string                             `json:"email"` // nullable
+	Phone              *string                             `json:"phone"` // nullable
+	Verified           bool                                `json:"verified" gorm:"not null;default:0"`
+	VerificationStatus VerificationStatus                  `json:"verificationStatus" gorm:"type:varchar(10);not null;default:'approved'"`
+	RejectReason       *string                             `json:"rejectReason"` // nullable
+	VerifiedByID       *int                                `json:"verifiedById"` // doctor who approved or rejected the signup
+	VerifiedAt         *int                                `json:"verifiedAt"`
+	Weight             *float32                            `json:"weight"` // nullable
+	Height             *float32                            `json:"height"` // nullable
+	BirthDate          int                                 `json:"birthDate" gorm:"not null"`
+	Language           Language                            `json:"language" gorm:"type:varchar(5);not null;default:'th'"`
+	VaccineHistory     datatypes.JSONSlice[VaccineHistory] `json:"vaccineHistory"` // nullable
+	Medicine           datatypes.JSONSlice[Medicine]       `json:"medicine"`       // nullable
+	DeletedAt          soft_delete.DeletedAt               `json:"-" gorm:"default:0"`
 }
 
 // type CreatePatientRequest struct {
@@ -67,12 +72,9 @@ type UpdatePatientRequest struct {
 	Weight     *float32 `json:"weight"`
 	Height     *float32 `json:"height"`
 	BirthDate  int      `json:"birthDate"`
+	Language   Language `json:"language" binding:"omitempty,oneof=th en"` // empty keeps current language
 }
 
-type UpdateVaccineHistoryRequest struct {
-	Data []VaccineHistory `json:"data" binding:"dive"`
-}
-
-type UpdateMedicineRequest struct {
-	Data []Medicine `json:"data" binding:"dive"`
+type UpdateLanguageRequest struct {
+	Language Language `json:"language" binding:"required,oneof=th en"`
 }
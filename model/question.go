@@ -2,18 +2,85 @@ package model
 
 import "gorm.io/plugin/soft_delete"
 
+// clinical area of a question, set by staff when triaging
+type QuestionCategory string
+
+const (
+	QUESTION_RESPIRATORY QuestionCategory = "respiratory"
+	QUESTION_CARDIAC     QuestionCategory = "cardiac"
+	QUESTION_MEDICATION  QuestionCategory = "medication"
+	QUESTION_MOBILITY    QuestionCategory = "mobility"
+	QUESTION_OTHER       QuestionCategory = "other"
+)
+
+type QuestionPriority string
+
+const (
+	PRIORITY_LOW    QuestionPriority = "low"
+	PRIORITY_NORMAL QuestionPriority = "normal"
+	PRIORITY_HIGH   QuestionPriority = "high"
+	PRIORITY_URGENT QuestionPriority = "urgent"
+)
+
 type Question struct {
-	ID        int     `json:"id"`
-	Topic     string  `json:"topic" gorm:"not null"`
-	Question  string  `json:"question" gorm:"not null"`
-	CreateAt  int     `json:"createAt" gorm:"not null"`
-	Answer    *string `json:"answer"`   // nullable
-	AnswerAt  *int    `json:"answerAt"` // nullable
-	PatientID int     `json:"-" gorm:"not null"`
-	Patient   Patient `json:"patient"`
-	DoctorID  *int    `json:"-"`
-	Doctor    *Doctor `json:"doctor"` // nullable
-	DeletedAt soft_delete.DeletedAt
+	ID            int                  `json:"id"`
+	Topic         string               `json:"topic" gorm:"not null"`
+	Question      string               `json:"question" gorm:"not null"` // first message of the thread
+	CreateAt      int                  `json:"createAt" gorm:"not null"`
+	Answer        *string              `json:"answer"`   // nullable, first reply of a doctor
+	AnswerAt      *int                 `json:"answerAt"` // nullable
+	PatientID     int                  `json:"-" gorm:"not null"`
+	Patient       Patient              `json:"patient"`
+	DoctorID      *int                 `json:"-"`
+	Doctor        *Doctor              `json:"doctor"` // nullable
+	LastMessageAt int                  `json:"lastMessageAt" gorm:"not null;default:0"`
+	PatientUnread int                  `json:"patientUnread" gorm:"not null;default:0"` // doctor messages the patient hasn't read
+	DoctorUnread  int                  `json:"doctorUnread" gorm:"not null;default:0"`  // patient messages the doctors haven't read
+	ClosedAt      *int                 `json:"closedAt"`                                // nullable, no new messages while closed
+	AwaitingSince *int                 `json:"awaitingSince"`                           // nullable, first patient message that has no doctor reply yet
+	Category      QuestionCategory     `json:"category" gorm:"type:varchar(20);not null;default:'other'"`
+	Priority      QuestionPriority     `json:"priority" gorm:"type:varchar(10);not null;default:'normal'"`
+	AssigneeID    *int                 `json:"assigneeId"` // nullable, unassigned questions can be claimed by any doctor
+	AssignAt      *int                 `json:"assignAt"`   // nullable
+	Messages      []QuestionMessage    `json:"messages,omitempty"`
+	Attachments   []QuestionAttachment `json:"attachments,omitempty"`
+	DeletedAt     soft_delete.DeletedAt
+}
+
+// message in the thread of a question, oldest first
+type QuestionMessage struct {
+	ID         int       `json:"id"`
+	QuestionID int       `json:"questionId" gorm:"not null;index"`
+	AuthorType ActorType `json:"authorType" gorm:"type:varchar(20);not null"` // patient or doctor
+	AuthorID   int       `json:"authorId" gorm:"not null"`
+	Message    string    `json:"message" gorm:"type:text;not null"`
+	CreateAt   int       `json:"createAt" gorm:"autoCreateTime;not null"`
+}
+
+// photo or document of a question, stored privately and downloaded by a signed url
+type QuestionAttachment struct {
+	ID           int       `json:"id"`
+	QuestionID   int       `json:"questionId" gorm:"not null;index"`
+	UploaderType ActorType `json:"uploaderType" gorm:"type:varchar(20);not null"`
+	UploaderID   int       `json:"uploaderId" gorm:"not null"`
+	FileName     string    `json:"fileName" gorm:"not null"` // original name from the phone
+	ContentType  string    `json:"contentType" gorm:"type:varchar(100);not null"`
+	Size         int64     `json:"size" gorm:"not null"` // bytes
+	ObjectName   string    `json:"-" gorm:"not null"`    // path in the private bucket
+	CreateAt     int       `json:"createAt" gorm:"autoCreateTime;not null"`
+}
+
+// content types patients can attach, detected from the file content
+var ATTACHMENT_CONTENT_TYPES = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+type AttachmentURLResponse struct {
+	URL      string `json:"url"`
+	ExpireAt int    `json:"expireAt"`
 }
 
 type SafeQuestion struct {
@@ -22,19 +89,52 @@ type SafeQuestion struct {
 }
 
 type QuestionTopic struct {
-	ID        int         `json:"id"`
-	Topic     string      `json:"topic"`
-	CreateAt  int         `json:"createAt"`
-	AnswerAt  *int        `json:"answerAt"` // nullable
-	PatientID int         `json:"-"`
-	Patient   Patient     `json:"patient"`
-	DoctorID  int         `json:"-"`
-	Doctor    *TrimDoctor `json:"doctor"` // nullable
+	ID            int              `json:"id"`
+	Topic         string           `json:"topic"`
+	CreateAt      int              `json:"createAt"`
+	AnswerAt      *int             `json:"answerAt"` // nullable
+	PatientID     int              `json:"-"`
+	Patient       Patient          `json:"patient"`
+	DoctorID      int              `json:"-"`
+	Doctor        *TrimDoctor      `json:"doctor"` // nullable
+	LastMessageAt int              `json:"lastMessageAt"`
+	PatientUnread int              `json:"patientUnread"`
+	DoctorUnread  int              `json:"doctorUnread"`
+	ClosedAt      *int             `json:"closedAt"`      // nullable
+	AwaitingSince *int             `json:"awaitingSince"` // nullable
+	Category      QuestionCategory `json:"category"`
+	Priority      QuestionPriority `json:"priority"`
+	AssigneeID    *int             `json:"assigneeId"`           // nullable
+	SLABreached   bool             `json:"slaBreached" gorm:"-"` // waiting for a doctor reply longer than the SLA
+}
+
+// the patient has waited for a doctor reply longer than slaHours
+func (q QuestionTopic) IsOverdue(now int, slaHours int) bool {
+	return q.ClosedAt == nil && q.AwaitingSince != nil && now-*q.AwaitingSince > slaHours*60*60
 }
 
 
 type QuestionAnswerRequest struct {
-	Answer string `json:"answer" binding:"required,max=500"`
+	Answer    string `json:"answer" binding:"required,max=500"`
+	SnippetID *int   `json:"snippetId"` // nullable, snippet the answer came from
+}
+
+type TriageQuestionRequest struct {
+	Category QuestionCategory `json:"category" binding:"required,oneof=respiratory cardiac medication mobility other"`
+	Priority QuestionPriority `json:"priority" binding:"required,oneof=low normal high urgent"`
+}
+
+type AssignQuestionRequest struct {
+	AssigneeID *int `json:"assigneeId"` // null to unassign
+}
+
+type QuestionMessageRequest struct {
+	Message string `json:"message" binding:"required,max=700"`
+}
+
+type DoctorQuestionMessageRequest struct {
+	Message   string `json:"message" binding:"required,max=700"`
+	SnippetID *int   `json:"snippetId"` // nullable, snippet the message came from
 }
 
 type CreateQuestionRequest struct {
@@ -0,0 +1,27 @@
+package model
+
+// send reminder at OffsetMinutes before appointment date e.g. 7 days, 1 day, 2 hours
+type ReminderRule struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name" gorm:"not null"`
+	OffsetMinutes int    `json:"offsetMinutes" gorm:"not null;uniqueIndex"`
+	Enabled       bool   `json:"enabled" gorm:"not null"`
+	CreateAt      int    `json:"createAt" gorm:"autoCreateTime;not null"`
+	UpdateAt      int    `json:"updateAt" gorm:"autoUpdateTime;not null"`
+}
+
+// ledger of sent reminders, one row per appointment date and rule so each reminder is sent only once
+type ReminderLog struct {
+	ID              int  `json:"id"`
+	AppointmentID   int  `json:"appointmentId" gorm:"not null;uniqueIndex:idx_reminder_logs_once"`
+	RuleID          int  `json:"ruleId" gorm:"not null;uniqueIndex:idx_reminder_logs_once"`
+	AppointmentDate int  `json:"appointmentDate" gorm:"not null;uniqueIndex:idx_reminder_logs_once"` // rescheduled appointment gets reminded again
+	Skipped         bool `json:"skipped" gorm:"not null;default:0"`                                  // a closer reminder was sent instead
+	CreateAt        int  `json:"createAt" gorm:"autoCreateTime;not null"`
+}
+
+type ReminderRuleRequest struct {
+	Name          string `json:"name" binding:"required,max=100"`
+	OffsetMinutes int    `json:"offsetMinutes" binding:"required,min=5,max=43200"` // up to 30 days
+	Enabled       bool   `json:"enabled"`
+}
@@ -0,0 +1,34 @@
+package model
+
+// Reschedule request states
+type RescheduleStatus string
+
+const (
+	RESCHEDULE_PENDING   RescheduleStatus = "pending"
+	RESCHEDULE_ACCEPTED  RescheduleStatus = "accepted"
+	RESCHEDULE_DECLINED  RescheduleStatus = "declined"
+	RESCHEDULE_CANCELLED RescheduleStatus = "cancelled" // the appointment is no longer active
+)
+
+// a new date proposed by the patient, waiting for staff to accept or decline
+type RescheduleRequest struct {
+	ID            int              `json:"id"`
+	AppointmentID int              `json:"appointmentId" gorm:"not null;index"`
+	ProposedDate  int              `json:"proposedDate" gorm:"not null"`
+	Reason        *string          `json:"reason"` // nullable
+	Status        RescheduleStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+	DeclineReason *string          `json:"declineReason"` // nullable
+	CreateAt      int              `json:"createAt" gorm:"autoCreateTime;not null"`
+	ResolveAt     *int             `json:"resolveAt"`   // nullable
+	ResolveByID   *int             `json:"resolveById"` // nullable, doctor who accepted or declined
+}
+
+type PatientRescheduleRequest struct {
+	Date   int     `json:"date" binding:"required"`
+	Reason *string `json:"reason" binding:"omitempty,max=500"`
+}
+
+type ResolveRescheduleRequest struct {
+	Accept bool    `json:"accept"`
+	Reason *string `json:"reason" binding:"omitempty,max=500"` // reason for declining
+}
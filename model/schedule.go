@@ -0,0 +1,49 @@
+package model
+
+// weekly recurring clinic hours of a doctor, minutes are counted from midnight in clinic timezone
+type DoctorSchedule struct {
+	ID          int `json:"id"`
+	DoctorID    int `json:"doctorId" gorm:"not null;index"`
+	Weekday     int `json:"weekday" gorm:"not null"` // 0 = Sunday
+	StartMinute int `json:"startMinute" gorm:"not null"`
+	EndMinute   int `json:"endMinute" gorm:"not null"`
+	SlotMinutes int `json:"slotMinutes" gorm:"not null;default:30"`
+}
+
+// a period that can't be appointed e.g. doctor's leave, or clinic holiday when DoctorID is null
+type ScheduleException struct {
+	ID       int     `json:"id"`
+	DoctorID *int    `json:"doctorId" gorm:"index"` // nullable
+	StartAt  int     `json:"startAt" gorm:"not null"`
+	EndAt    int     `json:"endAt" gorm:"not null"`
+	Reason   *string `json:"reason"` // nullable
+	CreateAt int     `json:"createAt" gorm:"autoCreateTime;not null"`
+}
+
+type Slot struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+type DoctorScheduleResponse struct {
+	Schedules  []DoctorSchedule    `json:"schedules"`
+	Exceptions []ScheduleException `json:"exceptions"`
+}
+
+type DoctorScheduleInput struct {
+	Weekday     int `json:"weekday" binding:"min=0,max=6"`
+	StartMinute int `json:"startMinute" binding:"min=0,max=1440"`
+	EndMinute   int `json:"endMinute" binding:"required,min=1,max=1440,gtfield=StartMinute"`
+	SlotMinutes int `json:"slotMinutes" binding:"required,min=5,max=240"`
+}
+
+type UpdateDoctorScheduleRequest struct {
+	Data []DoctorScheduleInput `json:"data" binding:"dive"`
+}
+
+type CreateScheduleExceptionRequest struct {
+	DoctorId *int    `json:"doctorId"`
+	StartAt  int     `json:"startAt" binding:"required"`
+	EndAt    int     `json:"endAt" binding:"required,gtfield=StartAt"`
+	Reason   *string `json:"reason"`
+}
@@ -0,0 +1,51 @@
+package model
+
+// review state of a patient signup, only approved accounts can log in
+type VerificationStatus string
+
+const (
+	SIGNUP_PENDING  VerificationStatus = "pending"
+	SIGNUP_APPROVED VerificationStatus = "approved"
+	SIGNUP_REJECTED VerificationStatus = "rejected"
+)
+
+// one-time code issued by the clinic to let a family sign up, optionally bound to an HN
+type InvitationCode struct {
+	ID          int      `json:"id"`
+	Code        string   `json:"code" gorm:"type:varchar(16);uniqueIndex;not null"`
+	Hn          *string  `json:"hn" gorm:"type:varchar(20)"` // nullable, any HN can use the code
+	Note        *string  `json:"note"`                       // nullable
+	CreatedByID int      `json:"createdById" gorm:"not null"`
+	CreatedBy   Doctor   `json:"-" gorm:"foreignKey:CreatedByID;constraint:OnDelete:CASCADE;"`
+	ExpireAt    *int     `json:"expireAt"` // nullable, never expires
+	UsedByID    *int     `json:"usedById"` // patient who signed up with the code
+	UsedBy      *Patient `json:"-" gorm:"foreignKey:UsedByID;constraint:OnDelete:SET NULL;"`
+	UsedAt      *int     `json:"usedAt"`
+	CreateAt    int      `json:"createAt" gorm:"autoCreateTime;not null"`
+}
+
+// code can be used for a signup with the HN at the time
+func (i *InvitationCode) Valid(hn string, now int) bool {
+	if i.UsedByID != nil {
+		return false
+	}
+	if i.ExpireAt != nil && *i.ExpireAt <= now {
+		return false
+	}
+	return i.Hn == nil || *i.Hn == hn
+}
+
+type CreateInvitationCodeRequest struct {
+	Hn       *string `json:"hn" binding:"omitempty,max=20"`
+	Note     *string `json:"note" binding:"omitempty,max=200"`
+	ExpireAt *int    `json:"expireAt"`
+}
+
+// staff confirm the HN of the applicant from the hospital record, it replaces the HN given at signup
+type ApproveSignupRequest struct {
+	Hn string `json:"hn" binding:"required,max=20"`
+}
+
+type RejectSignupRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
@@ -0,0 +1,38 @@
+package model
+
+import "gorm.io/plugin/soft_delete"
+
+// reusable answer of a doctor, personal snippets are only visible to the owner
+type AnswerSnippet struct {
+	ID         int             `json:"id"`
+	Title      string          `json:"title" gorm:"not null"`
+	Body       string          `json:"body" gorm:"type:text;not null"`
+	Shared     bool            `json:"shared" gorm:"not null;default:false"` // visible to every doctor
+	OwnerID    int             `json:"ownerId" gorm:"not null;index"`        // doctor who wrote it
+	ContentID  *int            `json:"contentId"`                            // nullable, article to read more
+	Content    *SnippetContent `json:"content"`                              // nullable
+	UsageCount int             `json:"usageCount" gorm:"not null;default:0"` // answers sent from this snippet
+	LastUsedAt *int            `json:"lastUsedAt"`                           // nullable
+	CreateAt   int             `json:"createAt" gorm:"autoCreateTime;not null"`
+	UpdateAt   int             `json:"updateAt" gorm:"autoUpdateTime;not null"`
+	DeletedAt  soft_delete.DeletedAt
+}
+
+// linked content without its body
+type SnippetContent struct {
+	ID          int         `json:"id"`
+	Title       string      `json:"title"`
+	ContentType ContentType `json:"contentType"`
+	IsPublished bool        `json:"isPublished"`
+}
+
+func (SnippetContent) TableName() string {
+	return "contents"
+}
+
+type AnswerSnippetRequest struct {
+	Title     string `json:"title" binding:"required,max=100"`
+	Body      string `json:"body" binding:"required,max=700"`
+	Shared    bool   `json:"shared"`
+	ContentID *int   `json:"contentId"`
+}
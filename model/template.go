@@ -0,0 +1,72 @@
+package model
+
+// language of patient-facing text
+type Language string
+
+const (
+	LANGUAGE_TH Language = "th"
+	LANGUAGE_EN Language = "en"
+)
+
+var SUPPORTED_LANGUAGES = []Language{LANGUAGE_TH, LANGUAGE_EN}
+
+func (l Language) IsValid() bool {
+	for _, supported := range SUPPORTED_LANGUAGES {
+		if l == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// notification sent by the system, each key has built-in text for every supported language
+type TemplateKey string
+
+const (
+	TEMPLATE_APPOINTMENT_CREATED   TemplateKey = "appointment_created"
+	TEMPLATE_APPOINTMENT_UPDATED   TemplateKey = "appointment_updated"
+	TEMPLATE_APPOINTMENT_APPROVED  TemplateKey = "appointment_approved"
+	TEMPLATE_APPOINTMENT_REJECTED  TemplateKey = "appointment_rejected"
+	TEMPLATE_APPOINTMENT_CANCELLED TemplateKey = "appointment_cancelled"
+	TEMPLATE_RESCHEDULE_ACCEPTED   TemplateKey = "reschedule_accepted"
+	TEMPLATE_RESCHEDULE_DECLINED   TemplateKey = "reschedule_declined"
+	TEMPLATE_APPOINTMENT_REMINDER  TemplateKey = "appointment_reminder"
+	TEMPLATE_QUESTION_ANSWERED     TemplateKey = "question_answered"
+	TEMPLATE_QUESTION_MESSAGE      TemplateKey = "question_message"
+	TEMPLATE_MEDICATION_REMINDER   TemplateKey = "medication_reminder"
+	TEMPLATE_VACCINE_DUE           TemplateKey = "vaccine_due"
+	TEMPLATE_SIGNUP_APPROVED       TemplateKey = "signup_approved"
+	TEMPLATE_SIGNUP_REJECTED       TemplateKey = "signup_rejected"
+)
+
+// extra placeholder values from the caller e.g. reason, values from the linked appointment or question are filled by the service
+type TemplateParams map[string]string
+
+// admin's text of a template that replaces the built-in text
+type NotificationTemplate struct {
+	ID       int         `json:"-"`
+	Key      TemplateKey `json:"key" gorm:"type:varchar(64);not null;uniqueIndex:idx_notification_templates_key_language"`
+	Language Language    `json:"language" gorm:"type:varchar(5);not null;uniqueIndex:idx_notification_templates_key_language"`
+	Title    string      `json:"title" gorm:"not null"`
+	Body     string      `json:"body" gorm:"type:text;not null"`
+	UpdateAt int         `json:"updateAt" gorm:"autoUpdateTime;not null"`
+}
+
+type TemplateText struct {
+	Language  Language `json:"language"`
+	Title     string   `json:"title"`
+	Body      string   `json:"body"`
+	IsDefault bool     `json:"isDefault"` // built-in text, not edited by admin
+}
+
+type NotificationTemplateResponse struct {
+	Key          TemplateKey          `json:"key"`
+	Category     NotificationCategory `json:"category"`
+	Placeholders []string             `json:"placeholders"`
+	Texts        []TemplateText       `json:"texts"`
+}
+
+type UpdateNotificationTemplateRequest struct {
+	Title string `json:"title" binding:"required,max=200"`
+	Body  string `json:"body" binding:"required,max=1000"`
+}
@@ -0,0 +1,95 @@
+package model
+
+import "gorm.io/plugin/soft_delete"
+
+// vaccine of the catalog that doctors pick when recording a vaccination
+type Vaccine struct {
+	ID       int     `json:"id"`
+	Name     string  `json:"name" gorm:"type:varchar(100);not null;uniqueIndex:idx_vaccines_name"`
+	Live     bool    `json:"live" gorm:"not null;default:false"` // live attenuated, not given while on high dose steroids
+	Note     *string `json:"note"`                               // nullable
+	CreateAt int     `json:"createAt" gorm:"autoCreateTime;not null"`
+	UpdateAt int     `json:"updateAt" gorm:"autoUpdateTime;not null"`
+}
+
+type VaccineRequest struct {
+	Name string  `json:"name" binding:"required,max=100"`
+	Live bool    `json:"live"`
+	Note *string `json:"note" binding:"omitempty,max=500"`
+}
+
+/*
+when doses of a vaccine are due, the first dose at MinAgeMonths and every next dose IntervalMonths
+after the previous one, Doses 0 repeats forever e.g. yearly influenza
+*/
+type VaccineRule struct {
+	ID             int     `json:"id"`
+	VaccineID      int     `json:"vaccineId" gorm:"not null;uniqueIndex:idx_vaccine_rules_vaccine"`
+	Vaccine        Vaccine `json:"vaccine"`
+	MinAgeMonths   int     `json:"minAgeMonths" gorm:"not null"`
+	Doses          int     `json:"doses" gorm:"not null"`
+	IntervalMonths int     `json:"intervalMonths" gorm:"not null"`
+	SteroidOnly    bool    `json:"steroidOnly" gorm:"not null;default:false"` // only for patients taking steroids
+	Enabled        bool    `json:"enabled" gorm:"not null"`
+	CreateAt       int     `json:"createAt" gorm:"autoCreateTime;not null"`
+	UpdateAt       int     `json:"updateAt" gorm:"autoUpdateTime;not null"`
+}
+
+type VaccineRuleRequest struct {
+	VaccineID      int  `json:"vaccineId" binding:"required"`
+	MinAgeMonths   int  `json:"minAgeMonths" binding:"min=0,max=1200"`
+	Doses          int  `json:"doses" binding:"min=0,max=10"`
+	IntervalMonths int  `json:"intervalMonths" binding:"min=0,max=120"`
+	SteroidOnly    bool `json:"steroidOnly"`
+	Enabled        bool `json:"enabled"`
+}
+
+// vaccine given to the patient
+type Vaccination struct {
+	ID           int                   `json:"id"`
+	PatientID    int                   `json:"patientId" gorm:"not null;index:idx_vaccinations_patient,priority:1"`
+	VaccineID    *int                  `json:"vaccineId"`                                     // nullable, free text vaccine of the old history
+	VaccineName  string                `json:"vaccineName" gorm:"type:varchar(100);not null"` // name of the catalog when it's given
+	VaccinatedAt int                   `json:"vaccinatedAt" gorm:"not null;index:idx_vaccinations_patient,priority:2"`
+	Location     *string               `json:"location"`     // nullable
+	Complication *string               `json:"complication"` // nullable
+	DoctorID     *int                  `json:"doctorId"`     // nullable, doctor who recorded it
+	CreateAt     int                   `json:"createAt" gorm:"autoCreateTime;not null"`
+	UpdateAt     int                   `json:"updateAt" gorm:"autoUpdateTime;not null"`
+	DeletedAt    soft_delete.DeletedAt `json:"-"`
+}
+
+type VaccinationRequest struct {
+	VaccineID    int     `json:"vaccineId" binding:"required"`
+	VaccinatedAt int     `json:"vaccinatedAt" binding:"required"`
+	Location     *string `json:"location" binding:"omitempty,max=200"`
+	Complication *string `json:"complication" binding:"omitempty,max=500"`
+	// the doctor confirms giving a live vaccine while the patient is on high dose steroids
+	SteroidOverride bool `json:"steroidOverride"`
+}
+
+type VaccineDueStatus string
+
+const (
+	VACCINE_OVERDUE  VaccineDueStatus = "overdue"
+	VACCINE_UPCOMING VaccineDueStatus = "upcoming"
+)
+
+// next dose of a vaccine rule that the patient hasn't got
+type DueVaccine struct {
+	VaccineID       int              `json:"vaccineId"`
+	VaccineName     string           `json:"vaccineName"`
+	DoseNumber      int              `json:"doseNumber"` // 1 for the first dose
+	DueAt           int              `json:"dueAt"`
+	Status          VaccineDueStatus `json:"status"`
+	Contraindicated bool             `json:"contraindicated"` // live vaccine while on high dose steroids
+}
+
+// ledger of sent vaccine reminders, one row per dose so each dose is reminded only once
+type VaccineReminderLog struct {
+	ID         int `json:"id"`
+	PatientID  int `json:"patientId" gorm:"not null;uniqueIndex:idx_vaccine_reminder_logs_once"`
+	VaccineID  int `json:"vaccineId" gorm:"not null;uniqueIndex:idx_vaccine_reminder_logs_once"`
+	DoseNumber int `json:"doseNumber" gorm:"not null;uniqueIndex:idx_vaccine_reminder_logs_once"`
+	CreateAt   int `json:"createAt" gorm:"autoCreateTime;not null"`
+}
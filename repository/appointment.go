@@ -9,11 +9,13 @@ import (
 
 	"github.com/PhasitWo/duchenne-server/model"
 	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func (r *Repo) GetAppointment(appointmentId any) (model.SafeAppointment, error) {
 	var ap model.SafeAppointment
-	err := r.db.Model(&model.Appointment{}).Joins("Doctor").Preload("Patient").Where("Appointments.id = ?", appointmentId).First(&ap).Error
+	err := r.db.Model(&model.Appointment{}).Joins("Doctor").Preload("Patient").Preload("PendingReschedule", "status = ?", model.RESCHEDULE_PENDING).Where("Appointments.id = ?", appointmentId).First(&ap).Error
 	if err != nil {
 		return ap, fmt.Errorf("exec : %w", err)
 	}
@@ -24,7 +26,7 @@ func (r *Repo) GetAppointment(appointmentId any) (model.SafeAppointment, error)
 func (r *Repo) GetAllAppointment(limit int, offset int, criteria ...Criteria) ([]model.SafeAppointment, error) {
 	res := []model.SafeAppointment{}
 	db := attachCriteria(r.db, criteria...)
-	err := db.Model(&model.Appointment{}).Joins("Doctor").Preload("Patient").Limit(limit).Offset(offset).Order("date ASC").Find(&res).Error
+	err := db.Model(&model.Appointment{}).Joins("Doctor").Preload("Patient").Preload("PendingReschedule", "status = ?", model.RESCHEDULE_PENDING).Limit(limit).Offset(offset).Order("date ASC").Find(&res).Error
 	if err != nil {
 		return res, fmt.Errorf("exec : %w", err)
 	}
@@ -35,7 +37,15 @@ func (r *Repo) CreateAppointment(appointment model.Appointment) (int, error) {
 	now := int(time.Now().Unix())
 	appointment.CreateAt = now
 	appointment.UpdateAt = now
-	err := r.db.Create(&appointment).Error
+	if appointment.Duration == 0 {
+		appointment.Duration = model.DEFAULT_APPOINTMENT_DURATION
+	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkAppointmentConflict(tx, appointment); err != nil {
+			return err
+		}
+		return tx.Create(&appointment).Error
+	})
 	if err != nil {
 		var mysqlErr *mysql.MySQLError
 		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1452 {
@@ -47,8 +57,16 @@ func (r *Repo) CreateAppointment(appointment model.Appointment) (int, error) {
 }
 
 func (r *Repo) UpdateAppointment(appointment model.Appointment) error {
-	result := r.db.Select("*").Omit("create_at").Updates(&appointment)
-	err := result.Error
+	if appointment.Duration == 0 {
+		appointment.Duration = model.DEFAULT_APPOINTMENT_DURATION
+	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkAppointmentConflict(tx, appointment); err != nil {
+			return err
+		}
+		// status can only be changed through ChangeAppointmentStatus
+		return tx.Select("*").Omit("create_at", "status", "status_reason", "approve_at").Updates(&appointment).Error
+	})
 	if err != nil {
 		var mysqlErr *mysql.MySQLError
 		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1452 {
@@ -66,3 +84,114 @@ func (r *Repo) DeleteAppointment(appointmentId any) error {
 	}
 	return nil
 }
+
+// validate and apply status transition, then record it in appointment history
+func (r *Repo) ChangeAppointmentStatus(appointmentId int, change model.AppointmentStatusChange) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return changeAppointmentStatus(tx, appointmentId, change)
+	})
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+func changeAppointmentStatus(tx *gorm.DB, appointmentId int, change model.AppointmentStatusChange) error {
+	var ap model.Appointment
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", appointmentId).First(&ap).Error
+	if err != nil {
+		return err
+	}
+	if !ap.Status.CanTransitionTo(change.Status) {
+		return ErrInvalidStatusTransition
+	}
+	now := int(time.Now().Unix())
+	updates := map[string]any{"status": change.Status, "status_reason": change.Reason, "update_at": now}
+	if change.Status == model.APPROVED && ap.ApproveAt == nil {
+		updates["approve_at"] = now
+	}
+	history := model.AppointmentHistory{
+		AppointmentID: appointmentId,
+		FromStatus:    ap.Status,
+		ToStatus:      change.Status,
+		Reason:        change.Reason,
+		ActorType:     change.ActorType,
+		ActorID:       change.ActorID,
+	}
+	if change.Date != nil && *change.Date != ap.Date {
+		moved := ap
+		moved.Date = *change.Date
+		if err := checkAppointmentConflict(tx, moved); err != nil {
+			return err
+		}
+		updates["date"] = *change.Date
+		history.FromDate = &ap.Date
+		history.ToDate = change.Date
+	}
+	err = tx.Model(&model.Appointment{}).Where("id = ?", appointmentId).Updates(updates).Error
+	if err != nil {
+		return err
+	}
+	// pending reschedule request can't be accepted anymore
+	if !change.Status.IsActive() {
+		err = tx.Model(&model.RescheduleRequest{}).
+			Where("appointment_id = ? AND status = ?", appointmentId, model.RESCHEDULE_PENDING).
+			Updates(map[string]any{"status": model.RESCHEDULE_CANCELLED, "resolve_at": now}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Create(&history).Error
+}
+
+func (r *Repo) GetAppointmentHistory(appointmentId any) ([]model.AppointmentHistory, error) {
+	res := []model.AppointmentHistory{}
+	err := r.db.Where("appointment_id = ?", appointmentId).Order("create_at ASC, id ASC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+// count appointments of each status with following criteria
+func (r *Repo) GetAppointmentStatusCount(criteria ...Criteria) ([]model.AppointmentStatusCount, error) {
+	res := []model.AppointmentStatusCount{}
+	db := attachCriteria(r.db, criteria...)
+	err := db.Model(&model.Appointment{}).Select("status, COUNT(*) AS count").Group("status").Scan(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+/*
+find an active appointment of the same doctor or patient that overlaps the given one, must be called inside transaction,
+the doctor and patient rows are locked first so concurrent bookings wait for each other,
+locking the overlap query alone doesn't block inserts on TiDB
+*/
+func checkAppointmentConflict(tx *gorm.DB, appointment model.Appointment) error {
+	var locked []int
+	err := tx.Model(&model.Doctor{}).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", appointment.DoctorID).Pluck("id", &locked).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Model(&model.Patient{}).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", appointment.PatientID).Pluck("id", &locked).Error
+	if err != nil {
+		return err
+	}
+	var conflict model.Appointment
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id <> ?", appointment.ID).
+		Where("(doctor_id = ? OR patient_id = ?)", appointment.DoctorID, appointment.PatientID).
+		Where(string(STATUS_ACTIVE)).
+		Where("date < ? AND date + duration * 60 > ?", appointment.EndDate(), appointment.Date).
+		Order("date ASC").
+		First(&conflict).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	return &ErrAppointmentConflict{Appointment: conflict}
+}
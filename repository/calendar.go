@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// current version of the owner's calendar feed, 0 until the feed is rotated
+func (r *Repo) GetCalendarFeedVersion(owner string, ownerId int) (int, error) {
+	versions := []int{}
+	err := r.db.Model(&model.CalendarFeed{}).Where("owner = ? AND owner_id = ?", owner, ownerId).Pluck("version", &versions).Error
+	if err != nil {
+		return 0, fmt.Errorf("query : %w", err)
+	}
+	if len(versions) == 0 {
+		return 0, nil
+	}
+	return versions[0], nil
+}
+
+// increase version of the owner's calendar feed so urls given before stop working, return the new version
+func (r *Repo) RotateCalendarFeed(owner string, ownerId int) (int, error) {
+	var version int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		version, err = rotateCalendarFeed(tx, owner, ownerId)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("exec : %w", err)
+	}
+	return version, nil
+}
+
+func rotateCalendarFeed(tx *gorm.DB, owner string, ownerId int) (int, error) {
+	feed := model.CalendarFeed{Owner: owner, OwnerID: ownerId, Version: 1}
+	err := tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{"version": gorm.Expr("version + 1")}),
+	}).Create(&feed).Error
+	if err != nil {
+		return 0, err
+	}
+	err = tx.Where("owner = ? AND owner_id = ?", owner, ownerId).First(&feed).Error
+	return feed.Version, err
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ids of patients in the segment at the given time
+func (r *Repo) GetSegmentPatientIds(segment model.CampaignSegment, now int) ([]int, error) {
+	ids := []int{}
+	db := r.db.Model(&model.Patient{}).Where("verification_status = ?", model.SIGNUP_APPROVED)
+	if segment.DoctorID != nil {
+		db = db.Where("EXISTS (SELECT 1 FROM appointments WHERE appointments.patient_id = patients.id AND appointments.doctor_id = ? AND appointments.deleted_at = 0)", *segment.DoctorID)
+	}
+	if segment.UpcomingDays != nil {
+		db = db.Where("EXISTS (SELECT 1 FROM appointments WHERE appointments.patient_id = patients.id AND appointments.status IN ? AND appointments.date > ? AND appointments.date < ? AND appointments.deleted_at = 0)",
+			[]model.AppointmentStatus{model.APPROVED, model.RESCHEDULED}, now, now+*segment.UpcomingDays*24*60*60)
+	}
+	t := time.Unix(int64(now), 0)
+	if segment.MinAge != nil {
+		// born on or before this date
+		db = db.Where("birth_date <= ?", t.AddDate(-*segment.MinAge, 0, 0).Unix())
+	}
+	if segment.MaxAge != nil {
+		// not yet MaxAge + 1 years old
+		db = db.Where("birth_date > ?", t.AddDate(-*segment.MaxAge-1, 0, 0).Unix())
+	}
+	err := db.Order("id ASC").Pluck("id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("query : %w", err)
+	}
+	return ids, nil
+}
+
+func (r *Repo) GetCampaign(campaignId any) (model.Campaign, error) {
+	var c model.Campaign
+	err := r.db.Where("id = ?", campaignId).First(&c).Error
+	if err != nil {
+		return c, fmt.Errorf("query : %w", err)
+	}
+	return c, nil
+}
+
+// Get campaigns with following criteria, newest first
+func (r *Repo) GetAllCampaign(limit int, offset int, criteria ...Criteria) ([]model.Campaign, error) {
+	res := []model.Campaign{}
+	db := attachCriteria(r.db, criteria...)
+	err := db.Limit(limit).Offset(offset).Order("scheduled_at DESC, id DESC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) CreateCampaign(campaign model.Campaign) (int, error) {
+	err := r.db.Create(&campaign).Error
+	if err != nil {
+		return -1, fmt.Errorf("exec : %w", err)
+	}
+	return campaign.ID, nil
+}
+
+func (r *Repo) UpdateCampaign(campaign model.Campaign) error {
+	err := r.db.Select("*").Omit("create_at", "create_by_id").Updates(&campaign).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// cancel a scheduled campaign, campaigns that already started can't be cancelled
+func (r *Repo) CancelCampaign(campaignId int) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var c model.Campaign
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", campaignId).First(&c).Error
+		if err != nil {
+			return err
+		}
+		if c.Status != model.CAMPAIGN_SCHEDULED {
+			return ErrInvalidStatusTransition
+		}
+		return tx.Model(&c).Update("status", model.CAMPAIGN_CANCELLED).Error
+	})
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// lock due scheduled campaigns and mark them sending, so only one worker sends each campaign
+func (r *Repo) ClaimDueCampaigns(now int) ([]model.Campaign, error) {
+	res := []model.Campaign{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("status = ? AND scheduled_at <= ?", model.CAMPAIGN_SCHEDULED, now).
+			Order("scheduled_at ASC").
+			Find(&res).Error
+		if err != nil || len(res) == 0 {
+			return err
+		}
+		ids := []int{}
+		for i := range res {
+			ids = append(ids, res[i].ID)
+			res[i].Status = model.CAMPAIGN_SENDING
+		}
+		return tx.Model(&model.Campaign{}).Where("id IN ?", ids).Update("status", model.CAMPAIGN_SENDING).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("exec : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) GetCampaignStats(campaignId int) (model.CampaignStats, error) {
+	var stats model.CampaignStats
+	err := r.db.Model(&model.Notification{}).Where("campaign_id = ? AND read_at IS NOT NULL", campaignId).Select("COUNT(*)").Scan(&stats.ReadCount).Error
+	if err != nil {
+		return stats, fmt.Errorf("query : %w", err)
+	}
+	stats.Outbox = []model.OutboxStatusCount{}
+	err = r.db.Model(&model.NotificationOutbox{}).Select("status, COUNT(*) AS count").Where("campaign_id = ?", campaignId).Group("status").Scan(&stats.Outbox).Error
+	if err != nil {
+		return stats, fmt.Errorf("query : %w", err)
+	}
+	return stats, nil
+}
+
+/*
+save inbox entries of the campaign, the outbox messages built from the saved entries and the sent campaign
+in one transaction, so a failed send leaves nothing to duplicate when the campaign is retried
+*/
+func (r *Repo) SaveSentCampaign(campaign model.Campaign, notifications []model.Notification, buildMessages func(saved []model.Notification) []model.NotificationOutbox) ([]model.NotificationOutbox, error) {
+	var messages []model.NotificationOutbox
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if len(notifications) > 0 {
+			if err := tx.CreateInBatches(&notifications, 500).Error; err != nil {
+				return err
+			}
+		}
+		messages = buildMessages(notifications)
+		if len(messages) > 0 {
+			if err := tx.CreateInBatches(&messages, 500).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Select("*").Omit("create_at", "create_by_id").Updates(&campaign).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("exec : %w", err)
+	}
+	return messages, nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+)
+
+// Get care team of the patient with their doctors
+func (r *Repo) GetAllCareTeamMember(patientId int) ([]model.SafeCareTeamMember, error) {
+	res := []model.SafeCareTeamMember{}
+	err := r.db.Model(&model.CareTeamMember{}).Joins("Doctor").
+		Where("care_team_members.patient_id = ?", patientId).
+		Order("care_team_members.create_at ASC, care_team_members.id ASC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) GetCareTeamMember(patientId any, doctorId any) (model.CareTeamMember, error) {
+	var m model.CareTeamMember
+	err := r.db.Where("patient_id = ? AND doctor_id = ?", patientId, doctorId).First(&m).Error
+	if err != nil {
+		return m, fmt.Errorf("query : %w", err)
+	}
+	return m, nil
+}
+
+// return ErrDuplicateEntry when the doctor is already in the team, ErrForeignKeyFail when the patient or doctor doesn't exist
+func (r *Repo) CreateCareTeamMember(member model.CareTeamMember) (int, error) {
+	err := r.db.Omit("Patient", "Doctor").Create(&member).Error
+	if err != nil {
+		return -1, constraintError(err)
+	}
+	return member.ID, nil
+}
+
+func (r *Repo) UpdateCareTeamMember(member model.CareTeamMember) error {
+	err := r.db.Select("role").Updates(&member).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+func (r *Repo) DeleteCareTeamMember(memberId any) error {
+	err := r.db.Where("id = ?", memberId).Delete(&model.CareTeamMember{}).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+func (r *Repo) CreateDoctorNotifications(notifications []model.DoctorNotification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	err := r.db.Create(&notifications).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// Get notifications of doctors with following criteria, newest first
+func (r *Repo) GetAllDoctorNotification(limit int, offset int, criteria ...Criteria) ([]model.DoctorNotification, error) {
+	res := []model.DoctorNotification{}
+	db := attachCriteria(r.db, criteria...)
+	err := db.Limit(limit).Offset(offset).Order("create_at DESC, id DESC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) CountDoctorNotification(criteria ...Criteria) (int, error) {
+	var count int64
+	db := attachCriteria(r.db.Model(&model.DoctorNotification{}), criteria...)
+	err := db.Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("query : %w", err)
+	}
+	return int(count), nil
+}
+
+// mark a notification of the doctor as read, reading it again keeps the first read time
+func (r *Repo) ReadDoctorNotification(doctorId int, notificationId any) error {
+	var n model.DoctorNotification
+	err := r.db.Where("id = ? AND doctor_id = ?", notificationId, doctorId).First(&n).Error
+	if err != nil {
+		return fmt.Errorf("query : %w", err)
+	}
+	if n.ReadAt != nil {
+		return nil
+	}
+	err = r.db.Model(&n).Update("read_at", int(time.Now().Unix())).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// mark every unread notification of the doctor as read, return number of updated notifications
+func (r *Repo) ReadAllDoctorNotification(doctorId int) (int, error) {
+	result := r.db.Model(&model.DoctorNotification{}).
+		Where("doctor_id = ? AND read_at IS NULL", doctorId).
+		Update("read_at", int(time.Now().Unix()))
+	if result.Error != nil {
+		return 0, fmt.Errorf("exec : %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
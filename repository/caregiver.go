@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"gorm.io/gorm"
+)
+
+func (r *Repo) GetCaregiverById(caregiverId any) (model.Caregiver, error) {
+	var cg model.Caregiver
+	err := r.db.Where("id = ?", caregiverId).First(&cg).Error
+	if err != nil {
+		return cg, fmt.Errorf("query : %w", err)
+	}
+	return cg, nil
+}
+
+func (r *Repo) GetCaregiverByNID(nid string) (model.Caregiver, error) {
+	var cg model.Caregiver
+	err := r.db.Where("nid = ?", nid).First(&cg).Error
+	if err != nil {
+		return cg, fmt.Errorf("query : %w", err)
+	}
+	return cg, nil
+}
+
+// return ErrDuplicateEntry when the NID is already used
+func (r *Repo) CreateCaregiver(caregiver model.Caregiver) (int, error) {
+	err := r.db.Create(&caregiver).Error
+	if err != nil {
+		return -1, constraintError(err)
+	}
+	return caregiver.ID, nil
+}
+
+// caregivers of the patient, oldest link first
+func (r *Repo) GetAllCaregiverLink(patientId int) ([]model.SafeCaregiverLink, error) {
+	res := []model.SafeCaregiverLink{}
+	err := r.db.Model(&model.CaregiverLink{}).Joins("Caregiver").
+		Where("caregiver_links.patient_id = ?", patientId).
+		Order("caregiver_links.create_at ASC, caregiver_links.id ASC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+// patients of the caregiver, oldest link first
+func (r *Repo) GetAllCaregiverPatient(caregiverId int) ([]model.CaregiverPatient, error) {
+	res := []model.CaregiverPatient{}
+	err := r.db.Model(&model.CaregiverLink{}).Joins("Patient").
+		Where("caregiver_links.caregiver_id = ?", caregiverId).
+		Order("caregiver_links.create_at ASC, caregiver_links.id ASC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) GetCaregiverLink(caregiverId any, patientId any) (model.CaregiverLink, error) {
+	var link model.CaregiverLink
+	err := r.db.Where("caregiver_id = ? AND patient_id = ?", caregiverId, patientId).First(&link).Error
+	if err != nil {
+		return link, fmt.Errorf("query : %w", err)
+	}
+	return link, nil
+}
+
+// return ErrDuplicateEntry when the caregiver is already linked
+func (r *Repo) CreateCaregiverLink(link model.CaregiverLink) (int, error) {
+	err := r.db.Omit("Caregiver", "Patient").Create(&link).Error
+	if err != nil {
+		return -1, constraintError(err)
+	}
+	return link.ID, nil
+}
+
+// update relationship and permissions of the link
+func (r *Repo) UpdateCaregiverLink(link model.CaregiverLink) error {
+	err := r.db.Select("relationship", "permissions").Updates(&link).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+/*
+unlink and log out the caregiver's devices acting for the patient, so they stop getting its pushes.
+the patient's calendar feed is rotated too, the caregiver may have kept its url
+*/
+func (r *Repo) DeleteCaregiverLink(link model.CaregiverLink) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", link.ID).Delete(&model.CaregiverLink{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("caregiver_id = ? AND patient_id = ?", link.CaregiverID, link.PatientID).Delete(&model.Device{}).Error; err != nil {
+			return err
+		}
+		_, err := rotateCalendarFeed(tx, "patient", link.PatientID) // owner of auth.CALENDAR_PATIENT
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// Get activity logs with following criteria, newest first
+func (r *Repo) GetAllActivityLog(limit int, offset int, criteria ...Criteria) ([]model.ActivityLog, error) {
+	res := []model.ActivityLog{}
+	db := attachCriteria(r.db, criteria...)
+	err := db.Limit(limit).Offset(offset).Order("create_at DESC, id DESC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
@@ -21,7 +21,9 @@ func (c *Criteria) ToString() string {
 type ColumnCriteria string
 
 const (
+	ID                   ColumnCriteria = "id = %v"
 	PATIENTID            ColumnCriteria = "patient_id = %v"
+	PATIENTID_IN         ColumnCriteria = "patient_id IN (%v)"
 	DOCTORID             ColumnCriteria = "doctor_id = %v"
 	ANSWERAT_ISNULL      ColumnCriteria = "answer_at IS NULL"
 	ANSWERAT_ISNOTNULL   ColumnCriteria = "answer_at IS NOT NULL"
@@ -33,6 +35,42 @@ const (
 	DOCTOR_SEARCH        ColumnCriteria = "first_name ILIKE '%%%[1]v%%' OR middle_name ILIKE '%%%[1]v%%' OR last_name ILIKE '%%%[1]v%%'"
 	PATIENT_SEARCH       ColumnCriteria = "first_name ILIKE '%%%[1]v%%' OR middle_name ILIKE '%%%[1]v%%' OR last_name ILIKE '%%%[1]v%%' OR hn ILIKE '%%%[1]v%%'"
 	QUESTION_SEARCH      ColumnCriteria = "topic ILIKE '%%%v%%'"
+	DOCTORID_OR_CLINIC   ColumnCriteria = "(doctor_id = %v OR doctor_id IS NULL)"
+	STARTAT_LESSTHAN     ColumnCriteria = "start_at < %v"
+	ENDAT_GREATERTHAN    ColumnCriteria = "end_at > %v"
+	STATUS               ColumnCriteria = "status = '%v'"
+	STATUS_ACTIVE        ColumnCriteria = "status IN ('requested', 'approved', 'rescheduled')"
+	STATUS_CONFIRMED     ColumnCriteria = "status IN ('approved', 'rescheduled')"
+	IS_ENABLED           ColumnCriteria = "enabled = %v"
+	APPOINTMENTID_IN     ColumnCriteria = "appointment_id IN (%v)"
+	TICKETID_ISNOTNULL   ColumnCriteria = "ticket_id IS NOT NULL"
+	READAT_ISNULL        ColumnCriteria = "read_at IS NULL"
+	SENTAT_LESSTHAN      ColumnCriteria = "sent_at < %v"
+	DOCTOR_UNREAD        ColumnCriteria = "doctor_unread > 0"
+	CLOSEDAT_ISNULL      ColumnCriteria = "closed_at IS NULL"
+	CLOSEDAT_ISNOTNULL   ColumnCriteria = "closed_at IS NOT NULL"
+	ASSIGNEEID           ColumnCriteria = "assignee_id = %v"
+	ASSIGNEEID_ISNULL    ColumnCriteria = "assignee_id IS NULL"
+	CATEGORY             ColumnCriteria = "category = '%v'"
+	PRIORITY             ColumnCriteria = "priority = '%v'"
+	AWAITING_LESSTHAN    ColumnCriteria = "awaiting_since < %v"
+	OWNERID              ColumnCriteria = "owner_id = %v"
+	IS_SHARED            ColumnCriteria = "shared = %v"
+	SNIPPET_VISIBLE      ColumnCriteria = "(owner_id = %v OR shared = true)"
+	CONTENTID_ISNULL     ColumnCriteria = "content_id IS NULL"
+	TYPE                 ColumnCriteria = "type = '%v'"
+	MEASURED_AFTER       ColumnCriteria = "measured_at > %v"
+	MEASURED_BEFORE      ColumnCriteria = "measured_at < %v"
+	ENDDATE_ISNULL       ColumnCriteria = "end_date IS NULL"
+	ENDDATE_ISNOTNULL    ColumnCriteria = "end_date IS NOT NULL"
+	MY_PATIENT           ColumnCriteria = "id IN (SELECT patient_id FROM care_team_members WHERE doctor_id = %v)"
+	MY_PATIENTID         ColumnCriteria = "patient_id IN (SELECT patient_id FROM care_team_members WHERE doctor_id = %v)"
+	CAREGIVERID          ColumnCriteria = "caregiver_id = %v"
+	CAREGIVERID_ISNULL   ColumnCriteria = "caregiver_id IS NULL"
+	VERIFICATION         ColumnCriteria = "verification_status = '%v'"
+	USEDBYID_ISNULL      ColumnCriteria = "used_by_id IS NULL"
+	PATIENT_DEVICE       ColumnCriteria = "(patient_id = %[1]v OR caregiver_id IN (SELECT caregiver_id FROM caregiver_links WHERE patient_id = %[1]v))"
+	PENDING_RESCHEDULE   ColumnCriteria = "EXISTS (SELECT 1 FROM reschedule_requests WHERE reschedule_requests.appointment_id = appointments.id AND reschedule_requests.status = 'pending')"
 )
 
 func attachCriteria(db *gorm.DB, criteria ...Criteria) *gorm.DB {
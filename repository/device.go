@@ -39,3 +39,21 @@ func (r *Repo) DeleteDevice(deviceId any) error {
 	}
 	return nil
 }
+
+func (r *Repo) GetAllDeviceRemoval(criteria ...Criteria) ([]model.DeviceRemoval, error) {
+	res := []model.DeviceRemoval{}
+	db := attachCriteria(r.db, criteria...)
+	err := db.Order("remove_at DESC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) CreateDeviceRemoval(removal model.DeviceRemoval) error {
+	err := r.db.Create(&removal).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
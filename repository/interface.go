@@ -4,6 +4,7 @@ import (
 	// "database/sql"
 	"database/sql"
 	"errors"
+	"fmt"
 
 	"github.com/PhasitWo/duchenne-server/model"
 	"gorm.io/gorm"
@@ -25,6 +26,22 @@ func (r *Repo) New(db *gorm.DB) IRepo {
 // ERROR
 var ErrDuplicateEntry = errors.New("duplicate entry")
 var ErrForeignKeyFail = errors.New("foreign key error")
+var ErrInvalidStatusTransition = errors.New("invalid status transition")
+var ErrQuestionClosed = errors.New("question is closed")
+var ErrQuestionAssigned = errors.New("question is assigned to another doctor")
+var ErrRegimenEnded = errors.New("medication regimen has ended")
+var ErrRegimenReplaced = errors.New("medication regimen was changed by a later regimen")
+var ErrRegimenHasDoses = errors.New("medication regimen has logged doses")
+var ErrInvitationUsed = errors.New("invitation code is already used")
+
+// the appointment overlaps an active appointment of the same doctor or patient
+type ErrAppointmentConflict struct {
+	Appointment model.Appointment // the clashing appointment
+}
+
+func (e *ErrAppointmentConflict) Error() string {
+	return fmt.Sprintf("appointment conflict with appointment id %v", e.Appointment.ID)
+}
 
 type IRepo interface {
 	New(db *gorm.DB) IRepo
@@ -33,10 +50,49 @@ type IRepo interface {
 	CreateAppointment(appointment model.Appointment) (int, error)
 	UpdateAppointment(appointment model.Appointment) error
 	DeleteAppointment(appointmentId any) error
+	ChangeAppointmentStatus(appointmentId int, change model.AppointmentStatusChange) error
+	GetAppointmentHistory(appointmentId any) ([]model.AppointmentHistory, error)
+	GetAppointmentStatusCount(criteria ...Criteria) ([]model.AppointmentStatusCount, error)
+	CreateRescheduleRequest(request model.RescheduleRequest) (int, error)
+	ResolveRescheduleRequest(appointmentId int, accept bool, reason *string, doctorId int) (model.RescheduleRequest, error)
+	GetAllReminderRule(criteria ...Criteria) ([]model.ReminderRule, error)
+	CreateReminderRule(rule model.ReminderRule) (int, error)
+	UpdateReminderRule(rule model.ReminderRule) error
+	DeleteReminderRule(ruleId any) error
+	GetAllReminderLog(criteria ...Criteria) ([]model.ReminderLog, error)
+	CreateReminderLog(log model.ReminderLog) error
+	CreateNotification(notification model.Notification) (int, error)
+	CreateNotifications(notifications []model.Notification) ([]model.Notification, error)
+	GetAllNotification(limit int, offset int, criteria ...Criteria) ([]model.Notification, error)
+	CountNotification(criteria ...Criteria) (int, error)
+	ReadNotification(patientId int, notificationId any) error
+	ReadAllNotification(patientId int) (int, error)
+	GetNotificationPreference(patientId int) (model.NotificationPreference, error)
+	GetAllNotificationPreference(criteria ...Criteria) ([]model.NotificationPreference, error)
+	UpsertNotificationPreference(pref model.NotificationPreference) error
+	GetNotificationTemplate(key model.TemplateKey, language model.Language) (model.NotificationTemplate, error)
+	GetAllNotificationTemplate() ([]model.NotificationTemplate, error)
+	UpsertNotificationTemplate(template model.NotificationTemplate) error
+	DeleteNotificationTemplate(key model.TemplateKey, language model.Language) error
+	GetSegmentPatientIds(segment model.CampaignSegment, now int) ([]int, error)
+	GetCampaign(campaignId any) (model.Campaign, error)
+	GetAllCampaign(limit int, offset int, criteria ...Criteria) ([]model.Campaign, error)
+	CreateCampaign(campaign model.Campaign) (int, error)
+	UpdateCampaign(campaign model.Campaign) error
+	SaveSentCampaign(campaign model.Campaign, notifications []model.Notification, buildMessages func(saved []model.Notification) []model.NotificationOutbox) ([]model.NotificationOutbox, error)
+	CancelCampaign(campaignId int) error
+	ClaimDueCampaigns(now int) ([]model.Campaign, error)
+	GetCampaignStats(campaignId int) (model.CampaignStats, error)
+	CreateOutboxMessages(messages []model.NotificationOutbox) ([]model.NotificationOutbox, error)
+	GetAllOutboxMessage(limit int, criteria ...Criteria) ([]model.NotificationOutbox, error)
+	ClaimDueOutboxMessages(now int, lease int, limit int) ([]model.NotificationOutbox, error)
+	UpdateOutboxMessage(message model.NotificationOutbox) error
 	GetAllDevice(criteria ...Criteria) ([]model.Device, error)
 	UpdateDevice(d model.Device) error
 	CreateDevice(d model.Device) (int, error)
 	DeleteDevice(deviceId any) error
+	GetAllDeviceRemoval(criteria ...Criteria) ([]model.DeviceRemoval, error)
+	CreateDeviceRemoval(removal model.DeviceRemoval) error
 	GetDoctorByUsername(username string) (model.Doctor, error)
 	GetDoctorById(id any) (model.Doctor, error)
 	GetAllDoctor(limit int, offset int, criteria ...Criteria) ([]model.TrimDoctor, error)
@@ -51,24 +107,104 @@ type IRepo interface {
 	UpdatePatient(patient model.Patient) error
 	UpdatePatientPassword(patientId int, newPassword string) error
 	UpdatePatientPin(patientId int, newPin string) error
-	UpdatePatientVaccineHistory(patientId int, vaccineHistory []model.VaccineHistory) error
-	UpdatePatientMedicine(patientId int, medicines []model.Medicine) error
+	UpdatePatientLanguage(patientId int, language model.Language) error
+	GetMeasurement(patientId any, measurementId any) (model.Measurement, error)
+	GetAllMeasurement(patientId int, criteria ...Criteria) ([]model.Measurement, error)
+	CreateMeasurement(measurement model.Measurement) (int, error)
+	UpdateMeasurement(measurement model.Measurement) error
+	DeleteMeasurement(measurement model.Measurement) error
+	GetMedicationRegimen(patientId any, regimenId any) (model.MedicationRegimen, error)
+	GetAllMedicationRegimen(patientId int, criteria ...Criteria) ([]model.MedicationRegimen, error)
+	CreateMedicationRegimen(regimen model.MedicationRegimen) (int, error)
+	ChangeMedicationRegimen(currentId int, next model.MedicationRegimen) (int, error)
+	StopMedicationRegimen(regimenId int, endDate int, reason string) error
+	DeleteMedicationRegimen(regimen model.MedicationRegimen) error
+	GetRemindableMedicationRegimen() ([]model.MedicationRegimen, error)
+	UpdateMedicationReminder(regimenId int, disabled bool, times []string) error
+	GetAllMedicationDose(patientId int, from int, to int) ([]model.MedicationDose, error)
+	CreateMedicationDose(dose model.MedicationDose) error
+	LogMedicationDose(dose model.MedicationDose) error
+	GetVaccine(vaccineId any) (model.Vaccine, error)
+	GetAllVaccine() ([]model.Vaccine, error)
+	CreateVaccine(vaccine model.Vaccine) (int, error)
+	UpdateVaccine(vaccine model.Vaccine) error
+	DeleteVaccine(vaccineId any) error
+	GetAllVaccineRule(criteria ...Criteria) ([]model.VaccineRule, error)
+	CreateVaccineRule(rule model.VaccineRule) (int, error)
+	UpdateVaccineRule(rule model.VaccineRule) error
+	DeleteVaccineRule(ruleId any) error
+	GetVaccination(patientId any, vaccinationId any) (model.Vaccination, error)
+	GetAllVaccination(patientId int, criteria ...Criteria) ([]model.Vaccination, error)
+	CreateVaccination(vaccination model.Vaccination) (int, error)
+	UpdateVaccination(vaccination model.Vaccination) error
+	DeleteVaccination(vaccinationId any) error
+	CreateVaccineReminderLog(log model.VaccineReminderLog) error
+	GetAllCareTeamMember(patientId int) ([]model.SafeCareTeamMember, error)
+	GetCareTeamMember(patientId any, doctorId any) (model.CareTeamMember, error)
+	CreateCareTeamMember(member model.CareTeamMember) (int, error)
+	UpdateCareTeamMember(member model.CareTeamMember) error
+	DeleteCareTeamMember(memberId any) error
+	CreateDoctorNotifications(notifications []model.DoctorNotification) error
+	GetAllDoctorNotification(limit int, offset int, criteria ...Criteria) ([]model.DoctorNotification, error)
+	CountDoctorNotification(criteria ...Criteria) (int, error)
+	ReadDoctorNotification(doctorId int, notificationId any) error
+	ReadAllDoctorNotification(doctorId int) (int, error)
+	GetCaregiverById(caregiverId any) (model.Caregiver, error)
+	GetCaregiverByNID(nid string) (model.Caregiver, error)
+	CreateCaregiver(caregiver model.Caregiver) (int, error)
+	GetAllCaregiverLink(patientId int) ([]model.SafeCaregiverLink, error)
+	GetAllCaregiverPatient(caregiverId int) ([]model.CaregiverPatient, error)
+	GetCaregiverLink(caregiverId any, patientId any) (model.CaregiverLink, error)
+	CreateCaregiverLink(link model.CaregiverLink) (int, error)
+	UpdateCaregiverLink(link model.CaregiverLink) error
+	DeleteCaregiverLink(link model.CaregiverLink) error
+	GetAllActivityLog(limit int, offset int, criteria ...Criteria) ([]model.ActivityLog, error)
+	CreatePatientWithInvitation(patient model.Patient, invitationId int) (int, error)
+	ApproveSignup(patientId int, hn string, doctorId int) error
+	RejectSignup(patientId int, reason string, doctorId int) error
+	GetAllInvitationCode(limit int, offset int, criteria ...Criteria) ([]model.InvitationCode, error)
+	GetInvitationCode(code string) (model.InvitationCode, error)
+	CreateInvitationCode(invitation model.InvitationCode) (int, error)
+	DeleteInvitationCode(id any) error
 	DeletePatientById(id any) error
 	GetQuestion(questionId any) (model.SafeQuestion, error)
 	GetAllQuestion(limit int, offset int, criteria ...Criteria) ([]model.QuestionTopic, error)
 	CreateQuestion(patientId int, topic string, question string, createAt int) (int, error)
 	UpdateQuestionAnswer(questionId int, answer string, doctorId int) error
+	CreateQuestionMessage(message model.QuestionMessage) (int, error)
+	ReadQuestion(questionId int, reader model.ActorType) error
+	SetQuestionClosed(questionId int, closed bool) error
+	GetQuestionQueue(doctorId int, limit int, offset int) ([]model.QuestionTopic, error)
+	UpdateQuestionTriage(questionId int, category model.QuestionCategory, priority model.QuestionPriority) error
+	AssignQuestion(questionId int, assigneeId *int) error
+	ClaimQuestion(questionId int, doctorId int) error
+	ReleaseQuestion(questionId int, doctorId int) error
+	CreateQuestionAttachment(attachment model.QuestionAttachment) (int, error)
+	GetQuestionAttachment(questionId any, attachmentId any) (model.QuestionAttachment, error)
 	DeleteQuestion(questionId any) error
 	GetContent(contentID any) (model.Content, error)
 	GetAllContent(limit int, offset int, criteria ...Criteria) ([]model.Content, error)
 	CreateContent(content model.Content) (int, error)
 	UpdateContent(content model.Content) error
 	DeleteContent(contentID any) error
+	GetAnswerSnippet(snippetId any) (model.AnswerSnippet, error)
+	GetAllAnswerSnippet(limit int, offset int, search string, criteria ...Criteria) ([]model.AnswerSnippet, error)
+	CreateAnswerSnippet(snippet model.AnswerSnippet) (int, error)
+	UpdateAnswerSnippet(snippet model.AnswerSnippet) error
+	DeleteAnswerSnippet(snippetId any) error
+	IncreaseSnippetUsage(snippetId int) error
+	GetCalendarFeedVersion(owner string, ownerId int) (int, error)
+	RotateCalendarFeed(owner string, ownerId int) (int, error)
 	GetConsentById(consentId any) (model.Consent, error)
 	GetConsentBySlug(slug string) (model.Consent, error)
 	UpsertConsent(consent model.Consent) (string, error)
 	DeleteConsentById(consentID any) error
 	DeleteConsentBySlug(slug string) error
+	GetDoctorSchedule(doctorId any) ([]model.DoctorSchedule, error)
+	ReplaceDoctorSchedule(doctorId int, schedules []model.DoctorSchedule) error
+	GetAllScheduleException(criteria ...Criteria) ([]model.ScheduleException, error)
+	CreateScheduleException(exception model.ScheduleException) (int, error)
+	DeleteScheduleException(exceptionId any) error
 }
 
 type IGorm interface {
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"gorm.io/gorm"
+)
+
+func (r *Repo) GetMeasurement(patientId any, measurementId any) (model.Measurement, error) {
+	var m model.Measurement
+	err := r.db.Where("id = ? AND patient_id = ?", measurementId, patientId).First(&m).Error
+	if err != nil {
+		return m, fmt.Errorf("query : %w", err)
+	}
+	return m, nil
+}
+
+// Get all measurements of the patient with following criteria, oldest first
+func (r *Repo) GetAllMeasurement(patientId int, criteria ...Criteria) ([]model.Measurement, error) {
+	res := []model.Measurement{}
+	db := attachCriteria(r.db, criteria...)
+	err := db.Where("patient_id = ?", patientId).Order("measured_at ASC, id ASC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) CreateMeasurement(measurement model.Measurement) (int, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&measurement).Error; err != nil {
+			return err
+		}
+		return syncPatientMeasure(tx, measurement.PatientID, measurement.Type)
+	})
+	if err != nil {
+		return -1, fmt.Errorf("exec : %w", err)
+	}
+	return measurement.ID, nil
+}
+
+// update value, unit, date and note of the measurement
+func (r *Repo) UpdateMeasurement(measurement model.Measurement) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Select("value", "unit", "measured_at", "note").Updates(&measurement).Error
+		if err != nil {
+			return err
+		}
+		return syncPatientMeasure(tx, measurement.PatientID, measurement.Type)
+	})
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+func (r *Repo) DeleteMeasurement(measurement model.Measurement) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", measurement.ID).Delete(&model.Measurement{}).Error; err != nil {
+			return err
+		}
+		return syncPatientMeasure(tx, measurement.PatientID, measurement.Type)
+	})
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// keep the current weight and height of the patient at the latest measurement
+func syncPatientMeasure(tx *gorm.DB, patientId int, measurementType model.MeasurementType) error {
+	var column string
+	switch measurementType {
+	case model.MEASURE_WEIGHT:
+		column = "weight"
+	case model.MEASURE_HEIGHT:
+		column = "height"
+	default:
+		return nil
+	}
+	var latest model.Measurement
+	result := tx.Where("patient_id = ? AND type = ?", patientId, measurementType).Order("measured_at DESC, id DESC").Limit(1).Find(&latest)
+	if result.Error != nil {
+		return result.Error
+	}
+	// no measurement left, keep the value from the profile
+	if result.RowsAffected == 0 {
+		return nil
+	}
+	return tx.Model(&model.Patient{}).Where("id = ?", patientId).Update(column, latest.Value).Error
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *Repo) GetMedicationRegimen(patientId any, regimenId any) (model.MedicationRegimen, error) {
+	var m model.MedicationRegimen
+	err := r.db.Where("id = ? AND patient_id = ?", regimenId, patientId).First(&m).Error
+	if err != nil {
+		return m, fmt.Errorf("query : %w", err)
+	}
+	return m, nil
+}
+
+// Get all regimens of the patient with following criteria, latest start first
+func (r *Repo) GetAllMedicationRegimen(patientId int, criteria ...Criteria) ([]model.MedicationRegimen, error) {
+	res := []model.MedicationRegimen{}
+	db := attachCriteria(r.db, criteria...)
+	err := db.Where("patient_id = ?", patientId).Order("start_date IS NULL, start_date DESC, id DESC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) CreateMedicationRegimen(regimen model.MedicationRegimen) (int, error) {
+	err := r.db.Create(&regimen).Error
+	if err != nil {
+		return -1, fmt.Errorf("exec : %w", err)
+	}
+	return regimen.ID, nil
+}
+
+/*
+end the current regimen at the start date of the next one and create the next one,
+return ErrRegimenEnded when the current regimen has already ended
+*/
+func (r *Repo) ChangeMedicationRegimen(currentId int, next model.MedicationRegimen) (int, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var current model.MedicationRegimen
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", currentId).First(&current).Error
+		if err != nil {
+			return err
+		}
+		if current.EndDate != nil {
+			return ErrRegimenEnded
+		}
+		err = tx.Model(&current).Updates(map[string]any{"end_date": next.StartDate, "end_reason": next.Reason}).Error
+		if err != nil {
+			return err
+		}
+		next.PreviousID = &current.ID
+		// keep reminder settings of the patient, custom times only fit the same schedule
+		next.ReminderDisabled = current.ReminderDisabled
+		if next.Schedule == current.Schedule {
+			next.ReminderTimes = current.ReminderTimes
+		}
+		return tx.Create(&next).Error
+	})
+	if err != nil {
+		return -1, fmt.Errorf("exec : %w", err)
+	}
+	return next.ID, nil
+}
+
+// return ErrRegimenEnded when the regimen has already ended
+func (r *Repo) StopMedicationRegimen(regimenId int, endDate int, reason string) error {
+	result := r.db.Model(&model.MedicationRegimen{}).Where("id = ? AND end_date IS NULL", regimenId).
+		Updates(map[string]any{"end_date": endDate, "end_reason": reason})
+	if result.Error != nil {
+		return fmt.Errorf("exec : %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("exec : %w", ErrRegimenEnded)
+	}
+	return nil
+}
+
+/*
+remove a regimen entered by mistake, the regimen it replaced is still taken again,
+only the last regimen of a change chain without logged doses can be removed
+*/
+func (r *Repo) DeleteMedicationRegimen(regimen model.MedicationRegimen) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&model.MedicationRegimen{}).Where("previous_id = ?", regimen.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrRegimenReplaced
+		}
+		if err := tx.Model(&model.MedicationDose{}).Where("regimen_id = ? AND status IS NOT NULL", regimen.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrRegimenHasDoses
+		}
+		// only reminder bookkeeping is left
+		if err := tx.Where("regimen_id = ?", regimen.ID).Delete(&model.MedicationDose{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id = ?", regimen.ID).Delete(&model.MedicationRegimen{}).Error; err != nil {
+			return err
+		}
+		if regimen.PreviousID == nil {
+			return nil
+		}
+		return tx.Model(&model.MedicationRegimen{}).Where("id = ?", *regimen.PreviousID).
+			Updates(map[string]any{"end_date": nil, "end_reason": nil}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// regimens of approved patients still taken on a clock schedule with reminders on
+func (r *Repo) GetRemindableMedicationRegimen() ([]model.MedicationRegimen, error) {
+	res := []model.MedicationRegimen{}
+	err := r.db.Where("end_date IS NULL AND reminder_disabled = false AND schedule NOT IN ?",
+		[]model.MedicationSchedule{model.SCHEDULE_AS_NEEDED, model.SCHEDULE_OTHER}).
+		Where("patient_id IN (SELECT id FROM patients WHERE verification_status = ? AND deleted_at = 0)", model.SIGNUP_APPROVED).
+		Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) UpdateMedicationReminder(regimenId int, disabled bool, times []string) error {
+	var reminderTimes datatypes.JSONSlice[string]
+	if len(times) > 0 {
+		reminderTimes = datatypes.NewJSONSlice(times)
+	}
+	err := r.db.Model(&model.MedicationRegimen{}).Where("id = ?", regimenId).
+		Updates(map[string]any{"reminder_disabled": disabled, "reminder_times": reminderTimes}).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// logged and reminded doses of the patient scheduled in [from, to)
+func (r *Repo) GetAllMedicationDose(patientId int, from int, to int) ([]model.MedicationDose, error) {
+	res := []model.MedicationDose{}
+	err := r.db.Where("patient_id = ? AND scheduled_at >= ? AND scheduled_at < ?", patientId, from, to).
+		Order("scheduled_at ASC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+// record the reminder of a dose in the ledger, ErrDuplicateEntry means it's already reminded or logged
+func (r *Repo) CreateMedicationDose(dose model.MedicationDose) error {
+	err := r.db.Create(&dose).Error
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return fmt.Errorf("exec : %w", ErrDuplicateEntry)
+		}
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// taken or skipped by the patient, logging the same dose again replaces its status
+func (r *Repo) LogMedicationDose(dose model.MedicationDose) error {
+	err := r.db.Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"status", "log_at"}),
+	}).Create(&dose).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"gorm.io/gorm/clause"
+)
+
+func (r *Repo) CreateNotification(notification model.Notification) (int, error) {
+	err := r.db.Create(&notification).Error
+	if err != nil {
+		return -1, fmt.Errorf("exec : %w", err)
+	}
+	return notification.ID, nil
+}
+
+func (r *Repo) CreateNotifications(notifications []model.Notification) ([]model.Notification, error) {
+	if len(notifications) == 0 {
+		return notifications, nil
+	}
+	err := r.db.CreateInBatches(&notifications, 500).Error
+	if err != nil {
+		return nil, fmt.Errorf("exec : %w", err)
+	}
+	return notifications, nil
+}
+
+// Get notifications with following criteria, newest first
+func (r *Repo) GetAllNotification(limit int, offset int, criteria ...Criteria) ([]model.Notification, error) {
+	res := []model.Notification{}
+	db := attachCriteria(r.db, criteria...)
+	err := db.Limit(limit).Offset(offset).Order("create_at DESC, id DESC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) CountNotification(criteria ...Criteria) (int, error) {
+	var count int64
+	db := attachCriteria(r.db.Model(&model.Notification{}), criteria...)
+	err := db.Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("query : %w", err)
+	}
+	return int(count), nil
+}
+
+// mark a notification of the patient as read, reading it again keeps the first read time
+func (r *Repo) ReadNotification(patientId int, notificationId any) error {
+	var n model.Notification
+	err := r.db.Where("id = ? AND patient_id = ?", notificationId, patientId).First(&n).Error
+	if err != nil {
+		return fmt.Errorf("query : %w", err)
+	}
+	if n.ReadAt != nil {
+		return nil
+	}
+	err = r.db.Model(&n).Update("read_at", int(time.Now().Unix())).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// mark every unread notification of the patient as read, return number of updated notifications
+func (r *Repo) ReadAllNotification(patientId int) (int, error) {
+	result := r.db.Model(&model.Notification{}).
+		Where("patient_id = ? AND read_at IS NULL", patientId).
+		Update("read_at", int(time.Now().Unix()))
+	if result.Error != nil {
+		return 0, fmt.Errorf("exec : %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
+
+func (r *Repo) GetNotificationPreference(patientId int) (model.NotificationPreference, error) {
+	var res model.NotificationPreference
+	err := r.db.Where("patient_id = ?", patientId).First(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) GetAllNotificationPreference(criteria ...Criteria) ([]model.NotificationPreference, error) {
+	res := []model.NotificationPreference{}
+	db := attachCriteria(r.db, criteria...)
+	err := db.Find(&res).Error
+	if err != nil {
+		return nil, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) UpsertNotificationPreference(pref model.NotificationPreference) error {
+	err := r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&pref).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
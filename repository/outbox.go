@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *Repo) CreateOutboxMessages(messages []model.NotificationOutbox) ([]model.NotificationOutbox, error) {
+	if len(messages) == 0 {
+		return messages, nil
+	}
+	err := r.db.CreateInBatches(&messages, 500).Error
+	if err != nil {
+		return nil, fmt.Errorf("exec : %w", err)
+	}
+	return messages, nil
+}
+
+// Get outbox messages with following criteria, limit = -1 means no limit
+func (r *Repo) GetAllOutboxMessage(limit int, criteria ...Criteria) ([]model.NotificationOutbox, error) {
+	res := []model.NotificationOutbox{}
+	db := attachCriteria(r.db, criteria...)
+	err := db.Limit(limit).Order("id ASC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+/*
+lock due pending messages and push their next attempt by lease seconds,
+so other workers don't pick the same messages while they are being sent
+*/
+func (r *Repo) ClaimDueOutboxMessages(now int, lease int, limit int) ([]model.NotificationOutbox, error) {
+	res := []model.NotificationOutbox{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("status = ? AND next_attempt_at <= ?", model.OUTBOX_PENDING, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&res).Error
+		if err != nil || len(res) == 0 {
+			return err
+		}
+		ids := []int{}
+		for _, m := range res {
+			ids = append(ids, m.ID)
+		}
+		return tx.Model(&model.NotificationOutbox{}).Where("id IN ?", ids).Update("next_attempt_at", now+lease).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("exec : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) UpdateOutboxMessage(message model.NotificationOutbox) error {
+	err := r.db.Select("*").Omit("create_at").Updates(&message).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
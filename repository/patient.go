@@ -6,7 +6,6 @@ import (
 
 	"github.com/PhasitWo/duchenne-server/model"
 	"github.com/go-sql-driver/mysql"
-	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -49,7 +48,12 @@ func (r *Repo) GetAllPatient(limit int, offset int, criteria ...Criteria) ([]mod
 
 // return last inserted id
 func (r *Repo) CreatePatient(patient model.Patient) (int, error) {
-	err := r.db.Create(&patient).Error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := releaseRejectedSignup(tx, patient); err != nil {
+			return err
+		}
+		return tx.Create(&patient).Error
+	})
 	if err != nil {
 		var mysqlErr *mysql.MySQLError
 		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
@@ -61,7 +65,11 @@ func (r *Repo) CreatePatient(patient model.Patient) (int, error) {
 }
 
 func (r *Repo) UpdatePatient(patient model.Patient) error {
-	err := r.db.Select("*").Omit("vaccine_history", "medicine", "pin", "password").Updates(&patient).Error
+	omit := []string{"vaccine_history", "medicine", "pin", "password", "verification_status", "reject_reason", "verified_by_id", "verified_at"}
+	if patient.Language == "" {
+		omit = append(omit, "language")
+	}
+	err := r.db.Select("*").Omit(omit...).Updates(&patient).Error
 	if err != nil {
 		var mysqlErr *mysql.MySQLError
 		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
@@ -94,21 +102,10 @@ func (r *Repo) UpdatePatientPin(patientId int, newPin string) error {
 	return nil
 }
 
-func (r *Repo) UpdatePatientVaccineHistory(patientId int, vaccineHistory []model.VaccineHistory) error {
-	err := r.db.Select("vaccine_history").Updates(&model.Patient{
-		ID:             patientId,
-		VaccineHistory: datatypes.NewJSONSlice(vaccineHistory),
-	}).Error
-	if err != nil {
-		return fmt.Errorf("exec : %w", err)
-	}
-	return nil
-}
-
-func (r *Repo) UpdatePatientMedicine(patientId int, medicines []model.Medicine) error {
-	err := r.db.Select("medicine").Updates(&model.Patient{
+func (r *Repo) UpdatePatientLanguage(patientId int, language model.Language) error {
+	err := r.db.Select("language").Updates(&model.Patient{
 		ID:       patientId,
-		Medicine: datatypes.NewJSONSlice(medicines),
+		Language: language,
 	}).Error
 	if err != nil {
 		return fmt.Errorf("exec : %w", err)
@@ -5,11 +5,16 @@ import (
 	"time"
 
 	"github.com/PhasitWo/duchenne-server/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func (r *Repo) GetQuestion(questionId any) (model.SafeQuestion, error) {
 	var q model.SafeQuestion
-	err := r.db.Model(&model.Question{}).Joins("Doctor").Preload("Patient").Where("questions.id = ?", questionId).First(&q).Error
+	err := r.db.Model(&model.Question{}).Joins("Doctor").Preload("Patient").
+		Preload("Messages", func(db *gorm.DB) *gorm.DB { return db.Order("create_at ASC, id ASC") }).
+		Preload("Attachments", func(db *gorm.DB) *gorm.DB { return db.Order("create_at ASC, id ASC") }).
+		Where("questions.id = ?", questionId).First(&q).Error
 	if err != nil {
 		return q, fmt.Errorf("exec : %w", err)
 	}
@@ -20,31 +25,199 @@ func (r *Repo) GetQuestion(questionId any) (model.SafeQuestion, error) {
 func (r *Repo) GetAllQuestion(limit int, offset int, criteria ...Criteria) ([]model.QuestionTopic, error) {
 	res := []model.QuestionTopic{}
 	db := attachCriteria(r.db, criteria...)
-	err := db.Model(&model.Question{}).Joins("Doctor").Preload("Patient").Limit(limit).Offset(offset).Order("COALESCE(answer_at, create_at)  DESC").Find(&res).Error
+	err := db.Model(&model.Question{}).Joins("Doctor").Preload("Patient").Limit(limit).Offset(offset).Order("last_message_at DESC, questions.id DESC").Find(&res).Error
 	if err != nil {
 		return res, fmt.Errorf("exec : %w", err)
 	}
 	return res, nil
 }
 
+// create the question with its text as the first message of the thread
 func (r *Repo) CreateQuestion(patientId int, topic string, question string, createAt int) (int, error) {
-	q := &model.Question{PatientID: patientId, Topic: topic, Question: question, CreateAt: createAt, DoctorID: nil}
-	err := r.db.Create(&q).Error
+	q := &model.Question{PatientID: patientId, Topic: topic, Question: question, CreateAt: createAt, DoctorID: nil, LastMessageAt: createAt, DoctorUnread: 1, AwaitingSince: &createAt}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&q).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.QuestionMessage{
+			QuestionID: q.ID,
+			AuthorType: model.ACTOR_PATIENT,
+			AuthorID:   patientId,
+			Message:    question,
+			CreateAt:   createAt,
+		}).Error
+	})
 	if err != nil {
 		return -1, fmt.Errorf("exec : %w", err)
 	}
 	return q.ID, nil
 }
 
+// reply of the doctor, kept for clients that only know a single answer
 func (r *Repo) UpdateQuestionAnswer(questionId int, answer string, doctorId int) error {
-	now := int(time.Now().Unix())
-	err := r.db.Updates(&model.Question{ID: questionId, Answer: &answer, DoctorID: &doctorId, AnswerAt: &now}).Error
+	_, err := r.CreateQuestionMessage(model.QuestionMessage{
+		QuestionID: questionId,
+		AuthorType: model.ACTOR_DOCTOR,
+		AuthorID:   doctorId,
+		Message:    answer,
+	})
+	return err
+}
+
+/*
+append the message to the thread and mark it unread for the other side,
+the first doctor message is also the answer of the question, return ErrQuestionClosed when the question is closed
+*/
+func (r *Repo) CreateQuestionMessage(message model.QuestionMessage) (int, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var q model.Question
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", message.QuestionID).First(&q).Error
+		if err != nil {
+			return err
+		}
+		if q.ClosedAt != nil {
+			return ErrQuestionClosed
+		}
+		now := int(time.Now().Unix())
+		message.CreateAt = now
+		if err := tx.Create(&message).Error; err != nil {
+			return err
+		}
+		updates := map[string]any{"last_message_at": now}
+		switch message.AuthorType {
+		case model.ACTOR_DOCTOR:
+			updates["patient_unread"] = gorm.Expr("patient_unread + 1")
+			updates["awaiting_since"] = nil
+			if q.AnswerAt == nil {
+				updates["answer"] = message.Message
+				updates["answer_at"] = now
+				updates["doctor_id"] = message.AuthorID
+			}
+		case model.ACTOR_PATIENT:
+			updates["doctor_unread"] = gorm.Expr("doctor_unread + 1")
+			if q.AwaitingSince == nil {
+				updates["awaiting_since"] = now
+			}
+		}
+		return tx.Model(&q).Updates(updates).Error
+	})
+	if err != nil {
+		return -1, fmt.Errorf("exec : %w", err)
+	}
+	return message.ID, nil
+}
+
+// mark messages of the other side as read by the reader
+func (r *Repo) ReadQuestion(questionId int, reader model.ActorType) error {
+	column := "patient_unread"
+	if reader == model.ACTOR_DOCTOR {
+		column = "doctor_unread"
+	}
+	err := r.db.Model(&model.Question{}).Where("id = ?", questionId).Update(column, 0).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// close or reopen the thread, closing a closed question keeps the first close time
+func (r *Repo) SetQuestionClosed(questionId int, closed bool) error {
+	db := r.db.Model(&model.Question{}).Where("id = ?", questionId)
+	var err error
+	if closed {
+		err = db.Where("closed_at IS NULL").Update("closed_at", int(time.Now().Unix())).Error
+	} else {
+		err = db.Update("closed_at", nil).Error
+	}
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// open questions assigned to the doctor, most urgent and longest waiting first
+func (r *Repo) GetQuestionQueue(doctorId int, limit int, offset int) ([]model.QuestionTopic, error) {
+	res := []model.QuestionTopic{}
+	err := r.db.Model(&model.Question{}).Joins("Doctor").Preload("Patient").
+		Where("questions.assignee_id = ? AND questions.closed_at IS NULL", doctorId).
+		Limit(limit).Offset(offset).
+		Order("FIELD(questions.priority, 'urgent', 'high', 'normal', 'low'), questions.awaiting_since IS NULL, questions.awaiting_since ASC, questions.id ASC").
+		Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("exec : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) UpdateQuestionTriage(questionId int, category model.QuestionCategory, priority model.QuestionPriority) error {
+	err := r.db.Model(&model.Question{}).Where("id = ?", questionId).
+		Updates(map[string]any{"category": category, "priority": priority}).Error
 	if err != nil {
 		return fmt.Errorf("exec : %w", err)
 	}
 	return nil
 }
 
+// assign the question to the doctor, nil assignee makes it unassigned
+func (r *Repo) AssignQuestion(questionId int, assigneeId *int) error {
+	var assignAt *int
+	if assigneeId != nil {
+		now := int(time.Now().Unix())
+		assignAt = &now
+	}
+	err := r.db.Model(&model.Question{}).Where("id = ?", questionId).
+		Updates(map[string]any{"assignee_id": assigneeId, "assign_at": assignAt}).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// assign an unassigned question to the doctor, return ErrQuestionAssigned when another doctor has it
+func (r *Repo) ClaimQuestion(questionId int, doctorId int) error {
+	result := r.db.Model(&model.Question{}).
+		Where("id = ? AND (assignee_id IS NULL OR assignee_id = ?)", questionId, doctorId).
+		Updates(map[string]any{"assignee_id": doctorId, "assign_at": int(time.Now().Unix())})
+	if result.Error != nil {
+		return fmt.Errorf("exec : %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("exec : %w", ErrQuestionAssigned)
+	}
+	return nil
+}
+
+// unassign the doctor's question, return ErrQuestionAssigned when the doctor doesn't have it
+func (r *Repo) ReleaseQuestion(questionId int, doctorId int) error {
+	result := r.db.Model(&model.Question{}).
+		Where("id = ? AND assignee_id = ?", questionId, doctorId).
+		Updates(map[string]any{"assignee_id": nil, "assign_at": nil})
+	if result.Error != nil {
+		return fmt.Errorf("exec : %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("exec : %w", ErrQuestionAssigned)
+	}
+	return nil
+}
+
+func (r *Repo) CreateQuestionAttachment(attachment model.QuestionAttachment) (int, error) {
+	err := r.db.Create(&attachment).Error
+	if err != nil {
+		return -1, fmt.Errorf("exec : %w", err)
+	}
+	return attachment.ID, nil
+}
+
+func (r *Repo) GetQuestionAttachment(questionId any, attachmentId any) (model.QuestionAttachment, error) {
+	var res model.QuestionAttachment
+	err := r.db.Where("id = ? AND question_id = ?", attachmentId, questionId).First(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
 func (r *Repo) DeleteQuestion(questionId any) error {
 	err := r.db.Where("id = ?", questionId).Delete(&model.Question{}).Error
 	if err != nil {
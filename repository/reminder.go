@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/go-sql-driver/mysql"
+)
+
+func (r *Repo) GetAllReminderRule(criteria ...Criteria) ([]model.ReminderRule, error) {
+	res := []model.ReminderRule{}
+	db := attachCriteria(r.db, criteria...)
+	err := db.Order("offset_minutes DESC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) CreateReminderRule(rule model.ReminderRule) (int, error) {
+	err := r.db.Create(&rule).Error
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return -1, fmt.Errorf("exec : %w", ErrDuplicateEntry)
+		}
+		return -1, fmt.Errorf("exec : %w", err)
+	}
+	return rule.ID, nil
+}
+
+func (r *Repo) UpdateReminderRule(rule model.ReminderRule) error {
+	result := r.db.Select("*").Omit("create_at").Updates(&rule)
+	err := result.Error
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return fmt.Errorf("exec : %w", ErrDuplicateEntry)
+		}
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+func (r *Repo) DeleteReminderRule(ruleId any) error {
+	err := r.db.Where("id = ?", ruleId).Delete(&model.ReminderRule{}).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+func (r *Repo) GetAllReminderLog(criteria ...Criteria) ([]model.ReminderLog, error) {
+	res := []model.ReminderLog{}
+	db := attachCriteria(r.db, criteria...)
+	err := db.Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+// record reminder in the ledger, ErrDuplicateEntry means it's already sent by another run
+func (r *Repo) CreateReminderLog(log model.ReminderLog) error {
+	err := r.db.Create(&log).Error
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return fmt.Errorf("exec : %w", ErrDuplicateEntry)
+		}
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
@@ -37,288 +37,344 @@ func (_m *MockRepo) EXPECT() *MockRepo_Expecter {
 	return &MockRepo_Expecter{mock: &_m.Mock}
 }
 
-// CreateAppointment provides a mock function for the type MockRepo
-func (_mock *MockRepo) CreateAppointment(appointment model.Appointment) (int, error) {
-	ret := _mock.Called(appointment)
+// ApproveSignup provides a mock function for the type MockRepo
+func (_mock *MockRepo) ApproveSignup(patientId int, hn string, doctorId int) error {
+	ret := _mock.Called(patientId, hn, doctorId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateAppointment")
+		panic("no return value specified for ApproveSignup")
 	}
 
-	var r0 int
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(model.Appointment) (int, error)); ok {
-		return returnFunc(appointment)
-	}
-	if returnFunc, ok := ret.Get(0).(func(model.Appointment) int); ok {
-		r0 = returnFunc(appointment)
-	} else {
-		r0 = ret.Get(0).(int)
-	}
-	if returnFunc, ok := ret.Get(1).(func(model.Appointment) error); ok {
-		r1 = returnFunc(appointment)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, string, int) error); ok {
+		r0 = returnFunc(patientId, hn, doctorId)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockRepo_CreateAppointment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAppointment'
-type MockRepo_CreateAppointment_Call struct {
+// MockRepo_ApproveSignup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveSignup'
+type MockRepo_ApproveSignup_Call struct {
 	*mock.Call
 }
 
-// CreateAppointment is a helper method to define mock.On call
-//   - appointment model.Appointment
-func (_e *MockRepo_Expecter) CreateAppointment(appointment interface{}) *MockRepo_CreateAppointment_Call {
-	return &MockRepo_CreateAppointment_Call{Call: _e.mock.On("CreateAppointment", appointment)}
+// ApproveSignup is a helper method to define mock.On call
+//   - patientId int
+//   - hn string
+//   - doctorId int
+func (_e *MockRepo_Expecter) ApproveSignup(patientId interface{}, hn interface{}, doctorId interface{}) *MockRepo_ApproveSignup_Call {
+	return &MockRepo_ApproveSignup_Call{Call: _e.mock.On("ApproveSignup", patientId, hn, doctorId)}
 }
 
-func (_c *MockRepo_CreateAppointment_Call) Run(run func(appointment model.Appointment)) *MockRepo_CreateAppointment_Call {
+func (_c *MockRepo_ApproveSignup_Call) Run(run func(patientId int, hn string, doctorId int)) *MockRepo_ApproveSignup_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 model.Appointment
+		var arg0 int
 		if args[0] != nil {
-			arg0 = args[0].(model.Appointment)
+			arg0 = args[0].(int)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_CreateAppointment_Call) Return(n int, err error) *MockRepo_CreateAppointment_Call {
-	_c.Call.Return(n, err)
+func (_c *MockRepo_ApproveSignup_Call) Return(err error) *MockRepo_ApproveSignup_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_CreateAppointment_Call) RunAndReturn(run func(appointment model.Appointment) (int, error)) *MockRepo_CreateAppointment_Call {
+func (_c *MockRepo_ApproveSignup_Call) RunAndReturn(run func(patientId int, hn string, doctorId int) error) *MockRepo_ApproveSignup_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateContent provides a mock function for the type MockRepo
-func (_mock *MockRepo) CreateContent(content model.Content) (int, error) {
-	ret := _mock.Called(content)
+// AssignQuestion provides a mock function for the type MockRepo
+func (_mock *MockRepo) AssignQuestion(questionId int, assigneeId *int) error {
+	ret := _mock.Called(questionId, assigneeId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateContent")
+		panic("no return value specified for AssignQuestion")
 	}
 
-	var r0 int
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(model.Content) (int, error)); ok {
-		return returnFunc(content)
-	}
-	if returnFunc, ok := ret.Get(0).(func(model.Content) int); ok {
-		r0 = returnFunc(content)
-	} else {
-		r0 = ret.Get(0).(int)
-	}
-	if returnFunc, ok := ret.Get(1).(func(model.Content) error); ok {
-		r1 = returnFunc(content)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, *int) error); ok {
+		r0 = returnFunc(questionId, assigneeId)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockRepo_CreateContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateContent'
-type MockRepo_CreateContent_Call struct {
+// MockRepo_AssignQuestion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignQuestion'
+type MockRepo_AssignQuestion_Call struct {
 	*mock.Call
 }
 
-// CreateContent is a helper method to define mock.On call
-//   - content model.Content
-func (_e *MockRepo_Expecter) CreateContent(content interface{}) *MockRepo_CreateContent_Call {
-	return &MockRepo_CreateContent_Call{Call: _e.mock.On("CreateContent", content)}
+// AssignQuestion is a helper method to define mock.On call
+//   - questionId int
+//   - assigneeId *int
+func (_e *MockRepo_Expecter) AssignQuestion(questionId interface{}, assigneeId interface{}) *MockRepo_AssignQuestion_Call {
+	return &MockRepo_AssignQuestion_Call{Call: _e.mock.On("AssignQuestion", questionId, assigneeId)}
 }
 
-func (_c *MockRepo_CreateContent_Call) Run(run func(content model.Content)) *MockRepo_CreateContent_Call {
+func (_c *MockRepo_AssignQuestion_Call) Run(run func(questionId int, assigneeId *int)) *MockRepo_AssignQuestion_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 model.Content
+		var arg0 int
 		if args[0] != nil {
-			arg0 = args[0].(model.Content)
+			arg0 = args[0].(int)
+		}
+		var arg1 *int
+		if args[1] != nil {
+			arg1 = args[1].(*int)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_CreateContent_Call) Return(n int, err error) *MockRepo_CreateContent_Call {
-	_c.Call.Return(n, err)
+func (_c *MockRepo_AssignQuestion_Call) Return(err error) *MockRepo_AssignQuestion_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_CreateContent_Call) RunAndReturn(run func(content model.Content) (int, error)) *MockRepo_CreateContent_Call {
+func (_c *MockRepo_AssignQuestion_Call) RunAndReturn(run func(questionId int, assigneeId *int) error) *MockRepo_AssignQuestion_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateDevice provides a mock function for the type MockRepo
-func (_mock *MockRepo) CreateDevice(d model.Device) (int, error) {
-	ret := _mock.Called(d)
+// CancelCampaign provides a mock function for the type MockRepo
+func (_mock *MockRepo) CancelCampaign(campaignId int) error {
+	ret := _mock.Called(campaignId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateDevice")
+		panic("no return value specified for CancelCampaign")
 	}
 
-	var r0 int
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(model.Device) (int, error)); ok {
-		return returnFunc(d)
-	}
-	if returnFunc, ok := ret.Get(0).(func(model.Device) int); ok {
-		r0 = returnFunc(d)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int) error); ok {
+		r0 = returnFunc(campaignId)
 	} else {
-		r0 = ret.Get(0).(int)
+		r0 = ret.Error(0)
 	}
-	if returnFunc, ok := ret.Get(1).(func(model.Device) error); ok {
-		r1 = returnFunc(d)
+	return r0
+}
+
+// MockRepo_CancelCampaign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelCampaign'
+type MockRepo_CancelCampaign_Call struct {
+	*mock.Call
+}
+
+// CancelCampaign is a helper method to define mock.On call
+//   - campaignId int
+func (_e *MockRepo_Expecter) CancelCampaign(campaignId interface{}) *MockRepo_CancelCampaign_Call {
+	return &MockRepo_CancelCampaign_Call{Call: _e.mock.On("CancelCampaign", campaignId)}
+}
+
+func (_c *MockRepo_CancelCampaign_Call) Run(run func(campaignId int)) *MockRepo_CancelCampaign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CancelCampaign_Call) Return(err error) *MockRepo_CancelCampaign_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_CancelCampaign_Call) RunAndReturn(run func(campaignId int) error) *MockRepo_CancelCampaign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangeAppointmentStatus provides a mock function for the type MockRepo
+func (_mock *MockRepo) ChangeAppointmentStatus(appointmentId int, change model.AppointmentStatusChange) error {
+	ret := _mock.Called(appointmentId, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeAppointmentStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, model.AppointmentStatusChange) error); ok {
+		r0 = returnFunc(appointmentId, change)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockRepo_CreateDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDevice'
-type MockRepo_CreateDevice_Call struct {
+// MockRepo_ChangeAppointmentStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeAppointmentStatus'
+type MockRepo_ChangeAppointmentStatus_Call struct {
 	*mock.Call
 }
 
-// CreateDevice is a helper method to define mock.On call
-//   - d model.Device
-func (_e *MockRepo_Expecter) CreateDevice(d interface{}) *MockRepo_CreateDevice_Call {
-	return &MockRepo_CreateDevice_Call{Call: _e.mock.On("CreateDevice", d)}
+// ChangeAppointmentStatus is a helper method to define mock.On call
+//   - appointmentId int
+//   - change model.AppointmentStatusChange
+func (_e *MockRepo_Expecter) ChangeAppointmentStatus(appointmentId interface{}, change interface{}) *MockRepo_ChangeAppointmentStatus_Call {
+	return &MockRepo_ChangeAppointmentStatus_Call{Call: _e.mock.On("ChangeAppointmentStatus", appointmentId, change)}
 }
 
-func (_c *MockRepo_CreateDevice_Call) Run(run func(d model.Device)) *MockRepo_CreateDevice_Call {
+func (_c *MockRepo_ChangeAppointmentStatus_Call) Run(run func(appointmentId int, change model.AppointmentStatusChange)) *MockRepo_ChangeAppointmentStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 model.Device
+		var arg0 int
 		if args[0] != nil {
-			arg0 = args[0].(model.Device)
+			arg0 = args[0].(int)
+		}
+		var arg1 model.AppointmentStatusChange
+		if args[1] != nil {
+			arg1 = args[1].(model.AppointmentStatusChange)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_CreateDevice_Call) Return(n int, err error) *MockRepo_CreateDevice_Call {
-	_c.Call.Return(n, err)
+func (_c *MockRepo_ChangeAppointmentStatus_Call) Return(err error) *MockRepo_ChangeAppointmentStatus_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_CreateDevice_Call) RunAndReturn(run func(d model.Device) (int, error)) *MockRepo_CreateDevice_Call {
+func (_c *MockRepo_ChangeAppointmentStatus_Call) RunAndReturn(run func(appointmentId int, change model.AppointmentStatusChange) error) *MockRepo_ChangeAppointmentStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateDoctor provides a mock function for the type MockRepo
-func (_mock *MockRepo) CreateDoctor(doctor model.Doctor) (int, error) {
-	ret := _mock.Called(doctor)
+// ChangeMedicationRegimen provides a mock function for the type MockRepo
+func (_mock *MockRepo) ChangeMedicationRegimen(currentId int, next model.MedicationRegimen) (int, error) {
+	ret := _mock.Called(currentId, next)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateDoctor")
+		panic("no return value specified for ChangeMedicationRegimen")
 	}
 
 	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(model.Doctor) (int, error)); ok {
-		return returnFunc(doctor)
+	if returnFunc, ok := ret.Get(0).(func(int, model.MedicationRegimen) (int, error)); ok {
+		return returnFunc(currentId, next)
 	}
-	if returnFunc, ok := ret.Get(0).(func(model.Doctor) int); ok {
-		r0 = returnFunc(doctor)
+	if returnFunc, ok := ret.Get(0).(func(int, model.MedicationRegimen) int); ok {
+		r0 = returnFunc(currentId, next)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(model.Doctor) error); ok {
-		r1 = returnFunc(doctor)
+	if returnFunc, ok := ret.Get(1).(func(int, model.MedicationRegimen) error); ok {
+		r1 = returnFunc(currentId, next)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepo_CreateDoctor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDoctor'
-type MockRepo_CreateDoctor_Call struct {
+// MockRepo_ChangeMedicationRegimen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeMedicationRegimen'
+type MockRepo_ChangeMedicationRegimen_Call struct {
 	*mock.Call
 }
 
-// CreateDoctor is a helper method to define mock.On call
-//   - doctor model.Doctor
-func (_e *MockRepo_Expecter) CreateDoctor(doctor interface{}) *MockRepo_CreateDoctor_Call {
-	return &MockRepo_CreateDoctor_Call{Call: _e.mock.On("CreateDoctor", doctor)}
+// ChangeMedicationRegimen is a helper method to define mock.On call
+//   - currentId int
+//   - next model.MedicationRegimen
+func (_e *MockRepo_Expecter) ChangeMedicationRegimen(currentId interface{}, next interface{}) *MockRepo_ChangeMedicationRegimen_Call {
+	return &MockRepo_ChangeMedicationRegimen_Call{Call: _e.mock.On("ChangeMedicationRegimen", currentId, next)}
 }
 
-func (_c *MockRepo_CreateDoctor_Call) Run(run func(doctor model.Doctor)) *MockRepo_CreateDoctor_Call {
+func (_c *MockRepo_ChangeMedicationRegimen_Call) Run(run func(currentId int, next model.MedicationRegimen)) *MockRepo_ChangeMedicationRegimen_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 model.Doctor
+		var arg0 int
 		if args[0] != nil {
-			arg0 = args[0].(model.Doctor)
+			arg0 = args[0].(int)
+		}
+		var arg1 model.MedicationRegimen
+		if args[1] != nil {
+			arg1 = args[1].(model.MedicationRegimen)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_CreateDoctor_Call) Return(n int, err error) *MockRepo_CreateDoctor_Call {
+func (_c *MockRepo_ChangeMedicationRegimen_Call) Return(n int, err error) *MockRepo_ChangeMedicationRegimen_Call {
 	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepo_CreateDoctor_Call) RunAndReturn(run func(doctor model.Doctor) (int, error)) *MockRepo_CreateDoctor_Call {
+func (_c *MockRepo_ChangeMedicationRegimen_Call) RunAndReturn(run func(currentId int, next model.MedicationRegimen) (int, error)) *MockRepo_ChangeMedicationRegimen_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreatePatient provides a mock function for the type MockRepo
-func (_mock *MockRepo) CreatePatient(patient model.Patient) (int, error) {
-	ret := _mock.Called(patient)
+// ClaimDueCampaigns provides a mock function for the type MockRepo
+func (_mock *MockRepo) ClaimDueCampaigns(now int) ([]model.Campaign, error) {
+	ret := _mock.Called(now)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreatePatient")
+		panic("no return value specified for ClaimDueCampaigns")
 	}
 
-	var r0 int
+	var r0 []model.Campaign
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(model.Patient) (int, error)); ok {
-		return returnFunc(patient)
+	if returnFunc, ok := ret.Get(0).(func(int) ([]model.Campaign, error)); ok {
+		return returnFunc(now)
 	}
-	if returnFunc, ok := ret.Get(0).(func(model.Patient) int); ok {
-		r0 = returnFunc(patient)
+	if returnFunc, ok := ret.Get(0).(func(int) []model.Campaign); ok {
+		r0 = returnFunc(now)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Campaign)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(model.Patient) error); ok {
-		r1 = returnFunc(patient)
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(now)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepo_CreatePatient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePatient'
-type MockRepo_CreatePatient_Call struct {
+// MockRepo_ClaimDueCampaigns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDueCampaigns'
+type MockRepo_ClaimDueCampaigns_Call struct {
 	*mock.Call
 }
 
-// CreatePatient is a helper method to define mock.On call
-//   - patient model.Patient
-func (_e *MockRepo_Expecter) CreatePatient(patient interface{}) *MockRepo_CreatePatient_Call {
-	return &MockRepo_CreatePatient_Call{Call: _e.mock.On("CreatePatient", patient)}
+// ClaimDueCampaigns is a helper method to define mock.On call
+//   - now int
+func (_e *MockRepo_Expecter) ClaimDueCampaigns(now interface{}) *MockRepo_ClaimDueCampaigns_Call {
+	return &MockRepo_ClaimDueCampaigns_Call{Call: _e.mock.On("ClaimDueCampaigns", now)}
 }
 
-func (_c *MockRepo_CreatePatient_Call) Run(run func(patient model.Patient)) *MockRepo_CreatePatient_Call {
+func (_c *MockRepo_ClaimDueCampaigns_Call) Run(run func(now int)) *MockRepo_ClaimDueCampaigns_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 model.Patient
+		var arg0 int
 		if args[0] != nil {
-			arg0 = args[0].(model.Patient)
+			arg0 = args[0].(int)
 		}
 		run(
 			arg0,
@@ -327,280 +383,327 @@ func (_c *MockRepo_CreatePatient_Call) Run(run func(patient model.Patient)) *Moc
 	return _c
 }
 
-func (_c *MockRepo_CreatePatient_Call) Return(n int, err error) *MockRepo_CreatePatient_Call {
-	_c.Call.Return(n, err)
+func (_c *MockRepo_ClaimDueCampaigns_Call) Return(campaigns []model.Campaign, err error) *MockRepo_ClaimDueCampaigns_Call {
+	_c.Call.Return(campaigns, err)
 	return _c
 }
 
-func (_c *MockRepo_CreatePatient_Call) RunAndReturn(run func(patient model.Patient) (int, error)) *MockRepo_CreatePatient_Call {
+func (_c *MockRepo_ClaimDueCampaigns_Call) RunAndReturn(run func(now int) ([]model.Campaign, error)) *MockRepo_ClaimDueCampaigns_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateQuestion provides a mock function for the type MockRepo
-func (_mock *MockRepo) CreateQuestion(patientId int, topic string, question string, createAt int) (int, error) {
-	ret := _mock.Called(patientId, topic, question, createAt)
+// ClaimDueOutboxMessages provides a mock function for the type MockRepo
+func (_mock *MockRepo) ClaimDueOutboxMessages(now int, lease int, limit int) ([]model.NotificationOutbox, error) {
+	ret := _mock.Called(now, lease, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateQuestion")
+		panic("no return value specified for ClaimDueOutboxMessages")
 	}
 
-	var r0 int
+	var r0 []model.NotificationOutbox
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int, string, string, int) (int, error)); ok {
-		return returnFunc(patientId, topic, question, createAt)
+	if returnFunc, ok := ret.Get(0).(func(int, int, int) ([]model.NotificationOutbox, error)); ok {
+		return returnFunc(now, lease, limit)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int, string, string, int) int); ok {
-		r0 = returnFunc(patientId, topic, question, createAt)
+	if returnFunc, ok := ret.Get(0).(func(int, int, int) []model.NotificationOutbox); ok {
+		r0 = returnFunc(now, lease, limit)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.NotificationOutbox)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(int, string, string, int) error); ok {
-		r1 = returnFunc(patientId, topic, question, createAt)
+	if returnFunc, ok := ret.Get(1).(func(int, int, int) error); ok {
+		r1 = returnFunc(now, lease, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepo_CreateQuestion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateQuestion'
-type MockRepo_CreateQuestion_Call struct {
+// MockRepo_ClaimDueOutboxMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDueOutboxMessages'
+type MockRepo_ClaimDueOutboxMessages_Call struct {
 	*mock.Call
 }
 
-// CreateQuestion is a helper method to define mock.On call
-//   - patientId int
-//   - topic string
-//   - question string
-//   - createAt int
-func (_e *MockRepo_Expecter) CreateQuestion(patientId interface{}, topic interface{}, question interface{}, createAt interface{}) *MockRepo_CreateQuestion_Call {
-	return &MockRepo_CreateQuestion_Call{Call: _e.mock.On("CreateQuestion", patientId, topic, question, createAt)}
+// ClaimDueOutboxMessages is a helper method to define mock.On call
+//   - now int
+//   - lease int
+//   - limit int
+func (_e *MockRepo_Expecter) ClaimDueOutboxMessages(now interface{}, lease interface{}, limit interface{}) *MockRepo_ClaimDueOutboxMessages_Call {
+	return &MockRepo_ClaimDueOutboxMessages_Call{Call: _e.mock.On("ClaimDueOutboxMessages", now, lease, limit)}
 }
 
-func (_c *MockRepo_CreateQuestion_Call) Run(run func(patientId int, topic string, question string, createAt int)) *MockRepo_CreateQuestion_Call {
+func (_c *MockRepo_ClaimDueOutboxMessages_Call) Run(run func(now int, lease int, limit int)) *MockRepo_ClaimDueOutboxMessages_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int
 		if args[0] != nil {
 			arg0 = args[0].(int)
 		}
-		var arg1 string
+		var arg1 int
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(int)
 		}
-		var arg2 string
+		var arg2 int
 		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 int
-		if args[3] != nil {
-			arg3 = args[3].(int)
+			arg2 = args[2].(int)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_CreateQuestion_Call) Return(n int, err error) *MockRepo_CreateQuestion_Call {
-	_c.Call.Return(n, err)
+func (_c *MockRepo_ClaimDueOutboxMessages_Call) Return(notificationOutboxes []model.NotificationOutbox, err error) *MockRepo_ClaimDueOutboxMessages_Call {
+	_c.Call.Return(notificationOutboxes, err)
 	return _c
 }
 
-func (_c *MockRepo_CreateQuestion_Call) RunAndReturn(run func(patientId int, topic string, question string, createAt int) (int, error)) *MockRepo_CreateQuestion_Call {
+func (_c *MockRepo_ClaimDueOutboxMessages_Call) RunAndReturn(run func(now int, lease int, limit int) ([]model.NotificationOutbox, error)) *MockRepo_ClaimDueOutboxMessages_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteAppointment provides a mock function for the type MockRepo
-func (_mock *MockRepo) DeleteAppointment(appointmentId any) error {
-	ret := _mock.Called(appointmentId)
+// ClaimQuestion provides a mock function for the type MockRepo
+func (_mock *MockRepo) ClaimQuestion(questionId int, doctorId int) error {
+	ret := _mock.Called(questionId, doctorId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteAppointment")
+		panic("no return value specified for ClaimQuestion")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
-		r0 = returnFunc(appointmentId)
+	if returnFunc, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = returnFunc(questionId, doctorId)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepo_DeleteAppointment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAppointment'
-type MockRepo_DeleteAppointment_Call struct {
+// MockRepo_ClaimQuestion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimQuestion'
+type MockRepo_ClaimQuestion_Call struct {
 	*mock.Call
 }
 
-// DeleteAppointment is a helper method to define mock.On call
-//   - appointmentId any
-func (_e *MockRepo_Expecter) DeleteAppointment(appointmentId interface{}) *MockRepo_DeleteAppointment_Call {
-	return &MockRepo_DeleteAppointment_Call{Call: _e.mock.On("DeleteAppointment", appointmentId)}
+// ClaimQuestion is a helper method to define mock.On call
+//   - questionId int
+//   - doctorId int
+func (_e *MockRepo_Expecter) ClaimQuestion(questionId interface{}, doctorId interface{}) *MockRepo_ClaimQuestion_Call {
+	return &MockRepo_ClaimQuestion_Call{Call: _e.mock.On("ClaimQuestion", questionId, doctorId)}
 }
 
-func (_c *MockRepo_DeleteAppointment_Call) Run(run func(appointmentId any)) *MockRepo_DeleteAppointment_Call {
+func (_c *MockRepo_ClaimQuestion_Call) Run(run func(questionId int, doctorId int)) *MockRepo_ClaimQuestion_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 any
+		var arg0 int
 		if args[0] != nil {
-			arg0 = args[0].(any)
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_DeleteAppointment_Call) Return(err error) *MockRepo_DeleteAppointment_Call {
+func (_c *MockRepo_ClaimQuestion_Call) Return(err error) *MockRepo_ClaimQuestion_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_DeleteAppointment_Call) RunAndReturn(run func(appointmentId any) error) *MockRepo_DeleteAppointment_Call {
+func (_c *MockRepo_ClaimQuestion_Call) RunAndReturn(run func(questionId int, doctorId int) error) *MockRepo_ClaimQuestion_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteConsentById provides a mock function for the type MockRepo
-func (_mock *MockRepo) DeleteConsentById(consentID any) error {
-	ret := _mock.Called(consentID)
+// CountDoctorNotification provides a mock function for the type MockRepo
+func (_mock *MockRepo) CountDoctorNotification(criteria ...Criteria) (int, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(criteria)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteConsentById")
+		panic("no return value specified for CountDoctorNotification")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
-		r0 = returnFunc(consentID)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) (int, error)); ok {
+		return returnFunc(criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) int); ok {
+		r0 = returnFunc(criteria...)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(...Criteria) error); ok {
+		r1 = returnFunc(criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockRepo_DeleteConsentById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteConsentById'
-type MockRepo_DeleteConsentById_Call struct {
+// MockRepo_CountDoctorNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountDoctorNotification'
+type MockRepo_CountDoctorNotification_Call struct {
 	*mock.Call
 }
 
-// DeleteConsentById is a helper method to define mock.On call
-//   - consentID any
-func (_e *MockRepo_Expecter) DeleteConsentById(consentID interface{}) *MockRepo_DeleteConsentById_Call {
-	return &MockRepo_DeleteConsentById_Call{Call: _e.mock.On("DeleteConsentById", consentID)}
+// CountDoctorNotification is a helper method to define mock.On call
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) CountDoctorNotification(criteria ...interface{}) *MockRepo_CountDoctorNotification_Call {
+	return &MockRepo_CountDoctorNotification_Call{Call: _e.mock.On("CountDoctorNotification",
+		append([]interface{}{}, criteria...)...)}
 }
 
-func (_c *MockRepo_DeleteConsentById_Call) Run(run func(consentID any)) *MockRepo_DeleteConsentById_Call {
+func (_c *MockRepo_CountDoctorNotification_Call) Run(run func(criteria ...Criteria)) *MockRepo_CountDoctorNotification_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 any
-		if args[0] != nil {
-			arg0 = args[0].(any)
+		var arg0 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 0 {
+			variadicArgs = args[0].([]Criteria)
 		}
+		arg0 = variadicArgs
 		run(
-			arg0,
+			arg0...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_DeleteConsentById_Call) Return(err error) *MockRepo_DeleteConsentById_Call {
-	_c.Call.Return(err)
+func (_c *MockRepo_CountDoctorNotification_Call) Return(n int, err error) *MockRepo_CountDoctorNotification_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepo_DeleteConsentById_Call) RunAndReturn(run func(consentID any) error) *MockRepo_DeleteConsentById_Call {
+func (_c *MockRepo_CountDoctorNotification_Call) RunAndReturn(run func(criteria ...Criteria) (int, error)) *MockRepo_CountDoctorNotification_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteConsentBySlug provides a mock function for the type MockRepo
-func (_mock *MockRepo) DeleteConsentBySlug(slug string) error {
-	ret := _mock.Called(slug)
+// CountNotification provides a mock function for the type MockRepo
+func (_mock *MockRepo) CountNotification(criteria ...Criteria) (int, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(criteria)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteConsentBySlug")
+		panic("no return value specified for CountNotification")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
-		r0 = returnFunc(slug)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) (int, error)); ok {
+		return returnFunc(criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) int); ok {
+		r0 = returnFunc(criteria...)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(...Criteria) error); ok {
+		r1 = returnFunc(criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockRepo_DeleteConsentBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteConsentBySlug'
-type MockRepo_DeleteConsentBySlug_Call struct {
+// MockRepo_CountNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountNotification'
+type MockRepo_CountNotification_Call struct {
 	*mock.Call
 }
 
-// DeleteConsentBySlug is a helper method to define mock.On call
-//   - slug string
-func (_e *MockRepo_Expecter) DeleteConsentBySlug(slug interface{}) *MockRepo_DeleteConsentBySlug_Call {
-	return &MockRepo_DeleteConsentBySlug_Call{Call: _e.mock.On("DeleteConsentBySlug", slug)}
+// CountNotification is a helper method to define mock.On call
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) CountNotification(criteria ...interface{}) *MockRepo_CountNotification_Call {
+	return &MockRepo_CountNotification_Call{Call: _e.mock.On("CountNotification",
+		append([]interface{}{}, criteria...)...)}
 }
 
-func (_c *MockRepo_DeleteConsentBySlug_Call) Run(run func(slug string)) *MockRepo_DeleteConsentBySlug_Call {
+func (_c *MockRepo_CountNotification_Call) Run(run func(criteria ...Criteria)) *MockRepo_CountNotification_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
-		if args[0] != nil {
-			arg0 = args[0].(string)
+		var arg0 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 0 {
+			variadicArgs = args[0].([]Criteria)
 		}
+		arg0 = variadicArgs
 		run(
-			arg0,
+			arg0...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_DeleteConsentBySlug_Call) Return(err error) *MockRepo_DeleteConsentBySlug_Call {
-	_c.Call.Return(err)
+func (_c *MockRepo_CountNotification_Call) Return(n int, err error) *MockRepo_CountNotification_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepo_DeleteConsentBySlug_Call) RunAndReturn(run func(slug string) error) *MockRepo_DeleteConsentBySlug_Call {
+func (_c *MockRepo_CountNotification_Call) RunAndReturn(run func(criteria ...Criteria) (int, error)) *MockRepo_CountNotification_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteContent provides a mock function for the type MockRepo
-func (_mock *MockRepo) DeleteContent(contentID any) error {
-	ret := _mock.Called(contentID)
+// CreateAnswerSnippet provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateAnswerSnippet(snippet model.AnswerSnippet) (int, error) {
+	ret := _mock.Called(snippet)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteContent")
+		panic("no return value specified for CreateAnswerSnippet")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
-		r0 = returnFunc(contentID)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.AnswerSnippet) (int, error)); ok {
+		return returnFunc(snippet)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.AnswerSnippet) int); ok {
+		r0 = returnFunc(snippet)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(model.AnswerSnippet) error); ok {
+		r1 = returnFunc(snippet)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockRepo_DeleteContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteContent'
-type MockRepo_DeleteContent_Call struct {
+// MockRepo_CreateAnswerSnippet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAnswerSnippet'
+type MockRepo_CreateAnswerSnippet_Call struct {
 	*mock.Call
 }
 
-// DeleteContent is a helper method to define mock.On call
-//   - contentID any
-func (_e *MockRepo_Expecter) DeleteContent(contentID interface{}) *MockRepo_DeleteContent_Call {
-	return &MockRepo_DeleteContent_Call{Call: _e.mock.On("DeleteContent", contentID)}
+// CreateAnswerSnippet is a helper method to define mock.On call
+//   - snippet model.AnswerSnippet
+func (_e *MockRepo_Expecter) CreateAnswerSnippet(snippet interface{}) *MockRepo_CreateAnswerSnippet_Call {
+	return &MockRepo_CreateAnswerSnippet_Call{Call: _e.mock.On("CreateAnswerSnippet", snippet)}
 }
 
-func (_c *MockRepo_DeleteContent_Call) Run(run func(contentID any)) *MockRepo_DeleteContent_Call {
+func (_c *MockRepo_CreateAnswerSnippet_Call) Run(run func(snippet model.AnswerSnippet)) *MockRepo_CreateAnswerSnippet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 any
+		var arg0 model.AnswerSnippet
 		if args[0] != nil {
-			arg0 = args[0].(any)
+			arg0 = args[0].(model.AnswerSnippet)
 		}
 		run(
 			arg0,
@@ -609,49 +712,58 @@ func (_c *MockRepo_DeleteContent_Call) Run(run func(contentID any)) *MockRepo_De
 	return _c
 }
 
-func (_c *MockRepo_DeleteContent_Call) Return(err error) *MockRepo_DeleteContent_Call {
-	_c.Call.Return(err)
+func (_c *MockRepo_CreateAnswerSnippet_Call) Return(n int, err error) *MockRepo_CreateAnswerSnippet_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepo_DeleteContent_Call) RunAndReturn(run func(contentID any) error) *MockRepo_DeleteContent_Call {
+func (_c *MockRepo_CreateAnswerSnippet_Call) RunAndReturn(run func(snippet model.AnswerSnippet) (int, error)) *MockRepo_CreateAnswerSnippet_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteDevice provides a mock function for the type MockRepo
-func (_mock *MockRepo) DeleteDevice(deviceId any) error {
-	ret := _mock.Called(deviceId)
+// CreateAppointment provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateAppointment(appointment model.Appointment) (int, error) {
+	ret := _mock.Called(appointment)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteDevice")
+		panic("no return value specified for CreateAppointment")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
-		r0 = returnFunc(deviceId)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Appointment) (int, error)); ok {
+		return returnFunc(appointment)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Appointment) int); ok {
+		r0 = returnFunc(appointment)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(model.Appointment) error); ok {
+		r1 = returnFunc(appointment)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockRepo_DeleteDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDevice'
-type MockRepo_DeleteDevice_Call struct {
+// MockRepo_CreateAppointment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAppointment'
+type MockRepo_CreateAppointment_Call struct {
 	*mock.Call
 }
 
-// DeleteDevice is a helper method to define mock.On call
-//   - deviceId any
-func (_e *MockRepo_Expecter) DeleteDevice(deviceId interface{}) *MockRepo_DeleteDevice_Call {
-	return &MockRepo_DeleteDevice_Call{Call: _e.mock.On("DeleteDevice", deviceId)}
+// CreateAppointment is a helper method to define mock.On call
+//   - appointment model.Appointment
+func (_e *MockRepo_Expecter) CreateAppointment(appointment interface{}) *MockRepo_CreateAppointment_Call {
+	return &MockRepo_CreateAppointment_Call{Call: _e.mock.On("CreateAppointment", appointment)}
 }
 
-func (_c *MockRepo_DeleteDevice_Call) Run(run func(deviceId any)) *MockRepo_DeleteDevice_Call {
+func (_c *MockRepo_CreateAppointment_Call) Run(run func(appointment model.Appointment)) *MockRepo_CreateAppointment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 any
+		var arg0 model.Appointment
 		if args[0] != nil {
-			arg0 = args[0].(any)
+			arg0 = args[0].(model.Appointment)
 		}
 		run(
 			arg0,
@@ -660,49 +772,58 @@ func (_c *MockRepo_DeleteDevice_Call) Run(run func(deviceId any)) *MockRepo_Dele
 	return _c
 }
 
-func (_c *MockRepo_DeleteDevice_Call) Return(err error) *MockRepo_DeleteDevice_Call {
-	_c.Call.Return(err)
+func (_c *MockRepo_CreateAppointment_Call) Return(n int, err error) *MockRepo_CreateAppointment_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepo_DeleteDevice_Call) RunAndReturn(run func(deviceId any) error) *MockRepo_DeleteDevice_Call {
+func (_c *MockRepo_CreateAppointment_Call) RunAndReturn(run func(appointment model.Appointment) (int, error)) *MockRepo_CreateAppointment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteDoctorById provides a mock function for the type MockRepo
-func (_mock *MockRepo) DeleteDoctorById(id any) error {
-	ret := _mock.Called(id)
+// CreateCampaign provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateCampaign(campaign model.Campaign) (int, error) {
+	ret := _mock.Called(campaign)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteDoctorById")
+		panic("no return value specified for CreateCampaign")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
-		r0 = returnFunc(id)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Campaign) (int, error)); ok {
+		return returnFunc(campaign)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Campaign) int); ok {
+		r0 = returnFunc(campaign)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(model.Campaign) error); ok {
+		r1 = returnFunc(campaign)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockRepo_DeleteDoctorById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDoctorById'
-type MockRepo_DeleteDoctorById_Call struct {
+// MockRepo_CreateCampaign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCampaign'
+type MockRepo_CreateCampaign_Call struct {
 	*mock.Call
 }
 
-// DeleteDoctorById is a helper method to define mock.On call
-//   - id any
-func (_e *MockRepo_Expecter) DeleteDoctorById(id interface{}) *MockRepo_DeleteDoctorById_Call {
-	return &MockRepo_DeleteDoctorById_Call{Call: _e.mock.On("DeleteDoctorById", id)}
+// CreateCampaign is a helper method to define mock.On call
+//   - campaign model.Campaign
+func (_e *MockRepo_Expecter) CreateCampaign(campaign interface{}) *MockRepo_CreateCampaign_Call {
+	return &MockRepo_CreateCampaign_Call{Call: _e.mock.On("CreateCampaign", campaign)}
 }
 
-func (_c *MockRepo_DeleteDoctorById_Call) Run(run func(id any)) *MockRepo_DeleteDoctorById_Call {
+func (_c *MockRepo_CreateCampaign_Call) Run(run func(campaign model.Campaign)) *MockRepo_CreateCampaign_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 any
+		var arg0 model.Campaign
 		if args[0] != nil {
-			arg0 = args[0].(any)
+			arg0 = args[0].(model.Campaign)
 		}
 		run(
 			arg0,
@@ -711,49 +832,58 @@ func (_c *MockRepo_DeleteDoctorById_Call) Run(run func(id any)) *MockRepo_Delete
 	return _c
 }
 
-func (_c *MockRepo_DeleteDoctorById_Call) Return(err error) *MockRepo_DeleteDoctorById_Call {
-	_c.Call.Return(err)
+func (_c *MockRepo_CreateCampaign_Call) Return(n int, err error) *MockRepo_CreateCampaign_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepo_DeleteDoctorById_Call) RunAndReturn(run func(id any) error) *MockRepo_DeleteDoctorById_Call {
+func (_c *MockRepo_CreateCampaign_Call) RunAndReturn(run func(campaign model.Campaign) (int, error)) *MockRepo_CreateCampaign_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeletePatientById provides a mock function for the type MockRepo
-func (_mock *MockRepo) DeletePatientById(id any) error {
-	ret := _mock.Called(id)
+// CreateCareTeamMember provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateCareTeamMember(member model.CareTeamMember) (int, error) {
+	ret := _mock.Called(member)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeletePatientById")
+		panic("no return value specified for CreateCareTeamMember")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
-		r0 = returnFunc(id)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.CareTeamMember) (int, error)); ok {
+		return returnFunc(member)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.CareTeamMember) int); ok {
+		r0 = returnFunc(member)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(model.CareTeamMember) error); ok {
+		r1 = returnFunc(member)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockRepo_DeletePatientById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePatientById'
-type MockRepo_DeletePatientById_Call struct {
+// MockRepo_CreateCareTeamMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCareTeamMember'
+type MockRepo_CreateCareTeamMember_Call struct {
 	*mock.Call
 }
 
-// DeletePatientById is a helper method to define mock.On call
-//   - id any
-func (_e *MockRepo_Expecter) DeletePatientById(id interface{}) *MockRepo_DeletePatientById_Call {
-	return &MockRepo_DeletePatientById_Call{Call: _e.mock.On("DeletePatientById", id)}
+// CreateCareTeamMember is a helper method to define mock.On call
+//   - member model.CareTeamMember
+func (_e *MockRepo_Expecter) CreateCareTeamMember(member interface{}) *MockRepo_CreateCareTeamMember_Call {
+	return &MockRepo_CreateCareTeamMember_Call{Call: _e.mock.On("CreateCareTeamMember", member)}
 }
 
-func (_c *MockRepo_DeletePatientById_Call) Run(run func(id any)) *MockRepo_DeletePatientById_Call {
+func (_c *MockRepo_CreateCareTeamMember_Call) Run(run func(member model.CareTeamMember)) *MockRepo_CreateCareTeamMember_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 any
+		var arg0 model.CareTeamMember
 		if args[0] != nil {
-			arg0 = args[0].(any)
+			arg0 = args[0].(model.CareTeamMember)
 		}
 		run(
 			arg0,
@@ -762,49 +892,58 @@ func (_c *MockRepo_DeletePatientById_Call) Run(run func(id any)) *MockRepo_Delet
 	return _c
 }
 
-func (_c *MockRepo_DeletePatientById_Call) Return(err error) *MockRepo_DeletePatientById_Call {
-	_c.Call.Return(err)
+func (_c *MockRepo_CreateCareTeamMember_Call) Return(n int, err error) *MockRepo_CreateCareTeamMember_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepo_DeletePatientById_Call) RunAndReturn(run func(id any) error) *MockRepo_DeletePatientById_Call {
+func (_c *MockRepo_CreateCareTeamMember_Call) RunAndReturn(run func(member model.CareTeamMember) (int, error)) *MockRepo_CreateCareTeamMember_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteQuestion provides a mock function for the type MockRepo
-func (_mock *MockRepo) DeleteQuestion(questionId any) error {
-	ret := _mock.Called(questionId)
+// CreateCaregiver provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateCaregiver(caregiver model.Caregiver) (int, error) {
+	ret := _mock.Called(caregiver)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteQuestion")
+		panic("no return value specified for CreateCaregiver")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
-		r0 = returnFunc(questionId)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Caregiver) (int, error)); ok {
+		return returnFunc(caregiver)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Caregiver) int); ok {
+		r0 = returnFunc(caregiver)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(model.Caregiver) error); ok {
+		r1 = returnFunc(caregiver)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockRepo_DeleteQuestion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteQuestion'
-type MockRepo_DeleteQuestion_Call struct {
+// MockRepo_CreateCaregiver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCaregiver'
+type MockRepo_CreateCaregiver_Call struct {
 	*mock.Call
 }
 
-// DeleteQuestion is a helper method to define mock.On call
-//   - questionId any
-func (_e *MockRepo_Expecter) DeleteQuestion(questionId interface{}) *MockRepo_DeleteQuestion_Call {
-	return &MockRepo_DeleteQuestion_Call{Call: _e.mock.On("DeleteQuestion", questionId)}
+// CreateCaregiver is a helper method to define mock.On call
+//   - caregiver model.Caregiver
+func (_e *MockRepo_Expecter) CreateCaregiver(caregiver interface{}) *MockRepo_CreateCaregiver_Call {
+	return &MockRepo_CreateCaregiver_Call{Call: _e.mock.On("CreateCaregiver", caregiver)}
 }
 
-func (_c *MockRepo_DeleteQuestion_Call) Run(run func(questionId any)) *MockRepo_DeleteQuestion_Call {
+func (_c *MockRepo_CreateCaregiver_Call) Run(run func(caregiver model.Caregiver)) *MockRepo_CreateCaregiver_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 any
+		var arg0 model.Caregiver
 		if args[0] != nil {
-			arg0 = args[0].(any)
+			arg0 = args[0].(model.Caregiver)
 		}
 		run(
 			arg0,
@@ -813,964 +952,7673 @@ func (_c *MockRepo_DeleteQuestion_Call) Run(run func(questionId any)) *MockRepo_
 	return _c
 }
 
-func (_c *MockRepo_DeleteQuestion_Call) Return(err error) *MockRepo_DeleteQuestion_Call {
-	_c.Call.Return(err)
+func (_c *MockRepo_CreateCaregiver_Call) Return(n int, err error) *MockRepo_CreateCaregiver_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepo_DeleteQuestion_Call) RunAndReturn(run func(questionId any) error) *MockRepo_DeleteQuestion_Call {
+func (_c *MockRepo_CreateCaregiver_Call) RunAndReturn(run func(caregiver model.Caregiver) (int, error)) *MockRepo_CreateCaregiver_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllAppointment provides a mock function for the type MockRepo
-func (_mock *MockRepo) GetAllAppointment(limit int, offset int, criteria ...Criteria) ([]model.SafeAppointment, error) {
-	var tmpRet mock.Arguments
-	if len(criteria) > 0 {
-		tmpRet = _mock.Called(limit, offset, criteria)
-	} else {
-		tmpRet = _mock.Called(limit, offset)
-	}
-	ret := tmpRet
+// CreateCaregiverLink provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateCaregiverLink(link model.CaregiverLink) (int, error) {
+	ret := _mock.Called(link)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllAppointment")
+		panic("no return value specified for CreateCaregiverLink")
 	}
 
-	var r0 []model.SafeAppointment
+	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) ([]model.SafeAppointment, error)); ok {
-		return returnFunc(limit, offset, criteria...)
+	if returnFunc, ok := ret.Get(0).(func(model.CaregiverLink) (int, error)); ok {
+		return returnFunc(link)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) []model.SafeAppointment); ok {
-		r0 = returnFunc(limit, offset, criteria...)
+	if returnFunc, ok := ret.Get(0).(func(model.CaregiverLink) int); ok {
+		r0 = returnFunc(link)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.SafeAppointment)
-		}
+		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(int, int, ...Criteria) error); ok {
-		r1 = returnFunc(limit, offset, criteria...)
+	if returnFunc, ok := ret.Get(1).(func(model.CaregiverLink) error); ok {
+		r1 = returnFunc(link)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepo_GetAllAppointment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllAppointment'
-type MockRepo_GetAllAppointment_Call struct {
+// MockRepo_CreateCaregiverLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCaregiverLink'
+type MockRepo_CreateCaregiverLink_Call struct {
 	*mock.Call
 }
 
-// GetAllAppointment is a helper method to define mock.On call
-//   - limit int
-//   - offset int
-//   - criteria ...Criteria
-func (_e *MockRepo_Expecter) GetAllAppointment(limit interface{}, offset interface{}, criteria ...interface{}) *MockRepo_GetAllAppointment_Call {
-	return &MockRepo_GetAllAppointment_Call{Call: _e.mock.On("GetAllAppointment",
-		append([]interface{}{limit, offset}, criteria...)...)}
+// CreateCaregiverLink is a helper method to define mock.On call
+//   - link model.CaregiverLink
+func (_e *MockRepo_Expecter) CreateCaregiverLink(link interface{}) *MockRepo_CreateCaregiverLink_Call {
+	return &MockRepo_CreateCaregiverLink_Call{Call: _e.mock.On("CreateCaregiverLink", link)}
 }
 
-func (_c *MockRepo_GetAllAppointment_Call) Run(run func(limit int, offset int, criteria ...Criteria)) *MockRepo_GetAllAppointment_Call {
+func (_c *MockRepo_CreateCaregiverLink_Call) Run(run func(link model.CaregiverLink)) *MockRepo_CreateCaregiverLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 model.CaregiverLink
 		if args[0] != nil {
-			arg0 = args[0].(int)
-		}
-		var arg1 int
-		if args[1] != nil {
-			arg1 = args[1].(int)
-		}
-		var arg2 []Criteria
-		var variadicArgs []Criteria
-		if len(args) > 2 {
-			variadicArgs = args[2].([]Criteria)
+			arg0 = args[0].(model.CaregiverLink)
 		}
-		arg2 = variadicArgs
 		run(
 			arg0,
-			arg1,
-			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_GetAllAppointment_Call) Return(safeAppointments []model.SafeAppointment, err error) *MockRepo_GetAllAppointment_Call {
-	_c.Call.Return(safeAppointments, err)
+func (_c *MockRepo_CreateCaregiverLink_Call) Return(n int, err error) *MockRepo_CreateCaregiverLink_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepo_GetAllAppointment_Call) RunAndReturn(run func(limit int, offset int, criteria ...Criteria) ([]model.SafeAppointment, error)) *MockRepo_GetAllAppointment_Call {
+func (_c *MockRepo_CreateCaregiverLink_Call) RunAndReturn(run func(link model.CaregiverLink) (int, error)) *MockRepo_CreateCaregiverLink_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllContent provides a mock function for the type MockRepo
-func (_mock *MockRepo) GetAllContent(limit int, offset int, criteria ...Criteria) ([]model.Content, error) {
-	var tmpRet mock.Arguments
-	if len(criteria) > 0 {
-		tmpRet = _mock.Called(limit, offset, criteria)
-	} else {
-		tmpRet = _mock.Called(limit, offset)
-	}
-	ret := tmpRet
+// CreateContent provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateContent(content model.Content) (int, error) {
+	ret := _mock.Called(content)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllContent")
+		panic("no return value specified for CreateContent")
 	}
 
-	var r0 []model.Content
+	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) ([]model.Content, error)); ok {
-		return returnFunc(limit, offset, criteria...)
+	if returnFunc, ok := ret.Get(0).(func(model.Content) (int, error)); ok {
+		return returnFunc(content)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) []model.Content); ok {
-		r0 = returnFunc(limit, offset, criteria...)
+	if returnFunc, ok := ret.Get(0).(func(model.Content) int); ok {
+		r0 = returnFunc(content)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.Content)
-		}
+		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(int, int, ...Criteria) error); ok {
-		r1 = returnFunc(limit, offset, criteria...)
+	if returnFunc, ok := ret.Get(1).(func(model.Content) error); ok {
+		r1 = returnFunc(content)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepo_GetAllContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllContent'
-type MockRepo_GetAllContent_Call struct {
+// MockRepo_CreateContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateContent'
+type MockRepo_CreateContent_Call struct {
 	*mock.Call
 }
 
-// GetAllContent is a helper method to define mock.On call
-//   - limit int
-//   - offset int
-//   - criteria ...Criteria
-func (_e *MockRepo_Expecter) GetAllContent(limit interface{}, offset interface{}, criteria ...interface{}) *MockRepo_GetAllContent_Call {
-	return &MockRepo_GetAllContent_Call{Call: _e.mock.On("GetAllContent",
-		append([]interface{}{limit, offset}, criteria...)...)}
+// CreateContent is a helper method to define mock.On call
+//   - content model.Content
+func (_e *MockRepo_Expecter) CreateContent(content interface{}) *MockRepo_CreateContent_Call {
+	return &MockRepo_CreateContent_Call{Call: _e.mock.On("CreateContent", content)}
 }
 
-func (_c *MockRepo_GetAllContent_Call) Run(run func(limit int, offset int, criteria ...Criteria)) *MockRepo_GetAllContent_Call {
+func (_c *MockRepo_CreateContent_Call) Run(run func(content model.Content)) *MockRepo_CreateContent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 model.Content
 		if args[0] != nil {
-			arg0 = args[0].(int)
-		}
-		var arg1 int
-		if args[1] != nil {
-			arg1 = args[1].(int)
-		}
-		var arg2 []Criteria
-		var variadicArgs []Criteria
-		if len(args) > 2 {
-			variadicArgs = args[2].([]Criteria)
+			arg0 = args[0].(model.Content)
 		}
-		arg2 = variadicArgs
 		run(
 			arg0,
-			arg1,
-			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_GetAllContent_Call) Return(contents []model.Content, err error) *MockRepo_GetAllContent_Call {
-	_c.Call.Return(contents, err)
+func (_c *MockRepo_CreateContent_Call) Return(n int, err error) *MockRepo_CreateContent_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepo_GetAllContent_Call) RunAndReturn(run func(limit int, offset int, criteria ...Criteria) ([]model.Content, error)) *MockRepo_GetAllContent_Call {
+func (_c *MockRepo_CreateContent_Call) RunAndReturn(run func(content model.Content) (int, error)) *MockRepo_CreateContent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllDevice provides a mock function for the type MockRepo
-func (_mock *MockRepo) GetAllDevice(criteria ...Criteria) ([]model.Device, error) {
-	var tmpRet mock.Arguments
-	if len(criteria) > 0 {
-		tmpRet = _mock.Called(criteria)
-	} else {
-		tmpRet = _mock.Called()
-	}
-	ret := tmpRet
+// CreateDevice provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateDevice(d model.Device) (int, error) {
+	ret := _mock.Called(d)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllDevice")
+		panic("no return value specified for CreateDevice")
 	}
 
-	var r0 []model.Device
+	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(...Criteria) ([]model.Device, error)); ok {
-		return returnFunc(criteria...)
+	if returnFunc, ok := ret.Get(0).(func(model.Device) (int, error)); ok {
+		return returnFunc(d)
 	}
-	if returnFunc, ok := ret.Get(0).(func(...Criteria) []model.Device); ok {
-		r0 = returnFunc(criteria...)
+	if returnFunc, ok := ret.Get(0).(func(model.Device) int); ok {
+		r0 = returnFunc(d)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.Device)
-		}
+		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(...Criteria) error); ok {
-		r1 = returnFunc(criteria...)
+	if returnFunc, ok := ret.Get(1).(func(model.Device) error); ok {
+		r1 = returnFunc(d)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepo_GetAllDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllDevice'
-type MockRepo_GetAllDevice_Call struct {
+// MockRepo_CreateDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDevice'
+type MockRepo_CreateDevice_Call struct {
 	*mock.Call
 }
 
-// GetAllDevice is a helper method to define mock.On call
-//   - criteria ...Criteria
-func (_e *MockRepo_Expecter) GetAllDevice(criteria ...interface{}) *MockRepo_GetAllDevice_Call {
-	return &MockRepo_GetAllDevice_Call{Call: _e.mock.On("GetAllDevice",
-		append([]interface{}{}, criteria...)...)}
+// CreateDevice is a helper method to define mock.On call
+//   - d model.Device
+func (_e *MockRepo_Expecter) CreateDevice(d interface{}) *MockRepo_CreateDevice_Call {
+	return &MockRepo_CreateDevice_Call{Call: _e.mock.On("CreateDevice", d)}
 }
 
-func (_c *MockRepo_GetAllDevice_Call) Run(run func(criteria ...Criteria)) *MockRepo_GetAllDevice_Call {
+func (_c *MockRepo_CreateDevice_Call) Run(run func(d model.Device)) *MockRepo_CreateDevice_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 []Criteria
-		var variadicArgs []Criteria
-		if len(args) > 0 {
-			variadicArgs = args[0].([]Criteria)
+		var arg0 model.Device
+		if args[0] != nil {
+			arg0 = args[0].(model.Device)
 		}
-		arg0 = variadicArgs
 		run(
-			arg0...,
+			arg0,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_GetAllDevice_Call) Return(devices []model.Device, err error) *MockRepo_GetAllDevice_Call {
-	_c.Call.Return(devices, err)
+func (_c *MockRepo_CreateDevice_Call) Return(n int, err error) *MockRepo_CreateDevice_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepo_GetAllDevice_Call) RunAndReturn(run func(criteria ...Criteria) ([]model.Device, error)) *MockRepo_GetAllDevice_Call {
+func (_c *MockRepo_CreateDevice_Call) RunAndReturn(run func(d model.Device) (int, error)) *MockRepo_CreateDevice_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllDoctor provides a mock function for the type MockRepo
-func (_mock *MockRepo) GetAllDoctor(limit int, offset int, criteria ...Criteria) ([]model.TrimDoctor, error) {
-	var tmpRet mock.Arguments
-	if len(criteria) > 0 {
-		tmpRet = _mock.Called(limit, offset, criteria)
-	} else {
-		tmpRet = _mock.Called(limit, offset)
-	}
-	ret := tmpRet
+// CreateDeviceRemoval provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateDeviceRemoval(removal model.DeviceRemoval) error {
+	ret := _mock.Called(removal)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllDoctor")
+		panic("no return value specified for CreateDeviceRemoval")
 	}
 
-	var r0 []model.TrimDoctor
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) ([]model.TrimDoctor, error)); ok {
-		return returnFunc(limit, offset, criteria...)
-	}
-	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) []model.TrimDoctor); ok {
-		r0 = returnFunc(limit, offset, criteria...)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.TrimDoctor)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(int, int, ...Criteria) error); ok {
-		r1 = returnFunc(limit, offset, criteria...)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.DeviceRemoval) error); ok {
+		r0 = returnFunc(removal)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockRepo_GetAllDoctor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllDoctor'
-type MockRepo_GetAllDoctor_Call struct {
+// MockRepo_CreateDeviceRemoval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDeviceRemoval'
+type MockRepo_CreateDeviceRemoval_Call struct {
 	*mock.Call
 }
 
-// GetAllDoctor is a helper method to define mock.On call
-//   - limit int
-//   - offset int
-//   - criteria ...Criteria
-func (_e *MockRepo_Expecter) GetAllDoctor(limit interface{}, offset interface{}, criteria ...interface{}) *MockRepo_GetAllDoctor_Call {
-	return &MockRepo_GetAllDoctor_Call{Call: _e.mock.On("GetAllDoctor",
-		append([]interface{}{limit, offset}, criteria...)...)}
+// CreateDeviceRemoval is a helper method to define mock.On call
+//   - removal model.DeviceRemoval
+func (_e *MockRepo_Expecter) CreateDeviceRemoval(removal interface{}) *MockRepo_CreateDeviceRemoval_Call {
+	return &MockRepo_CreateDeviceRemoval_Call{Call: _e.mock.On("CreateDeviceRemoval", removal)}
 }
 
-func (_c *MockRepo_GetAllDoctor_Call) Run(run func(limit int, offset int, criteria ...Criteria)) *MockRepo_GetAllDoctor_Call {
+func (_c *MockRepo_CreateDeviceRemoval_Call) Run(run func(removal model.DeviceRemoval)) *MockRepo_CreateDeviceRemoval_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 model.DeviceRemoval
 		if args[0] != nil {
-			arg0 = args[0].(int)
-		}
-		var arg1 int
-		if args[1] != nil {
-			arg1 = args[1].(int)
+			arg0 = args[0].(model.DeviceRemoval)
 		}
-		var arg2 []Criteria
-		var variadicArgs []Criteria
-		if len(args) > 2 {
-			variadicArgs = args[2].([]Criteria)
-		}
-		arg2 = variadicArgs
 		run(
 			arg0,
-			arg1,
-			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_GetAllDoctor_Call) Return(trimDoctors []model.TrimDoctor, err error) *MockRepo_GetAllDoctor_Call {
-	_c.Call.Return(trimDoctors, err)
+func (_c *MockRepo_CreateDeviceRemoval_Call) Return(err error) *MockRepo_CreateDeviceRemoval_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_GetAllDoctor_Call) RunAndReturn(run func(limit int, offset int, criteria ...Criteria) ([]model.TrimDoctor, error)) *MockRepo_GetAllDoctor_Call {
+func (_c *MockRepo_CreateDeviceRemoval_Call) RunAndReturn(run func(removal model.DeviceRemoval) error) *MockRepo_CreateDeviceRemoval_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllPatient provides a mock function for the type MockRepo
-func (_mock *MockRepo) GetAllPatient(limit int, offset int, criteria ...Criteria) ([]model.Patient, error) {
-	var tmpRet mock.Arguments
-	if len(criteria) > 0 {
-		tmpRet = _mock.Called(limit, offset, criteria)
-	} else {
-		tmpRet = _mock.Called(limit, offset)
-	}
-	ret := tmpRet
+// CreateDoctor provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateDoctor(doctor model.Doctor) (int, error) {
+	ret := _mock.Called(doctor)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllPatient")
+		panic("no return value specified for CreateDoctor")
 	}
 
-	var r0 []model.Patient
+	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) ([]model.Patient, error)); ok {
-		return returnFunc(limit, offset, criteria...)
+	if returnFunc, ok := ret.Get(0).(func(model.Doctor) (int, error)); ok {
+		return returnFunc(doctor)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) []model.Patient); ok {
-		r0 = returnFunc(limit, offset, criteria...)
+	if returnFunc, ok := ret.Get(0).(func(model.Doctor) int); ok {
+		r0 = returnFunc(doctor)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.Patient)
-		}
+		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(int, int, ...Criteria) error); ok {
-		r1 = returnFunc(limit, offset, criteria...)
+	if returnFunc, ok := ret.Get(1).(func(model.Doctor) error); ok {
+		r1 = returnFunc(doctor)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepo_GetAllPatient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllPatient'
-type MockRepo_GetAllPatient_Call struct {
+// MockRepo_CreateDoctor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDoctor'
+type MockRepo_CreateDoctor_Call struct {
 	*mock.Call
 }
 
-// GetAllPatient is a helper method to define mock.On call
-//   - limit int
-//   - offset int
-//   - criteria ...Criteria
-func (_e *MockRepo_Expecter) GetAllPatient(limit interface{}, offset interface{}, criteria ...interface{}) *MockRepo_GetAllPatient_Call {
-	return &MockRepo_GetAllPatient_Call{Call: _e.mock.On("GetAllPatient",
-		append([]interface{}{limit, offset}, criteria...)...)}
+// CreateDoctor is a helper method to define mock.On call
+//   - doctor model.Doctor
+func (_e *MockRepo_Expecter) CreateDoctor(doctor interface{}) *MockRepo_CreateDoctor_Call {
+	return &MockRepo_CreateDoctor_Call{Call: _e.mock.On("CreateDoctor", doctor)}
 }
 
-func (_c *MockRepo_GetAllPatient_Call) Run(run func(limit int, offset int, criteria ...Criteria)) *MockRepo_GetAllPatient_Call {
+func (_c *MockRepo_CreateDoctor_Call) Run(run func(doctor model.Doctor)) *MockRepo_CreateDoctor_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 model.Doctor
 		if args[0] != nil {
-			arg0 = args[0].(int)
-		}
-		var arg1 int
-		if args[1] != nil {
-			arg1 = args[1].(int)
-		}
-		var arg2 []Criteria
-		var variadicArgs []Criteria
-		if len(args) > 2 {
-			variadicArgs = args[2].([]Criteria)
+			arg0 = args[0].(model.Doctor)
 		}
-		arg2 = variadicArgs
 		run(
 			arg0,
-			arg1,
-			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_GetAllPatient_Call) Return(patients []model.Patient, err error) *MockRepo_GetAllPatient_Call {
-	_c.Call.Return(patients, err)
+func (_c *MockRepo_CreateDoctor_Call) Return(n int, err error) *MockRepo_CreateDoctor_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepo_GetAllPatient_Call) RunAndReturn(run func(limit int, offset int, criteria ...Criteria) ([]model.Patient, error)) *MockRepo_GetAllPatient_Call {
+func (_c *MockRepo_CreateDoctor_Call) RunAndReturn(run func(doctor model.Doctor) (int, error)) *MockRepo_CreateDoctor_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllQuestion provides a mock function for the type MockRepo
-func (_mock *MockRepo) GetAllQuestion(limit int, offset int, criteria ...Criteria) ([]model.QuestionTopic, error) {
-	var tmpRet mock.Arguments
-	if len(criteria) > 0 {
-		tmpRet = _mock.Called(limit, offset, criteria)
+// CreateDoctorNotifications provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateDoctorNotifications(notifications []model.DoctorNotification) error {
+	ret := _mock.Called(notifications)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDoctorNotifications")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func([]model.DoctorNotification) error); ok {
+		r0 = returnFunc(notifications)
 	} else {
-		tmpRet = _mock.Called(limit, offset)
+		r0 = ret.Error(0)
 	}
-	ret := tmpRet
+	return r0
+}
+
+// MockRepo_CreateDoctorNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDoctorNotifications'
+type MockRepo_CreateDoctorNotifications_Call struct {
+	*mock.Call
+}
+
+// CreateDoctorNotifications is a helper method to define mock.On call
+//   - notifications []model.DoctorNotification
+func (_e *MockRepo_Expecter) CreateDoctorNotifications(notifications interface{}) *MockRepo_CreateDoctorNotifications_Call {
+	return &MockRepo_CreateDoctorNotifications_Call{Call: _e.mock.On("CreateDoctorNotifications", notifications)}
+}
+
+func (_c *MockRepo_CreateDoctorNotifications_Call) Run(run func(notifications []model.DoctorNotification)) *MockRepo_CreateDoctorNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []model.DoctorNotification
+		if args[0] != nil {
+			arg0 = args[0].([]model.DoctorNotification)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateDoctorNotifications_Call) Return(err error) *MockRepo_CreateDoctorNotifications_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_CreateDoctorNotifications_Call) RunAndReturn(run func(notifications []model.DoctorNotification) error) *MockRepo_CreateDoctorNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateInvitationCode provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateInvitationCode(invitation model.InvitationCode) (int, error) {
+	ret := _mock.Called(invitation)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllQuestion")
+		panic("no return value specified for CreateInvitationCode")
 	}
 
-	var r0 []model.QuestionTopic
+	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) ([]model.QuestionTopic, error)); ok {
-		return returnFunc(limit, offset, criteria...)
+	if returnFunc, ok := ret.Get(0).(func(model.InvitationCode) (int, error)); ok {
+		return returnFunc(invitation)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) []model.QuestionTopic); ok {
-		r0 = returnFunc(limit, offset, criteria...)
+	if returnFunc, ok := ret.Get(0).(func(model.InvitationCode) int); ok {
+		r0 = returnFunc(invitation)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.QuestionTopic)
-		}
+		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(int, int, ...Criteria) error); ok {
-		r1 = returnFunc(limit, offset, criteria...)
+	if returnFunc, ok := ret.Get(1).(func(model.InvitationCode) error); ok {
+		r1 = returnFunc(invitation)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepo_GetAllQuestion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllQuestion'
-type MockRepo_GetAllQuestion_Call struct {
+// MockRepo_CreateInvitationCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateInvitationCode'
+type MockRepo_CreateInvitationCode_Call struct {
 	*mock.Call
 }
 
-// GetAllQuestion is a helper method to define mock.On call
-//   - limit int
-//   - offset int
-//   - criteria ...Criteria
-func (_e *MockRepo_Expecter) GetAllQuestion(limit interface{}, offset interface{}, criteria ...interface{}) *MockRepo_GetAllQuestion_Call {
-	return &MockRepo_GetAllQuestion_Call{Call: _e.mock.On("GetAllQuestion",
-		append([]interface{}{limit, offset}, criteria...)...)}
+// CreateInvitationCode is a helper method to define mock.On call
+//   - invitation model.InvitationCode
+func (_e *MockRepo_Expecter) CreateInvitationCode(invitation interface{}) *MockRepo_CreateInvitationCode_Call {
+	return &MockRepo_CreateInvitationCode_Call{Call: _e.mock.On("CreateInvitationCode", invitation)}
 }
 
-func (_c *MockRepo_GetAllQuestion_Call) Run(run func(limit int, offset int, criteria ...Criteria)) *MockRepo_GetAllQuestion_Call {
+func (_c *MockRepo_CreateInvitationCode_Call) Run(run func(invitation model.InvitationCode)) *MockRepo_CreateInvitationCode_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 model.InvitationCode
 		if args[0] != nil {
-			arg0 = args[0].(int)
-		}
-		var arg1 int
-		if args[1] != nil {
-			arg1 = args[1].(int)
-		}
-		var arg2 []Criteria
-		var variadicArgs []Criteria
-		if len(args) > 2 {
-			variadicArgs = args[2].([]Criteria)
+			arg0 = args[0].(model.InvitationCode)
 		}
-		arg2 = variadicArgs
 		run(
 			arg0,
-			arg1,
-			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_GetAllQuestion_Call) Return(questionTopics []model.QuestionTopic, err error) *MockRepo_GetAllQuestion_Call {
-	_c.Call.Return(questionTopics, err)
+func (_c *MockRepo_CreateInvitationCode_Call) Return(n int, err error) *MockRepo_CreateInvitationCode_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepo_GetAllQuestion_Call) RunAndReturn(run func(limit int, offset int, criteria ...Criteria) ([]model.QuestionTopic, error)) *MockRepo_GetAllQuestion_Call {
+func (_c *MockRepo_CreateInvitationCode_Call) RunAndReturn(run func(invitation model.InvitationCode) (int, error)) *MockRepo_CreateInvitationCode_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAppointment provides a mock function for the type MockRepo
-func (_mock *MockRepo) GetAppointment(appointmentId any) (model.SafeAppointment, error) {
-	ret := _mock.Called(appointmentId)
+// CreateMeasurement provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateMeasurement(measurement model.Measurement) (int, error) {
+	ret := _mock.Called(measurement)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAppointment")
+		panic("no return value specified for CreateMeasurement")
 	}
 
-	var r0 model.SafeAppointment
+	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(any) (model.SafeAppointment, error)); ok {
-		return returnFunc(appointmentId)
+	if returnFunc, ok := ret.Get(0).(func(model.Measurement) (int, error)); ok {
+		return returnFunc(measurement)
 	}
-	if returnFunc, ok := ret.Get(0).(func(any) model.SafeAppointment); ok {
-		r0 = returnFunc(appointmentId)
+	if returnFunc, ok := ret.Get(0).(func(model.Measurement) int); ok {
+		r0 = returnFunc(measurement)
 	} else {
-		r0 = ret.Get(0).(model.SafeAppointment)
+		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
-		r1 = returnFunc(appointmentId)
+	if returnFunc, ok := ret.Get(1).(func(model.Measurement) error); ok {
+		r1 = returnFunc(measurement)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepo_GetAppointment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAppointment'
-type MockRepo_GetAppointment_Call struct {
+// MockRepo_CreateMeasurement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMeasurement'
+type MockRepo_CreateMeasurement_Call struct {
 	*mock.Call
 }
 
-// GetAppointment is a helper method to define mock.On call
-//   - appointmentId any
-func (_e *MockRepo_Expecter) GetAppointment(appointmentId interface{}) *MockRepo_GetAppointment_Call {
-	return &MockRepo_GetAppointment_Call{Call: _e.mock.On("GetAppointment", appointmentId)}
+// CreateMeasurement is a helper method to define mock.On call
+//   - measurement model.Measurement
+func (_e *MockRepo_Expecter) CreateMeasurement(measurement interface{}) *MockRepo_CreateMeasurement_Call {
+	return &MockRepo_CreateMeasurement_Call{Call: _e.mock.On("CreateMeasurement", measurement)}
 }
 
-func (_c *MockRepo_GetAppointment_Call) Run(run func(appointmentId any)) *MockRepo_GetAppointment_Call {
+func (_c *MockRepo_CreateMeasurement_Call) Run(run func(measurement model.Measurement)) *MockRepo_CreateMeasurement_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 any
+		var arg0 model.Measurement
 		if args[0] != nil {
-			arg0 = args[0].(any)
+			arg0 = args[0].(model.Measurement)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateMeasurement_Call) Return(n int, err error) *MockRepo_CreateMeasurement_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_CreateMeasurement_Call) RunAndReturn(run func(measurement model.Measurement) (int, error)) *MockRepo_CreateMeasurement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateMedicationDose provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateMedicationDose(dose model.MedicationDose) error {
+	ret := _mock.Called(dose)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMedicationDose")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.MedicationDose) error); ok {
+		r0 = returnFunc(dose)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_CreateMedicationDose_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMedicationDose'
+type MockRepo_CreateMedicationDose_Call struct {
+	*mock.Call
+}
+
+// CreateMedicationDose is a helper method to define mock.On call
+//   - dose model.MedicationDose
+func (_e *MockRepo_Expecter) CreateMedicationDose(dose interface{}) *MockRepo_CreateMedicationDose_Call {
+	return &MockRepo_CreateMedicationDose_Call{Call: _e.mock.On("CreateMedicationDose", dose)}
+}
+
+func (_c *MockRepo_CreateMedicationDose_Call) Run(run func(dose model.MedicationDose)) *MockRepo_CreateMedicationDose_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.MedicationDose
+		if args[0] != nil {
+			arg0 = args[0].(model.MedicationDose)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateMedicationDose_Call) Return(err error) *MockRepo_CreateMedicationDose_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_CreateMedicationDose_Call) RunAndReturn(run func(dose model.MedicationDose) error) *MockRepo_CreateMedicationDose_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateMedicationRegimen provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateMedicationRegimen(regimen model.MedicationRegimen) (int, error) {
+	ret := _mock.Called(regimen)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMedicationRegimen")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.MedicationRegimen) (int, error)); ok {
+		return returnFunc(regimen)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.MedicationRegimen) int); ok {
+		r0 = returnFunc(regimen)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.MedicationRegimen) error); ok {
+		r1 = returnFunc(regimen)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_CreateMedicationRegimen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMedicationRegimen'
+type MockRepo_CreateMedicationRegimen_Call struct {
+	*mock.Call
+}
+
+// CreateMedicationRegimen is a helper method to define mock.On call
+//   - regimen model.MedicationRegimen
+func (_e *MockRepo_Expecter) CreateMedicationRegimen(regimen interface{}) *MockRepo_CreateMedicationRegimen_Call {
+	return &MockRepo_CreateMedicationRegimen_Call{Call: _e.mock.On("CreateMedicationRegimen", regimen)}
+}
+
+func (_c *MockRepo_CreateMedicationRegimen_Call) Run(run func(regimen model.MedicationRegimen)) *MockRepo_CreateMedicationRegimen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.MedicationRegimen
+		if args[0] != nil {
+			arg0 = args[0].(model.MedicationRegimen)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateMedicationRegimen_Call) Return(n int, err error) *MockRepo_CreateMedicationRegimen_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_CreateMedicationRegimen_Call) RunAndReturn(run func(regimen model.MedicationRegimen) (int, error)) *MockRepo_CreateMedicationRegimen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateNotification provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateNotification(notification model.Notification) (int, error) {
+	ret := _mock.Called(notification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNotification")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Notification) (int, error)); ok {
+		return returnFunc(notification)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Notification) int); ok {
+		r0 = returnFunc(notification)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.Notification) error); ok {
+		r1 = returnFunc(notification)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_CreateNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNotification'
+type MockRepo_CreateNotification_Call struct {
+	*mock.Call
+}
+
+// CreateNotification is a helper method to define mock.On call
+//   - notification model.Notification
+func (_e *MockRepo_Expecter) CreateNotification(notification interface{}) *MockRepo_CreateNotification_Call {
+	return &MockRepo_CreateNotification_Call{Call: _e.mock.On("CreateNotification", notification)}
+}
+
+func (_c *MockRepo_CreateNotification_Call) Run(run func(notification model.Notification)) *MockRepo_CreateNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Notification
+		if args[0] != nil {
+			arg0 = args[0].(model.Notification)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateNotification_Call) Return(n int, err error) *MockRepo_CreateNotification_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_CreateNotification_Call) RunAndReturn(run func(notification model.Notification) (int, error)) *MockRepo_CreateNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateNotifications provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateNotifications(notifications []model.Notification) ([]model.Notification, error) {
+	ret := _mock.Called(notifications)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNotifications")
+	}
+
+	var r0 []model.Notification
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]model.Notification) ([]model.Notification, error)); ok {
+		return returnFunc(notifications)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]model.Notification) []model.Notification); ok {
+		r0 = returnFunc(notifications)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Notification)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]model.Notification) error); ok {
+		r1 = returnFunc(notifications)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_CreateNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNotifications'
+type MockRepo_CreateNotifications_Call struct {
+	*mock.Call
+}
+
+// CreateNotifications is a helper method to define mock.On call
+//   - notifications []model.Notification
+func (_e *MockRepo_Expecter) CreateNotifications(notifications interface{}) *MockRepo_CreateNotifications_Call {
+	return &MockRepo_CreateNotifications_Call{Call: _e.mock.On("CreateNotifications", notifications)}
+}
+
+func (_c *MockRepo_CreateNotifications_Call) Run(run func(notifications []model.Notification)) *MockRepo_CreateNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []model.Notification
+		if args[0] != nil {
+			arg0 = args[0].([]model.Notification)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateNotifications_Call) Return(notifications []model.Notification, err error) *MockRepo_CreateNotifications_Call {
+	_c.Call.Return(notifications, err)
+	return _c
+}
+
+func (_c *MockRepo_CreateNotifications_Call) RunAndReturn(run func(notifications []model.Notification) ([]model.Notification, error)) *MockRepo_CreateNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOutboxMessages provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateOutboxMessages(messages []model.NotificationOutbox) ([]model.NotificationOutbox, error) {
+	ret := _mock.Called(messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOutboxMessages")
+	}
+
+	var r0 []model.NotificationOutbox
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]model.NotificationOutbox) ([]model.NotificationOutbox, error)); ok {
+		return returnFunc(messages)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]model.NotificationOutbox) []model.NotificationOutbox); ok {
+		r0 = returnFunc(messages)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.NotificationOutbox)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]model.NotificationOutbox) error); ok {
+		r1 = returnFunc(messages)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_CreateOutboxMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOutboxMessages'
+type MockRepo_CreateOutboxMessages_Call struct {
+	*mock.Call
+}
+
+// CreateOutboxMessages is a helper method to define mock.On call
+//   - messages []model.NotificationOutbox
+func (_e *MockRepo_Expecter) CreateOutboxMessages(messages interface{}) *MockRepo_CreateOutboxMessages_Call {
+	return &MockRepo_CreateOutboxMessages_Call{Call: _e.mock.On("CreateOutboxMessages", messages)}
+}
+
+func (_c *MockRepo_CreateOutboxMessages_Call) Run(run func(messages []model.NotificationOutbox)) *MockRepo_CreateOutboxMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []model.NotificationOutbox
+		if args[0] != nil {
+			arg0 = args[0].([]model.NotificationOutbox)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateOutboxMessages_Call) Return(notificationOutboxes []model.NotificationOutbox, err error) *MockRepo_CreateOutboxMessages_Call {
+	_c.Call.Return(notificationOutboxes, err)
+	return _c
+}
+
+func (_c *MockRepo_CreateOutboxMessages_Call) RunAndReturn(run func(messages []model.NotificationOutbox) ([]model.NotificationOutbox, error)) *MockRepo_CreateOutboxMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePatient provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreatePatient(patient model.Patient) (int, error) {
+	ret := _mock.Called(patient)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePatient")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Patient) (int, error)); ok {
+		return returnFunc(patient)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Patient) int); ok {
+		r0 = returnFunc(patient)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.Patient) error); ok {
+		r1 = returnFunc(patient)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_CreatePatient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePatient'
+type MockRepo_CreatePatient_Call struct {
+	*mock.Call
+}
+
+// CreatePatient is a helper method to define mock.On call
+//   - patient model.Patient
+func (_e *MockRepo_Expecter) CreatePatient(patient interface{}) *MockRepo_CreatePatient_Call {
+	return &MockRepo_CreatePatient_Call{Call: _e.mock.On("CreatePatient", patient)}
+}
+
+func (_c *MockRepo_CreatePatient_Call) Run(run func(patient model.Patient)) *MockRepo_CreatePatient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Patient
+		if args[0] != nil {
+			arg0 = args[0].(model.Patient)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreatePatient_Call) Return(n int, err error) *MockRepo_CreatePatient_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_CreatePatient_Call) RunAndReturn(run func(patient model.Patient) (int, error)) *MockRepo_CreatePatient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePatientWithInvitation provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreatePatientWithInvitation(patient model.Patient, invitationId int) (int, error) {
+	ret := _mock.Called(patient, invitationId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePatientWithInvitation")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Patient, int) (int, error)); ok {
+		return returnFunc(patient, invitationId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Patient, int) int); ok {
+		r0 = returnFunc(patient, invitationId)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.Patient, int) error); ok {
+		r1 = returnFunc(patient, invitationId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_CreatePatientWithInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePatientWithInvitation'
+type MockRepo_CreatePatientWithInvitation_Call struct {
+	*mock.Call
+}
+
+// CreatePatientWithInvitation is a helper method to define mock.On call
+//   - patient model.Patient
+//   - invitationId int
+func (_e *MockRepo_Expecter) CreatePatientWithInvitation(patient interface{}, invitationId interface{}) *MockRepo_CreatePatientWithInvitation_Call {
+	return &MockRepo_CreatePatientWithInvitation_Call{Call: _e.mock.On("CreatePatientWithInvitation", patient, invitationId)}
+}
+
+func (_c *MockRepo_CreatePatientWithInvitation_Call) Run(run func(patient model.Patient, invitationId int)) *MockRepo_CreatePatientWithInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Patient
+		if args[0] != nil {
+			arg0 = args[0].(model.Patient)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreatePatientWithInvitation_Call) Return(n int, err error) *MockRepo_CreatePatientWithInvitation_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_CreatePatientWithInvitation_Call) RunAndReturn(run func(patient model.Patient, invitationId int) (int, error)) *MockRepo_CreatePatientWithInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateQuestion provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateQuestion(patientId int, topic string, question string, createAt int) (int, error) {
+	ret := _mock.Called(patientId, topic, question, createAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateQuestion")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, string, string, int) (int, error)); ok {
+		return returnFunc(patientId, topic, question, createAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, string, string, int) int); ok {
+		r0 = returnFunc(patientId, topic, question, createAt)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, string, string, int) error); ok {
+		r1 = returnFunc(patientId, topic, question, createAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_CreateQuestion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateQuestion'
+type MockRepo_CreateQuestion_Call struct {
+	*mock.Call
+}
+
+// CreateQuestion is a helper method to define mock.On call
+//   - patientId int
+//   - topic string
+//   - question string
+//   - createAt int
+func (_e *MockRepo_Expecter) CreateQuestion(patientId interface{}, topic interface{}, question interface{}, createAt interface{}) *MockRepo_CreateQuestion_Call {
+	return &MockRepo_CreateQuestion_Call{Call: _e.mock.On("CreateQuestion", patientId, topic, question, createAt)}
+}
+
+func (_c *MockRepo_CreateQuestion_Call) Run(run func(patientId int, topic string, question string, createAt int)) *MockRepo_CreateQuestion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateQuestion_Call) Return(n int, err error) *MockRepo_CreateQuestion_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_CreateQuestion_Call) RunAndReturn(run func(patientId int, topic string, question string, createAt int) (int, error)) *MockRepo_CreateQuestion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateQuestionAttachment provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateQuestionAttachment(attachment model.QuestionAttachment) (int, error) {
+	ret := _mock.Called(attachment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateQuestionAttachment")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.QuestionAttachment) (int, error)); ok {
+		return returnFunc(attachment)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.QuestionAttachment) int); ok {
+		r0 = returnFunc(attachment)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.QuestionAttachment) error); ok {
+		r1 = returnFunc(attachment)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_CreateQuestionAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateQuestionAttachment'
+type MockRepo_CreateQuestionAttachment_Call struct {
+	*mock.Call
+}
+
+// CreateQuestionAttachment is a helper method to define mock.On call
+//   - attachment model.QuestionAttachment
+func (_e *MockRepo_Expecter) CreateQuestionAttachment(attachment interface{}) *MockRepo_CreateQuestionAttachment_Call {
+	return &MockRepo_CreateQuestionAttachment_Call{Call: _e.mock.On("CreateQuestionAttachment", attachment)}
+}
+
+func (_c *MockRepo_CreateQuestionAttachment_Call) Run(run func(attachment model.QuestionAttachment)) *MockRepo_CreateQuestionAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.QuestionAttachment
+		if args[0] != nil {
+			arg0 = args[0].(model.QuestionAttachment)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateQuestionAttachment_Call) Return(n int, err error) *MockRepo_CreateQuestionAttachment_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_CreateQuestionAttachment_Call) RunAndReturn(run func(attachment model.QuestionAttachment) (int, error)) *MockRepo_CreateQuestionAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateQuestionMessage provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateQuestionMessage(message model.QuestionMessage) (int, error) {
+	ret := _mock.Called(message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateQuestionMessage")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.QuestionMessage) (int, error)); ok {
+		return returnFunc(message)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.QuestionMessage) int); ok {
+		r0 = returnFunc(message)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.QuestionMessage) error); ok {
+		r1 = returnFunc(message)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_CreateQuestionMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateQuestionMessage'
+type MockRepo_CreateQuestionMessage_Call struct {
+	*mock.Call
+}
+
+// CreateQuestionMessage is a helper method to define mock.On call
+//   - message model.QuestionMessage
+func (_e *MockRepo_Expecter) CreateQuestionMessage(message interface{}) *MockRepo_CreateQuestionMessage_Call {
+	return &MockRepo_CreateQuestionMessage_Call{Call: _e.mock.On("CreateQuestionMessage", message)}
+}
+
+func (_c *MockRepo_CreateQuestionMessage_Call) Run(run func(message model.QuestionMessage)) *MockRepo_CreateQuestionMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.QuestionMessage
+		if args[0] != nil {
+			arg0 = args[0].(model.QuestionMessage)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateQuestionMessage_Call) Return(n int, err error) *MockRepo_CreateQuestionMessage_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_CreateQuestionMessage_Call) RunAndReturn(run func(message model.QuestionMessage) (int, error)) *MockRepo_CreateQuestionMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateReminderLog provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateReminderLog(log model.ReminderLog) error {
+	ret := _mock.Called(log)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReminderLog")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.ReminderLog) error); ok {
+		r0 = returnFunc(log)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_CreateReminderLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReminderLog'
+type MockRepo_CreateReminderLog_Call struct {
+	*mock.Call
+}
+
+// CreateReminderLog is a helper method to define mock.On call
+//   - log model.ReminderLog
+func (_e *MockRepo_Expecter) CreateReminderLog(log interface{}) *MockRepo_CreateReminderLog_Call {
+	return &MockRepo_CreateReminderLog_Call{Call: _e.mock.On("CreateReminderLog", log)}
+}
+
+func (_c *MockRepo_CreateReminderLog_Call) Run(run func(log model.ReminderLog)) *MockRepo_CreateReminderLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.ReminderLog
+		if args[0] != nil {
+			arg0 = args[0].(model.ReminderLog)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateReminderLog_Call) Return(err error) *MockRepo_CreateReminderLog_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_CreateReminderLog_Call) RunAndReturn(run func(log model.ReminderLog) error) *MockRepo_CreateReminderLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateReminderRule provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateReminderRule(rule model.ReminderRule) (int, error) {
+	ret := _mock.Called(rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReminderRule")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.ReminderRule) (int, error)); ok {
+		return returnFunc(rule)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.ReminderRule) int); ok {
+		r0 = returnFunc(rule)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.ReminderRule) error); ok {
+		r1 = returnFunc(rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_CreateReminderRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReminderRule'
+type MockRepo_CreateReminderRule_Call struct {
+	*mock.Call
+}
+
+// CreateReminderRule is a helper method to define mock.On call
+//   - rule model.ReminderRule
+func (_e *MockRepo_Expecter) CreateReminderRule(rule interface{}) *MockRepo_CreateReminderRule_Call {
+	return &MockRepo_CreateReminderRule_Call{Call: _e.mock.On("CreateReminderRule", rule)}
+}
+
+func (_c *MockRepo_CreateReminderRule_Call) Run(run func(rule model.ReminderRule)) *MockRepo_CreateReminderRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.ReminderRule
+		if args[0] != nil {
+			arg0 = args[0].(model.ReminderRule)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateReminderRule_Call) Return(n int, err error) *MockRepo_CreateReminderRule_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_CreateReminderRule_Call) RunAndReturn(run func(rule model.ReminderRule) (int, error)) *MockRepo_CreateReminderRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRescheduleRequest provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateRescheduleRequest(request model.RescheduleRequest) (int, error) {
+	ret := _mock.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRescheduleRequest")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.RescheduleRequest) (int, error)); ok {
+		return returnFunc(request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.RescheduleRequest) int); ok {
+		r0 = returnFunc(request)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.RescheduleRequest) error); ok {
+		r1 = returnFunc(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_CreateRescheduleRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRescheduleRequest'
+type MockRepo_CreateRescheduleRequest_Call struct {
+	*mock.Call
+}
+
+// CreateRescheduleRequest is a helper method to define mock.On call
+//   - request model.RescheduleRequest
+func (_e *MockRepo_Expecter) CreateRescheduleRequest(request interface{}) *MockRepo_CreateRescheduleRequest_Call {
+	return &MockRepo_CreateRescheduleRequest_Call{Call: _e.mock.On("CreateRescheduleRequest", request)}
+}
+
+func (_c *MockRepo_CreateRescheduleRequest_Call) Run(run func(request model.RescheduleRequest)) *MockRepo_CreateRescheduleRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.RescheduleRequest
+		if args[0] != nil {
+			arg0 = args[0].(model.RescheduleRequest)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateRescheduleRequest_Call) Return(n int, err error) *MockRepo_CreateRescheduleRequest_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_CreateRescheduleRequest_Call) RunAndReturn(run func(request model.RescheduleRequest) (int, error)) *MockRepo_CreateRescheduleRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateScheduleException provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateScheduleException(exception model.ScheduleException) (int, error) {
+	ret := _mock.Called(exception)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateScheduleException")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.ScheduleException) (int, error)); ok {
+		return returnFunc(exception)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.ScheduleException) int); ok {
+		r0 = returnFunc(exception)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.ScheduleException) error); ok {
+		r1 = returnFunc(exception)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_CreateScheduleException_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateScheduleException'
+type MockRepo_CreateScheduleException_Call struct {
+	*mock.Call
+}
+
+// CreateScheduleException is a helper method to define mock.On call
+//   - exception model.ScheduleException
+func (_e *MockRepo_Expecter) CreateScheduleException(exception interface{}) *MockRepo_CreateScheduleException_Call {
+	return &MockRepo_CreateScheduleException_Call{Call: _e.mock.On("CreateScheduleException", exception)}
+}
+
+func (_c *MockRepo_CreateScheduleException_Call) Run(run func(exception model.ScheduleException)) *MockRepo_CreateScheduleException_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.ScheduleException
+		if args[0] != nil {
+			arg0 = args[0].(model.ScheduleException)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateScheduleException_Call) Return(n int, err error) *MockRepo_CreateScheduleException_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_CreateScheduleException_Call) RunAndReturn(run func(exception model.ScheduleException) (int, error)) *MockRepo_CreateScheduleException_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateVaccination provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateVaccination(vaccination model.Vaccination) (int, error) {
+	ret := _mock.Called(vaccination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateVaccination")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Vaccination) (int, error)); ok {
+		return returnFunc(vaccination)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Vaccination) int); ok {
+		r0 = returnFunc(vaccination)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.Vaccination) error); ok {
+		r1 = returnFunc(vaccination)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_CreateVaccination_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateVaccination'
+type MockRepo_CreateVaccination_Call struct {
+	*mock.Call
+}
+
+// CreateVaccination is a helper method to define mock.On call
+//   - vaccination model.Vaccination
+func (_e *MockRepo_Expecter) CreateVaccination(vaccination interface{}) *MockRepo_CreateVaccination_Call {
+	return &MockRepo_CreateVaccination_Call{Call: _e.mock.On("CreateVaccination", vaccination)}
+}
+
+func (_c *MockRepo_CreateVaccination_Call) Run(run func(vaccination model.Vaccination)) *MockRepo_CreateVaccination_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Vaccination
+		if args[0] != nil {
+			arg0 = args[0].(model.Vaccination)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateVaccination_Call) Return(n int, err error) *MockRepo_CreateVaccination_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_CreateVaccination_Call) RunAndReturn(run func(vaccination model.Vaccination) (int, error)) *MockRepo_CreateVaccination_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateVaccine provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateVaccine(vaccine model.Vaccine) (int, error) {
+	ret := _mock.Called(vaccine)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateVaccine")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.Vaccine) (int, error)); ok {
+		return returnFunc(vaccine)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.Vaccine) int); ok {
+		r0 = returnFunc(vaccine)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.Vaccine) error); ok {
+		r1 = returnFunc(vaccine)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_CreateVaccine_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateVaccine'
+type MockRepo_CreateVaccine_Call struct {
+	*mock.Call
+}
+
+// CreateVaccine is a helper method to define mock.On call
+//   - vaccine model.Vaccine
+func (_e *MockRepo_Expecter) CreateVaccine(vaccine interface{}) *MockRepo_CreateVaccine_Call {
+	return &MockRepo_CreateVaccine_Call{Call: _e.mock.On("CreateVaccine", vaccine)}
+}
+
+func (_c *MockRepo_CreateVaccine_Call) Run(run func(vaccine model.Vaccine)) *MockRepo_CreateVaccine_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Vaccine
+		if args[0] != nil {
+			arg0 = args[0].(model.Vaccine)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateVaccine_Call) Return(n int, err error) *MockRepo_CreateVaccine_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_CreateVaccine_Call) RunAndReturn(run func(vaccine model.Vaccine) (int, error)) *MockRepo_CreateVaccine_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateVaccineReminderLog provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateVaccineReminderLog(log model.VaccineReminderLog) error {
+	ret := _mock.Called(log)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateVaccineReminderLog")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.VaccineReminderLog) error); ok {
+		r0 = returnFunc(log)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_CreateVaccineReminderLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateVaccineReminderLog'
+type MockRepo_CreateVaccineReminderLog_Call struct {
+	*mock.Call
+}
+
+// CreateVaccineReminderLog is a helper method to define mock.On call
+//   - log model.VaccineReminderLog
+func (_e *MockRepo_Expecter) CreateVaccineReminderLog(log interface{}) *MockRepo_CreateVaccineReminderLog_Call {
+	return &MockRepo_CreateVaccineReminderLog_Call{Call: _e.mock.On("CreateVaccineReminderLog", log)}
+}
+
+func (_c *MockRepo_CreateVaccineReminderLog_Call) Run(run func(log model.VaccineReminderLog)) *MockRepo_CreateVaccineReminderLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.VaccineReminderLog
+		if args[0] != nil {
+			arg0 = args[0].(model.VaccineReminderLog)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateVaccineReminderLog_Call) Return(err error) *MockRepo_CreateVaccineReminderLog_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_CreateVaccineReminderLog_Call) RunAndReturn(run func(log model.VaccineReminderLog) error) *MockRepo_CreateVaccineReminderLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateVaccineRule provides a mock function for the type MockRepo
+func (_mock *MockRepo) CreateVaccineRule(rule model.VaccineRule) (int, error) {
+	ret := _mock.Called(rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateVaccineRule")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.VaccineRule) (int, error)); ok {
+		return returnFunc(rule)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.VaccineRule) int); ok {
+		r0 = returnFunc(rule)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.VaccineRule) error); ok {
+		r1 = returnFunc(rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_CreateVaccineRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateVaccineRule'
+type MockRepo_CreateVaccineRule_Call struct {
+	*mock.Call
+}
+
+// CreateVaccineRule is a helper method to define mock.On call
+//   - rule model.VaccineRule
+func (_e *MockRepo_Expecter) CreateVaccineRule(rule interface{}) *MockRepo_CreateVaccineRule_Call {
+	return &MockRepo_CreateVaccineRule_Call{Call: _e.mock.On("CreateVaccineRule", rule)}
+}
+
+func (_c *MockRepo_CreateVaccineRule_Call) Run(run func(rule model.VaccineRule)) *MockRepo_CreateVaccineRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.VaccineRule
+		if args[0] != nil {
+			arg0 = args[0].(model.VaccineRule)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_CreateVaccineRule_Call) Return(n int, err error) *MockRepo_CreateVaccineRule_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_CreateVaccineRule_Call) RunAndReturn(run func(rule model.VaccineRule) (int, error)) *MockRepo_CreateVaccineRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAnswerSnippet provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteAnswerSnippet(snippetId any) error {
+	ret := _mock.Called(snippetId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAnswerSnippet")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
+		r0 = returnFunc(snippetId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteAnswerSnippet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAnswerSnippet'
+type MockRepo_DeleteAnswerSnippet_Call struct {
+	*mock.Call
+}
+
+// DeleteAnswerSnippet is a helper method to define mock.On call
+//   - snippetId any
+func (_e *MockRepo_Expecter) DeleteAnswerSnippet(snippetId interface{}) *MockRepo_DeleteAnswerSnippet_Call {
+	return &MockRepo_DeleteAnswerSnippet_Call{Call: _e.mock.On("DeleteAnswerSnippet", snippetId)}
+}
+
+func (_c *MockRepo_DeleteAnswerSnippet_Call) Run(run func(snippetId any)) *MockRepo_DeleteAnswerSnippet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteAnswerSnippet_Call) Return(err error) *MockRepo_DeleteAnswerSnippet_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteAnswerSnippet_Call) RunAndReturn(run func(snippetId any) error) *MockRepo_DeleteAnswerSnippet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAppointment provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteAppointment(appointmentId any) error {
+	ret := _mock.Called(appointmentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAppointment")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
+		r0 = returnFunc(appointmentId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteAppointment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAppointment'
+type MockRepo_DeleteAppointment_Call struct {
+	*mock.Call
+}
+
+// DeleteAppointment is a helper method to define mock.On call
+//   - appointmentId any
+func (_e *MockRepo_Expecter) DeleteAppointment(appointmentId interface{}) *MockRepo_DeleteAppointment_Call {
+	return &MockRepo_DeleteAppointment_Call{Call: _e.mock.On("DeleteAppointment", appointmentId)}
+}
+
+func (_c *MockRepo_DeleteAppointment_Call) Run(run func(appointmentId any)) *MockRepo_DeleteAppointment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteAppointment_Call) Return(err error) *MockRepo_DeleteAppointment_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteAppointment_Call) RunAndReturn(run func(appointmentId any) error) *MockRepo_DeleteAppointment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCareTeamMember provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteCareTeamMember(memberId any) error {
+	ret := _mock.Called(memberId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCareTeamMember")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
+		r0 = returnFunc(memberId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteCareTeamMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCareTeamMember'
+type MockRepo_DeleteCareTeamMember_Call struct {
+	*mock.Call
+}
+
+// DeleteCareTeamMember is a helper method to define mock.On call
+//   - memberId any
+func (_e *MockRepo_Expecter) DeleteCareTeamMember(memberId interface{}) *MockRepo_DeleteCareTeamMember_Call {
+	return &MockRepo_DeleteCareTeamMember_Call{Call: _e.mock.On("DeleteCareTeamMember", memberId)}
+}
+
+func (_c *MockRepo_DeleteCareTeamMember_Call) Run(run func(memberId any)) *MockRepo_DeleteCareTeamMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteCareTeamMember_Call) Return(err error) *MockRepo_DeleteCareTeamMember_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteCareTeamMember_Call) RunAndReturn(run func(memberId any) error) *MockRepo_DeleteCareTeamMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCaregiverLink provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteCaregiverLink(link model.CaregiverLink) error {
+	ret := _mock.Called(link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCaregiverLink")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.CaregiverLink) error); ok {
+		r0 = returnFunc(link)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteCaregiverLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCaregiverLink'
+type MockRepo_DeleteCaregiverLink_Call struct {
+	*mock.Call
+}
+
+// DeleteCaregiverLink is a helper method to define mock.On call
+//   - link model.CaregiverLink
+func (_e *MockRepo_Expecter) DeleteCaregiverLink(link interface{}) *MockRepo_DeleteCaregiverLink_Call {
+	return &MockRepo_DeleteCaregiverLink_Call{Call: _e.mock.On("DeleteCaregiverLink", link)}
+}
+
+func (_c *MockRepo_DeleteCaregiverLink_Call) Run(run func(link model.CaregiverLink)) *MockRepo_DeleteCaregiverLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.CaregiverLink
+		if args[0] != nil {
+			arg0 = args[0].(model.CaregiverLink)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteCaregiverLink_Call) Return(err error) *MockRepo_DeleteCaregiverLink_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteCaregiverLink_Call) RunAndReturn(run func(link model.CaregiverLink) error) *MockRepo_DeleteCaregiverLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteConsentById provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteConsentById(consentID any) error {
+	ret := _mock.Called(consentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteConsentById")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
+		r0 = returnFunc(consentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteConsentById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteConsentById'
+type MockRepo_DeleteConsentById_Call struct {
+	*mock.Call
+}
+
+// DeleteConsentById is a helper method to define mock.On call
+//   - consentID any
+func (_e *MockRepo_Expecter) DeleteConsentById(consentID interface{}) *MockRepo_DeleteConsentById_Call {
+	return &MockRepo_DeleteConsentById_Call{Call: _e.mock.On("DeleteConsentById", consentID)}
+}
+
+func (_c *MockRepo_DeleteConsentById_Call) Run(run func(consentID any)) *MockRepo_DeleteConsentById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteConsentById_Call) Return(err error) *MockRepo_DeleteConsentById_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteConsentById_Call) RunAndReturn(run func(consentID any) error) *MockRepo_DeleteConsentById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteConsentBySlug provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteConsentBySlug(slug string) error {
+	ret := _mock.Called(slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteConsentBySlug")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(slug)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteConsentBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteConsentBySlug'
+type MockRepo_DeleteConsentBySlug_Call struct {
+	*mock.Call
+}
+
+// DeleteConsentBySlug is a helper method to define mock.On call
+//   - slug string
+func (_e *MockRepo_Expecter) DeleteConsentBySlug(slug interface{}) *MockRepo_DeleteConsentBySlug_Call {
+	return &MockRepo_DeleteConsentBySlug_Call{Call: _e.mock.On("DeleteConsentBySlug", slug)}
+}
+
+func (_c *MockRepo_DeleteConsentBySlug_Call) Run(run func(slug string)) *MockRepo_DeleteConsentBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteConsentBySlug_Call) Return(err error) *MockRepo_DeleteConsentBySlug_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteConsentBySlug_Call) RunAndReturn(run func(slug string) error) *MockRepo_DeleteConsentBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteContent provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteContent(contentID any) error {
+	ret := _mock.Called(contentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteContent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
+		r0 = returnFunc(contentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteContent'
+type MockRepo_DeleteContent_Call struct {
+	*mock.Call
+}
+
+// DeleteContent is a helper method to define mock.On call
+//   - contentID any
+func (_e *MockRepo_Expecter) DeleteContent(contentID interface{}) *MockRepo_DeleteContent_Call {
+	return &MockRepo_DeleteContent_Call{Call: _e.mock.On("DeleteContent", contentID)}
+}
+
+func (_c *MockRepo_DeleteContent_Call) Run(run func(contentID any)) *MockRepo_DeleteContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteContent_Call) Return(err error) *MockRepo_DeleteContent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteContent_Call) RunAndReturn(run func(contentID any) error) *MockRepo_DeleteContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteDevice provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteDevice(deviceId any) error {
+	ret := _mock.Called(deviceId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDevice")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
+		r0 = returnFunc(deviceId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDevice'
+type MockRepo_DeleteDevice_Call struct {
+	*mock.Call
+}
+
+// DeleteDevice is a helper method to define mock.On call
+//   - deviceId any
+func (_e *MockRepo_Expecter) DeleteDevice(deviceId interface{}) *MockRepo_DeleteDevice_Call {
+	return &MockRepo_DeleteDevice_Call{Call: _e.mock.On("DeleteDevice", deviceId)}
+}
+
+func (_c *MockRepo_DeleteDevice_Call) Run(run func(deviceId any)) *MockRepo_DeleteDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteDevice_Call) Return(err error) *MockRepo_DeleteDevice_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteDevice_Call) RunAndReturn(run func(deviceId any) error) *MockRepo_DeleteDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteDoctorById provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteDoctorById(id any) error {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDoctorById")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteDoctorById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDoctorById'
+type MockRepo_DeleteDoctorById_Call struct {
+	*mock.Call
+}
+
+// DeleteDoctorById is a helper method to define mock.On call
+//   - id any
+func (_e *MockRepo_Expecter) DeleteDoctorById(id interface{}) *MockRepo_DeleteDoctorById_Call {
+	return &MockRepo_DeleteDoctorById_Call{Call: _e.mock.On("DeleteDoctorById", id)}
+}
+
+func (_c *MockRepo_DeleteDoctorById_Call) Run(run func(id any)) *MockRepo_DeleteDoctorById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteDoctorById_Call) Return(err error) *MockRepo_DeleteDoctorById_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteDoctorById_Call) RunAndReturn(run func(id any) error) *MockRepo_DeleteDoctorById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteInvitationCode provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteInvitationCode(id any) error {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInvitationCode")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteInvitationCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteInvitationCode'
+type MockRepo_DeleteInvitationCode_Call struct {
+	*mock.Call
+}
+
+// DeleteInvitationCode is a helper method to define mock.On call
+//   - id any
+func (_e *MockRepo_Expecter) DeleteInvitationCode(id interface{}) *MockRepo_DeleteInvitationCode_Call {
+	return &MockRepo_DeleteInvitationCode_Call{Call: _e.mock.On("DeleteInvitationCode", id)}
+}
+
+func (_c *MockRepo_DeleteInvitationCode_Call) Run(run func(id any)) *MockRepo_DeleteInvitationCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteInvitationCode_Call) Return(err error) *MockRepo_DeleteInvitationCode_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteInvitationCode_Call) RunAndReturn(run func(id any) error) *MockRepo_DeleteInvitationCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMeasurement provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteMeasurement(measurement model.Measurement) error {
+	ret := _mock.Called(measurement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMeasurement")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.Measurement) error); ok {
+		r0 = returnFunc(measurement)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteMeasurement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMeasurement'
+type MockRepo_DeleteMeasurement_Call struct {
+	*mock.Call
+}
+
+// DeleteMeasurement is a helper method to define mock.On call
+//   - measurement model.Measurement
+func (_e *MockRepo_Expecter) DeleteMeasurement(measurement interface{}) *MockRepo_DeleteMeasurement_Call {
+	return &MockRepo_DeleteMeasurement_Call{Call: _e.mock.On("DeleteMeasurement", measurement)}
+}
+
+func (_c *MockRepo_DeleteMeasurement_Call) Run(run func(measurement model.Measurement)) *MockRepo_DeleteMeasurement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Measurement
+		if args[0] != nil {
+			arg0 = args[0].(model.Measurement)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteMeasurement_Call) Return(err error) *MockRepo_DeleteMeasurement_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteMeasurement_Call) RunAndReturn(run func(measurement model.Measurement) error) *MockRepo_DeleteMeasurement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMedicationRegimen provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteMedicationRegimen(regimen model.MedicationRegimen) error {
+	ret := _mock.Called(regimen)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMedicationRegimen")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.MedicationRegimen) error); ok {
+		r0 = returnFunc(regimen)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteMedicationRegimen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMedicationRegimen'
+type MockRepo_DeleteMedicationRegimen_Call struct {
+	*mock.Call
+}
+
+// DeleteMedicationRegimen is a helper method to define mock.On call
+//   - regimen model.MedicationRegimen
+func (_e *MockRepo_Expecter) DeleteMedicationRegimen(regimen interface{}) *MockRepo_DeleteMedicationRegimen_Call {
+	return &MockRepo_DeleteMedicationRegimen_Call{Call: _e.mock.On("DeleteMedicationRegimen", regimen)}
+}
+
+func (_c *MockRepo_DeleteMedicationRegimen_Call) Run(run func(regimen model.MedicationRegimen)) *MockRepo_DeleteMedicationRegimen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.MedicationRegimen
+		if args[0] != nil {
+			arg0 = args[0].(model.MedicationRegimen)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteMedicationRegimen_Call) Return(err error) *MockRepo_DeleteMedicationRegimen_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteMedicationRegimen_Call) RunAndReturn(run func(regimen model.MedicationRegimen) error) *MockRepo_DeleteMedicationRegimen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteNotificationTemplate provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteNotificationTemplate(key model.TemplateKey, language model.Language) error {
+	ret := _mock.Called(key, language)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteNotificationTemplate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.TemplateKey, model.Language) error); ok {
+		r0 = returnFunc(key, language)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteNotificationTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNotificationTemplate'
+type MockRepo_DeleteNotificationTemplate_Call struct {
+	*mock.Call
+}
+
+// DeleteNotificationTemplate is a helper method to define mock.On call
+//   - key model.TemplateKey
+//   - language model.Language
+func (_e *MockRepo_Expecter) DeleteNotificationTemplate(key interface{}, language interface{}) *MockRepo_DeleteNotificationTemplate_Call {
+	return &MockRepo_DeleteNotificationTemplate_Call{Call: _e.mock.On("DeleteNotificationTemplate", key, language)}
+}
+
+func (_c *MockRepo_DeleteNotificationTemplate_Call) Run(run func(key model.TemplateKey, language model.Language)) *MockRepo_DeleteNotificationTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.TemplateKey
+		if args[0] != nil {
+			arg0 = args[0].(model.TemplateKey)
+		}
+		var arg1 model.Language
+		if args[1] != nil {
+			arg1 = args[1].(model.Language)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteNotificationTemplate_Call) Return(err error) *MockRepo_DeleteNotificationTemplate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteNotificationTemplate_Call) RunAndReturn(run func(key model.TemplateKey, language model.Language) error) *MockRepo_DeleteNotificationTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePatientById provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeletePatientById(id any) error {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePatientById")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeletePatientById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePatientById'
+type MockRepo_DeletePatientById_Call struct {
+	*mock.Call
+}
+
+// DeletePatientById is a helper method to define mock.On call
+//   - id any
+func (_e *MockRepo_Expecter) DeletePatientById(id interface{}) *MockRepo_DeletePatientById_Call {
+	return &MockRepo_DeletePatientById_Call{Call: _e.mock.On("DeletePatientById", id)}
+}
+
+func (_c *MockRepo_DeletePatientById_Call) Run(run func(id any)) *MockRepo_DeletePatientById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeletePatientById_Call) Return(err error) *MockRepo_DeletePatientById_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeletePatientById_Call) RunAndReturn(run func(id any) error) *MockRepo_DeletePatientById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteQuestion provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteQuestion(questionId any) error {
+	ret := _mock.Called(questionId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteQuestion")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
+		r0 = returnFunc(questionId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteQuestion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteQuestion'
+type MockRepo_DeleteQuestion_Call struct {
+	*mock.Call
+}
+
+// DeleteQuestion is a helper method to define mock.On call
+//   - questionId any
+func (_e *MockRepo_Expecter) DeleteQuestion(questionId interface{}) *MockRepo_DeleteQuestion_Call {
+	return &MockRepo_DeleteQuestion_Call{Call: _e.mock.On("DeleteQuestion", questionId)}
+}
+
+func (_c *MockRepo_DeleteQuestion_Call) Run(run func(questionId any)) *MockRepo_DeleteQuestion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteQuestion_Call) Return(err error) *MockRepo_DeleteQuestion_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteQuestion_Call) RunAndReturn(run func(questionId any) error) *MockRepo_DeleteQuestion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteReminderRule provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteReminderRule(ruleId any) error {
+	ret := _mock.Called(ruleId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReminderRule")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
+		r0 = returnFunc(ruleId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteReminderRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteReminderRule'
+type MockRepo_DeleteReminderRule_Call struct {
+	*mock.Call
+}
+
+// DeleteReminderRule is a helper method to define mock.On call
+//   - ruleId any
+func (_e *MockRepo_Expecter) DeleteReminderRule(ruleId interface{}) *MockRepo_DeleteReminderRule_Call {
+	return &MockRepo_DeleteReminderRule_Call{Call: _e.mock.On("DeleteReminderRule", ruleId)}
+}
+
+func (_c *MockRepo_DeleteReminderRule_Call) Run(run func(ruleId any)) *MockRepo_DeleteReminderRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteReminderRule_Call) Return(err error) *MockRepo_DeleteReminderRule_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteReminderRule_Call) RunAndReturn(run func(ruleId any) error) *MockRepo_DeleteReminderRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteScheduleException provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteScheduleException(exceptionId any) error {
+	ret := _mock.Called(exceptionId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteScheduleException")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
+		r0 = returnFunc(exceptionId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteScheduleException_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteScheduleException'
+type MockRepo_DeleteScheduleException_Call struct {
+	*mock.Call
+}
+
+// DeleteScheduleException is a helper method to define mock.On call
+//   - exceptionId any
+func (_e *MockRepo_Expecter) DeleteScheduleException(exceptionId interface{}) *MockRepo_DeleteScheduleException_Call {
+	return &MockRepo_DeleteScheduleException_Call{Call: _e.mock.On("DeleteScheduleException", exceptionId)}
+}
+
+func (_c *MockRepo_DeleteScheduleException_Call) Run(run func(exceptionId any)) *MockRepo_DeleteScheduleException_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteScheduleException_Call) Return(err error) *MockRepo_DeleteScheduleException_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteScheduleException_Call) RunAndReturn(run func(exceptionId any) error) *MockRepo_DeleteScheduleException_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteVaccination provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteVaccination(vaccinationId any) error {
+	ret := _mock.Called(vaccinationId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVaccination")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
+		r0 = returnFunc(vaccinationId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteVaccination_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteVaccination'
+type MockRepo_DeleteVaccination_Call struct {
+	*mock.Call
+}
+
+// DeleteVaccination is a helper method to define mock.On call
+//   - vaccinationId any
+func (_e *MockRepo_Expecter) DeleteVaccination(vaccinationId interface{}) *MockRepo_DeleteVaccination_Call {
+	return &MockRepo_DeleteVaccination_Call{Call: _e.mock.On("DeleteVaccination", vaccinationId)}
+}
+
+func (_c *MockRepo_DeleteVaccination_Call) Run(run func(vaccinationId any)) *MockRepo_DeleteVaccination_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteVaccination_Call) Return(err error) *MockRepo_DeleteVaccination_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteVaccination_Call) RunAndReturn(run func(vaccinationId any) error) *MockRepo_DeleteVaccination_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteVaccine provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteVaccine(vaccineId any) error {
+	ret := _mock.Called(vaccineId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVaccine")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
+		r0 = returnFunc(vaccineId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteVaccine_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteVaccine'
+type MockRepo_DeleteVaccine_Call struct {
+	*mock.Call
+}
+
+// DeleteVaccine is a helper method to define mock.On call
+//   - vaccineId any
+func (_e *MockRepo_Expecter) DeleteVaccine(vaccineId interface{}) *MockRepo_DeleteVaccine_Call {
+	return &MockRepo_DeleteVaccine_Call{Call: _e.mock.On("DeleteVaccine", vaccineId)}
+}
+
+func (_c *MockRepo_DeleteVaccine_Call) Run(run func(vaccineId any)) *MockRepo_DeleteVaccine_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteVaccine_Call) Return(err error) *MockRepo_DeleteVaccine_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteVaccine_Call) RunAndReturn(run func(vaccineId any) error) *MockRepo_DeleteVaccine_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteVaccineRule provides a mock function for the type MockRepo
+func (_mock *MockRepo) DeleteVaccineRule(ruleId any) error {
+	ret := _mock.Called(ruleId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVaccineRule")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(any) error); ok {
+		r0 = returnFunc(ruleId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_DeleteVaccineRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteVaccineRule'
+type MockRepo_DeleteVaccineRule_Call struct {
+	*mock.Call
+}
+
+// DeleteVaccineRule is a helper method to define mock.On call
+//   - ruleId any
+func (_e *MockRepo_Expecter) DeleteVaccineRule(ruleId interface{}) *MockRepo_DeleteVaccineRule_Call {
+	return &MockRepo_DeleteVaccineRule_Call{Call: _e.mock.On("DeleteVaccineRule", ruleId)}
+}
+
+func (_c *MockRepo_DeleteVaccineRule_Call) Run(run func(ruleId any)) *MockRepo_DeleteVaccineRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_DeleteVaccineRule_Call) Return(err error) *MockRepo_DeleteVaccineRule_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_DeleteVaccineRule_Call) RunAndReturn(run func(ruleId any) error) *MockRepo_DeleteVaccineRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllActivityLog provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllActivityLog(limit int, offset int, criteria ...Criteria) ([]model.ActivityLog, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(limit, offset, criteria)
+	} else {
+		tmpRet = _mock.Called(limit, offset)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllActivityLog")
+	}
+
+	var r0 []model.ActivityLog
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) ([]model.ActivityLog, error)); ok {
+		return returnFunc(limit, offset, criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) []model.ActivityLog); ok {
+		r0 = returnFunc(limit, offset, criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ActivityLog)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, ...Criteria) error); ok {
+		r1 = returnFunc(limit, offset, criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllActivityLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllActivityLog'
+type MockRepo_GetAllActivityLog_Call struct {
+	*mock.Call
+}
+
+// GetAllActivityLog is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllActivityLog(limit interface{}, offset interface{}, criteria ...interface{}) *MockRepo_GetAllActivityLog_Call {
+	return &MockRepo_GetAllActivityLog_Call{Call: _e.mock.On("GetAllActivityLog",
+		append([]interface{}{limit, offset}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllActivityLog_Call) Run(run func(limit int, offset int, criteria ...Criteria)) *MockRepo_GetAllActivityLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 2 {
+			variadicArgs = args[2].([]Criteria)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllActivityLog_Call) Return(activityLogs []model.ActivityLog, err error) *MockRepo_GetAllActivityLog_Call {
+	_c.Call.Return(activityLogs, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllActivityLog_Call) RunAndReturn(run func(limit int, offset int, criteria ...Criteria) ([]model.ActivityLog, error)) *MockRepo_GetAllActivityLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllAnswerSnippet provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllAnswerSnippet(limit int, offset int, search string, criteria ...Criteria) ([]model.AnswerSnippet, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(limit, offset, search, criteria)
+	} else {
+		tmpRet = _mock.Called(limit, offset, search)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllAnswerSnippet")
+	}
+
+	var r0 []model.AnswerSnippet
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, string, ...Criteria) ([]model.AnswerSnippet, error)); ok {
+		return returnFunc(limit, offset, search, criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, string, ...Criteria) []model.AnswerSnippet); ok {
+		r0 = returnFunc(limit, offset, search, criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.AnswerSnippet)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, string, ...Criteria) error); ok {
+		r1 = returnFunc(limit, offset, search, criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllAnswerSnippet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllAnswerSnippet'
+type MockRepo_GetAllAnswerSnippet_Call struct {
+	*mock.Call
+}
+
+// GetAllAnswerSnippet is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+//   - search string
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllAnswerSnippet(limit interface{}, offset interface{}, search interface{}, criteria ...interface{}) *MockRepo_GetAllAnswerSnippet_Call {
+	return &MockRepo_GetAllAnswerSnippet_Call{Call: _e.mock.On("GetAllAnswerSnippet",
+		append([]interface{}{limit, offset, search}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllAnswerSnippet_Call) Run(run func(limit int, offset int, search string, criteria ...Criteria)) *MockRepo_GetAllAnswerSnippet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 3 {
+			variadicArgs = args[3].([]Criteria)
+		}
+		arg3 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllAnswerSnippet_Call) Return(answerSnippets []model.AnswerSnippet, err error) *MockRepo_GetAllAnswerSnippet_Call {
+	_c.Call.Return(answerSnippets, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllAnswerSnippet_Call) RunAndReturn(run func(limit int, offset int, search string, criteria ...Criteria) ([]model.AnswerSnippet, error)) *MockRepo_GetAllAnswerSnippet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllAppointment provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllAppointment(limit int, offset int, criteria ...Criteria) ([]model.SafeAppointment, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(limit, offset, criteria)
+	} else {
+		tmpRet = _mock.Called(limit, offset)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllAppointment")
+	}
+
+	var r0 []model.SafeAppointment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) ([]model.SafeAppointment, error)); ok {
+		return returnFunc(limit, offset, criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) []model.SafeAppointment); ok {
+		r0 = returnFunc(limit, offset, criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SafeAppointment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, ...Criteria) error); ok {
+		r1 = returnFunc(limit, offset, criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllAppointment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllAppointment'
+type MockRepo_GetAllAppointment_Call struct {
+	*mock.Call
+}
+
+// GetAllAppointment is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllAppointment(limit interface{}, offset interface{}, criteria ...interface{}) *MockRepo_GetAllAppointment_Call {
+	return &MockRepo_GetAllAppointment_Call{Call: _e.mock.On("GetAllAppointment",
+		append([]interface{}{limit, offset}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllAppointment_Call) Run(run func(limit int, offset int, criteria ...Criteria)) *MockRepo_GetAllAppointment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 2 {
+			variadicArgs = args[2].([]Criteria)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllAppointment_Call) Return(safeAppointments []model.SafeAppointment, err error) *MockRepo_GetAllAppointment_Call {
+	_c.Call.Return(safeAppointments, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllAppointment_Call) RunAndReturn(run func(limit int, offset int, criteria ...Criteria) ([]model.SafeAppointment, error)) *MockRepo_GetAllAppointment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllCampaign provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllCampaign(limit int, offset int, criteria ...Criteria) ([]model.Campaign, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(limit, offset, criteria)
+	} else {
+		tmpRet = _mock.Called(limit, offset)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllCampaign")
+	}
+
+	var r0 []model.Campaign
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) ([]model.Campaign, error)); ok {
+		return returnFunc(limit, offset, criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) []model.Campaign); ok {
+		r0 = returnFunc(limit, offset, criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Campaign)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, ...Criteria) error); ok {
+		r1 = returnFunc(limit, offset, criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllCampaign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllCampaign'
+type MockRepo_GetAllCampaign_Call struct {
+	*mock.Call
+}
+
+// GetAllCampaign is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllCampaign(limit interface{}, offset interface{}, criteria ...interface{}) *MockRepo_GetAllCampaign_Call {
+	return &MockRepo_GetAllCampaign_Call{Call: _e.mock.On("GetAllCampaign",
+		append([]interface{}{limit, offset}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllCampaign_Call) Run(run func(limit int, offset int, criteria ...Criteria)) *MockRepo_GetAllCampaign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 2 {
+			variadicArgs = args[2].([]Criteria)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllCampaign_Call) Return(campaigns []model.Campaign, err error) *MockRepo_GetAllCampaign_Call {
+	_c.Call.Return(campaigns, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllCampaign_Call) RunAndReturn(run func(limit int, offset int, criteria ...Criteria) ([]model.Campaign, error)) *MockRepo_GetAllCampaign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllCareTeamMember provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllCareTeamMember(patientId int) ([]model.SafeCareTeamMember, error) {
+	ret := _mock.Called(patientId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllCareTeamMember")
+	}
+
+	var r0 []model.SafeCareTeamMember
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) ([]model.SafeCareTeamMember, error)); ok {
+		return returnFunc(patientId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) []model.SafeCareTeamMember); ok {
+		r0 = returnFunc(patientId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SafeCareTeamMember)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(patientId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllCareTeamMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllCareTeamMember'
+type MockRepo_GetAllCareTeamMember_Call struct {
+	*mock.Call
+}
+
+// GetAllCareTeamMember is a helper method to define mock.On call
+//   - patientId int
+func (_e *MockRepo_Expecter) GetAllCareTeamMember(patientId interface{}) *MockRepo_GetAllCareTeamMember_Call {
+	return &MockRepo_GetAllCareTeamMember_Call{Call: _e.mock.On("GetAllCareTeamMember", patientId)}
+}
+
+func (_c *MockRepo_GetAllCareTeamMember_Call) Run(run func(patientId int)) *MockRepo_GetAllCareTeamMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllCareTeamMember_Call) Return(safeCareTeamMembers []model.SafeCareTeamMember, err error) *MockRepo_GetAllCareTeamMember_Call {
+	_c.Call.Return(safeCareTeamMembers, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllCareTeamMember_Call) RunAndReturn(run func(patientId int) ([]model.SafeCareTeamMember, error)) *MockRepo_GetAllCareTeamMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllCaregiverLink provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllCaregiverLink(patientId int) ([]model.SafeCaregiverLink, error) {
+	ret := _mock.Called(patientId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllCaregiverLink")
+	}
+
+	var r0 []model.SafeCaregiverLink
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) ([]model.SafeCaregiverLink, error)); ok {
+		return returnFunc(patientId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) []model.SafeCaregiverLink); ok {
+		r0 = returnFunc(patientId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SafeCaregiverLink)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(patientId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllCaregiverLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllCaregiverLink'
+type MockRepo_GetAllCaregiverLink_Call struct {
+	*mock.Call
+}
+
+// GetAllCaregiverLink is a helper method to define mock.On call
+//   - patientId int
+func (_e *MockRepo_Expecter) GetAllCaregiverLink(patientId interface{}) *MockRepo_GetAllCaregiverLink_Call {
+	return &MockRepo_GetAllCaregiverLink_Call{Call: _e.mock.On("GetAllCaregiverLink", patientId)}
+}
+
+func (_c *MockRepo_GetAllCaregiverLink_Call) Run(run func(patientId int)) *MockRepo_GetAllCaregiverLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllCaregiverLink_Call) Return(safeCaregiverLinks []model.SafeCaregiverLink, err error) *MockRepo_GetAllCaregiverLink_Call {
+	_c.Call.Return(safeCaregiverLinks, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllCaregiverLink_Call) RunAndReturn(run func(patientId int) ([]model.SafeCaregiverLink, error)) *MockRepo_GetAllCaregiverLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllCaregiverPatient provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllCaregiverPatient(caregiverId int) ([]model.CaregiverPatient, error) {
+	ret := _mock.Called(caregiverId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllCaregiverPatient")
+	}
+
+	var r0 []model.CaregiverPatient
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) ([]model.CaregiverPatient, error)); ok {
+		return returnFunc(caregiverId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) []model.CaregiverPatient); ok {
+		r0 = returnFunc(caregiverId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CaregiverPatient)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(caregiverId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllCaregiverPatient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllCaregiverPatient'
+type MockRepo_GetAllCaregiverPatient_Call struct {
+	*mock.Call
+}
+
+// GetAllCaregiverPatient is a helper method to define mock.On call
+//   - caregiverId int
+func (_e *MockRepo_Expecter) GetAllCaregiverPatient(caregiverId interface{}) *MockRepo_GetAllCaregiverPatient_Call {
+	return &MockRepo_GetAllCaregiverPatient_Call{Call: _e.mock.On("GetAllCaregiverPatient", caregiverId)}
+}
+
+func (_c *MockRepo_GetAllCaregiverPatient_Call) Run(run func(caregiverId int)) *MockRepo_GetAllCaregiverPatient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllCaregiverPatient_Call) Return(caregiverPatients []model.CaregiverPatient, err error) *MockRepo_GetAllCaregiverPatient_Call {
+	_c.Call.Return(caregiverPatients, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllCaregiverPatient_Call) RunAndReturn(run func(caregiverId int) ([]model.CaregiverPatient, error)) *MockRepo_GetAllCaregiverPatient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllContent provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllContent(limit int, offset int, criteria ...Criteria) ([]model.Content, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(limit, offset, criteria)
+	} else {
+		tmpRet = _mock.Called(limit, offset)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllContent")
+	}
+
+	var r0 []model.Content
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) ([]model.Content, error)); ok {
+		return returnFunc(limit, offset, criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) []model.Content); ok {
+		r0 = returnFunc(limit, offset, criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Content)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, ...Criteria) error); ok {
+		r1 = returnFunc(limit, offset, criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllContent'
+type MockRepo_GetAllContent_Call struct {
+	*mock.Call
+}
+
+// GetAllContent is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllContent(limit interface{}, offset interface{}, criteria ...interface{}) *MockRepo_GetAllContent_Call {
+	return &MockRepo_GetAllContent_Call{Call: _e.mock.On("GetAllContent",
+		append([]interface{}{limit, offset}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllContent_Call) Run(run func(limit int, offset int, criteria ...Criteria)) *MockRepo_GetAllContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 2 {
+			variadicArgs = args[2].([]Criteria)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllContent_Call) Return(contents []model.Content, err error) *MockRepo_GetAllContent_Call {
+	_c.Call.Return(contents, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllContent_Call) RunAndReturn(run func(limit int, offset int, criteria ...Criteria) ([]model.Content, error)) *MockRepo_GetAllContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllDevice provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllDevice(criteria ...Criteria) ([]model.Device, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(criteria)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllDevice")
+	}
+
+	var r0 []model.Device
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) ([]model.Device, error)); ok {
+		return returnFunc(criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) []model.Device); ok {
+		r0 = returnFunc(criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Device)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(...Criteria) error); ok {
+		r1 = returnFunc(criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllDevice'
+type MockRepo_GetAllDevice_Call struct {
+	*mock.Call
+}
+
+// GetAllDevice is a helper method to define mock.On call
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllDevice(criteria ...interface{}) *MockRepo_GetAllDevice_Call {
+	return &MockRepo_GetAllDevice_Call{Call: _e.mock.On("GetAllDevice",
+		append([]interface{}{}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllDevice_Call) Run(run func(criteria ...Criteria)) *MockRepo_GetAllDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 0 {
+			variadicArgs = args[0].([]Criteria)
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllDevice_Call) Return(devices []model.Device, err error) *MockRepo_GetAllDevice_Call {
+	_c.Call.Return(devices, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllDevice_Call) RunAndReturn(run func(criteria ...Criteria) ([]model.Device, error)) *MockRepo_GetAllDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllDeviceRemoval provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllDeviceRemoval(criteria ...Criteria) ([]model.DeviceRemoval, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(criteria)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllDeviceRemoval")
+	}
+
+	var r0 []model.DeviceRemoval
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) ([]model.DeviceRemoval, error)); ok {
+		return returnFunc(criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) []model.DeviceRemoval); ok {
+		r0 = returnFunc(criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DeviceRemoval)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(...Criteria) error); ok {
+		r1 = returnFunc(criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllDeviceRemoval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllDeviceRemoval'
+type MockRepo_GetAllDeviceRemoval_Call struct {
+	*mock.Call
+}
+
+// GetAllDeviceRemoval is a helper method to define mock.On call
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllDeviceRemoval(criteria ...interface{}) *MockRepo_GetAllDeviceRemoval_Call {
+	return &MockRepo_GetAllDeviceRemoval_Call{Call: _e.mock.On("GetAllDeviceRemoval",
+		append([]interface{}{}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllDeviceRemoval_Call) Run(run func(criteria ...Criteria)) *MockRepo_GetAllDeviceRemoval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 0 {
+			variadicArgs = args[0].([]Criteria)
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllDeviceRemoval_Call) Return(deviceRemovals []model.DeviceRemoval, err error) *MockRepo_GetAllDeviceRemoval_Call {
+	_c.Call.Return(deviceRemovals, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllDeviceRemoval_Call) RunAndReturn(run func(criteria ...Criteria) ([]model.DeviceRemoval, error)) *MockRepo_GetAllDeviceRemoval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllDoctor provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllDoctor(limit int, offset int, criteria ...Criteria) ([]model.TrimDoctor, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(limit, offset, criteria)
+	} else {
+		tmpRet = _mock.Called(limit, offset)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllDoctor")
+	}
+
+	var r0 []model.TrimDoctor
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) ([]model.TrimDoctor, error)); ok {
+		return returnFunc(limit, offset, criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) []model.TrimDoctor); ok {
+		r0 = returnFunc(limit, offset, criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TrimDoctor)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, ...Criteria) error); ok {
+		r1 = returnFunc(limit, offset, criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllDoctor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllDoctor'
+type MockRepo_GetAllDoctor_Call struct {
+	*mock.Call
+}
+
+// GetAllDoctor is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllDoctor(limit interface{}, offset interface{}, criteria ...interface{}) *MockRepo_GetAllDoctor_Call {
+	return &MockRepo_GetAllDoctor_Call{Call: _e.mock.On("GetAllDoctor",
+		append([]interface{}{limit, offset}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllDoctor_Call) Run(run func(limit int, offset int, criteria ...Criteria)) *MockRepo_GetAllDoctor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 2 {
+			variadicArgs = args[2].([]Criteria)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllDoctor_Call) Return(trimDoctors []model.TrimDoctor, err error) *MockRepo_GetAllDoctor_Call {
+	_c.Call.Return(trimDoctors, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllDoctor_Call) RunAndReturn(run func(limit int, offset int, criteria ...Criteria) ([]model.TrimDoctor, error)) *MockRepo_GetAllDoctor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllDoctorNotification provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllDoctorNotification(limit int, offset int, criteria ...Criteria) ([]model.DoctorNotification, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(limit, offset, criteria)
+	} else {
+		tmpRet = _mock.Called(limit, offset)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllDoctorNotification")
+	}
+
+	var r0 []model.DoctorNotification
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) ([]model.DoctorNotification, error)); ok {
+		return returnFunc(limit, offset, criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) []model.DoctorNotification); ok {
+		r0 = returnFunc(limit, offset, criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DoctorNotification)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, ...Criteria) error); ok {
+		r1 = returnFunc(limit, offset, criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllDoctorNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllDoctorNotification'
+type MockRepo_GetAllDoctorNotification_Call struct {
+	*mock.Call
+}
+
+// GetAllDoctorNotification is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllDoctorNotification(limit interface{}, offset interface{}, criteria ...interface{}) *MockRepo_GetAllDoctorNotification_Call {
+	return &MockRepo_GetAllDoctorNotification_Call{Call: _e.mock.On("GetAllDoctorNotification",
+		append([]interface{}{limit, offset}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllDoctorNotification_Call) Run(run func(limit int, offset int, criteria ...Criteria)) *MockRepo_GetAllDoctorNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 2 {
+			variadicArgs = args[2].([]Criteria)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllDoctorNotification_Call) Return(doctorNotifications []model.DoctorNotification, err error) *MockRepo_GetAllDoctorNotification_Call {
+	_c.Call.Return(doctorNotifications, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllDoctorNotification_Call) RunAndReturn(run func(limit int, offset int, criteria ...Criteria) ([]model.DoctorNotification, error)) *MockRepo_GetAllDoctorNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllInvitationCode provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllInvitationCode(limit int, offset int, criteria ...Criteria) ([]model.InvitationCode, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(limit, offset, criteria)
+	} else {
+		tmpRet = _mock.Called(limit, offset)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllInvitationCode")
+	}
+
+	var r0 []model.InvitationCode
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) ([]model.InvitationCode, error)); ok {
+		return returnFunc(limit, offset, criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) []model.InvitationCode); ok {
+		r0 = returnFunc(limit, offset, criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.InvitationCode)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, ...Criteria) error); ok {
+		r1 = returnFunc(limit, offset, criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllInvitationCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllInvitationCode'
+type MockRepo_GetAllInvitationCode_Call struct {
+	*mock.Call
+}
+
+// GetAllInvitationCode is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllInvitationCode(limit interface{}, offset interface{}, criteria ...interface{}) *MockRepo_GetAllInvitationCode_Call {
+	return &MockRepo_GetAllInvitationCode_Call{Call: _e.mock.On("GetAllInvitationCode",
+		append([]interface{}{limit, offset}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllInvitationCode_Call) Run(run func(limit int, offset int, criteria ...Criteria)) *MockRepo_GetAllInvitationCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 2 {
+			variadicArgs = args[2].([]Criteria)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllInvitationCode_Call) Return(invitationCodes []model.InvitationCode, err error) *MockRepo_GetAllInvitationCode_Call {
+	_c.Call.Return(invitationCodes, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllInvitationCode_Call) RunAndReturn(run func(limit int, offset int, criteria ...Criteria) ([]model.InvitationCode, error)) *MockRepo_GetAllInvitationCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllMeasurement provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllMeasurement(patientId int, criteria ...Criteria) ([]model.Measurement, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(patientId, criteria)
+	} else {
+		tmpRet = _mock.Called(patientId)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllMeasurement")
+	}
+
+	var r0 []model.Measurement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, ...Criteria) ([]model.Measurement, error)); ok {
+		return returnFunc(patientId, criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, ...Criteria) []model.Measurement); ok {
+		r0 = returnFunc(patientId, criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Measurement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, ...Criteria) error); ok {
+		r1 = returnFunc(patientId, criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllMeasurement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllMeasurement'
+type MockRepo_GetAllMeasurement_Call struct {
+	*mock.Call
+}
+
+// GetAllMeasurement is a helper method to define mock.On call
+//   - patientId int
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllMeasurement(patientId interface{}, criteria ...interface{}) *MockRepo_GetAllMeasurement_Call {
+	return &MockRepo_GetAllMeasurement_Call{Call: _e.mock.On("GetAllMeasurement",
+		append([]interface{}{patientId}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllMeasurement_Call) Run(run func(patientId int, criteria ...Criteria)) *MockRepo_GetAllMeasurement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 1 {
+			variadicArgs = args[1].([]Criteria)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllMeasurement_Call) Return(measurements []model.Measurement, err error) *MockRepo_GetAllMeasurement_Call {
+	_c.Call.Return(measurements, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllMeasurement_Call) RunAndReturn(run func(patientId int, criteria ...Criteria) ([]model.Measurement, error)) *MockRepo_GetAllMeasurement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllMedicationDose provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllMedicationDose(patientId int, from int, to int) ([]model.MedicationDose, error) {
+	ret := _mock.Called(patientId, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllMedicationDose")
+	}
+
+	var r0 []model.MedicationDose
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, int) ([]model.MedicationDose, error)); ok {
+		return returnFunc(patientId, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, int) []model.MedicationDose); ok {
+		r0 = returnFunc(patientId, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.MedicationDose)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, int) error); ok {
+		r1 = returnFunc(patientId, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllMedicationDose_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllMedicationDose'
+type MockRepo_GetAllMedicationDose_Call struct {
+	*mock.Call
+}
+
+// GetAllMedicationDose is a helper method to define mock.On call
+//   - patientId int
+//   - from int
+//   - to int
+func (_e *MockRepo_Expecter) GetAllMedicationDose(patientId interface{}, from interface{}, to interface{}) *MockRepo_GetAllMedicationDose_Call {
+	return &MockRepo_GetAllMedicationDose_Call{Call: _e.mock.On("GetAllMedicationDose", patientId, from, to)}
+}
+
+func (_c *MockRepo_GetAllMedicationDose_Call) Run(run func(patientId int, from int, to int)) *MockRepo_GetAllMedicationDose_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllMedicationDose_Call) Return(medicationDoses []model.MedicationDose, err error) *MockRepo_GetAllMedicationDose_Call {
+	_c.Call.Return(medicationDoses, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllMedicationDose_Call) RunAndReturn(run func(patientId int, from int, to int) ([]model.MedicationDose, error)) *MockRepo_GetAllMedicationDose_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllMedicationRegimen provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllMedicationRegimen(patientId int, criteria ...Criteria) ([]model.MedicationRegimen, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(patientId, criteria)
+	} else {
+		tmpRet = _mock.Called(patientId)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllMedicationRegimen")
+	}
+
+	var r0 []model.MedicationRegimen
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, ...Criteria) ([]model.MedicationRegimen, error)); ok {
+		return returnFunc(patientId, criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, ...Criteria) []model.MedicationRegimen); ok {
+		r0 = returnFunc(patientId, criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.MedicationRegimen)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, ...Criteria) error); ok {
+		r1 = returnFunc(patientId, criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllMedicationRegimen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllMedicationRegimen'
+type MockRepo_GetAllMedicationRegimen_Call struct {
+	*mock.Call
+}
+
+// GetAllMedicationRegimen is a helper method to define mock.On call
+//   - patientId int
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllMedicationRegimen(patientId interface{}, criteria ...interface{}) *MockRepo_GetAllMedicationRegimen_Call {
+	return &MockRepo_GetAllMedicationRegimen_Call{Call: _e.mock.On("GetAllMedicationRegimen",
+		append([]interface{}{patientId}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllMedicationRegimen_Call) Run(run func(patientId int, criteria ...Criteria)) *MockRepo_GetAllMedicationRegimen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 1 {
+			variadicArgs = args[1].([]Criteria)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllMedicationRegimen_Call) Return(medicationRegimens []model.MedicationRegimen, err error) *MockRepo_GetAllMedicationRegimen_Call {
+	_c.Call.Return(medicationRegimens, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllMedicationRegimen_Call) RunAndReturn(run func(patientId int, criteria ...Criteria) ([]model.MedicationRegimen, error)) *MockRepo_GetAllMedicationRegimen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllNotification provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllNotification(limit int, offset int, criteria ...Criteria) ([]model.Notification, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(limit, offset, criteria)
+	} else {
+		tmpRet = _mock.Called(limit, offset)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllNotification")
+	}
+
+	var r0 []model.Notification
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) ([]model.Notification, error)); ok {
+		return returnFunc(limit, offset, criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) []model.Notification); ok {
+		r0 = returnFunc(limit, offset, criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Notification)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, ...Criteria) error); ok {
+		r1 = returnFunc(limit, offset, criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllNotification'
+type MockRepo_GetAllNotification_Call struct {
+	*mock.Call
+}
+
+// GetAllNotification is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllNotification(limit interface{}, offset interface{}, criteria ...interface{}) *MockRepo_GetAllNotification_Call {
+	return &MockRepo_GetAllNotification_Call{Call: _e.mock.On("GetAllNotification",
+		append([]interface{}{limit, offset}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllNotification_Call) Run(run func(limit int, offset int, criteria ...Criteria)) *MockRepo_GetAllNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 2 {
+			variadicArgs = args[2].([]Criteria)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllNotification_Call) Return(notifications []model.Notification, err error) *MockRepo_GetAllNotification_Call {
+	_c.Call.Return(notifications, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllNotification_Call) RunAndReturn(run func(limit int, offset int, criteria ...Criteria) ([]model.Notification, error)) *MockRepo_GetAllNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllNotificationPreference provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllNotificationPreference(criteria ...Criteria) ([]model.NotificationPreference, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(criteria)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllNotificationPreference")
+	}
+
+	var r0 []model.NotificationPreference
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) ([]model.NotificationPreference, error)); ok {
+		return returnFunc(criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) []model.NotificationPreference); ok {
+		r0 = returnFunc(criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.NotificationPreference)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(...Criteria) error); ok {
+		r1 = returnFunc(criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllNotificationPreference_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllNotificationPreference'
+type MockRepo_GetAllNotificationPreference_Call struct {
+	*mock.Call
+}
+
+// GetAllNotificationPreference is a helper method to define mock.On call
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllNotificationPreference(criteria ...interface{}) *MockRepo_GetAllNotificationPreference_Call {
+	return &MockRepo_GetAllNotificationPreference_Call{Call: _e.mock.On("GetAllNotificationPreference",
+		append([]interface{}{}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllNotificationPreference_Call) Run(run func(criteria ...Criteria)) *MockRepo_GetAllNotificationPreference_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 0 {
+			variadicArgs = args[0].([]Criteria)
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllNotificationPreference_Call) Return(notificationPreferences []model.NotificationPreference, err error) *MockRepo_GetAllNotificationPreference_Call {
+	_c.Call.Return(notificationPreferences, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllNotificationPreference_Call) RunAndReturn(run func(criteria ...Criteria) ([]model.NotificationPreference, error)) *MockRepo_GetAllNotificationPreference_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllNotificationTemplate provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllNotificationTemplate() ([]model.NotificationTemplate, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllNotificationTemplate")
+	}
+
+	var r0 []model.NotificationTemplate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]model.NotificationTemplate, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []model.NotificationTemplate); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.NotificationTemplate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllNotificationTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllNotificationTemplate'
+type MockRepo_GetAllNotificationTemplate_Call struct {
+	*mock.Call
+}
+
+// GetAllNotificationTemplate is a helper method to define mock.On call
+func (_e *MockRepo_Expecter) GetAllNotificationTemplate() *MockRepo_GetAllNotificationTemplate_Call {
+	return &MockRepo_GetAllNotificationTemplate_Call{Call: _e.mock.On("GetAllNotificationTemplate")}
+}
+
+func (_c *MockRepo_GetAllNotificationTemplate_Call) Run(run func()) *MockRepo_GetAllNotificationTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllNotificationTemplate_Call) Return(notificationTemplates []model.NotificationTemplate, err error) *MockRepo_GetAllNotificationTemplate_Call {
+	_c.Call.Return(notificationTemplates, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllNotificationTemplate_Call) RunAndReturn(run func() ([]model.NotificationTemplate, error)) *MockRepo_GetAllNotificationTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllOutboxMessage provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllOutboxMessage(limit int, criteria ...Criteria) ([]model.NotificationOutbox, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(limit, criteria)
+	} else {
+		tmpRet = _mock.Called(limit)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllOutboxMessage")
+	}
+
+	var r0 []model.NotificationOutbox
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, ...Criteria) ([]model.NotificationOutbox, error)); ok {
+		return returnFunc(limit, criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, ...Criteria) []model.NotificationOutbox); ok {
+		r0 = returnFunc(limit, criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.NotificationOutbox)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, ...Criteria) error); ok {
+		r1 = returnFunc(limit, criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllOutboxMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllOutboxMessage'
+type MockRepo_GetAllOutboxMessage_Call struct {
+	*mock.Call
+}
+
+// GetAllOutboxMessage is a helper method to define mock.On call
+//   - limit int
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllOutboxMessage(limit interface{}, criteria ...interface{}) *MockRepo_GetAllOutboxMessage_Call {
+	return &MockRepo_GetAllOutboxMessage_Call{Call: _e.mock.On("GetAllOutboxMessage",
+		append([]interface{}{limit}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllOutboxMessage_Call) Run(run func(limit int, criteria ...Criteria)) *MockRepo_GetAllOutboxMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 1 {
+			variadicArgs = args[1].([]Criteria)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllOutboxMessage_Call) Return(notificationOutboxes []model.NotificationOutbox, err error) *MockRepo_GetAllOutboxMessage_Call {
+	_c.Call.Return(notificationOutboxes, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllOutboxMessage_Call) RunAndReturn(run func(limit int, criteria ...Criteria) ([]model.NotificationOutbox, error)) *MockRepo_GetAllOutboxMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllPatient provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllPatient(limit int, offset int, criteria ...Criteria) ([]model.Patient, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(limit, offset, criteria)
+	} else {
+		tmpRet = _mock.Called(limit, offset)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllPatient")
+	}
+
+	var r0 []model.Patient
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) ([]model.Patient, error)); ok {
+		return returnFunc(limit, offset, criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) []model.Patient); ok {
+		r0 = returnFunc(limit, offset, criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Patient)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, ...Criteria) error); ok {
+		r1 = returnFunc(limit, offset, criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllPatient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllPatient'
+type MockRepo_GetAllPatient_Call struct {
+	*mock.Call
+}
+
+// GetAllPatient is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllPatient(limit interface{}, offset interface{}, criteria ...interface{}) *MockRepo_GetAllPatient_Call {
+	return &MockRepo_GetAllPatient_Call{Call: _e.mock.On("GetAllPatient",
+		append([]interface{}{limit, offset}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllPatient_Call) Run(run func(limit int, offset int, criteria ...Criteria)) *MockRepo_GetAllPatient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 2 {
+			variadicArgs = args[2].([]Criteria)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllPatient_Call) Return(patients []model.Patient, err error) *MockRepo_GetAllPatient_Call {
+	_c.Call.Return(patients, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllPatient_Call) RunAndReturn(run func(limit int, offset int, criteria ...Criteria) ([]model.Patient, error)) *MockRepo_GetAllPatient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllQuestion provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllQuestion(limit int, offset int, criteria ...Criteria) ([]model.QuestionTopic, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(limit, offset, criteria)
+	} else {
+		tmpRet = _mock.Called(limit, offset)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllQuestion")
+	}
+
+	var r0 []model.QuestionTopic
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) ([]model.QuestionTopic, error)); ok {
+		return returnFunc(limit, offset, criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, ...Criteria) []model.QuestionTopic); ok {
+		r0 = returnFunc(limit, offset, criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.QuestionTopic)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, ...Criteria) error); ok {
+		r1 = returnFunc(limit, offset, criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllQuestion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllQuestion'
+type MockRepo_GetAllQuestion_Call struct {
+	*mock.Call
+}
+
+// GetAllQuestion is a helper method to define mock.On call
+//   - limit int
+//   - offset int
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllQuestion(limit interface{}, offset interface{}, criteria ...interface{}) *MockRepo_GetAllQuestion_Call {
+	return &MockRepo_GetAllQuestion_Call{Call: _e.mock.On("GetAllQuestion",
+		append([]interface{}{limit, offset}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllQuestion_Call) Run(run func(limit int, offset int, criteria ...Criteria)) *MockRepo_GetAllQuestion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 2 {
+			variadicArgs = args[2].([]Criteria)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllQuestion_Call) Return(questionTopics []model.QuestionTopic, err error) *MockRepo_GetAllQuestion_Call {
+	_c.Call.Return(questionTopics, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllQuestion_Call) RunAndReturn(run func(limit int, offset int, criteria ...Criteria) ([]model.QuestionTopic, error)) *MockRepo_GetAllQuestion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllReminderLog provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllReminderLog(criteria ...Criteria) ([]model.ReminderLog, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(criteria)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllReminderLog")
+	}
+
+	var r0 []model.ReminderLog
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) ([]model.ReminderLog, error)); ok {
+		return returnFunc(criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) []model.ReminderLog); ok {
+		r0 = returnFunc(criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ReminderLog)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(...Criteria) error); ok {
+		r1 = returnFunc(criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllReminderLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllReminderLog'
+type MockRepo_GetAllReminderLog_Call struct {
+	*mock.Call
+}
+
+// GetAllReminderLog is a helper method to define mock.On call
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllReminderLog(criteria ...interface{}) *MockRepo_GetAllReminderLog_Call {
+	return &MockRepo_GetAllReminderLog_Call{Call: _e.mock.On("GetAllReminderLog",
+		append([]interface{}{}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllReminderLog_Call) Run(run func(criteria ...Criteria)) *MockRepo_GetAllReminderLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 0 {
+			variadicArgs = args[0].([]Criteria)
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllReminderLog_Call) Return(reminderLogs []model.ReminderLog, err error) *MockRepo_GetAllReminderLog_Call {
+	_c.Call.Return(reminderLogs, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllReminderLog_Call) RunAndReturn(run func(criteria ...Criteria) ([]model.ReminderLog, error)) *MockRepo_GetAllReminderLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllReminderRule provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllReminderRule(criteria ...Criteria) ([]model.ReminderRule, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(criteria)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllReminderRule")
+	}
+
+	var r0 []model.ReminderRule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) ([]model.ReminderRule, error)); ok {
+		return returnFunc(criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) []model.ReminderRule); ok {
+		r0 = returnFunc(criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ReminderRule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(...Criteria) error); ok {
+		r1 = returnFunc(criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllReminderRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllReminderRule'
+type MockRepo_GetAllReminderRule_Call struct {
+	*mock.Call
+}
+
+// GetAllReminderRule is a helper method to define mock.On call
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllReminderRule(criteria ...interface{}) *MockRepo_GetAllReminderRule_Call {
+	return &MockRepo_GetAllReminderRule_Call{Call: _e.mock.On("GetAllReminderRule",
+		append([]interface{}{}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllReminderRule_Call) Run(run func(criteria ...Criteria)) *MockRepo_GetAllReminderRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 0 {
+			variadicArgs = args[0].([]Criteria)
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllReminderRule_Call) Return(reminderRules []model.ReminderRule, err error) *MockRepo_GetAllReminderRule_Call {
+	_c.Call.Return(reminderRules, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllReminderRule_Call) RunAndReturn(run func(criteria ...Criteria) ([]model.ReminderRule, error)) *MockRepo_GetAllReminderRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllScheduleException provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllScheduleException(criteria ...Criteria) ([]model.ScheduleException, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(criteria)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllScheduleException")
+	}
+
+	var r0 []model.ScheduleException
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) ([]model.ScheduleException, error)); ok {
+		return returnFunc(criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) []model.ScheduleException); ok {
+		r0 = returnFunc(criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ScheduleException)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(...Criteria) error); ok {
+		r1 = returnFunc(criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllScheduleException_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllScheduleException'
+type MockRepo_GetAllScheduleException_Call struct {
+	*mock.Call
+}
+
+// GetAllScheduleException is a helper method to define mock.On call
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllScheduleException(criteria ...interface{}) *MockRepo_GetAllScheduleException_Call {
+	return &MockRepo_GetAllScheduleException_Call{Call: _e.mock.On("GetAllScheduleException",
+		append([]interface{}{}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllScheduleException_Call) Run(run func(criteria ...Criteria)) *MockRepo_GetAllScheduleException_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 0 {
+			variadicArgs = args[0].([]Criteria)
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllScheduleException_Call) Return(scheduleExceptions []model.ScheduleException, err error) *MockRepo_GetAllScheduleException_Call {
+	_c.Call.Return(scheduleExceptions, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllScheduleException_Call) RunAndReturn(run func(criteria ...Criteria) ([]model.ScheduleException, error)) *MockRepo_GetAllScheduleException_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllVaccination provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllVaccination(patientId int, criteria ...Criteria) ([]model.Vaccination, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(patientId, criteria)
+	} else {
+		tmpRet = _mock.Called(patientId)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllVaccination")
+	}
+
+	var r0 []model.Vaccination
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, ...Criteria) ([]model.Vaccination, error)); ok {
+		return returnFunc(patientId, criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, ...Criteria) []model.Vaccination); ok {
+		r0 = returnFunc(patientId, criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Vaccination)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, ...Criteria) error); ok {
+		r1 = returnFunc(patientId, criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllVaccination_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllVaccination'
+type MockRepo_GetAllVaccination_Call struct {
+	*mock.Call
+}
+
+// GetAllVaccination is a helper method to define mock.On call
+//   - patientId int
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllVaccination(patientId interface{}, criteria ...interface{}) *MockRepo_GetAllVaccination_Call {
+	return &MockRepo_GetAllVaccination_Call{Call: _e.mock.On("GetAllVaccination",
+		append([]interface{}{patientId}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllVaccination_Call) Run(run func(patientId int, criteria ...Criteria)) *MockRepo_GetAllVaccination_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 1 {
+			variadicArgs = args[1].([]Criteria)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllVaccination_Call) Return(vaccinations []model.Vaccination, err error) *MockRepo_GetAllVaccination_Call {
+	_c.Call.Return(vaccinations, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllVaccination_Call) RunAndReturn(run func(patientId int, criteria ...Criteria) ([]model.Vaccination, error)) *MockRepo_GetAllVaccination_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllVaccine provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllVaccine() ([]model.Vaccine, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllVaccine")
+	}
+
+	var r0 []model.Vaccine
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]model.Vaccine, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []model.Vaccine); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Vaccine)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllVaccine_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllVaccine'
+type MockRepo_GetAllVaccine_Call struct {
+	*mock.Call
+}
+
+// GetAllVaccine is a helper method to define mock.On call
+func (_e *MockRepo_Expecter) GetAllVaccine() *MockRepo_GetAllVaccine_Call {
+	return &MockRepo_GetAllVaccine_Call{Call: _e.mock.On("GetAllVaccine")}
+}
+
+func (_c *MockRepo_GetAllVaccine_Call) Run(run func()) *MockRepo_GetAllVaccine_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllVaccine_Call) Return(vaccines []model.Vaccine, err error) *MockRepo_GetAllVaccine_Call {
+	_c.Call.Return(vaccines, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllVaccine_Call) RunAndReturn(run func() ([]model.Vaccine, error)) *MockRepo_GetAllVaccine_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllVaccineRule provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAllVaccineRule(criteria ...Criteria) ([]model.VaccineRule, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(criteria)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllVaccineRule")
+	}
+
+	var r0 []model.VaccineRule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) ([]model.VaccineRule, error)); ok {
+		return returnFunc(criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) []model.VaccineRule); ok {
+		r0 = returnFunc(criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.VaccineRule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(...Criteria) error); ok {
+		r1 = returnFunc(criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAllVaccineRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllVaccineRule'
+type MockRepo_GetAllVaccineRule_Call struct {
+	*mock.Call
+}
+
+// GetAllVaccineRule is a helper method to define mock.On call
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAllVaccineRule(criteria ...interface{}) *MockRepo_GetAllVaccineRule_Call {
+	return &MockRepo_GetAllVaccineRule_Call{Call: _e.mock.On("GetAllVaccineRule",
+		append([]interface{}{}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAllVaccineRule_Call) Run(run func(criteria ...Criteria)) *MockRepo_GetAllVaccineRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 0 {
+			variadicArgs = args[0].([]Criteria)
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAllVaccineRule_Call) Return(vaccineRules []model.VaccineRule, err error) *MockRepo_GetAllVaccineRule_Call {
+	_c.Call.Return(vaccineRules, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAllVaccineRule_Call) RunAndReturn(run func(criteria ...Criteria) ([]model.VaccineRule, error)) *MockRepo_GetAllVaccineRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAnswerSnippet provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAnswerSnippet(snippetId any) (model.AnswerSnippet, error) {
+	ret := _mock.Called(snippetId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAnswerSnippet")
+	}
+
+	var r0 model.AnswerSnippet
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any) (model.AnswerSnippet, error)); ok {
+		return returnFunc(snippetId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any) model.AnswerSnippet); ok {
+		r0 = returnFunc(snippetId)
+	} else {
+		r0 = ret.Get(0).(model.AnswerSnippet)
+	}
+	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
+		r1 = returnFunc(snippetId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAnswerSnippet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAnswerSnippet'
+type MockRepo_GetAnswerSnippet_Call struct {
+	*mock.Call
+}
+
+// GetAnswerSnippet is a helper method to define mock.On call
+//   - snippetId any
+func (_e *MockRepo_Expecter) GetAnswerSnippet(snippetId interface{}) *MockRepo_GetAnswerSnippet_Call {
+	return &MockRepo_GetAnswerSnippet_Call{Call: _e.mock.On("GetAnswerSnippet", snippetId)}
+}
+
+func (_c *MockRepo_GetAnswerSnippet_Call) Run(run func(snippetId any)) *MockRepo_GetAnswerSnippet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAnswerSnippet_Call) Return(answerSnippet model.AnswerSnippet, err error) *MockRepo_GetAnswerSnippet_Call {
+	_c.Call.Return(answerSnippet, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAnswerSnippet_Call) RunAndReturn(run func(snippetId any) (model.AnswerSnippet, error)) *MockRepo_GetAnswerSnippet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAppointment provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAppointment(appointmentId any) (model.SafeAppointment, error) {
+	ret := _mock.Called(appointmentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppointment")
+	}
+
+	var r0 model.SafeAppointment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any) (model.SafeAppointment, error)); ok {
+		return returnFunc(appointmentId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any) model.SafeAppointment); ok {
+		r0 = returnFunc(appointmentId)
+	} else {
+		r0 = ret.Get(0).(model.SafeAppointment)
+	}
+	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
+		r1 = returnFunc(appointmentId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAppointment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAppointment'
+type MockRepo_GetAppointment_Call struct {
+	*mock.Call
+}
+
+// GetAppointment is a helper method to define mock.On call
+//   - appointmentId any
+func (_e *MockRepo_Expecter) GetAppointment(appointmentId interface{}) *MockRepo_GetAppointment_Call {
+	return &MockRepo_GetAppointment_Call{Call: _e.mock.On("GetAppointment", appointmentId)}
+}
+
+func (_c *MockRepo_GetAppointment_Call) Run(run func(appointmentId any)) *MockRepo_GetAppointment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAppointment_Call) Return(safeAppointment model.SafeAppointment, err error) *MockRepo_GetAppointment_Call {
+	_c.Call.Return(safeAppointment, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAppointment_Call) RunAndReturn(run func(appointmentId any) (model.SafeAppointment, error)) *MockRepo_GetAppointment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAppointmentHistory provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAppointmentHistory(appointmentId any) ([]model.AppointmentHistory, error) {
+	ret := _mock.Called(appointmentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppointmentHistory")
+	}
+
+	var r0 []model.AppointmentHistory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any) ([]model.AppointmentHistory, error)); ok {
+		return returnFunc(appointmentId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any) []model.AppointmentHistory); ok {
+		r0 = returnFunc(appointmentId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.AppointmentHistory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
+		r1 = returnFunc(appointmentId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAppointmentHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAppointmentHistory'
+type MockRepo_GetAppointmentHistory_Call struct {
+	*mock.Call
+}
+
+// GetAppointmentHistory is a helper method to define mock.On call
+//   - appointmentId any
+func (_e *MockRepo_Expecter) GetAppointmentHistory(appointmentId interface{}) *MockRepo_GetAppointmentHistory_Call {
+	return &MockRepo_GetAppointmentHistory_Call{Call: _e.mock.On("GetAppointmentHistory", appointmentId)}
+}
+
+func (_c *MockRepo_GetAppointmentHistory_Call) Run(run func(appointmentId any)) *MockRepo_GetAppointmentHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAppointmentHistory_Call) Return(appointmentHistories []model.AppointmentHistory, err error) *MockRepo_GetAppointmentHistory_Call {
+	_c.Call.Return(appointmentHistories, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAppointmentHistory_Call) RunAndReturn(run func(appointmentId any) ([]model.AppointmentHistory, error)) *MockRepo_GetAppointmentHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAppointmentStatusCount provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetAppointmentStatusCount(criteria ...Criteria) ([]model.AppointmentStatusCount, error) {
+	var tmpRet mock.Arguments
+	if len(criteria) > 0 {
+		tmpRet = _mock.Called(criteria)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAppointmentStatusCount")
+	}
+
+	var r0 []model.AppointmentStatusCount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) ([]model.AppointmentStatusCount, error)); ok {
+		return returnFunc(criteria...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(...Criteria) []model.AppointmentStatusCount); ok {
+		r0 = returnFunc(criteria...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.AppointmentStatusCount)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(...Criteria) error); ok {
+		r1 = returnFunc(criteria...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetAppointmentStatusCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAppointmentStatusCount'
+type MockRepo_GetAppointmentStatusCount_Call struct {
+	*mock.Call
+}
+
+// GetAppointmentStatusCount is a helper method to define mock.On call
+//   - criteria ...Criteria
+func (_e *MockRepo_Expecter) GetAppointmentStatusCount(criteria ...interface{}) *MockRepo_GetAppointmentStatusCount_Call {
+	return &MockRepo_GetAppointmentStatusCount_Call{Call: _e.mock.On("GetAppointmentStatusCount",
+		append([]interface{}{}, criteria...)...)}
+}
+
+func (_c *MockRepo_GetAppointmentStatusCount_Call) Run(run func(criteria ...Criteria)) *MockRepo_GetAppointmentStatusCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []Criteria
+		var variadicArgs []Criteria
+		if len(args) > 0 {
+			variadicArgs = args[0].([]Criteria)
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetAppointmentStatusCount_Call) Return(appointmentStatusCounts []model.AppointmentStatusCount, err error) *MockRepo_GetAppointmentStatusCount_Call {
+	_c.Call.Return(appointmentStatusCounts, err)
+	return _c
+}
+
+func (_c *MockRepo_GetAppointmentStatusCount_Call) RunAndReturn(run func(criteria ...Criteria) ([]model.AppointmentStatusCount, error)) *MockRepo_GetAppointmentStatusCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCalendarFeedVersion provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetCalendarFeedVersion(owner string, ownerId int) (int, error) {
+	ret := _mock.Called(owner, ownerId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCalendarFeedVersion")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, int) (int, error)); ok {
+		return returnFunc(owner, ownerId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, int) int); ok {
+		r0 = returnFunc(owner, ownerId)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = returnFunc(owner, ownerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetCalendarFeedVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCalendarFeedVersion'
+type MockRepo_GetCalendarFeedVersion_Call struct {
+	*mock.Call
+}
+
+// GetCalendarFeedVersion is a helper method to define mock.On call
+//   - owner string
+//   - ownerId int
+func (_e *MockRepo_Expecter) GetCalendarFeedVersion(owner interface{}, ownerId interface{}) *MockRepo_GetCalendarFeedVersion_Call {
+	return &MockRepo_GetCalendarFeedVersion_Call{Call: _e.mock.On("GetCalendarFeedVersion", owner, ownerId)}
+}
+
+func (_c *MockRepo_GetCalendarFeedVersion_Call) Run(run func(owner string, ownerId int)) *MockRepo_GetCalendarFeedVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetCalendarFeedVersion_Call) Return(n int, err error) *MockRepo_GetCalendarFeedVersion_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_GetCalendarFeedVersion_Call) RunAndReturn(run func(owner string, ownerId int) (int, error)) *MockRepo_GetCalendarFeedVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCampaign provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetCampaign(campaignId any) (model.Campaign, error) {
+	ret := _mock.Called(campaignId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCampaign")
+	}
+
+	var r0 model.Campaign
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any) (model.Campaign, error)); ok {
+		return returnFunc(campaignId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any) model.Campaign); ok {
+		r0 = returnFunc(campaignId)
+	} else {
+		r0 = ret.Get(0).(model.Campaign)
+	}
+	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
+		r1 = returnFunc(campaignId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetCampaign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCampaign'
+type MockRepo_GetCampaign_Call struct {
+	*mock.Call
+}
+
+// GetCampaign is a helper method to define mock.On call
+//   - campaignId any
+func (_e *MockRepo_Expecter) GetCampaign(campaignId interface{}) *MockRepo_GetCampaign_Call {
+	return &MockRepo_GetCampaign_Call{Call: _e.mock.On("GetCampaign", campaignId)}
+}
+
+func (_c *MockRepo_GetCampaign_Call) Run(run func(campaignId any)) *MockRepo_GetCampaign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetCampaign_Call) Return(campaign model.Campaign, err error) *MockRepo_GetCampaign_Call {
+	_c.Call.Return(campaign, err)
+	return _c
+}
+
+func (_c *MockRepo_GetCampaign_Call) RunAndReturn(run func(campaignId any) (model.Campaign, error)) *MockRepo_GetCampaign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCampaignStats provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetCampaignStats(campaignId int) (model.CampaignStats, error) {
+	ret := _mock.Called(campaignId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCampaignStats")
+	}
+
+	var r0 model.CampaignStats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) (model.CampaignStats, error)); ok {
+		return returnFunc(campaignId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) model.CampaignStats); ok {
+		r0 = returnFunc(campaignId)
+	} else {
+		r0 = ret.Get(0).(model.CampaignStats)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(campaignId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetCampaignStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCampaignStats'
+type MockRepo_GetCampaignStats_Call struct {
+	*mock.Call
+}
+
+// GetCampaignStats is a helper method to define mock.On call
+//   - campaignId int
+func (_e *MockRepo_Expecter) GetCampaignStats(campaignId interface{}) *MockRepo_GetCampaignStats_Call {
+	return &MockRepo_GetCampaignStats_Call{Call: _e.mock.On("GetCampaignStats", campaignId)}
+}
+
+func (_c *MockRepo_GetCampaignStats_Call) Run(run func(campaignId int)) *MockRepo_GetCampaignStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetCampaignStats_Call) Return(campaignStats model.CampaignStats, err error) *MockRepo_GetCampaignStats_Call {
+	_c.Call.Return(campaignStats, err)
+	return _c
+}
+
+func (_c *MockRepo_GetCampaignStats_Call) RunAndReturn(run func(campaignId int) (model.CampaignStats, error)) *MockRepo_GetCampaignStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCareTeamMember provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetCareTeamMember(patientId any, doctorId any) (model.CareTeamMember, error) {
+	ret := _mock.Called(patientId, doctorId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCareTeamMember")
+	}
+
+	var r0 model.CareTeamMember
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any, any) (model.CareTeamMember, error)); ok {
+		return returnFunc(patientId, doctorId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any, any) model.CareTeamMember); ok {
+		r0 = returnFunc(patientId, doctorId)
+	} else {
+		r0 = ret.Get(0).(model.CareTeamMember)
+	}
+	if returnFunc, ok := ret.Get(1).(func(any, any) error); ok {
+		r1 = returnFunc(patientId, doctorId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetCareTeamMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCareTeamMember'
+type MockRepo_GetCareTeamMember_Call struct {
+	*mock.Call
+}
+
+// GetCareTeamMember is a helper method to define mock.On call
+//   - patientId any
+//   - doctorId any
+func (_e *MockRepo_Expecter) GetCareTeamMember(patientId interface{}, doctorId interface{}) *MockRepo_GetCareTeamMember_Call {
+	return &MockRepo_GetCareTeamMember_Call{Call: _e.mock.On("GetCareTeamMember", patientId, doctorId)}
+}
+
+func (_c *MockRepo_GetCareTeamMember_Call) Run(run func(patientId any, doctorId any)) *MockRepo_GetCareTeamMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		var arg1 any
+		if args[1] != nil {
+			arg1 = args[1].(any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetCareTeamMember_Call) Return(careTeamMember model.CareTeamMember, err error) *MockRepo_GetCareTeamMember_Call {
+	_c.Call.Return(careTeamMember, err)
+	return _c
+}
+
+func (_c *MockRepo_GetCareTeamMember_Call) RunAndReturn(run func(patientId any, doctorId any) (model.CareTeamMember, error)) *MockRepo_GetCareTeamMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCaregiverById provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetCaregiverById(caregiverId any) (model.Caregiver, error) {
+	ret := _mock.Called(caregiverId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCaregiverById")
+	}
+
+	var r0 model.Caregiver
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any) (model.Caregiver, error)); ok {
+		return returnFunc(caregiverId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any) model.Caregiver); ok {
+		r0 = returnFunc(caregiverId)
+	} else {
+		r0 = ret.Get(0).(model.Caregiver)
+	}
+	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
+		r1 = returnFunc(caregiverId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetCaregiverById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCaregiverById'
+type MockRepo_GetCaregiverById_Call struct {
+	*mock.Call
+}
+
+// GetCaregiverById is a helper method to define mock.On call
+//   - caregiverId any
+func (_e *MockRepo_Expecter) GetCaregiverById(caregiverId interface{}) *MockRepo_GetCaregiverById_Call {
+	return &MockRepo_GetCaregiverById_Call{Call: _e.mock.On("GetCaregiverById", caregiverId)}
+}
+
+func (_c *MockRepo_GetCaregiverById_Call) Run(run func(caregiverId any)) *MockRepo_GetCaregiverById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetCaregiverById_Call) Return(caregiver model.Caregiver, err error) *MockRepo_GetCaregiverById_Call {
+	_c.Call.Return(caregiver, err)
+	return _c
+}
+
+func (_c *MockRepo_GetCaregiverById_Call) RunAndReturn(run func(caregiverId any) (model.Caregiver, error)) *MockRepo_GetCaregiverById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCaregiverByNID provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetCaregiverByNID(nid string) (model.Caregiver, error) {
+	ret := _mock.Called(nid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCaregiverByNID")
+	}
+
+	var r0 model.Caregiver
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (model.Caregiver, error)); ok {
+		return returnFunc(nid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) model.Caregiver); ok {
+		r0 = returnFunc(nid)
+	} else {
+		r0 = ret.Get(0).(model.Caregiver)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(nid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetCaregiverByNID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCaregiverByNID'
+type MockRepo_GetCaregiverByNID_Call struct {
+	*mock.Call
+}
+
+// GetCaregiverByNID is a helper method to define mock.On call
+//   - nid string
+func (_e *MockRepo_Expecter) GetCaregiverByNID(nid interface{}) *MockRepo_GetCaregiverByNID_Call {
+	return &MockRepo_GetCaregiverByNID_Call{Call: _e.mock.On("GetCaregiverByNID", nid)}
+}
+
+func (_c *MockRepo_GetCaregiverByNID_Call) Run(run func(nid string)) *MockRepo_GetCaregiverByNID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetCaregiverByNID_Call) Return(caregiver model.Caregiver, err error) *MockRepo_GetCaregiverByNID_Call {
+	_c.Call.Return(caregiver, err)
+	return _c
+}
+
+func (_c *MockRepo_GetCaregiverByNID_Call) RunAndReturn(run func(nid string) (model.Caregiver, error)) *MockRepo_GetCaregiverByNID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCaregiverLink provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetCaregiverLink(caregiverId any, patientId any) (model.CaregiverLink, error) {
+	ret := _mock.Called(caregiverId, patientId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCaregiverLink")
+	}
+
+	var r0 model.CaregiverLink
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any, any) (model.CaregiverLink, error)); ok {
+		return returnFunc(caregiverId, patientId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any, any) model.CaregiverLink); ok {
+		r0 = returnFunc(caregiverId, patientId)
+	} else {
+		r0 = ret.Get(0).(model.CaregiverLink)
+	}
+	if returnFunc, ok := ret.Get(1).(func(any, any) error); ok {
+		r1 = returnFunc(caregiverId, patientId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetCaregiverLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCaregiverLink'
+type MockRepo_GetCaregiverLink_Call struct {
+	*mock.Call
+}
+
+// GetCaregiverLink is a helper method to define mock.On call
+//   - caregiverId any
+//   - patientId any
+func (_e *MockRepo_Expecter) GetCaregiverLink(caregiverId interface{}, patientId interface{}) *MockRepo_GetCaregiverLink_Call {
+	return &MockRepo_GetCaregiverLink_Call{Call: _e.mock.On("GetCaregiverLink", caregiverId, patientId)}
+}
+
+func (_c *MockRepo_GetCaregiverLink_Call) Run(run func(caregiverId any, patientId any)) *MockRepo_GetCaregiverLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		var arg1 any
+		if args[1] != nil {
+			arg1 = args[1].(any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetCaregiverLink_Call) Return(caregiverLink model.CaregiverLink, err error) *MockRepo_GetCaregiverLink_Call {
+	_c.Call.Return(caregiverLink, err)
+	return _c
+}
+
+func (_c *MockRepo_GetCaregiverLink_Call) RunAndReturn(run func(caregiverId any, patientId any) (model.CaregiverLink, error)) *MockRepo_GetCaregiverLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConsentById provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetConsentById(consentId any) (model.Consent, error) {
+	ret := _mock.Called(consentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConsentById")
+	}
+
+	var r0 model.Consent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any) (model.Consent, error)); ok {
+		return returnFunc(consentId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any) model.Consent); ok {
+		r0 = returnFunc(consentId)
+	} else {
+		r0 = ret.Get(0).(model.Consent)
+	}
+	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
+		r1 = returnFunc(consentId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetConsentById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConsentById'
+type MockRepo_GetConsentById_Call struct {
+	*mock.Call
+}
+
+// GetConsentById is a helper method to define mock.On call
+//   - consentId any
+func (_e *MockRepo_Expecter) GetConsentById(consentId interface{}) *MockRepo_GetConsentById_Call {
+	return &MockRepo_GetConsentById_Call{Call: _e.mock.On("GetConsentById", consentId)}
+}
+
+func (_c *MockRepo_GetConsentById_Call) Run(run func(consentId any)) *MockRepo_GetConsentById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetConsentById_Call) Return(consent model.Consent, err error) *MockRepo_GetConsentById_Call {
+	_c.Call.Return(consent, err)
+	return _c
+}
+
+func (_c *MockRepo_GetConsentById_Call) RunAndReturn(run func(consentId any) (model.Consent, error)) *MockRepo_GetConsentById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConsentBySlug provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetConsentBySlug(slug string) (model.Consent, error) {
+	ret := _mock.Called(slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConsentBySlug")
+	}
+
+	var r0 model.Consent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (model.Consent, error)); ok {
+		return returnFunc(slug)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) model.Consent); ok {
+		r0 = returnFunc(slug)
+	} else {
+		r0 = ret.Get(0).(model.Consent)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetConsentBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConsentBySlug'
+type MockRepo_GetConsentBySlug_Call struct {
+	*mock.Call
+}
+
+// GetConsentBySlug is a helper method to define mock.On call
+//   - slug string
+func (_e *MockRepo_Expecter) GetConsentBySlug(slug interface{}) *MockRepo_GetConsentBySlug_Call {
+	return &MockRepo_GetConsentBySlug_Call{Call: _e.mock.On("GetConsentBySlug", slug)}
+}
+
+func (_c *MockRepo_GetConsentBySlug_Call) Run(run func(slug string)) *MockRepo_GetConsentBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetConsentBySlug_Call) Return(consent model.Consent, err error) *MockRepo_GetConsentBySlug_Call {
+	_c.Call.Return(consent, err)
+	return _c
+}
+
+func (_c *MockRepo_GetConsentBySlug_Call) RunAndReturn(run func(slug string) (model.Consent, error)) *MockRepo_GetConsentBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContent provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetContent(contentID any) (model.Content, error) {
+	ret := _mock.Called(contentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContent")
+	}
+
+	var r0 model.Content
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any) (model.Content, error)); ok {
+		return returnFunc(contentID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any) model.Content); ok {
+		r0 = returnFunc(contentID)
+	} else {
+		r0 = ret.Get(0).(model.Content)
+	}
+	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
+		r1 = returnFunc(contentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContent'
+type MockRepo_GetContent_Call struct {
+	*mock.Call
+}
+
+// GetContent is a helper method to define mock.On call
+//   - contentID any
+func (_e *MockRepo_Expecter) GetContent(contentID interface{}) *MockRepo_GetContent_Call {
+	return &MockRepo_GetContent_Call{Call: _e.mock.On("GetContent", contentID)}
+}
+
+func (_c *MockRepo_GetContent_Call) Run(run func(contentID any)) *MockRepo_GetContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetContent_Call) Return(content model.Content, err error) *MockRepo_GetContent_Call {
+	_c.Call.Return(content, err)
+	return _c
+}
+
+func (_c *MockRepo_GetContent_Call) RunAndReturn(run func(contentID any) (model.Content, error)) *MockRepo_GetContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDoctorById provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetDoctorById(id any) (model.Doctor, error) {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDoctorById")
+	}
+
+	var r0 model.Doctor
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any) (model.Doctor, error)); ok {
+		return returnFunc(id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any) model.Doctor); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Get(0).(model.Doctor)
+	}
+	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
+		r1 = returnFunc(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetDoctorById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDoctorById'
+type MockRepo_GetDoctorById_Call struct {
+	*mock.Call
+}
+
+// GetDoctorById is a helper method to define mock.On call
+//   - id any
+func (_e *MockRepo_Expecter) GetDoctorById(id interface{}) *MockRepo_GetDoctorById_Call {
+	return &MockRepo_GetDoctorById_Call{Call: _e.mock.On("GetDoctorById", id)}
+}
+
+func (_c *MockRepo_GetDoctorById_Call) Run(run func(id any)) *MockRepo_GetDoctorById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetDoctorById_Call) Return(doctor model.Doctor, err error) *MockRepo_GetDoctorById_Call {
+	_c.Call.Return(doctor, err)
+	return _c
+}
+
+func (_c *MockRepo_GetDoctorById_Call) RunAndReturn(run func(id any) (model.Doctor, error)) *MockRepo_GetDoctorById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDoctorByUsername provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetDoctorByUsername(username string) (model.Doctor, error) {
+	ret := _mock.Called(username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDoctorByUsername")
+	}
+
+	var r0 model.Doctor
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (model.Doctor, error)); ok {
+		return returnFunc(username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) model.Doctor); ok {
+		r0 = returnFunc(username)
+	} else {
+		r0 = ret.Get(0).(model.Doctor)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(username)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetDoctorByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDoctorByUsername'
+type MockRepo_GetDoctorByUsername_Call struct {
+	*mock.Call
+}
+
+// GetDoctorByUsername is a helper method to define mock.On call
+//   - username string
+func (_e *MockRepo_Expecter) GetDoctorByUsername(username interface{}) *MockRepo_GetDoctorByUsername_Call {
+	return &MockRepo_GetDoctorByUsername_Call{Call: _e.mock.On("GetDoctorByUsername", username)}
+}
+
+func (_c *MockRepo_GetDoctorByUsername_Call) Run(run func(username string)) *MockRepo_GetDoctorByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetDoctorByUsername_Call) Return(doctor model.Doctor, err error) *MockRepo_GetDoctorByUsername_Call {
+	_c.Call.Return(doctor, err)
+	return _c
+}
+
+func (_c *MockRepo_GetDoctorByUsername_Call) RunAndReturn(run func(username string) (model.Doctor, error)) *MockRepo_GetDoctorByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDoctorSchedule provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetDoctorSchedule(doctorId any) ([]model.DoctorSchedule, error) {
+	ret := _mock.Called(doctorId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDoctorSchedule")
+	}
+
+	var r0 []model.DoctorSchedule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any) ([]model.DoctorSchedule, error)); ok {
+		return returnFunc(doctorId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any) []model.DoctorSchedule); ok {
+		r0 = returnFunc(doctorId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DoctorSchedule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
+		r1 = returnFunc(doctorId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetDoctorSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDoctorSchedule'
+type MockRepo_GetDoctorSchedule_Call struct {
+	*mock.Call
+}
+
+// GetDoctorSchedule is a helper method to define mock.On call
+//   - doctorId any
+func (_e *MockRepo_Expecter) GetDoctorSchedule(doctorId interface{}) *MockRepo_GetDoctorSchedule_Call {
+	return &MockRepo_GetDoctorSchedule_Call{Call: _e.mock.On("GetDoctorSchedule", doctorId)}
+}
+
+func (_c *MockRepo_GetDoctorSchedule_Call) Run(run func(doctorId any)) *MockRepo_GetDoctorSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetDoctorSchedule_Call) Return(doctorSchedules []model.DoctorSchedule, err error) *MockRepo_GetDoctorSchedule_Call {
+	_c.Call.Return(doctorSchedules, err)
+	return _c
+}
+
+func (_c *MockRepo_GetDoctorSchedule_Call) RunAndReturn(run func(doctorId any) ([]model.DoctorSchedule, error)) *MockRepo_GetDoctorSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInvitationCode provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetInvitationCode(code string) (model.InvitationCode, error) {
+	ret := _mock.Called(code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvitationCode")
+	}
+
+	var r0 model.InvitationCode
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (model.InvitationCode, error)); ok {
+		return returnFunc(code)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) model.InvitationCode); ok {
+		r0 = returnFunc(code)
+	} else {
+		r0 = ret.Get(0).(model.InvitationCode)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetInvitationCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvitationCode'
+type MockRepo_GetInvitationCode_Call struct {
+	*mock.Call
+}
+
+// GetInvitationCode is a helper method to define mock.On call
+//   - code string
+func (_e *MockRepo_Expecter) GetInvitationCode(code interface{}) *MockRepo_GetInvitationCode_Call {
+	return &MockRepo_GetInvitationCode_Call{Call: _e.mock.On("GetInvitationCode", code)}
+}
+
+func (_c *MockRepo_GetInvitationCode_Call) Run(run func(code string)) *MockRepo_GetInvitationCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetInvitationCode_Call) Return(invitationCode model.InvitationCode, err error) *MockRepo_GetInvitationCode_Call {
+	_c.Call.Return(invitationCode, err)
+	return _c
+}
+
+func (_c *MockRepo_GetInvitationCode_Call) RunAndReturn(run func(code string) (model.InvitationCode, error)) *MockRepo_GetInvitationCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMeasurement provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetMeasurement(patientId any, measurementId any) (model.Measurement, error) {
+	ret := _mock.Called(patientId, measurementId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMeasurement")
+	}
+
+	var r0 model.Measurement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any, any) (model.Measurement, error)); ok {
+		return returnFunc(patientId, measurementId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any, any) model.Measurement); ok {
+		r0 = returnFunc(patientId, measurementId)
+	} else {
+		r0 = ret.Get(0).(model.Measurement)
+	}
+	if returnFunc, ok := ret.Get(1).(func(any, any) error); ok {
+		r1 = returnFunc(patientId, measurementId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetMeasurement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMeasurement'
+type MockRepo_GetMeasurement_Call struct {
+	*mock.Call
+}
+
+// GetMeasurement is a helper method to define mock.On call
+//   - patientId any
+//   - measurementId any
+func (_e *MockRepo_Expecter) GetMeasurement(patientId interface{}, measurementId interface{}) *MockRepo_GetMeasurement_Call {
+	return &MockRepo_GetMeasurement_Call{Call: _e.mock.On("GetMeasurement", patientId, measurementId)}
+}
+
+func (_c *MockRepo_GetMeasurement_Call) Run(run func(patientId any, measurementId any)) *MockRepo_GetMeasurement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		var arg1 any
+		if args[1] != nil {
+			arg1 = args[1].(any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetMeasurement_Call) Return(measurement model.Measurement, err error) *MockRepo_GetMeasurement_Call {
+	_c.Call.Return(measurement, err)
+	return _c
+}
+
+func (_c *MockRepo_GetMeasurement_Call) RunAndReturn(run func(patientId any, measurementId any) (model.Measurement, error)) *MockRepo_GetMeasurement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMedicationRegimen provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetMedicationRegimen(patientId any, regimenId any) (model.MedicationRegimen, error) {
+	ret := _mock.Called(patientId, regimenId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMedicationRegimen")
+	}
+
+	var r0 model.MedicationRegimen
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any, any) (model.MedicationRegimen, error)); ok {
+		return returnFunc(patientId, regimenId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any, any) model.MedicationRegimen); ok {
+		r0 = returnFunc(patientId, regimenId)
+	} else {
+		r0 = ret.Get(0).(model.MedicationRegimen)
+	}
+	if returnFunc, ok := ret.Get(1).(func(any, any) error); ok {
+		r1 = returnFunc(patientId, regimenId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetMedicationRegimen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMedicationRegimen'
+type MockRepo_GetMedicationRegimen_Call struct {
+	*mock.Call
+}
+
+// GetMedicationRegimen is a helper method to define mock.On call
+//   - patientId any
+//   - regimenId any
+func (_e *MockRepo_Expecter) GetMedicationRegimen(patientId interface{}, regimenId interface{}) *MockRepo_GetMedicationRegimen_Call {
+	return &MockRepo_GetMedicationRegimen_Call{Call: _e.mock.On("GetMedicationRegimen", patientId, regimenId)}
+}
+
+func (_c *MockRepo_GetMedicationRegimen_Call) Run(run func(patientId any, regimenId any)) *MockRepo_GetMedicationRegimen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		var arg1 any
+		if args[1] != nil {
+			arg1 = args[1].(any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetMedicationRegimen_Call) Return(medicationRegimen model.MedicationRegimen, err error) *MockRepo_GetMedicationRegimen_Call {
+	_c.Call.Return(medicationRegimen, err)
+	return _c
+}
+
+func (_c *MockRepo_GetMedicationRegimen_Call) RunAndReturn(run func(patientId any, regimenId any) (model.MedicationRegimen, error)) *MockRepo_GetMedicationRegimen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotificationPreference provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetNotificationPreference(patientId int) (model.NotificationPreference, error) {
+	ret := _mock.Called(patientId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotificationPreference")
+	}
+
+	var r0 model.NotificationPreference
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) (model.NotificationPreference, error)); ok {
+		return returnFunc(patientId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) model.NotificationPreference); ok {
+		r0 = returnFunc(patientId)
+	} else {
+		r0 = ret.Get(0).(model.NotificationPreference)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(patientId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetNotificationPreference_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotificationPreference'
+type MockRepo_GetNotificationPreference_Call struct {
+	*mock.Call
+}
+
+// GetNotificationPreference is a helper method to define mock.On call
+//   - patientId int
+func (_e *MockRepo_Expecter) GetNotificationPreference(patientId interface{}) *MockRepo_GetNotificationPreference_Call {
+	return &MockRepo_GetNotificationPreference_Call{Call: _e.mock.On("GetNotificationPreference", patientId)}
+}
+
+func (_c *MockRepo_GetNotificationPreference_Call) Run(run func(patientId int)) *MockRepo_GetNotificationPreference_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetNotificationPreference_Call) Return(notificationPreference model.NotificationPreference, err error) *MockRepo_GetNotificationPreference_Call {
+	_c.Call.Return(notificationPreference, err)
+	return _c
+}
+
+func (_c *MockRepo_GetNotificationPreference_Call) RunAndReturn(run func(patientId int) (model.NotificationPreference, error)) *MockRepo_GetNotificationPreference_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotificationTemplate provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetNotificationTemplate(key model.TemplateKey, language model.Language) (model.NotificationTemplate, error) {
+	ret := _mock.Called(key, language)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotificationTemplate")
+	}
+
+	var r0 model.NotificationTemplate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.TemplateKey, model.Language) (model.NotificationTemplate, error)); ok {
+		return returnFunc(key, language)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.TemplateKey, model.Language) model.NotificationTemplate); ok {
+		r0 = returnFunc(key, language)
+	} else {
+		r0 = ret.Get(0).(model.NotificationTemplate)
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.TemplateKey, model.Language) error); ok {
+		r1 = returnFunc(key, language)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetNotificationTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotificationTemplate'
+type MockRepo_GetNotificationTemplate_Call struct {
+	*mock.Call
+}
+
+// GetNotificationTemplate is a helper method to define mock.On call
+//   - key model.TemplateKey
+//   - language model.Language
+func (_e *MockRepo_Expecter) GetNotificationTemplate(key interface{}, language interface{}) *MockRepo_GetNotificationTemplate_Call {
+	return &MockRepo_GetNotificationTemplate_Call{Call: _e.mock.On("GetNotificationTemplate", key, language)}
+}
+
+func (_c *MockRepo_GetNotificationTemplate_Call) Run(run func(key model.TemplateKey, language model.Language)) *MockRepo_GetNotificationTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.TemplateKey
+		if args[0] != nil {
+			arg0 = args[0].(model.TemplateKey)
+		}
+		var arg1 model.Language
+		if args[1] != nil {
+			arg1 = args[1].(model.Language)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetNotificationTemplate_Call) Return(notificationTemplate model.NotificationTemplate, err error) *MockRepo_GetNotificationTemplate_Call {
+	_c.Call.Return(notificationTemplate, err)
+	return _c
+}
+
+func (_c *MockRepo_GetNotificationTemplate_Call) RunAndReturn(run func(key model.TemplateKey, language model.Language) (model.NotificationTemplate, error)) *MockRepo_GetNotificationTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPatientByHN provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetPatientByHN(hn string) (model.Patient, error) {
+	ret := _mock.Called(hn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPatientByHN")
+	}
+
+	var r0 model.Patient
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (model.Patient, error)); ok {
+		return returnFunc(hn)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) model.Patient); ok {
+		r0 = returnFunc(hn)
+	} else {
+		r0 = ret.Get(0).(model.Patient)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(hn)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetPatientByHN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPatientByHN'
+type MockRepo_GetPatientByHN_Call struct {
+	*mock.Call
+}
+
+// GetPatientByHN is a helper method to define mock.On call
+//   - hn string
+func (_e *MockRepo_Expecter) GetPatientByHN(hn interface{}) *MockRepo_GetPatientByHN_Call {
+	return &MockRepo_GetPatientByHN_Call{Call: _e.mock.On("GetPatientByHN", hn)}
+}
+
+func (_c *MockRepo_GetPatientByHN_Call) Run(run func(hn string)) *MockRepo_GetPatientByHN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetPatientByHN_Call) Return(patient model.Patient, err error) *MockRepo_GetPatientByHN_Call {
+	_c.Call.Return(patient, err)
+	return _c
+}
+
+func (_c *MockRepo_GetPatientByHN_Call) RunAndReturn(run func(hn string) (model.Patient, error)) *MockRepo_GetPatientByHN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPatientById provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetPatientById(id any) (model.Patient, error) {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPatientById")
+	}
+
+	var r0 model.Patient
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any) (model.Patient, error)); ok {
+		return returnFunc(id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any) model.Patient); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Get(0).(model.Patient)
+	}
+	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
+		r1 = returnFunc(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetPatientById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPatientById'
+type MockRepo_GetPatientById_Call struct {
+	*mock.Call
+}
+
+// GetPatientById is a helper method to define mock.On call
+//   - id any
+func (_e *MockRepo_Expecter) GetPatientById(id interface{}) *MockRepo_GetPatientById_Call {
+	return &MockRepo_GetPatientById_Call{Call: _e.mock.On("GetPatientById", id)}
+}
+
+func (_c *MockRepo_GetPatientById_Call) Run(run func(id any)) *MockRepo_GetPatientById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetPatientById_Call) Return(patient model.Patient, err error) *MockRepo_GetPatientById_Call {
+	_c.Call.Return(patient, err)
+	return _c
+}
+
+func (_c *MockRepo_GetPatientById_Call) RunAndReturn(run func(id any) (model.Patient, error)) *MockRepo_GetPatientById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPatientByNID provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetPatientByNID(nid string) (model.Patient, error) {
+	ret := _mock.Called(nid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPatientByNID")
+	}
+
+	var r0 model.Patient
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (model.Patient, error)); ok {
+		return returnFunc(nid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) model.Patient); ok {
+		r0 = returnFunc(nid)
+	} else {
+		r0 = ret.Get(0).(model.Patient)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(nid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetPatientByNID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPatientByNID'
+type MockRepo_GetPatientByNID_Call struct {
+	*mock.Call
+}
+
+// GetPatientByNID is a helper method to define mock.On call
+//   - nid string
+func (_e *MockRepo_Expecter) GetPatientByNID(nid interface{}) *MockRepo_GetPatientByNID_Call {
+	return &MockRepo_GetPatientByNID_Call{Call: _e.mock.On("GetPatientByNID", nid)}
+}
+
+func (_c *MockRepo_GetPatientByNID_Call) Run(run func(nid string)) *MockRepo_GetPatientByNID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetPatientByNID_Call) Return(patient model.Patient, err error) *MockRepo_GetPatientByNID_Call {
+	_c.Call.Return(patient, err)
+	return _c
+}
+
+func (_c *MockRepo_GetPatientByNID_Call) RunAndReturn(run func(nid string) (model.Patient, error)) *MockRepo_GetPatientByNID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQuestion provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetQuestion(questionId any) (model.SafeQuestion, error) {
+	ret := _mock.Called(questionId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuestion")
+	}
+
+	var r0 model.SafeQuestion
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any) (model.SafeQuestion, error)); ok {
+		return returnFunc(questionId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any) model.SafeQuestion); ok {
+		r0 = returnFunc(questionId)
+	} else {
+		r0 = ret.Get(0).(model.SafeQuestion)
+	}
+	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
+		r1 = returnFunc(questionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetQuestion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuestion'
+type MockRepo_GetQuestion_Call struct {
+	*mock.Call
+}
+
+// GetQuestion is a helper method to define mock.On call
+//   - questionId any
+func (_e *MockRepo_Expecter) GetQuestion(questionId interface{}) *MockRepo_GetQuestion_Call {
+	return &MockRepo_GetQuestion_Call{Call: _e.mock.On("GetQuestion", questionId)}
+}
+
+func (_c *MockRepo_GetQuestion_Call) Run(run func(questionId any)) *MockRepo_GetQuestion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetQuestion_Call) Return(safeQuestion model.SafeQuestion, err error) *MockRepo_GetQuestion_Call {
+	_c.Call.Return(safeQuestion, err)
+	return _c
+}
+
+func (_c *MockRepo_GetQuestion_Call) RunAndReturn(run func(questionId any) (model.SafeQuestion, error)) *MockRepo_GetQuestion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQuestionAttachment provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetQuestionAttachment(questionId any, attachmentId any) (model.QuestionAttachment, error) {
+	ret := _mock.Called(questionId, attachmentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuestionAttachment")
+	}
+
+	var r0 model.QuestionAttachment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any, any) (model.QuestionAttachment, error)); ok {
+		return returnFunc(questionId, attachmentId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any, any) model.QuestionAttachment); ok {
+		r0 = returnFunc(questionId, attachmentId)
+	} else {
+		r0 = ret.Get(0).(model.QuestionAttachment)
+	}
+	if returnFunc, ok := ret.Get(1).(func(any, any) error); ok {
+		r1 = returnFunc(questionId, attachmentId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetQuestionAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuestionAttachment'
+type MockRepo_GetQuestionAttachment_Call struct {
+	*mock.Call
+}
+
+// GetQuestionAttachment is a helper method to define mock.On call
+//   - questionId any
+//   - attachmentId any
+func (_e *MockRepo_Expecter) GetQuestionAttachment(questionId interface{}, attachmentId interface{}) *MockRepo_GetQuestionAttachment_Call {
+	return &MockRepo_GetQuestionAttachment_Call{Call: _e.mock.On("GetQuestionAttachment", questionId, attachmentId)}
+}
+
+func (_c *MockRepo_GetQuestionAttachment_Call) Run(run func(questionId any, attachmentId any)) *MockRepo_GetQuestionAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		var arg1 any
+		if args[1] != nil {
+			arg1 = args[1].(any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetQuestionAttachment_Call) Return(questionAttachment model.QuestionAttachment, err error) *MockRepo_GetQuestionAttachment_Call {
+	_c.Call.Return(questionAttachment, err)
+	return _c
+}
+
+func (_c *MockRepo_GetQuestionAttachment_Call) RunAndReturn(run func(questionId any, attachmentId any) (model.QuestionAttachment, error)) *MockRepo_GetQuestionAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQuestionQueue provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetQuestionQueue(doctorId int, limit int, offset int) ([]model.QuestionTopic, error) {
+	ret := _mock.Called(doctorId, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuestionQueue")
+	}
+
+	var r0 []model.QuestionTopic
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, int) ([]model.QuestionTopic, error)); ok {
+		return returnFunc(doctorId, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, int) []model.QuestionTopic); ok {
+		r0 = returnFunc(doctorId, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.QuestionTopic)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, int) error); ok {
+		r1 = returnFunc(doctorId, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetQuestionQueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuestionQueue'
+type MockRepo_GetQuestionQueue_Call struct {
+	*mock.Call
+}
+
+// GetQuestionQueue is a helper method to define mock.On call
+//   - doctorId int
+//   - limit int
+//   - offset int
+func (_e *MockRepo_Expecter) GetQuestionQueue(doctorId interface{}, limit interface{}, offset interface{}) *MockRepo_GetQuestionQueue_Call {
+	return &MockRepo_GetQuestionQueue_Call{Call: _e.mock.On("GetQuestionQueue", doctorId, limit, offset)}
+}
+
+func (_c *MockRepo_GetQuestionQueue_Call) Run(run func(doctorId int, limit int, offset int)) *MockRepo_GetQuestionQueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetQuestionQueue_Call) Return(questionTopics []model.QuestionTopic, err error) *MockRepo_GetQuestionQueue_Call {
+	_c.Call.Return(questionTopics, err)
+	return _c
+}
+
+func (_c *MockRepo_GetQuestionQueue_Call) RunAndReturn(run func(doctorId int, limit int, offset int) ([]model.QuestionTopic, error)) *MockRepo_GetQuestionQueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRemindableMedicationRegimen provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetRemindableMedicationRegimen() ([]model.MedicationRegimen, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRemindableMedicationRegimen")
+	}
+
+	var r0 []model.MedicationRegimen
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]model.MedicationRegimen, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []model.MedicationRegimen); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.MedicationRegimen)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetRemindableMedicationRegimen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRemindableMedicationRegimen'
+type MockRepo_GetRemindableMedicationRegimen_Call struct {
+	*mock.Call
+}
+
+// GetRemindableMedicationRegimen is a helper method to define mock.On call
+func (_e *MockRepo_Expecter) GetRemindableMedicationRegimen() *MockRepo_GetRemindableMedicationRegimen_Call {
+	return &MockRepo_GetRemindableMedicationRegimen_Call{Call: _e.mock.On("GetRemindableMedicationRegimen")}
+}
+
+func (_c *MockRepo_GetRemindableMedicationRegimen_Call) Run(run func()) *MockRepo_GetRemindableMedicationRegimen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetRemindableMedicationRegimen_Call) Return(medicationRegimens []model.MedicationRegimen, err error) *MockRepo_GetRemindableMedicationRegimen_Call {
+	_c.Call.Return(medicationRegimens, err)
+	return _c
+}
+
+func (_c *MockRepo_GetRemindableMedicationRegimen_Call) RunAndReturn(run func() ([]model.MedicationRegimen, error)) *MockRepo_GetRemindableMedicationRegimen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSegmentPatientIds provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetSegmentPatientIds(segment model.CampaignSegment, now int) ([]int, error) {
+	ret := _mock.Called(segment, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSegmentPatientIds")
+	}
+
+	var r0 []int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(model.CampaignSegment, int) ([]int, error)); ok {
+		return returnFunc(segment, now)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model.CampaignSegment, int) []int); ok {
+		r0 = returnFunc(segment, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(model.CampaignSegment, int) error); ok {
+		r1 = returnFunc(segment, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetSegmentPatientIds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSegmentPatientIds'
+type MockRepo_GetSegmentPatientIds_Call struct {
+	*mock.Call
+}
+
+// GetSegmentPatientIds is a helper method to define mock.On call
+//   - segment model.CampaignSegment
+//   - now int
+func (_e *MockRepo_Expecter) GetSegmentPatientIds(segment interface{}, now interface{}) *MockRepo_GetSegmentPatientIds_Call {
+	return &MockRepo_GetSegmentPatientIds_Call{Call: _e.mock.On("GetSegmentPatientIds", segment, now)}
+}
+
+func (_c *MockRepo_GetSegmentPatientIds_Call) Run(run func(segment model.CampaignSegment, now int)) *MockRepo_GetSegmentPatientIds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.CampaignSegment
+		if args[0] != nil {
+			arg0 = args[0].(model.CampaignSegment)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetSegmentPatientIds_Call) Return(ints []int, err error) *MockRepo_GetSegmentPatientIds_Call {
+	_c.Call.Return(ints, err)
+	return _c
+}
+
+func (_c *MockRepo_GetSegmentPatientIds_Call) RunAndReturn(run func(segment model.CampaignSegment, now int) ([]int, error)) *MockRepo_GetSegmentPatientIds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVaccination provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetVaccination(patientId any, vaccinationId any) (model.Vaccination, error) {
+	ret := _mock.Called(patientId, vaccinationId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVaccination")
+	}
+
+	var r0 model.Vaccination
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any, any) (model.Vaccination, error)); ok {
+		return returnFunc(patientId, vaccinationId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any, any) model.Vaccination); ok {
+		r0 = returnFunc(patientId, vaccinationId)
+	} else {
+		r0 = ret.Get(0).(model.Vaccination)
+	}
+	if returnFunc, ok := ret.Get(1).(func(any, any) error); ok {
+		r1 = returnFunc(patientId, vaccinationId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetVaccination_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVaccination'
+type MockRepo_GetVaccination_Call struct {
+	*mock.Call
+}
+
+// GetVaccination is a helper method to define mock.On call
+//   - patientId any
+//   - vaccinationId any
+func (_e *MockRepo_Expecter) GetVaccination(patientId interface{}, vaccinationId interface{}) *MockRepo_GetVaccination_Call {
+	return &MockRepo_GetVaccination_Call{Call: _e.mock.On("GetVaccination", patientId, vaccinationId)}
+}
+
+func (_c *MockRepo_GetVaccination_Call) Run(run func(patientId any, vaccinationId any)) *MockRepo_GetVaccination_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		var arg1 any
+		if args[1] != nil {
+			arg1 = args[1].(any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetVaccination_Call) Return(vaccination model.Vaccination, err error) *MockRepo_GetVaccination_Call {
+	_c.Call.Return(vaccination, err)
+	return _c
+}
+
+func (_c *MockRepo_GetVaccination_Call) RunAndReturn(run func(patientId any, vaccinationId any) (model.Vaccination, error)) *MockRepo_GetVaccination_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVaccine provides a mock function for the type MockRepo
+func (_mock *MockRepo) GetVaccine(vaccineId any) (model.Vaccine, error) {
+	ret := _mock.Called(vaccineId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVaccine")
+	}
+
+	var r0 model.Vaccine
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(any) (model.Vaccine, error)); ok {
+		return returnFunc(vaccineId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(any) model.Vaccine); ok {
+		r0 = returnFunc(vaccineId)
+	} else {
+		r0 = ret.Get(0).(model.Vaccine)
+	}
+	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
+		r1 = returnFunc(vaccineId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_GetVaccine_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVaccine'
+type MockRepo_GetVaccine_Call struct {
+	*mock.Call
+}
+
+// GetVaccine is a helper method to define mock.On call
+//   - vaccineId any
+func (_e *MockRepo_Expecter) GetVaccine(vaccineId interface{}) *MockRepo_GetVaccine_Call {
+	return &MockRepo_GetVaccine_Call{Call: _e.mock.On("GetVaccine", vaccineId)}
+}
+
+func (_c *MockRepo_GetVaccine_Call) Run(run func(vaccineId any)) *MockRepo_GetVaccine_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 any
+		if args[0] != nil {
+			arg0 = args[0].(any)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_GetVaccine_Call) Return(vaccine model.Vaccine, err error) *MockRepo_GetVaccine_Call {
+	_c.Call.Return(vaccine, err)
+	return _c
+}
+
+func (_c *MockRepo_GetVaccine_Call) RunAndReturn(run func(vaccineId any) (model.Vaccine, error)) *MockRepo_GetVaccine_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncreaseSnippetUsage provides a mock function for the type MockRepo
+func (_mock *MockRepo) IncreaseSnippetUsage(snippetId int) error {
+	ret := _mock.Called(snippetId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncreaseSnippetUsage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int) error); ok {
+		r0 = returnFunc(snippetId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_IncreaseSnippetUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncreaseSnippetUsage'
+type MockRepo_IncreaseSnippetUsage_Call struct {
+	*mock.Call
+}
+
+// IncreaseSnippetUsage is a helper method to define mock.On call
+//   - snippetId int
+func (_e *MockRepo_Expecter) IncreaseSnippetUsage(snippetId interface{}) *MockRepo_IncreaseSnippetUsage_Call {
+	return &MockRepo_IncreaseSnippetUsage_Call{Call: _e.mock.On("IncreaseSnippetUsage", snippetId)}
+}
+
+func (_c *MockRepo_IncreaseSnippetUsage_Call) Run(run func(snippetId int)) *MockRepo_IncreaseSnippetUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_IncreaseSnippetUsage_Call) Return(err error) *MockRepo_IncreaseSnippetUsage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_IncreaseSnippetUsage_Call) RunAndReturn(run func(snippetId int) error) *MockRepo_IncreaseSnippetUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LogMedicationDose provides a mock function for the type MockRepo
+func (_mock *MockRepo) LogMedicationDose(dose model.MedicationDose) error {
+	ret := _mock.Called(dose)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogMedicationDose")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.MedicationDose) error); ok {
+		r0 = returnFunc(dose)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_LogMedicationDose_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LogMedicationDose'
+type MockRepo_LogMedicationDose_Call struct {
+	*mock.Call
+}
+
+// LogMedicationDose is a helper method to define mock.On call
+//   - dose model.MedicationDose
+func (_e *MockRepo_Expecter) LogMedicationDose(dose interface{}) *MockRepo_LogMedicationDose_Call {
+	return &MockRepo_LogMedicationDose_Call{Call: _e.mock.On("LogMedicationDose", dose)}
+}
+
+func (_c *MockRepo_LogMedicationDose_Call) Run(run func(dose model.MedicationDose)) *MockRepo_LogMedicationDose_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.MedicationDose
+		if args[0] != nil {
+			arg0 = args[0].(model.MedicationDose)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_LogMedicationDose_Call) Return(err error) *MockRepo_LogMedicationDose_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_LogMedicationDose_Call) RunAndReturn(run func(dose model.MedicationDose) error) *MockRepo_LogMedicationDose_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// New provides a mock function for the type MockRepo
+func (_mock *MockRepo) New(db *gorm.DB) IRepo {
+	ret := _mock.Called(db)
+
+	if len(ret) == 0 {
+		panic("no return value specified for New")
+	}
+
+	var r0 IRepo
+	if returnFunc, ok := ret.Get(0).(func(*gorm.DB) IRepo); ok {
+		r0 = returnFunc(db)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(IRepo)
+		}
+	}
+	return r0
+}
+
+// MockRepo_New_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'New'
+type MockRepo_New_Call struct {
+	*mock.Call
+}
+
+// New is a helper method to define mock.On call
+//   - db *gorm.DB
+func (_e *MockRepo_Expecter) New(db interface{}) *MockRepo_New_Call {
+	return &MockRepo_New_Call{Call: _e.mock.On("New", db)}
+}
+
+func (_c *MockRepo_New_Call) Run(run func(db *gorm.DB)) *MockRepo_New_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *gorm.DB
+		if args[0] != nil {
+			arg0 = args[0].(*gorm.DB)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_New_Call) Return(iRepo IRepo) *MockRepo_New_Call {
+	_c.Call.Return(iRepo)
+	return _c
+}
+
+func (_c *MockRepo_New_Call) RunAndReturn(run func(db *gorm.DB) IRepo) *MockRepo_New_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadAllDoctorNotification provides a mock function for the type MockRepo
+func (_mock *MockRepo) ReadAllDoctorNotification(doctorId int) (int, error) {
+	ret := _mock.Called(doctorId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadAllDoctorNotification")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) (int, error)); ok {
+		return returnFunc(doctorId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) int); ok {
+		r0 = returnFunc(doctorId)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(doctorId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_ReadAllDoctorNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadAllDoctorNotification'
+type MockRepo_ReadAllDoctorNotification_Call struct {
+	*mock.Call
+}
+
+// ReadAllDoctorNotification is a helper method to define mock.On call
+//   - doctorId int
+func (_e *MockRepo_Expecter) ReadAllDoctorNotification(doctorId interface{}) *MockRepo_ReadAllDoctorNotification_Call {
+	return &MockRepo_ReadAllDoctorNotification_Call{Call: _e.mock.On("ReadAllDoctorNotification", doctorId)}
+}
+
+func (_c *MockRepo_ReadAllDoctorNotification_Call) Run(run func(doctorId int)) *MockRepo_ReadAllDoctorNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_ReadAllDoctorNotification_Call) Return(n int, err error) *MockRepo_ReadAllDoctorNotification_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_ReadAllDoctorNotification_Call) RunAndReturn(run func(doctorId int) (int, error)) *MockRepo_ReadAllDoctorNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadAllNotification provides a mock function for the type MockRepo
+func (_mock *MockRepo) ReadAllNotification(patientId int) (int, error) {
+	ret := _mock.Called(patientId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadAllNotification")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) (int, error)); ok {
+		return returnFunc(patientId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) int); ok {
+		r0 = returnFunc(patientId)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(patientId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepo_ReadAllNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadAllNotification'
+type MockRepo_ReadAllNotification_Call struct {
+	*mock.Call
+}
+
+// ReadAllNotification is a helper method to define mock.On call
+//   - patientId int
+func (_e *MockRepo_Expecter) ReadAllNotification(patientId interface{}) *MockRepo_ReadAllNotification_Call {
+	return &MockRepo_ReadAllNotification_Call{Call: _e.mock.On("ReadAllNotification", patientId)}
+}
+
+func (_c *MockRepo_ReadAllNotification_Call) Run(run func(patientId int)) *MockRepo_ReadAllNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_ReadAllNotification_Call) Return(n int, err error) *MockRepo_ReadAllNotification_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepo_ReadAllNotification_Call) RunAndReturn(run func(patientId int) (int, error)) *MockRepo_ReadAllNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadDoctorNotification provides a mock function for the type MockRepo
+func (_mock *MockRepo) ReadDoctorNotification(doctorId int, notificationId any) error {
+	ret := _mock.Called(doctorId, notificationId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadDoctorNotification")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, any) error); ok {
+		r0 = returnFunc(doctorId, notificationId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_ReadDoctorNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadDoctorNotification'
+type MockRepo_ReadDoctorNotification_Call struct {
+	*mock.Call
+}
+
+// ReadDoctorNotification is a helper method to define mock.On call
+//   - doctorId int
+//   - notificationId any
+func (_e *MockRepo_Expecter) ReadDoctorNotification(doctorId interface{}, notificationId interface{}) *MockRepo_ReadDoctorNotification_Call {
+	return &MockRepo_ReadDoctorNotification_Call{Call: _e.mock.On("ReadDoctorNotification", doctorId, notificationId)}
+}
+
+func (_c *MockRepo_ReadDoctorNotification_Call) Run(run func(doctorId int, notificationId any)) *MockRepo_ReadDoctorNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 any
+		if args[1] != nil {
+			arg1 = args[1].(any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_ReadDoctorNotification_Call) Return(err error) *MockRepo_ReadDoctorNotification_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_ReadDoctorNotification_Call) RunAndReturn(run func(doctorId int, notificationId any) error) *MockRepo_ReadDoctorNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadNotification provides a mock function for the type MockRepo
+func (_mock *MockRepo) ReadNotification(patientId int, notificationId any) error {
+	ret := _mock.Called(patientId, notificationId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadNotification")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, any) error); ok {
+		r0 = returnFunc(patientId, notificationId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_ReadNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadNotification'
+type MockRepo_ReadNotification_Call struct {
+	*mock.Call
+}
+
+// ReadNotification is a helper method to define mock.On call
+//   - patientId int
+//   - notificationId any
+func (_e *MockRepo_Expecter) ReadNotification(patientId interface{}, notificationId interface{}) *MockRepo_ReadNotification_Call {
+	return &MockRepo_ReadNotification_Call{Call: _e.mock.On("ReadNotification", patientId, notificationId)}
+}
+
+func (_c *MockRepo_ReadNotification_Call) Run(run func(patientId int, notificationId any)) *MockRepo_ReadNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 any
+		if args[1] != nil {
+			arg1 = args[1].(any)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_ReadNotification_Call) Return(err error) *MockRepo_ReadNotification_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_ReadNotification_Call) RunAndReturn(run func(patientId int, notificationId any) error) *MockRepo_ReadNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadQuestion provides a mock function for the type MockRepo
+func (_mock *MockRepo) ReadQuestion(questionId int, reader model.ActorType) error {
+	ret := _mock.Called(questionId, reader)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadQuestion")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, model.ActorType) error); ok {
+		r0 = returnFunc(questionId, reader)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_ReadQuestion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadQuestion'
+type MockRepo_ReadQuestion_Call struct {
+	*mock.Call
+}
+
+// ReadQuestion is a helper method to define mock.On call
+//   - questionId int
+//   - reader model.ActorType
+func (_e *MockRepo_Expecter) ReadQuestion(questionId interface{}, reader interface{}) *MockRepo_ReadQuestion_Call {
+	return &MockRepo_ReadQuestion_Call{Call: _e.mock.On("ReadQuestion", questionId, reader)}
+}
+
+func (_c *MockRepo_ReadQuestion_Call) Run(run func(questionId int, reader model.ActorType)) *MockRepo_ReadQuestion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 model.ActorType
+		if args[1] != nil {
+			arg1 = args[1].(model.ActorType)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_ReadQuestion_Call) Return(err error) *MockRepo_ReadQuestion_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_ReadQuestion_Call) RunAndReturn(run func(questionId int, reader model.ActorType) error) *MockRepo_ReadQuestion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RejectSignup provides a mock function for the type MockRepo
+func (_mock *MockRepo) RejectSignup(patientId int, reason string, doctorId int) error {
+	ret := _mock.Called(patientId, reason, doctorId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectSignup")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, string, int) error); ok {
+		r0 = returnFunc(patientId, reason, doctorId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_RejectSignup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RejectSignup'
+type MockRepo_RejectSignup_Call struct {
+	*mock.Call
+}
+
+// RejectSignup is a helper method to define mock.On call
+//   - patientId int
+//   - reason string
+//   - doctorId int
+func (_e *MockRepo_Expecter) RejectSignup(patientId interface{}, reason interface{}, doctorId interface{}) *MockRepo_RejectSignup_Call {
+	return &MockRepo_RejectSignup_Call{Call: _e.mock.On("RejectSignup", patientId, reason, doctorId)}
+}
+
+func (_c *MockRepo_RejectSignup_Call) Run(run func(patientId int, reason string, doctorId int)) *MockRepo_RejectSignup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_RejectSignup_Call) Return(err error) *MockRepo_RejectSignup_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_RejectSignup_Call) RunAndReturn(run func(patientId int, reason string, doctorId int) error) *MockRepo_RejectSignup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseQuestion provides a mock function for the type MockRepo
+func (_mock *MockRepo) ReleaseQuestion(questionId int, doctorId int) error {
+	ret := _mock.Called(questionId, doctorId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseQuestion")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = returnFunc(questionId, doctorId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_ReleaseQuestion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseQuestion'
+type MockRepo_ReleaseQuestion_Call struct {
+	*mock.Call
+}
+
+// ReleaseQuestion is a helper method to define mock.On call
+//   - questionId int
+//   - doctorId int
+func (_e *MockRepo_Expecter) ReleaseQuestion(questionId interface{}, doctorId interface{}) *MockRepo_ReleaseQuestion_Call {
+	return &MockRepo_ReleaseQuestion_Call{Call: _e.mock.On("ReleaseQuestion", questionId, doctorId)}
+}
+
+func (_c *MockRepo_ReleaseQuestion_Call) Run(run func(questionId int, doctorId int)) *MockRepo_ReleaseQuestion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_GetAppointment_Call) Return(safeAppointment model.SafeAppointment, err error) *MockRepo_GetAppointment_Call {
-	_c.Call.Return(safeAppointment, err)
+func (_c *MockRepo_ReleaseQuestion_Call) Return(err error) *MockRepo_ReleaseQuestion_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_GetAppointment_Call) RunAndReturn(run func(appointmentId any) (model.SafeAppointment, error)) *MockRepo_GetAppointment_Call {
+func (_c *MockRepo_ReleaseQuestion_Call) RunAndReturn(run func(questionId int, doctorId int) error) *MockRepo_ReleaseQuestion_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetConsentById provides a mock function for the type MockRepo
-func (_mock *MockRepo) GetConsentById(consentId any) (model.Consent, error) {
-	ret := _mock.Called(consentId)
+// ReplaceDoctorSchedule provides a mock function for the type MockRepo
+func (_mock *MockRepo) ReplaceDoctorSchedule(doctorId int, schedules []model.DoctorSchedule) error {
+	ret := _mock.Called(doctorId, schedules)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetConsentById")
+		panic("no return value specified for ReplaceDoctorSchedule")
 	}
 
-	var r0 model.Consent
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(any) (model.Consent, error)); ok {
-		return returnFunc(consentId)
-	}
-	if returnFunc, ok := ret.Get(0).(func(any) model.Consent); ok {
-		r0 = returnFunc(consentId)
-	} else {
-		r0 = ret.Get(0).(model.Consent)
-	}
-	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
-		r1 = returnFunc(consentId)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, []model.DoctorSchedule) error); ok {
+		r0 = returnFunc(doctorId, schedules)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockRepo_GetConsentById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConsentById'
-type MockRepo_GetConsentById_Call struct {
+// MockRepo_ReplaceDoctorSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceDoctorSchedule'
+type MockRepo_ReplaceDoctorSchedule_Call struct {
 	*mock.Call
 }
 
-// GetConsentById is a helper method to define mock.On call
-//   - consentId any
-func (_e *MockRepo_Expecter) GetConsentById(consentId interface{}) *MockRepo_GetConsentById_Call {
-	return &MockRepo_GetConsentById_Call{Call: _e.mock.On("GetConsentById", consentId)}
+// ReplaceDoctorSchedule is a helper method to define mock.On call
+//   - doctorId int
+//   - schedules []model.DoctorSchedule
+func (_e *MockRepo_Expecter) ReplaceDoctorSchedule(doctorId interface{}, schedules interface{}) *MockRepo_ReplaceDoctorSchedule_Call {
+	return &MockRepo_ReplaceDoctorSchedule_Call{Call: _e.mock.On("ReplaceDoctorSchedule", doctorId, schedules)}
 }
 
-func (_c *MockRepo_GetConsentById_Call) Run(run func(consentId any)) *MockRepo_GetConsentById_Call {
+func (_c *MockRepo_ReplaceDoctorSchedule_Call) Run(run func(doctorId int, schedules []model.DoctorSchedule)) *MockRepo_ReplaceDoctorSchedule_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 any
+		var arg0 int
 		if args[0] != nil {
-			arg0 = args[0].(any)
+			arg0 = args[0].(int)
+		}
+		var arg1 []model.DoctorSchedule
+		if args[1] != nil {
+			arg1 = args[1].([]model.DoctorSchedule)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_GetConsentById_Call) Return(consent model.Consent, err error) *MockRepo_GetConsentById_Call {
-	_c.Call.Return(consent, err)
+func (_c *MockRepo_ReplaceDoctorSchedule_Call) Return(err error) *MockRepo_ReplaceDoctorSchedule_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_GetConsentById_Call) RunAndReturn(run func(consentId any) (model.Consent, error)) *MockRepo_GetConsentById_Call {
+func (_c *MockRepo_ReplaceDoctorSchedule_Call) RunAndReturn(run func(doctorId int, schedules []model.DoctorSchedule) error) *MockRepo_ReplaceDoctorSchedule_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetConsentBySlug provides a mock function for the type MockRepo
-func (_mock *MockRepo) GetConsentBySlug(slug string) (model.Consent, error) {
-	ret := _mock.Called(slug)
+// ResolveRescheduleRequest provides a mock function for the type MockRepo
+func (_mock *MockRepo) ResolveRescheduleRequest(appointmentId int, accept bool, reason *string, doctorId int) (model.RescheduleRequest, error) {
+	ret := _mock.Called(appointmentId, accept, reason, doctorId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetConsentBySlug")
+		panic("no return value specified for ResolveRescheduleRequest")
 	}
 
-	var r0 model.Consent
+	var r0 model.RescheduleRequest
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (model.Consent, error)); ok {
-		return returnFunc(slug)
+	if returnFunc, ok := ret.Get(0).(func(int, bool, *string, int) (model.RescheduleRequest, error)); ok {
+		return returnFunc(appointmentId, accept, reason, doctorId)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) model.Consent); ok {
-		r0 = returnFunc(slug)
+	if returnFunc, ok := ret.Get(0).(func(int, bool, *string, int) model.RescheduleRequest); ok {
+		r0 = returnFunc(appointmentId, accept, reason, doctorId)
 	} else {
-		r0 = ret.Get(0).(model.Consent)
+		r0 = ret.Get(0).(model.RescheduleRequest)
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(slug)
+	if returnFunc, ok := ret.Get(1).(func(int, bool, *string, int) error); ok {
+		r1 = returnFunc(appointmentId, accept, reason, doctorId)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepo_GetConsentBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConsentBySlug'
-type MockRepo_GetConsentBySlug_Call struct {
+// MockRepo_ResolveRescheduleRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveRescheduleRequest'
+type MockRepo_ResolveRescheduleRequest_Call struct {
 	*mock.Call
 }
 
-// GetConsentBySlug is a helper method to define mock.On call
-//   - slug string
-func (_e *MockRepo_Expecter) GetConsentBySlug(slug interface{}) *MockRepo_GetConsentBySlug_Call {
-	return &MockRepo_GetConsentBySlug_Call{Call: _e.mock.On("GetConsentBySlug", slug)}
+// ResolveRescheduleRequest is a helper method to define mock.On call
+//   - appointmentId int
+//   - accept bool
+//   - reason *string
+//   - doctorId int
+func (_e *MockRepo_Expecter) ResolveRescheduleRequest(appointmentId interface{}, accept interface{}, reason interface{}, doctorId interface{}) *MockRepo_ResolveRescheduleRequest_Call {
+	return &MockRepo_ResolveRescheduleRequest_Call{Call: _e.mock.On("ResolveRescheduleRequest", appointmentId, accept, reason, doctorId)}
 }
 
-func (_c *MockRepo_GetConsentBySlug_Call) Run(run func(slug string)) *MockRepo_GetConsentBySlug_Call {
+func (_c *MockRepo_ResolveRescheduleRequest_Call) Run(run func(appointmentId int, accept bool, reason *string, doctorId int)) *MockRepo_ResolveRescheduleRequest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 int
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(int)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		var arg2 *string
+		if args[2] != nil {
+			arg2 = args[2].(*string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_GetConsentBySlug_Call) Return(consent model.Consent, err error) *MockRepo_GetConsentBySlug_Call {
-	_c.Call.Return(consent, err)
+func (_c *MockRepo_ResolveRescheduleRequest_Call) Return(rescheduleRequest model.RescheduleRequest, err error) *MockRepo_ResolveRescheduleRequest_Call {
+	_c.Call.Return(rescheduleRequest, err)
 	return _c
 }
 
-func (_c *MockRepo_GetConsentBySlug_Call) RunAndReturn(run func(slug string) (model.Consent, error)) *MockRepo_GetConsentBySlug_Call {
+func (_c *MockRepo_ResolveRescheduleRequest_Call) RunAndReturn(run func(appointmentId int, accept bool, reason *string, doctorId int) (model.RescheduleRequest, error)) *MockRepo_ResolveRescheduleRequest_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetContent provides a mock function for the type MockRepo
-func (_mock *MockRepo) GetContent(contentID any) (model.Content, error) {
-	ret := _mock.Called(contentID)
+// RotateCalendarFeed provides a mock function for the type MockRepo
+func (_mock *MockRepo) RotateCalendarFeed(owner string, ownerId int) (int, error) {
+	ret := _mock.Called(owner, ownerId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetContent")
+		panic("no return value specified for RotateCalendarFeed")
 	}
 
-	var r0 model.Content
+	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(any) (model.Content, error)); ok {
-		return returnFunc(contentID)
+	if returnFunc, ok := ret.Get(0).(func(string, int) (int, error)); ok {
+		return returnFunc(owner, ownerId)
 	}
-	if returnFunc, ok := ret.Get(0).(func(any) model.Content); ok {
-		r0 = returnFunc(contentID)
+	if returnFunc, ok := ret.Get(0).(func(string, int) int); ok {
+		r0 = returnFunc(owner, ownerId)
 	} else {
-		r0 = ret.Get(0).(model.Content)
+		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
-		r1 = returnFunc(contentID)
+	if returnFunc, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = returnFunc(owner, ownerId)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepo_GetContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContent'
-type MockRepo_GetContent_Call struct {
+// MockRepo_RotateCalendarFeed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateCalendarFeed'
+type MockRepo_RotateCalendarFeed_Call struct {
 	*mock.Call
 }
 
-// GetContent is a helper method to define mock.On call
-//   - contentID any
-func (_e *MockRepo_Expecter) GetContent(contentID interface{}) *MockRepo_GetContent_Call {
-	return &MockRepo_GetContent_Call{Call: _e.mock.On("GetContent", contentID)}
+// RotateCalendarFeed is a helper method to define mock.On call
+//   - owner string
+//   - ownerId int
+func (_e *MockRepo_Expecter) RotateCalendarFeed(owner interface{}, ownerId interface{}) *MockRepo_RotateCalendarFeed_Call {
+	return &MockRepo_RotateCalendarFeed_Call{Call: _e.mock.On("RotateCalendarFeed", owner, ownerId)}
 }
 
-func (_c *MockRepo_GetContent_Call) Run(run func(contentID any)) *MockRepo_GetContent_Call {
+func (_c *MockRepo_RotateCalendarFeed_Call) Run(run func(owner string, ownerId int)) *MockRepo_RotateCalendarFeed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 any
+		var arg0 string
 		if args[0] != nil {
-			arg0 = args[0].(any)
+			arg0 = args[0].(string)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_GetContent_Call) Return(content model.Content, err error) *MockRepo_GetContent_Call {
-	_c.Call.Return(content, err)
+func (_c *MockRepo_RotateCalendarFeed_Call) Return(n int, err error) *MockRepo_RotateCalendarFeed_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepo_GetContent_Call) RunAndReturn(run func(contentID any) (model.Content, error)) *MockRepo_GetContent_Call {
+func (_c *MockRepo_RotateCalendarFeed_Call) RunAndReturn(run func(owner string, ownerId int) (int, error)) *MockRepo_RotateCalendarFeed_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetDoctorById provides a mock function for the type MockRepo
-func (_mock *MockRepo) GetDoctorById(id any) (model.Doctor, error) {
-	ret := _mock.Called(id)
+// SaveSentCampaign provides a mock function for the type MockRepo
+func (_mock *MockRepo) SaveSentCampaign(campaign model.Campaign, notifications []model.Notification, buildMessages func(saved []model.Notification) []model.NotificationOutbox) ([]model.NotificationOutbox, error) {
+	ret := _mock.Called(campaign, notifications, buildMessages)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDoctorById")
+		panic("no return value specified for SaveSentCampaign")
 	}
 
-	var r0 model.Doctor
+	var r0 []model.NotificationOutbox
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(any) (model.Doctor, error)); ok {
-		return returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(model.Campaign, []model.Notification, func(saved []model.Notification) []model.NotificationOutbox) ([]model.NotificationOutbox, error)); ok {
+		return returnFunc(campaign, notifications, buildMessages)
 	}
-	if returnFunc, ok := ret.Get(0).(func(any) model.Doctor); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(model.Campaign, []model.Notification, func(saved []model.Notification) []model.NotificationOutbox) []model.NotificationOutbox); ok {
+		r0 = returnFunc(campaign, notifications, buildMessages)
 	} else {
-		r0 = ret.Get(0).(model.Doctor)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.NotificationOutbox)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
-		r1 = returnFunc(id)
+	if returnFunc, ok := ret.Get(1).(func(model.Campaign, []model.Notification, func(saved []model.Notification) []model.NotificationOutbox) error); ok {
+		r1 = returnFunc(campaign, notifications, buildMessages)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepo_GetDoctorById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDoctorById'
-type MockRepo_GetDoctorById_Call struct {
+// MockRepo_SaveSentCampaign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSentCampaign'
+type MockRepo_SaveSentCampaign_Call struct {
 	*mock.Call
 }
 
-// GetDoctorById is a helper method to define mock.On call
-//   - id any
-func (_e *MockRepo_Expecter) GetDoctorById(id interface{}) *MockRepo_GetDoctorById_Call {
-	return &MockRepo_GetDoctorById_Call{Call: _e.mock.On("GetDoctorById", id)}
+// SaveSentCampaign is a helper method to define mock.On call
+//   - campaign model.Campaign
+//   - notifications []model.Notification
+//   - buildMessages func(saved []model.Notification) []model.NotificationOutbox
+func (_e *MockRepo_Expecter) SaveSentCampaign(campaign interface{}, notifications interface{}, buildMessages interface{}) *MockRepo_SaveSentCampaign_Call {
+	return &MockRepo_SaveSentCampaign_Call{Call: _e.mock.On("SaveSentCampaign", campaign, notifications, buildMessages)}
 }
 
-func (_c *MockRepo_GetDoctorById_Call) Run(run func(id any)) *MockRepo_GetDoctorById_Call {
+func (_c *MockRepo_SaveSentCampaign_Call) Run(run func(campaign model.Campaign, notifications []model.Notification, buildMessages func(saved []model.Notification) []model.NotificationOutbox)) *MockRepo_SaveSentCampaign_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 any
+		var arg0 model.Campaign
 		if args[0] != nil {
-			arg0 = args[0].(any)
+			arg0 = args[0].(model.Campaign)
+		}
+		var arg1 []model.Notification
+		if args[1] != nil {
+			arg1 = args[1].([]model.Notification)
+		}
+		var arg2 func(saved []model.Notification) []model.NotificationOutbox
+		if args[2] != nil {
+			arg2 = args[2].(func(saved []model.Notification) []model.NotificationOutbox)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_GetDoctorById_Call) Return(doctor model.Doctor, err error) *MockRepo_GetDoctorById_Call {
-	_c.Call.Return(doctor, err)
+func (_c *MockRepo_SaveSentCampaign_Call) Return(notificationOutboxes []model.NotificationOutbox, err error) *MockRepo_SaveSentCampaign_Call {
+	_c.Call.Return(notificationOutboxes, err)
 	return _c
 }
 
-func (_c *MockRepo_GetDoctorById_Call) RunAndReturn(run func(id any) (model.Doctor, error)) *MockRepo_GetDoctorById_Call {
+func (_c *MockRepo_SaveSentCampaign_Call) RunAndReturn(run func(campaign model.Campaign, notifications []model.Notification, buildMessages func(saved []model.Notification) []model.NotificationOutbox) ([]model.NotificationOutbox, error)) *MockRepo_SaveSentCampaign_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetDoctorByUsername provides a mock function for the type MockRepo
-func (_mock *MockRepo) GetDoctorByUsername(username string) (model.Doctor, error) {
-	ret := _mock.Called(username)
+// SetQuestionClosed provides a mock function for the type MockRepo
+func (_mock *MockRepo) SetQuestionClosed(questionId int, closed bool) error {
+	ret := _mock.Called(questionId, closed)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDoctorByUsername")
+		panic("no return value specified for SetQuestionClosed")
 	}
 
-	var r0 model.Doctor
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (model.Doctor, error)); ok {
-		return returnFunc(username)
-	}
-	if returnFunc, ok := ret.Get(0).(func(string) model.Doctor); ok {
-		r0 = returnFunc(username)
-	} else {
-		r0 = ret.Get(0).(model.Doctor)
-	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(username)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, bool) error); ok {
+		r0 = returnFunc(questionId, closed)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockRepo_GetDoctorByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDoctorByUsername'
-type MockRepo_GetDoctorByUsername_Call struct {
+// MockRepo_SetQuestionClosed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetQuestionClosed'
+type MockRepo_SetQuestionClosed_Call struct {
 	*mock.Call
 }
 
-// GetDoctorByUsername is a helper method to define mock.On call
-//   - username string
-func (_e *MockRepo_Expecter) GetDoctorByUsername(username interface{}) *MockRepo_GetDoctorByUsername_Call {
-	return &MockRepo_GetDoctorByUsername_Call{Call: _e.mock.On("GetDoctorByUsername", username)}
+// SetQuestionClosed is a helper method to define mock.On call
+//   - questionId int
+//   - closed bool
+func (_e *MockRepo_Expecter) SetQuestionClosed(questionId interface{}, closed interface{}) *MockRepo_SetQuestionClosed_Call {
+	return &MockRepo_SetQuestionClosed_Call{Call: _e.mock.On("SetQuestionClosed", questionId, closed)}
 }
 
-func (_c *MockRepo_GetDoctorByUsername_Call) Run(run func(username string)) *MockRepo_GetDoctorByUsername_Call {
+func (_c *MockRepo_SetQuestionClosed_Call) Run(run func(questionId int, closed bool)) *MockRepo_SetQuestionClosed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 int
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(int)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_GetDoctorByUsername_Call) Return(doctor model.Doctor, err error) *MockRepo_GetDoctorByUsername_Call {
-	_c.Call.Return(doctor, err)
+func (_c *MockRepo_SetQuestionClosed_Call) Return(err error) *MockRepo_SetQuestionClosed_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_GetDoctorByUsername_Call) RunAndReturn(run func(username string) (model.Doctor, error)) *MockRepo_GetDoctorByUsername_Call {
+func (_c *MockRepo_SetQuestionClosed_Call) RunAndReturn(run func(questionId int, closed bool) error) *MockRepo_SetQuestionClosed_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPatientByHN provides a mock function for the type MockRepo
-func (_mock *MockRepo) GetPatientByHN(hn string) (model.Patient, error) {
-	ret := _mock.Called(hn)
+// StopMedicationRegimen provides a mock function for the type MockRepo
+func (_mock *MockRepo) StopMedicationRegimen(regimenId int, endDate int, reason string) error {
+	ret := _mock.Called(regimenId, endDate, reason)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPatientByHN")
+		panic("no return value specified for StopMedicationRegimen")
 	}
 
-	var r0 model.Patient
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (model.Patient, error)); ok {
-		return returnFunc(hn)
-	}
-	if returnFunc, ok := ret.Get(0).(func(string) model.Patient); ok {
-		r0 = returnFunc(hn)
-	} else {
-		r0 = ret.Get(0).(model.Patient)
-	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(hn)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, string) error); ok {
+		r0 = returnFunc(regimenId, endDate, reason)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockRepo_GetPatientByHN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPatientByHN'
-type MockRepo_GetPatientByHN_Call struct {
+// MockRepo_StopMedicationRegimen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StopMedicationRegimen'
+type MockRepo_StopMedicationRegimen_Call struct {
 	*mock.Call
 }
 
-// GetPatientByHN is a helper method to define mock.On call
-//   - hn string
-func (_e *MockRepo_Expecter) GetPatientByHN(hn interface{}) *MockRepo_GetPatientByHN_Call {
-	return &MockRepo_GetPatientByHN_Call{Call: _e.mock.On("GetPatientByHN", hn)}
+// StopMedicationRegimen is a helper method to define mock.On call
+//   - regimenId int
+//   - endDate int
+//   - reason string
+func (_e *MockRepo_Expecter) StopMedicationRegimen(regimenId interface{}, endDate interface{}, reason interface{}) *MockRepo_StopMedicationRegimen_Call {
+	return &MockRepo_StopMedicationRegimen_Call{Call: _e.mock.On("StopMedicationRegimen", regimenId, endDate, reason)}
 }
 
-func (_c *MockRepo_GetPatientByHN_Call) Run(run func(hn string)) *MockRepo_GetPatientByHN_Call {
+func (_c *MockRepo_StopMedicationRegimen_Call) Run(run func(regimenId int, endDate int, reason string)) *MockRepo_StopMedicationRegimen_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 int
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_GetPatientByHN_Call) Return(patient model.Patient, err error) *MockRepo_GetPatientByHN_Call {
-	_c.Call.Return(patient, err)
+func (_c *MockRepo_StopMedicationRegimen_Call) Return(err error) *MockRepo_StopMedicationRegimen_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_GetPatientByHN_Call) RunAndReturn(run func(hn string) (model.Patient, error)) *MockRepo_GetPatientByHN_Call {
+func (_c *MockRepo_StopMedicationRegimen_Call) RunAndReturn(run func(regimenId int, endDate int, reason string) error) *MockRepo_StopMedicationRegimen_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPatientById provides a mock function for the type MockRepo
-func (_mock *MockRepo) GetPatientById(id any) (model.Patient, error) {
-	ret := _mock.Called(id)
+// UpdateAnswerSnippet provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpdateAnswerSnippet(snippet model.AnswerSnippet) error {
+	ret := _mock.Called(snippet)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPatientById")
+		panic("no return value specified for UpdateAnswerSnippet")
 	}
 
-	var r0 model.Patient
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(any) (model.Patient, error)); ok {
-		return returnFunc(id)
-	}
-	if returnFunc, ok := ret.Get(0).(func(any) model.Patient); ok {
-		r0 = returnFunc(id)
-	} else {
-		r0 = ret.Get(0).(model.Patient)
-	}
-	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
-		r1 = returnFunc(id)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.AnswerSnippet) error); ok {
+		r0 = returnFunc(snippet)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockRepo_GetPatientById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPatientById'
-type MockRepo_GetPatientById_Call struct {
+// MockRepo_UpdateAnswerSnippet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAnswerSnippet'
+type MockRepo_UpdateAnswerSnippet_Call struct {
 	*mock.Call
 }
 
-// GetPatientById is a helper method to define mock.On call
-//   - id any
-func (_e *MockRepo_Expecter) GetPatientById(id interface{}) *MockRepo_GetPatientById_Call {
-	return &MockRepo_GetPatientById_Call{Call: _e.mock.On("GetPatientById", id)}
+// UpdateAnswerSnippet is a helper method to define mock.On call
+//   - snippet model.AnswerSnippet
+func (_e *MockRepo_Expecter) UpdateAnswerSnippet(snippet interface{}) *MockRepo_UpdateAnswerSnippet_Call {
+	return &MockRepo_UpdateAnswerSnippet_Call{Call: _e.mock.On("UpdateAnswerSnippet", snippet)}
 }
 
-func (_c *MockRepo_GetPatientById_Call) Run(run func(id any)) *MockRepo_GetPatientById_Call {
+func (_c *MockRepo_UpdateAnswerSnippet_Call) Run(run func(snippet model.AnswerSnippet)) *MockRepo_UpdateAnswerSnippet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 any
+		var arg0 model.AnswerSnippet
 		if args[0] != nil {
-			arg0 = args[0].(any)
+			arg0 = args[0].(model.AnswerSnippet)
 		}
 		run(
 			arg0,
@@ -1779,58 +8627,49 @@ func (_c *MockRepo_GetPatientById_Call) Run(run func(id any)) *MockRepo_GetPatie
 	return _c
 }
 
-func (_c *MockRepo_GetPatientById_Call) Return(patient model.Patient, err error) *MockRepo_GetPatientById_Call {
-	_c.Call.Return(patient, err)
+func (_c *MockRepo_UpdateAnswerSnippet_Call) Return(err error) *MockRepo_UpdateAnswerSnippet_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_GetPatientById_Call) RunAndReturn(run func(id any) (model.Patient, error)) *MockRepo_GetPatientById_Call {
+func (_c *MockRepo_UpdateAnswerSnippet_Call) RunAndReturn(run func(snippet model.AnswerSnippet) error) *MockRepo_UpdateAnswerSnippet_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPatientByNID provides a mock function for the type MockRepo
-func (_mock *MockRepo) GetPatientByNID(nid string) (model.Patient, error) {
-	ret := _mock.Called(nid)
+// UpdateAppointment provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpdateAppointment(appointment model.Appointment) error {
+	ret := _mock.Called(appointment)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPatientByNID")
+		panic("no return value specified for UpdateAppointment")
 	}
 
-	var r0 model.Patient
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (model.Patient, error)); ok {
-		return returnFunc(nid)
-	}
-	if returnFunc, ok := ret.Get(0).(func(string) model.Patient); ok {
-		r0 = returnFunc(nid)
-	} else {
-		r0 = ret.Get(0).(model.Patient)
-	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(nid)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.Appointment) error); ok {
+		r0 = returnFunc(appointment)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockRepo_GetPatientByNID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPatientByNID'
-type MockRepo_GetPatientByNID_Call struct {
+// MockRepo_UpdateAppointment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAppointment'
+type MockRepo_UpdateAppointment_Call struct {
 	*mock.Call
 }
 
-// GetPatientByNID is a helper method to define mock.On call
-//   - nid string
-func (_e *MockRepo_Expecter) GetPatientByNID(nid interface{}) *MockRepo_GetPatientByNID_Call {
-	return &MockRepo_GetPatientByNID_Call{Call: _e.mock.On("GetPatientByNID", nid)}
+// UpdateAppointment is a helper method to define mock.On call
+//   - appointment model.Appointment
+func (_e *MockRepo_Expecter) UpdateAppointment(appointment interface{}) *MockRepo_UpdateAppointment_Call {
+	return &MockRepo_UpdateAppointment_Call{Call: _e.mock.On("UpdateAppointment", appointment)}
 }
 
-func (_c *MockRepo_GetPatientByNID_Call) Run(run func(nid string)) *MockRepo_GetPatientByNID_Call {
+func (_c *MockRepo_UpdateAppointment_Call) Run(run func(appointment model.Appointment)) *MockRepo_UpdateAppointment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 model.Appointment
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(model.Appointment)
 		}
 		run(
 			arg0,
@@ -1839,58 +8678,49 @@ func (_c *MockRepo_GetPatientByNID_Call) Run(run func(nid string)) *MockRepo_Get
 	return _c
 }
 
-func (_c *MockRepo_GetPatientByNID_Call) Return(patient model.Patient, err error) *MockRepo_GetPatientByNID_Call {
-	_c.Call.Return(patient, err)
+func (_c *MockRepo_UpdateAppointment_Call) Return(err error) *MockRepo_UpdateAppointment_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_GetPatientByNID_Call) RunAndReturn(run func(nid string) (model.Patient, error)) *MockRepo_GetPatientByNID_Call {
+func (_c *MockRepo_UpdateAppointment_Call) RunAndReturn(run func(appointment model.Appointment) error) *MockRepo_UpdateAppointment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetQuestion provides a mock function for the type MockRepo
-func (_mock *MockRepo) GetQuestion(questionId any) (model.SafeQuestion, error) {
-	ret := _mock.Called(questionId)
+// UpdateCampaign provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpdateCampaign(campaign model.Campaign) error {
+	ret := _mock.Called(campaign)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetQuestion")
+		panic("no return value specified for UpdateCampaign")
 	}
 
-	var r0 model.SafeQuestion
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(any) (model.SafeQuestion, error)); ok {
-		return returnFunc(questionId)
-	}
-	if returnFunc, ok := ret.Get(0).(func(any) model.SafeQuestion); ok {
-		r0 = returnFunc(questionId)
-	} else {
-		r0 = ret.Get(0).(model.SafeQuestion)
-	}
-	if returnFunc, ok := ret.Get(1).(func(any) error); ok {
-		r1 = returnFunc(questionId)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.Campaign) error); ok {
+		r0 = returnFunc(campaign)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockRepo_GetQuestion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuestion'
-type MockRepo_GetQuestion_Call struct {
+// MockRepo_UpdateCampaign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCampaign'
+type MockRepo_UpdateCampaign_Call struct {
 	*mock.Call
 }
 
-// GetQuestion is a helper method to define mock.On call
-//   - questionId any
-func (_e *MockRepo_Expecter) GetQuestion(questionId interface{}) *MockRepo_GetQuestion_Call {
-	return &MockRepo_GetQuestion_Call{Call: _e.mock.On("GetQuestion", questionId)}
+// UpdateCampaign is a helper method to define mock.On call
+//   - campaign model.Campaign
+func (_e *MockRepo_Expecter) UpdateCampaign(campaign interface{}) *MockRepo_UpdateCampaign_Call {
+	return &MockRepo_UpdateCampaign_Call{Call: _e.mock.On("UpdateCampaign", campaign)}
 }
 
-func (_c *MockRepo_GetQuestion_Call) Run(run func(questionId any)) *MockRepo_GetQuestion_Call {
+func (_c *MockRepo_UpdateCampaign_Call) Run(run func(campaign model.Campaign)) *MockRepo_UpdateCampaign_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 any
+		var arg0 model.Campaign
 		if args[0] != nil {
-			arg0 = args[0].(any)
+			arg0 = args[0].(model.Campaign)
 		}
 		run(
 			arg0,
@@ -1899,51 +8729,49 @@ func (_c *MockRepo_GetQuestion_Call) Run(run func(questionId any)) *MockRepo_Get
 	return _c
 }
 
-func (_c *MockRepo_GetQuestion_Call) Return(safeQuestion model.SafeQuestion, err error) *MockRepo_GetQuestion_Call {
-	_c.Call.Return(safeQuestion, err)
+func (_c *MockRepo_UpdateCampaign_Call) Return(err error) *MockRepo_UpdateCampaign_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_GetQuestion_Call) RunAndReturn(run func(questionId any) (model.SafeQuestion, error)) *MockRepo_GetQuestion_Call {
+func (_c *MockRepo_UpdateCampaign_Call) RunAndReturn(run func(campaign model.Campaign) error) *MockRepo_UpdateCampaign_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// New provides a mock function for the type MockRepo
-func (_mock *MockRepo) New(db *gorm.DB) IRepo {
-	ret := _mock.Called(db)
+// UpdateCareTeamMember provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpdateCareTeamMember(member model.CareTeamMember) error {
+	ret := _mock.Called(member)
 
 	if len(ret) == 0 {
-		panic("no return value specified for New")
+		panic("no return value specified for UpdateCareTeamMember")
 	}
 
-	var r0 IRepo
-	if returnFunc, ok := ret.Get(0).(func(*gorm.DB) IRepo); ok {
-		r0 = returnFunc(db)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.CareTeamMember) error); ok {
+		r0 = returnFunc(member)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(IRepo)
-		}
+		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepo_New_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'New'
-type MockRepo_New_Call struct {
+// MockRepo_UpdateCareTeamMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCareTeamMember'
+type MockRepo_UpdateCareTeamMember_Call struct {
 	*mock.Call
 }
 
-// New is a helper method to define mock.On call
-//   - db *gorm.DB
-func (_e *MockRepo_Expecter) New(db interface{}) *MockRepo_New_Call {
-	return &MockRepo_New_Call{Call: _e.mock.On("New", db)}
+// UpdateCareTeamMember is a helper method to define mock.On call
+//   - member model.CareTeamMember
+func (_e *MockRepo_Expecter) UpdateCareTeamMember(member interface{}) *MockRepo_UpdateCareTeamMember_Call {
+	return &MockRepo_UpdateCareTeamMember_Call{Call: _e.mock.On("UpdateCareTeamMember", member)}
 }
 
-func (_c *MockRepo_New_Call) Run(run func(db *gorm.DB)) *MockRepo_New_Call {
+func (_c *MockRepo_UpdateCareTeamMember_Call) Run(run func(member model.CareTeamMember)) *MockRepo_UpdateCareTeamMember_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *gorm.DB
+		var arg0 model.CareTeamMember
 		if args[0] != nil {
-			arg0 = args[0].(*gorm.DB)
+			arg0 = args[0].(model.CareTeamMember)
 		}
 		run(
 			arg0,
@@ -1952,49 +8780,49 @@ func (_c *MockRepo_New_Call) Run(run func(db *gorm.DB)) *MockRepo_New_Call {
 	return _c
 }
 
-func (_c *MockRepo_New_Call) Return(iRepo IRepo) *MockRepo_New_Call {
-	_c.Call.Return(iRepo)
+func (_c *MockRepo_UpdateCareTeamMember_Call) Return(err error) *MockRepo_UpdateCareTeamMember_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_New_Call) RunAndReturn(run func(db *gorm.DB) IRepo) *MockRepo_New_Call {
+func (_c *MockRepo_UpdateCareTeamMember_Call) RunAndReturn(run func(member model.CareTeamMember) error) *MockRepo_UpdateCareTeamMember_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateAppointment provides a mock function for the type MockRepo
-func (_mock *MockRepo) UpdateAppointment(appointment model.Appointment) error {
-	ret := _mock.Called(appointment)
+// UpdateCaregiverLink provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpdateCaregiverLink(link model.CaregiverLink) error {
+	ret := _mock.Called(link)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateAppointment")
+		panic("no return value specified for UpdateCaregiverLink")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(model.Appointment) error); ok {
-		r0 = returnFunc(appointment)
+	if returnFunc, ok := ret.Get(0).(func(model.CaregiverLink) error); ok {
+		r0 = returnFunc(link)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepo_UpdateAppointment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAppointment'
-type MockRepo_UpdateAppointment_Call struct {
+// MockRepo_UpdateCaregiverLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCaregiverLink'
+type MockRepo_UpdateCaregiverLink_Call struct {
 	*mock.Call
 }
 
-// UpdateAppointment is a helper method to define mock.On call
-//   - appointment model.Appointment
-func (_e *MockRepo_Expecter) UpdateAppointment(appointment interface{}) *MockRepo_UpdateAppointment_Call {
-	return &MockRepo_UpdateAppointment_Call{Call: _e.mock.On("UpdateAppointment", appointment)}
+// UpdateCaregiverLink is a helper method to define mock.On call
+//   - link model.CaregiverLink
+func (_e *MockRepo_Expecter) UpdateCaregiverLink(link interface{}) *MockRepo_UpdateCaregiverLink_Call {
+	return &MockRepo_UpdateCaregiverLink_Call{Call: _e.mock.On("UpdateCaregiverLink", link)}
 }
 
-func (_c *MockRepo_UpdateAppointment_Call) Run(run func(appointment model.Appointment)) *MockRepo_UpdateAppointment_Call {
+func (_c *MockRepo_UpdateCaregiverLink_Call) Run(run func(link model.CaregiverLink)) *MockRepo_UpdateCaregiverLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 model.Appointment
+		var arg0 model.CaregiverLink
 		if args[0] != nil {
-			arg0 = args[0].(model.Appointment)
+			arg0 = args[0].(model.CaregiverLink)
 		}
 		run(
 			arg0,
@@ -2003,12 +8831,12 @@ func (_c *MockRepo_UpdateAppointment_Call) Run(run func(appointment model.Appoin
 	return _c
 }
 
-func (_c *MockRepo_UpdateAppointment_Call) Return(err error) *MockRepo_UpdateAppointment_Call {
+func (_c *MockRepo_UpdateCaregiverLink_Call) Return(err error) *MockRepo_UpdateCaregiverLink_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_UpdateAppointment_Call) RunAndReturn(run func(appointment model.Appointment) error) *MockRepo_UpdateAppointment_Call {
+func (_c *MockRepo_UpdateCaregiverLink_Call) RunAndReturn(run func(link model.CaregiverLink) error) *MockRepo_UpdateCaregiverLink_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2143,11 +8971,176 @@ func (_e *MockRepo_Expecter) UpdateDoctor(doctor interface{}) *MockRepo_UpdateDo
 	return &MockRepo_UpdateDoctor_Call{Call: _e.mock.On("UpdateDoctor", doctor)}
 }
 
-func (_c *MockRepo_UpdateDoctor_Call) Run(run func(doctor model.Doctor)) *MockRepo_UpdateDoctor_Call {
+func (_c *MockRepo_UpdateDoctor_Call) Run(run func(doctor model.Doctor)) *MockRepo_UpdateDoctor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Doctor
+		if args[0] != nil {
+			arg0 = args[0].(model.Doctor)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_UpdateDoctor_Call) Return(err error) *MockRepo_UpdateDoctor_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_UpdateDoctor_Call) RunAndReturn(run func(doctor model.Doctor) error) *MockRepo_UpdateDoctor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMeasurement provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpdateMeasurement(measurement model.Measurement) error {
+	ret := _mock.Called(measurement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMeasurement")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.Measurement) error); ok {
+		r0 = returnFunc(measurement)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_UpdateMeasurement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMeasurement'
+type MockRepo_UpdateMeasurement_Call struct {
+	*mock.Call
+}
+
+// UpdateMeasurement is a helper method to define mock.On call
+//   - measurement model.Measurement
+func (_e *MockRepo_Expecter) UpdateMeasurement(measurement interface{}) *MockRepo_UpdateMeasurement_Call {
+	return &MockRepo_UpdateMeasurement_Call{Call: _e.mock.On("UpdateMeasurement", measurement)}
+}
+
+func (_c *MockRepo_UpdateMeasurement_Call) Run(run func(measurement model.Measurement)) *MockRepo_UpdateMeasurement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Measurement
+		if args[0] != nil {
+			arg0 = args[0].(model.Measurement)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_UpdateMeasurement_Call) Return(err error) *MockRepo_UpdateMeasurement_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_UpdateMeasurement_Call) RunAndReturn(run func(measurement model.Measurement) error) *MockRepo_UpdateMeasurement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMedicationReminder provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpdateMedicationReminder(regimenId int, disabled bool, times []string) error {
+	ret := _mock.Called(regimenId, disabled, times)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMedicationReminder")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, bool, []string) error); ok {
+		r0 = returnFunc(regimenId, disabled, times)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_UpdateMedicationReminder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMedicationReminder'
+type MockRepo_UpdateMedicationReminder_Call struct {
+	*mock.Call
+}
+
+// UpdateMedicationReminder is a helper method to define mock.On call
+//   - regimenId int
+//   - disabled bool
+//   - times []string
+func (_e *MockRepo_Expecter) UpdateMedicationReminder(regimenId interface{}, disabled interface{}, times interface{}) *MockRepo_UpdateMedicationReminder_Call {
+	return &MockRepo_UpdateMedicationReminder_Call{Call: _e.mock.On("UpdateMedicationReminder", regimenId, disabled, times)}
+}
+
+func (_c *MockRepo_UpdateMedicationReminder_Call) Run(run func(regimenId int, disabled bool, times []string)) *MockRepo_UpdateMedicationReminder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_UpdateMedicationReminder_Call) Return(err error) *MockRepo_UpdateMedicationReminder_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_UpdateMedicationReminder_Call) RunAndReturn(run func(regimenId int, disabled bool, times []string) error) *MockRepo_UpdateMedicationReminder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateOutboxMessage provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpdateOutboxMessage(message model.NotificationOutbox) error {
+	ret := _mock.Called(message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOutboxMessage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.NotificationOutbox) error); ok {
+		r0 = returnFunc(message)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_UpdateOutboxMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOutboxMessage'
+type MockRepo_UpdateOutboxMessage_Call struct {
+	*mock.Call
+}
+
+// UpdateOutboxMessage is a helper method to define mock.On call
+//   - message model.NotificationOutbox
+func (_e *MockRepo_Expecter) UpdateOutboxMessage(message interface{}) *MockRepo_UpdateOutboxMessage_Call {
+	return &MockRepo_UpdateOutboxMessage_Call{Call: _e.mock.On("UpdateOutboxMessage", message)}
+}
+
+func (_c *MockRepo_UpdateOutboxMessage_Call) Run(run func(message model.NotificationOutbox)) *MockRepo_UpdateOutboxMessage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 model.Doctor
+		var arg0 model.NotificationOutbox
 		if args[0] != nil {
-			arg0 = args[0].(model.Doctor)
+			arg0 = args[0].(model.NotificationOutbox)
 		}
 		run(
 			arg0,
@@ -2156,12 +9149,12 @@ func (_c *MockRepo_UpdateDoctor_Call) Run(run func(doctor model.Doctor)) *MockRe
 	return _c
 }
 
-func (_c *MockRepo_UpdateDoctor_Call) Return(err error) *MockRepo_UpdateDoctor_Call {
+func (_c *MockRepo_UpdateOutboxMessage_Call) Return(err error) *MockRepo_UpdateOutboxMessage_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_UpdateDoctor_Call) RunAndReturn(run func(doctor model.Doctor) error) *MockRepo_UpdateDoctor_Call {
+func (_c *MockRepo_UpdateOutboxMessage_Call) RunAndReturn(run func(message model.NotificationOutbox) error) *MockRepo_UpdateOutboxMessage_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2217,44 +9210,44 @@ func (_c *MockRepo_UpdatePatient_Call) RunAndReturn(run func(patient model.Patie
 	return _c
 }
 
-// UpdatePatientMedicine provides a mock function for the type MockRepo
-func (_mock *MockRepo) UpdatePatientMedicine(patientId int, medicines []model.Medicine) error {
-	ret := _mock.Called(patientId, medicines)
+// UpdatePatientLanguage provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpdatePatientLanguage(patientId int, language model.Language) error {
+	ret := _mock.Called(patientId, language)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdatePatientMedicine")
+		panic("no return value specified for UpdatePatientLanguage")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(int, []model.Medicine) error); ok {
-		r0 = returnFunc(patientId, medicines)
+	if returnFunc, ok := ret.Get(0).(func(int, model.Language) error); ok {
+		r0 = returnFunc(patientId, language)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepo_UpdatePatientMedicine_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePatientMedicine'
-type MockRepo_UpdatePatientMedicine_Call struct {
+// MockRepo_UpdatePatientLanguage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePatientLanguage'
+type MockRepo_UpdatePatientLanguage_Call struct {
 	*mock.Call
 }
 
-// UpdatePatientMedicine is a helper method to define mock.On call
+// UpdatePatientLanguage is a helper method to define mock.On call
 //   - patientId int
-//   - medicines []model.Medicine
-func (_e *MockRepo_Expecter) UpdatePatientMedicine(patientId interface{}, medicines interface{}) *MockRepo_UpdatePatientMedicine_Call {
-	return &MockRepo_UpdatePatientMedicine_Call{Call: _e.mock.On("UpdatePatientMedicine", patientId, medicines)}
+//   - language model.Language
+func (_e *MockRepo_Expecter) UpdatePatientLanguage(patientId interface{}, language interface{}) *MockRepo_UpdatePatientLanguage_Call {
+	return &MockRepo_UpdatePatientLanguage_Call{Call: _e.mock.On("UpdatePatientLanguage", patientId, language)}
 }
 
-func (_c *MockRepo_UpdatePatientMedicine_Call) Run(run func(patientId int, medicines []model.Medicine)) *MockRepo_UpdatePatientMedicine_Call {
+func (_c *MockRepo_UpdatePatientLanguage_Call) Run(run func(patientId int, language model.Language)) *MockRepo_UpdatePatientLanguage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int
 		if args[0] != nil {
 			arg0 = args[0].(int)
 		}
-		var arg1 []model.Medicine
+		var arg1 model.Language
 		if args[1] != nil {
-			arg1 = args[1].([]model.Medicine)
+			arg1 = args[1].(model.Language)
 		}
 		run(
 			arg0,
@@ -2264,12 +9257,12 @@ func (_c *MockRepo_UpdatePatientMedicine_Call) Run(run func(patientId int, medic
 	return _c
 }
 
-func (_c *MockRepo_UpdatePatientMedicine_Call) Return(err error) *MockRepo_UpdatePatientMedicine_Call {
+func (_c *MockRepo_UpdatePatientLanguage_Call) Return(err error) *MockRepo_UpdatePatientLanguage_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_UpdatePatientMedicine_Call) RunAndReturn(run func(patientId int, medicines []model.Medicine) error) *MockRepo_UpdatePatientMedicine_Call {
+func (_c *MockRepo_UpdatePatientLanguage_Call) RunAndReturn(run func(patientId int, language model.Language) error) *MockRepo_UpdatePatientLanguage_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2388,106 +9381,112 @@ func (_c *MockRepo_UpdatePatientPin_Call) RunAndReturn(run func(patientId int, n
 	return _c
 }
 
-// UpdatePatientVaccineHistory provides a mock function for the type MockRepo
-func (_mock *MockRepo) UpdatePatientVaccineHistory(patientId int, vaccineHistory []model.VaccineHistory) error {
-	ret := _mock.Called(patientId, vaccineHistory)
+// UpdateQuestionAnswer provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpdateQuestionAnswer(questionId int, answer string, doctorId int) error {
+	ret := _mock.Called(questionId, answer, doctorId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdatePatientVaccineHistory")
+		panic("no return value specified for UpdateQuestionAnswer")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(int, []model.VaccineHistory) error); ok {
-		r0 = returnFunc(patientId, vaccineHistory)
+	if returnFunc, ok := ret.Get(0).(func(int, string, int) error); ok {
+		r0 = returnFunc(questionId, answer, doctorId)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepo_UpdatePatientVaccineHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePatientVaccineHistory'
-type MockRepo_UpdatePatientVaccineHistory_Call struct {
+// MockRepo_UpdateQuestionAnswer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateQuestionAnswer'
+type MockRepo_UpdateQuestionAnswer_Call struct {
 	*mock.Call
 }
 
-// UpdatePatientVaccineHistory is a helper method to define mock.On call
-//   - patientId int
-//   - vaccineHistory []model.VaccineHistory
-func (_e *MockRepo_Expecter) UpdatePatientVaccineHistory(patientId interface{}, vaccineHistory interface{}) *MockRepo_UpdatePatientVaccineHistory_Call {
-	return &MockRepo_UpdatePatientVaccineHistory_Call{Call: _e.mock.On("UpdatePatientVaccineHistory", patientId, vaccineHistory)}
+// UpdateQuestionAnswer is a helper method to define mock.On call
+//   - questionId int
+//   - answer string
+//   - doctorId int
+func (_e *MockRepo_Expecter) UpdateQuestionAnswer(questionId interface{}, answer interface{}, doctorId interface{}) *MockRepo_UpdateQuestionAnswer_Call {
+	return &MockRepo_UpdateQuestionAnswer_Call{Call: _e.mock.On("UpdateQuestionAnswer", questionId, answer, doctorId)}
 }
 
-func (_c *MockRepo_UpdatePatientVaccineHistory_Call) Run(run func(patientId int, vaccineHistory []model.VaccineHistory)) *MockRepo_UpdatePatientVaccineHistory_Call {
+func (_c *MockRepo_UpdateQuestionAnswer_Call) Run(run func(questionId int, answer string, doctorId int)) *MockRepo_UpdateQuestionAnswer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int
 		if args[0] != nil {
 			arg0 = args[0].(int)
 		}
-		var arg1 []model.VaccineHistory
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].([]model.VaccineHistory)
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepo_UpdatePatientVaccineHistory_Call) Return(err error) *MockRepo_UpdatePatientVaccineHistory_Call {
+func (_c *MockRepo_UpdateQuestionAnswer_Call) Return(err error) *MockRepo_UpdateQuestionAnswer_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_UpdatePatientVaccineHistory_Call) RunAndReturn(run func(patientId int, vaccineHistory []model.VaccineHistory) error) *MockRepo_UpdatePatientVaccineHistory_Call {
+func (_c *MockRepo_UpdateQuestionAnswer_Call) RunAndReturn(run func(questionId int, answer string, doctorId int) error) *MockRepo_UpdateQuestionAnswer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateQuestionAnswer provides a mock function for the type MockRepo
-func (_mock *MockRepo) UpdateQuestionAnswer(questionId int, answer string, doctorId int) error {
-	ret := _mock.Called(questionId, answer, doctorId)
+// UpdateQuestionTriage provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpdateQuestionTriage(questionId int, category model.QuestionCategory, priority model.QuestionPriority) error {
+	ret := _mock.Called(questionId, category, priority)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateQuestionAnswer")
+		panic("no return value specified for UpdateQuestionTriage")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(int, string, int) error); ok {
-		r0 = returnFunc(questionId, answer, doctorId)
+	if returnFunc, ok := ret.Get(0).(func(int, model.QuestionCategory, model.QuestionPriority) error); ok {
+		r0 = returnFunc(questionId, category, priority)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepo_UpdateQuestionAnswer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateQuestionAnswer'
-type MockRepo_UpdateQuestionAnswer_Call struct {
+// MockRepo_UpdateQuestionTriage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateQuestionTriage'
+type MockRepo_UpdateQuestionTriage_Call struct {
 	*mock.Call
 }
 
-// UpdateQuestionAnswer is a helper method to define mock.On call
+// UpdateQuestionTriage is a helper method to define mock.On call
 //   - questionId int
-//   - answer string
-//   - doctorId int
-func (_e *MockRepo_Expecter) UpdateQuestionAnswer(questionId interface{}, answer interface{}, doctorId interface{}) *MockRepo_UpdateQuestionAnswer_Call {
-	return &MockRepo_UpdateQuestionAnswer_Call{Call: _e.mock.On("UpdateQuestionAnswer", questionId, answer, doctorId)}
+//   - category model.QuestionCategory
+//   - priority model.QuestionPriority
+func (_e *MockRepo_Expecter) UpdateQuestionTriage(questionId interface{}, category interface{}, priority interface{}) *MockRepo_UpdateQuestionTriage_Call {
+	return &MockRepo_UpdateQuestionTriage_Call{Call: _e.mock.On("UpdateQuestionTriage", questionId, category, priority)}
 }
 
-func (_c *MockRepo_UpdateQuestionAnswer_Call) Run(run func(questionId int, answer string, doctorId int)) *MockRepo_UpdateQuestionAnswer_Call {
+func (_c *MockRepo_UpdateQuestionTriage_Call) Run(run func(questionId int, category model.QuestionCategory, priority model.QuestionPriority)) *MockRepo_UpdateQuestionTriage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int
 		if args[0] != nil {
 			arg0 = args[0].(int)
 		}
-		var arg1 string
+		var arg1 model.QuestionCategory
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(model.QuestionCategory)
 		}
-		var arg2 int
+		var arg2 model.QuestionPriority
 		if args[2] != nil {
-			arg2 = args[2].(int)
+			arg2 = args[2].(model.QuestionPriority)
 		}
 		run(
 			arg0,
@@ -2498,12 +9497,216 @@ func (_c *MockRepo_UpdateQuestionAnswer_Call) Run(run func(questionId int, answe
 	return _c
 }
 
-func (_c *MockRepo_UpdateQuestionAnswer_Call) Return(err error) *MockRepo_UpdateQuestionAnswer_Call {
+func (_c *MockRepo_UpdateQuestionTriage_Call) Return(err error) *MockRepo_UpdateQuestionTriage_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepo_UpdateQuestionAnswer_Call) RunAndReturn(run func(questionId int, answer string, doctorId int) error) *MockRepo_UpdateQuestionAnswer_Call {
+func (_c *MockRepo_UpdateQuestionTriage_Call) RunAndReturn(run func(questionId int, category model.QuestionCategory, priority model.QuestionPriority) error) *MockRepo_UpdateQuestionTriage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateReminderRule provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpdateReminderRule(rule model.ReminderRule) error {
+	ret := _mock.Called(rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateReminderRule")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.ReminderRule) error); ok {
+		r0 = returnFunc(rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_UpdateReminderRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateReminderRule'
+type MockRepo_UpdateReminderRule_Call struct {
+	*mock.Call
+}
+
+// UpdateReminderRule is a helper method to define mock.On call
+//   - rule model.ReminderRule
+func (_e *MockRepo_Expecter) UpdateReminderRule(rule interface{}) *MockRepo_UpdateReminderRule_Call {
+	return &MockRepo_UpdateReminderRule_Call{Call: _e.mock.On("UpdateReminderRule", rule)}
+}
+
+func (_c *MockRepo_UpdateReminderRule_Call) Run(run func(rule model.ReminderRule)) *MockRepo_UpdateReminderRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.ReminderRule
+		if args[0] != nil {
+			arg0 = args[0].(model.ReminderRule)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_UpdateReminderRule_Call) Return(err error) *MockRepo_UpdateReminderRule_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_UpdateReminderRule_Call) RunAndReturn(run func(rule model.ReminderRule) error) *MockRepo_UpdateReminderRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateVaccination provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpdateVaccination(vaccination model.Vaccination) error {
+	ret := _mock.Called(vaccination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVaccination")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.Vaccination) error); ok {
+		r0 = returnFunc(vaccination)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_UpdateVaccination_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateVaccination'
+type MockRepo_UpdateVaccination_Call struct {
+	*mock.Call
+}
+
+// UpdateVaccination is a helper method to define mock.On call
+//   - vaccination model.Vaccination
+func (_e *MockRepo_Expecter) UpdateVaccination(vaccination interface{}) *MockRepo_UpdateVaccination_Call {
+	return &MockRepo_UpdateVaccination_Call{Call: _e.mock.On("UpdateVaccination", vaccination)}
+}
+
+func (_c *MockRepo_UpdateVaccination_Call) Run(run func(vaccination model.Vaccination)) *MockRepo_UpdateVaccination_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Vaccination
+		if args[0] != nil {
+			arg0 = args[0].(model.Vaccination)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_UpdateVaccination_Call) Return(err error) *MockRepo_UpdateVaccination_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_UpdateVaccination_Call) RunAndReturn(run func(vaccination model.Vaccination) error) *MockRepo_UpdateVaccination_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateVaccine provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpdateVaccine(vaccine model.Vaccine) error {
+	ret := _mock.Called(vaccine)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVaccine")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.Vaccine) error); ok {
+		r0 = returnFunc(vaccine)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_UpdateVaccine_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateVaccine'
+type MockRepo_UpdateVaccine_Call struct {
+	*mock.Call
+}
+
+// UpdateVaccine is a helper method to define mock.On call
+//   - vaccine model.Vaccine
+func (_e *MockRepo_Expecter) UpdateVaccine(vaccine interface{}) *MockRepo_UpdateVaccine_Call {
+	return &MockRepo_UpdateVaccine_Call{Call: _e.mock.On("UpdateVaccine", vaccine)}
+}
+
+func (_c *MockRepo_UpdateVaccine_Call) Run(run func(vaccine model.Vaccine)) *MockRepo_UpdateVaccine_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.Vaccine
+		if args[0] != nil {
+			arg0 = args[0].(model.Vaccine)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_UpdateVaccine_Call) Return(err error) *MockRepo_UpdateVaccine_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_UpdateVaccine_Call) RunAndReturn(run func(vaccine model.Vaccine) error) *MockRepo_UpdateVaccine_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateVaccineRule provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpdateVaccineRule(rule model.VaccineRule) error {
+	ret := _mock.Called(rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVaccineRule")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.VaccineRule) error); ok {
+		r0 = returnFunc(rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_UpdateVaccineRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateVaccineRule'
+type MockRepo_UpdateVaccineRule_Call struct {
+	*mock.Call
+}
+
+// UpdateVaccineRule is a helper method to define mock.On call
+//   - rule model.VaccineRule
+func (_e *MockRepo_Expecter) UpdateVaccineRule(rule interface{}) *MockRepo_UpdateVaccineRule_Call {
+	return &MockRepo_UpdateVaccineRule_Call{Call: _e.mock.On("UpdateVaccineRule", rule)}
+}
+
+func (_c *MockRepo_UpdateVaccineRule_Call) Run(run func(rule model.VaccineRule)) *MockRepo_UpdateVaccineRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.VaccineRule
+		if args[0] != nil {
+			arg0 = args[0].(model.VaccineRule)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_UpdateVaccineRule_Call) Return(err error) *MockRepo_UpdateVaccineRule_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_UpdateVaccineRule_Call) RunAndReturn(run func(rule model.VaccineRule) error) *MockRepo_UpdateVaccineRule_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2567,3 +9770,105 @@ func (_c *MockRepo_UpsertConsent_Call) RunAndReturn(run func(consent model.Conse
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpsertNotificationPreference provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpsertNotificationPreference(pref model.NotificationPreference) error {
+	ret := _mock.Called(pref)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertNotificationPreference")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.NotificationPreference) error); ok {
+		r0 = returnFunc(pref)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_UpsertNotificationPreference_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertNotificationPreference'
+type MockRepo_UpsertNotificationPreference_Call struct {
+	*mock.Call
+}
+
+// UpsertNotificationPreference is a helper method to define mock.On call
+//   - pref model.NotificationPreference
+func (_e *MockRepo_Expecter) UpsertNotificationPreference(pref interface{}) *MockRepo_UpsertNotificationPreference_Call {
+	return &MockRepo_UpsertNotificationPreference_Call{Call: _e.mock.On("UpsertNotificationPreference", pref)}
+}
+
+func (_c *MockRepo_UpsertNotificationPreference_Call) Run(run func(pref model.NotificationPreference)) *MockRepo_UpsertNotificationPreference_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.NotificationPreference
+		if args[0] != nil {
+			arg0 = args[0].(model.NotificationPreference)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_UpsertNotificationPreference_Call) Return(err error) *MockRepo_UpsertNotificationPreference_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_UpsertNotificationPreference_Call) RunAndReturn(run func(pref model.NotificationPreference) error) *MockRepo_UpsertNotificationPreference_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertNotificationTemplate provides a mock function for the type MockRepo
+func (_mock *MockRepo) UpsertNotificationTemplate(template model.NotificationTemplate) error {
+	ret := _mock.Called(template)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertNotificationTemplate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(model.NotificationTemplate) error); ok {
+		r0 = returnFunc(template)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepo_UpsertNotificationTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertNotificationTemplate'
+type MockRepo_UpsertNotificationTemplate_Call struct {
+	*mock.Call
+}
+
+// UpsertNotificationTemplate is a helper method to define mock.On call
+//   - template model.NotificationTemplate
+func (_e *MockRepo_Expecter) UpsertNotificationTemplate(template interface{}) *MockRepo_UpsertNotificationTemplate_Call {
+	return &MockRepo_UpsertNotificationTemplate_Call{Call: _e.mock.On("UpsertNotificationTemplate", template)}
+}
+
+func (_c *MockRepo_UpsertNotificationTemplate_Call) Run(run func(template model.NotificationTemplate)) *MockRepo_UpsertNotificationTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 model.NotificationTemplate
+		if args[0] != nil {
+			arg0 = args[0].(model.NotificationTemplate)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepo_UpsertNotificationTemplate_Call) Return(err error) *MockRepo_UpsertNotificationTemplate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepo_UpsertNotificationTemplate_Call) RunAndReturn(run func(template model.NotificationTemplate) error) *MockRepo_UpsertNotificationTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// create reschedule request, an appointment can only have one pending request
+func (r *Repo) CreateRescheduleRequest(request model.RescheduleRequest) (int, error) {
+	request.Status = model.RESCHEDULE_PENDING
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// lock the appointment so concurrent requests are serialized
+		var ap model.Appointment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", request.AppointmentID).First(&ap).Error
+		if err != nil {
+			return err
+		}
+		var pending model.RescheduleRequest
+		err = tx.Where("appointment_id = ? AND status = ?", request.AppointmentID, model.RESCHEDULE_PENDING).First(&pending).Error
+		if err == nil {
+			return ErrDuplicateEntry
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return tx.Create(&request).Error
+	})
+	if err != nil {
+		return -1, fmt.Errorf("exec : %w", err)
+	}
+	return request.ID, nil
+}
+
+/*
+accept or decline pending reschedule request of the appointment,
+accepting moves the appointment to the proposed date
+*/
+func (r *Repo) ResolveRescheduleRequest(appointmentId int, accept bool, reason *string, doctorId int) (model.RescheduleRequest, error) {
+	var request model.RescheduleRequest
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("appointment_id = ? AND status = ?", appointmentId, model.RESCHEDULE_PENDING).
+			First(&request).Error
+		if err != nil {
+			return err
+		}
+		now := int(time.Now().Unix())
+		request.ResolveAt = &now
+		request.ResolveByID = &doctorId
+		if accept {
+			err = changeAppointmentStatus(tx, appointmentId, model.AppointmentStatusChange{
+				Status:    model.RESCHEDULED,
+				Reason:    request.Reason,
+				Date:      &request.ProposedDate,
+				ActorType: model.ACTOR_DOCTOR,
+				ActorID:   &doctorId,
+			})
+			if err != nil {
+				return err
+			}
+			request.Status = model.RESCHEDULE_ACCEPTED
+		} else {
+			request.Status = model.RESCHEDULE_DECLINED
+			request.DeclineReason = reason
+		}
+		return tx.Save(&request).Error
+	})
+	if err != nil {
+		return request, fmt.Errorf("exec : %w", err)
+	}
+	return request, nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"gorm.io/gorm"
+)
+
+func (r *Repo) GetDoctorSchedule(doctorId any) ([]model.DoctorSchedule, error) {
+	res := []model.DoctorSchedule{}
+	err := r.db.Where("doctor_id = ?", doctorId).Order("weekday ASC, start_minute ASC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+// replace all weekly schedules of the doctor
+func (r *Repo) ReplaceDoctorSchedule(doctorId int, schedules []model.DoctorSchedule) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("doctor_id = ?", doctorId).Delete(&model.DoctorSchedule{}).Error
+		if err != nil {
+			return err
+		}
+		if len(schedules) == 0 {
+			return nil
+		}
+		for i := range schedules {
+			schedules[i].ID = 0
+			schedules[i].DoctorID = doctorId
+		}
+		return tx.Create(&schedules).Error
+	})
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+func (r *Repo) GetAllScheduleException(criteria ...Criteria) ([]model.ScheduleException, error) {
+	res := []model.ScheduleException{}
+	db := attachCriteria(r.db, criteria...)
+	err := db.Order("start_at ASC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) CreateScheduleException(exception model.ScheduleException) (int, error) {
+	err := r.db.Create(&exception).Error
+	if err != nil {
+		return -1, fmt.Errorf("exec : %w", err)
+	}
+	return exception.ID, nil
+}
+
+func (r *Repo) DeleteScheduleException(exceptionId any) error {
+	err := r.db.Where("id = ?", exceptionId).Delete(&model.ScheduleException{}).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"gorm.io/gorm"
+)
+
+// signup with a one-time invitation code, ErrInvitationUsed when another signup took the code first
+func (r *Repo) CreatePatientWithInvitation(patient model.Patient, invitationId int) (int, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := releaseRejectedSignup(tx, patient); err != nil {
+			return fmt.Errorf("exec : %w", err)
+		}
+		if err := tx.Create(&patient).Error; err != nil {
+			return constraintError(err)
+		}
+		result := tx.Model(&model.InvitationCode{}).Where("id = ? AND used_by_id IS NULL", invitationId).
+			Updates(map[string]any{"used_by_id": patient.ID, "used_at": int(time.Now().Unix())})
+		if result.Error != nil {
+			return fmt.Errorf("exec : %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("exec : %w", ErrInvitationUsed)
+		}
+		return nil
+	})
+	if err != nil {
+		return -1, err
+	}
+	return patient.ID, nil
+}
+
+/*
+remove rejected signups that hold the NID or HN of the new patient so the patient can sign up again,
+the invitation code of a rejected signup becomes usable again
+*/
+func releaseRejectedSignup(tx *gorm.DB, patient model.Patient) error {
+	return tx.Unscoped().Where("(nid = ? OR hn = ?) AND verification_status = ?", patient.NID, patient.Hn, model.SIGNUP_REJECTED).
+		Delete(&model.Patient{}).Error
+}
+
+// approve a pending signup with the HN confirmed by the staff
+func (r *Repo) ApproveSignup(patientId int, hn string, doctorId int) error {
+	return r.reviewSignup(patientId, doctorId, map[string]any{
+		"verified":            true,
+		"verification_status": model.SIGNUP_APPROVED,
+		"hn":                  hn,
+		"reject_reason":       nil,
+	})
+}
+
+func (r *Repo) RejectSignup(patientId int, reason string, doctorId int) error {
+	return r.reviewSignup(patientId, doctorId, map[string]any{
+		"verified":            false,
+		"verification_status": model.SIGNUP_REJECTED,
+		"reject_reason":       reason,
+	})
+}
+
+// return ErrInvalidStatusTransition when the signup is not pending anymore
+func (r *Repo) reviewSignup(patientId int, doctorId int, values map[string]any) error {
+	values["verified_by_id"] = doctorId
+	values["verified_at"] = int(time.Now().Unix())
+	result := r.db.Model(&model.Patient{}).Where("id = ? AND verification_status = ?", patientId, model.SIGNUP_PENDING).Updates(values)
+	if result.Error != nil {
+		return constraintError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("exec : %w", ErrInvalidStatusTransition)
+	}
+	return nil
+}
+
+func (r *Repo) GetAllInvitationCode(limit int, offset int, criteria ...Criteria) ([]model.InvitationCode, error) {
+	res := []model.InvitationCode{}
+	db := attachCriteria(r.db, criteria...)
+	err := db.Order("create_at DESC").Limit(limit).Offset(offset).Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) GetInvitationCode(code string) (model.InvitationCode, error) {
+	var i model.InvitationCode
+	err := r.db.Where("code = ?", code).First(&i).Error
+	if err != nil {
+		return i, fmt.Errorf("query : %w", err)
+	}
+	return i, nil
+}
+
+func (r *Repo) CreateInvitationCode(invitation model.InvitationCode) (int, error) {
+	err := r.db.Omit("CreatedBy", "UsedBy").Create(&invitation).Error
+	if err != nil {
+		return -1, constraintError(err)
+	}
+	return invitation.ID, nil
+}
+
+func (r *Repo) DeleteInvitationCode(id any) error {
+	err := r.db.Where("id = ?", id).Delete(&model.InvitationCode{}).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"gorm.io/gorm"
+)
+
+func (r *Repo) GetAnswerSnippet(snippetId any) (model.AnswerSnippet, error) {
+	var s model.AnswerSnippet
+	err := r.db.Preload("Content").Where("id = ?", snippetId).First(&s).Error
+	if err != nil {
+		return s, fmt.Errorf("query : %w", err)
+	}
+	return s, nil
+}
+
+// Get all snippets with following criteria and title or body containing search if not empty, most used first
+func (r *Repo) GetAllAnswerSnippet(limit int, offset int, search string, criteria ...Criteria) ([]model.AnswerSnippet, error) {
+	res := []model.AnswerSnippet{}
+	db := attachCriteria(r.db, criteria...)
+	if search != "" {
+		pattern := "%" + search + "%"
+		db = db.Where("(title LIKE ? OR body LIKE ?)", pattern, pattern)
+	}
+	err := db.Preload("Content").Limit(limit).Offset(offset).Order("usage_count DESC, id ASC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) CreateAnswerSnippet(snippet model.AnswerSnippet) (int, error) {
+	err := r.db.Omit("Content").Create(&snippet).Error
+	if err != nil {
+		return -1, fmt.Errorf("exec : %w", err)
+	}
+	return snippet.ID, nil
+}
+
+func (r *Repo) UpdateAnswerSnippet(snippet model.AnswerSnippet) error {
+	err := r.db.Select("title", "body", "shared", "content_id").Updates(&snippet).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+func (r *Repo) DeleteAnswerSnippet(snippetId any) error {
+	err := r.db.Where("id = ?", snippetId).Delete(&model.AnswerSnippet{}).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// count an answer sent from the snippet
+func (r *Repo) IncreaseSnippetUsage(snippetId int) error {
+	err := r.db.Model(&model.AnswerSnippet{}).Where("id = ?", snippetId).
+		Updates(map[string]any{"usage_count": gorm.Expr("usage_count + 1"), "last_used_at": int(time.Now().Unix())}).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
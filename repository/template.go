@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"gorm.io/gorm/clause"
+)
+
+func (r *Repo) GetNotificationTemplate(key model.TemplateKey, language model.Language) (model.NotificationTemplate, error) {
+	var res model.NotificationTemplate
+	err := r.db.Where("`key` = ? AND language = ?", key, language).First(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) GetAllNotificationTemplate() ([]model.NotificationTemplate, error) {
+	res := []model.NotificationTemplate{}
+	err := r.db.Order("`key` ASC, language ASC").Find(&res).Error
+	if err != nil {
+		return nil, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+// create or replace the admin's text of the template in the language
+func (r *Repo) UpsertNotificationTemplate(template model.NotificationTemplate) error {
+	err := r.db.Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"title", "body", "update_at"})}).Create(&template).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// remove the admin's text, the template goes back to the built-in text
+func (r *Repo) DeleteNotificationTemplate(key model.TemplateKey, language model.Language) error {
+	err := r.db.Where("`key` = ? AND language = ?", key, language).Delete(&model.NotificationTemplate{}).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
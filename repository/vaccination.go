@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/go-sql-driver/mysql"
+)
+
+func (r *Repo) GetVaccine(vaccineId any) (model.Vaccine, error) {
+	var v model.Vaccine
+	err := r.db.Where("id = ?", vaccineId).First(&v).Error
+	if err != nil {
+		return v, fmt.Errorf("query : %w", err)
+	}
+	return v, nil
+}
+
+func (r *Repo) GetAllVaccine() ([]model.Vaccine, error) {
+	res := []model.Vaccine{}
+	err := r.db.Order("name ASC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) CreateVaccine(vaccine model.Vaccine) (int, error) {
+	err := r.db.Create(&vaccine).Error
+	if err != nil {
+		return -1, constraintError(err)
+	}
+	return vaccine.ID, nil
+}
+
+func (r *Repo) UpdateVaccine(vaccine model.Vaccine) error {
+	err := r.db.Select("*").Omit("create_at").Updates(&vaccine).Error
+	if err != nil {
+		return constraintError(err)
+	}
+	return nil
+}
+
+// return ErrForeignKeyFail when a schedule rule still uses the vaccine
+func (r *Repo) DeleteVaccine(vaccineId any) error {
+	err := r.db.Where("id = ?", vaccineId).Delete(&model.Vaccine{}).Error
+	if err != nil {
+		return constraintError(err)
+	}
+	return nil
+}
+
+// Get all schedule rules with their vaccine with following criteria
+func (r *Repo) GetAllVaccineRule(criteria ...Criteria) ([]model.VaccineRule, error) {
+	res := []model.VaccineRule{}
+	db := attachCriteria(r.db, criteria...)
+	err := db.Joins("Vaccine").Order("vaccine_rules.min_age_months ASC, vaccine_rules.id ASC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+// return ErrDuplicateEntry when the vaccine already has a rule, ErrForeignKeyFail when the vaccine doesn't exist
+func (r *Repo) CreateVaccineRule(rule model.VaccineRule) (int, error) {
+	err := r.db.Omit("Vaccine").Create(&rule).Error
+	if err != nil {
+		return -1, constraintError(err)
+	}
+	return rule.ID, nil
+}
+
+func (r *Repo) UpdateVaccineRule(rule model.VaccineRule) error {
+	err := r.db.Select("*").Omit("create_at", "Vaccine").Updates(&rule).Error
+	if err != nil {
+		return constraintError(err)
+	}
+	return nil
+}
+
+func (r *Repo) DeleteVaccineRule(ruleId any) error {
+	err := r.db.Where("id = ?", ruleId).Delete(&model.VaccineRule{}).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+func (r *Repo) GetVaccination(patientId any, vaccinationId any) (model.Vaccination, error) {
+	var v model.Vaccination
+	err := r.db.Where("id = ? AND patient_id = ?", vaccinationId, patientId).First(&v).Error
+	if err != nil {
+		return v, fmt.Errorf("query : %w", err)
+	}
+	return v, nil
+}
+
+// Get all vaccinations of the patient with following criteria, oldest first
+func (r *Repo) GetAllVaccination(patientId int, criteria ...Criteria) ([]model.Vaccination, error) {
+	res := []model.Vaccination{}
+	db := attachCriteria(r.db, criteria...)
+	err := db.Where("patient_id = ?", patientId).Order("vaccinated_at ASC, id ASC").Find(&res).Error
+	if err != nil {
+		return res, fmt.Errorf("query : %w", err)
+	}
+	return res, nil
+}
+
+func (r *Repo) CreateVaccination(vaccination model.Vaccination) (int, error) {
+	err := r.db.Create(&vaccination).Error
+	if err != nil {
+		return -1, fmt.Errorf("exec : %w", err)
+	}
+	return vaccination.ID, nil
+}
+
+// update vaccine, date, location and complication of the vaccination
+func (r *Repo) UpdateVaccination(vaccination model.Vaccination) error {
+	err := r.db.Select("vaccine_id", "vaccine_name", "vaccinated_at", "location", "complication").Updates(&vaccination).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+func (r *Repo) DeleteVaccination(vaccinationId any) error {
+	err := r.db.Where("id = ?", vaccinationId).Delete(&model.Vaccination{}).Error
+	if err != nil {
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// record reminder in the ledger, ErrDuplicateEntry means the dose is already reminded
+func (r *Repo) CreateVaccineReminderLog(log model.VaccineReminderLog) error {
+	err := r.db.Create(&log).Error
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return fmt.Errorf("exec : %w", ErrDuplicateEntry)
+		}
+		return fmt.Errorf("exec : %w", err)
+	}
+	return nil
+}
+
+// map unique and foreign key violations to ErrDuplicateEntry and ErrForeignKeyFail
+func constraintError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case 1062:
+			return fmt.Errorf("exec : %w", ErrDuplicateEntry)
+		case 1451, 1452:
+			return fmt.Errorf("exec : %w", ErrForeignKeyFail)
+		}
+	}
+	return fmt.Errorf("exec : %w", err)
+}
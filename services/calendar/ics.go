@@ -0,0 +1,141 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+)
+
+// iCalendar methods
+const (
+	METHOD_PUBLISH = "PUBLISH"
+	METHOD_CANCEL  = "CANCEL"
+)
+
+const CONTENT_TYPE = "text/calendar; charset=utf-8"
+
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       int
+	End         int
+	Stamp       int // last modified
+	Sequence    int
+	Status      string // TENTATIVE, CONFIRMED or CANCELLED
+}
+
+// uid must be stable so calendar apps update the same event
+func AppointmentUID(appointmentId int) string {
+	return fmt.Sprintf("appointment-%d@duchenne-server", appointmentId)
+}
+
+func AppointmentEvent(ap model.SafeAppointment, summary string) Event {
+	return Event{
+		UID:         AppointmentUID(ap.ID),
+		Summary:     summary,
+		Description: fmt.Sprintf("สถานะ: %v", ap.Status),
+		Start:       ap.Date,
+		End:         ap.EndDate(),
+		Stamp:       ap.UpdateAt,
+		// update_at only increases, so calendar apps see every change as a newer revision
+		Sequence: max(ap.UpdateAt-ap.CreateAt, 0),
+		Status:   eventStatus(ap.Status),
+	}
+}
+
+// summary in doctor's feed shows the patient
+func SummaryForDoctor(ap model.SafeAppointment) string {
+	return fmt.Sprintf("นัดหมาย %v (HN %v)", fullName(ap.Patient.FirstName, ap.Patient.MiddleName, ap.Patient.LastName), ap.Patient.Hn)
+}
+
+// summary in patient's feed shows the doctor
+func SummaryForPatient(ap model.SafeAppointment) string {
+	return fmt.Sprintf("นัดหมายกับ %v", fullName(ap.Doctor.FirstName, ap.Doctor.MiddleName, ap.Doctor.LastName))
+}
+
+func fullName(firstName string, middleName *string, lastName string) string {
+	if middleName != nil && *middleName != "" {
+		return firstName + " " + *middleName + " " + lastName
+	}
+	return firstName + " " + lastName
+}
+
+func eventStatus(status model.AppointmentStatus) string {
+	switch status {
+	case model.REQUESTED:
+		return "TENTATIVE"
+	case model.REJECTED, model.CANCELLED_BY_PATIENT, model.CANCELLED_BY_STAFF:
+		return "CANCELLED"
+	default:
+		return "CONFIRMED"
+	}
+}
+
+// method for single appointment file, cancelled appointment removes the event from calendar
+func AppointmentMethod(ap model.SafeAppointment) string {
+	if eventStatus(ap.Status) == "CANCELLED" {
+		return METHOD_CANCEL
+	}
+	return METHOD_PUBLISH
+}
+
+// build VCALENDAR object, lines are CRLF terminated and folded at 75 octets as RFC 5545
+func Build(name string, method string, events []Event) string {
+	var b strings.Builder
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:-//DMD We Care//Appointment//TH")
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:"+method)
+	writeLine(&b, "X-WR-CALNAME:"+escapeText(name))
+	for _, e := range events {
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+e.UID)
+		writeLine(&b, "DTSTAMP:"+formatTime(e.Stamp))
+		writeLine(&b, "LAST-MODIFIED:"+formatTime(e.Stamp))
+		writeLine(&b, "DTSTART:"+formatTime(e.Start))
+		writeLine(&b, "DTEND:"+formatTime(e.End))
+		writeLine(&b, fmt.Sprintf("SEQUENCE:%d", e.Sequence))
+		writeLine(&b, "STATUS:"+e.Status)
+		writeLine(&b, "SUMMARY:"+escapeText(e.Summary))
+		if e.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escapeText(e.Description))
+		}
+		writeLine(&b, "END:VEVENT")
+	}
+	writeLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+func formatTime(unix int) string {
+	return time.Unix(int64(unix), 0).UTC().Format("20060102T150405Z")
+}
+
+func escapeText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// fold long line without splitting multi-byte characters e.g. Thai names
+func writeLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // continuation line starts with a space
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
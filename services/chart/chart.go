@@ -0,0 +1,65 @@
+package chart
+
+import (
+	"github.com/PhasitWo/duchenne-server/model"
+)
+
+const secondsPerYear = 365.2425 * 24 * 60 * 60
+
+// rate of change isn't given for points closer than this, in seconds, it would be mostly noise
+const MIN_RATE_SPAN = 90 * 24 * 60 * 60
+
+// age in years at the time from the birth date
+func AgeYears(birthDate int, at int) float64 {
+	return float64(at-birthDate) / secondsPerYear
+}
+
+/*
+chart series of the measurements in MEASUREMENT_TYPES order, measurements must be oldest first,
+reference curves over the patient's ages are added for types that have them when withReference is true
+*/
+func NewCharts(birthDate int, measurements []model.Measurement, withReference bool) []model.MeasurementChart {
+	points := map[model.MeasurementType][]model.ChartPoint{}
+	for _, m := range measurements {
+		points[m.Type] = append(points[m.Type], model.ChartPoint{
+			ID:         m.ID,
+			Value:      m.Value,
+			MeasuredAt: m.MeasuredAt,
+			AgeYears:   AgeYears(birthDate, m.MeasuredAt),
+		})
+	}
+	res := []model.MeasurementChart{}
+	for _, t := range model.MEASUREMENT_TYPES {
+		p := points[t]
+		if len(p) == 0 {
+			continue
+		}
+		chart := model.MeasurementChart{Type: t, Unit: model.MEASUREMENT_UNITS[t], Points: p, RatePerYear: ratePerYear(p)}
+		if withReference {
+			chart.Reference = referenceCurves(t, p[0].AgeYears, p[len(p)-1].AgeYears)
+		}
+		res = append(res, chart)
+	}
+	return res
+}
+
+// least squares slope of value by age, nil when the points don't span MIN_RATE_SPAN
+func ratePerYear(points []model.ChartPoint) *float64 {
+	if len(points) < 2 || points[len(points)-1].MeasuredAt-points[0].MeasuredAt < MIN_RATE_SPAN {
+		return nil
+	}
+	var meanAge, meanValue float64
+	for _, p := range points {
+		meanAge += p.AgeYears
+		meanValue += p.Value
+	}
+	meanAge /= float64(len(points))
+	meanValue /= float64(len(points))
+	var cov, variance float64
+	for _, p := range points {
+		cov += (p.AgeYears - meanAge) * (p.Value - meanValue)
+		variance += (p.AgeYears - meanAge) * (p.AgeYears - meanAge)
+	}
+	rate := cov / variance
+	return &rate
+}
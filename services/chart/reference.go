@@ -0,0 +1,114 @@
+package chart
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/PhasitWo/duchenne-server/model"
+)
+
+// percentiles by age of each measurement type, header is type,ageYears,p5,p10,...
+//
+//go:embed reference.csv
+var referenceCSV string
+
+type reference struct {
+	percentiles []int
+	ages        []float64
+	values      [][]float64 // values[i][j] is percentiles[j] at ages[i]
+}
+
+var references = mustloadReferences(referenceCSV)
+
+func mustloadReferences(text string) map[model.MeasurementType]*reference {
+	res, err := loadReferences(strings.NewReader(text))
+	if err != nil {
+		panic(fmt.Sprintf("invalid bundled reference.csv : %v", err.Error()))
+	}
+	return res
+}
+
+// parse reference percentiles, rows of a type must be in age order
+func loadReferences(r io.Reader) (map[model.MeasurementType]*reference, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 || len(rows[0]) < 3 {
+		return nil, fmt.Errorf("missing header")
+	}
+	percentiles := []int{}
+	for _, column := range rows[0][2:] {
+		p, err := strconv.Atoi(strings.TrimPrefix(column, "p"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid percentile column %q", column)
+		}
+		percentiles = append(percentiles, p)
+	}
+	res := map[model.MeasurementType]*reference{}
+	for i, row := range rows[1:] {
+		t := model.MeasurementType(row[0])
+		if !t.IsValid() {
+			return nil, fmt.Errorf("line %d : invalid type %q", i+2, row[0])
+		}
+		age, err := strconv.ParseFloat(row[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d : invalid age %q", i+2, row[1])
+		}
+		values := []float64{}
+		for _, v := range row[2:] {
+			value, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d : invalid value %q", i+2, v)
+			}
+			values = append(values, value)
+		}
+		ref, ok := res[t]
+		if !ok {
+			ref = &reference{percentiles: percentiles}
+			res[t] = ref
+		}
+		if n := len(ref.ages); n > 0 && age <= ref.ages[n-1] {
+			return nil, fmt.Errorf("line %d : age is not after the previous row", i+2)
+		}
+		ref.ages = append(ref.ages, age)
+		ref.values = append(ref.values, values)
+	}
+	return res, nil
+}
+
+/*
+percentile curves of the type that cover the ages with one reference row beyond each end,
+nil when the type has no reference
+*/
+func referenceCurves(t model.MeasurementType, fromAge float64, toAge float64) []model.ReferenceCurve {
+	ref, ok := references[t]
+	if !ok {
+		return nil
+	}
+	first, last := 0, len(ref.ages)-1
+	for i, age := range ref.ages {
+		if age <= fromAge {
+			first = i
+		}
+		if age >= toAge && i < last {
+			last = i
+			break
+		}
+	}
+	res := []model.ReferenceCurve{}
+	for j, percentile := range ref.percentiles {
+		curve := model.ReferenceCurve{Percentile: percentile, Points: []model.ReferencePoint{}}
+		for i := first; i <= last; i++ {
+			curve.Points = append(curve.Points, model.ReferencePoint{AgeYears: ref.ages[i], Value: ref.values[i][j]})
+		}
+		res = append(res, curve)
+	}
+	return res
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"cloud.google.com/go/storage"
+	"github.com/PhasitWo/duchenne-server/config"
 )
 
 const bucketName = "dmd-we-care"
@@ -20,6 +21,9 @@ var gcsLogger = log.New(os.Stdout, "[GCS] ", log.LstdFlags)
 
 type ICloudStorageService interface {
 	UploadImage(file *multipart.FileHeader) (*string, error)
+	UploadPrivateFile(file *multipart.FileHeader, objectName string, contentType string) error
+	DeletePrivateFile(objectName string) error
+	SignedURL(objectName string, expires time.Time) (string, error)
 }
 
 type service struct {
@@ -58,3 +62,54 @@ func (s *service) UploadImage(file *multipart.FileHeader) (*string, error) {
 	publicURL := fmt.Sprintf("https://storage.googleapis.com/%s/%s", bucketName, filename)
 	return &publicURL, nil
 }
+
+// upload to the private bucket, the file can only be read by a signed url
+func (s *service) UploadPrivateFile(file *multipart.FileHeader, objectName string, contentType string) error {
+	src, err := file.Open()
+	if err != nil {
+		gcsLogger.Println("error opening file")
+		return errors.New("error opening file")
+	}
+	defer src.Close()
+
+	ctx := context.Background()
+	storageWriter := s.client.Bucket(config.AppConfig.GCS_PRIVATE_BUCKET).Object(objectName).NewWriter(ctx)
+	storageWriter.ContentType = contentType
+
+	if _, err := io.Copy(storageWriter, src); err != nil {
+		er := fmt.Errorf("error writing to GCS: %v", err)
+		gcsLogger.Println(er.Error())
+		return er
+	}
+	if err := storageWriter.Close(); err != nil {
+		er := fmt.Errorf("error closing GCS writer %v", err)
+		gcsLogger.Println(er.Error())
+		return er
+	}
+	return nil
+}
+
+func (s *service) DeletePrivateFile(objectName string) error {
+	err := s.client.Bucket(config.AppConfig.GCS_PRIVATE_BUCKET).Object(objectName).Delete(context.Background())
+	if err != nil {
+		er := fmt.Errorf("error deleting from GCS: %v", err)
+		gcsLogger.Println(er.Error())
+		return er
+	}
+	return nil
+}
+
+// short-lived url to download a file of the private bucket
+func (s *service) SignedURL(objectName string, expires time.Time) (string, error) {
+	url, err := s.client.Bucket(config.AppConfig.GCS_PRIVATE_BUCKET).SignedURL(objectName, &storage.SignedURLOptions{
+		Scheme:  storage.SigningSchemeV4,
+		Method:  "GET",
+		Expires: expires,
+	})
+	if err != nil {
+		er := fmt.Errorf("error signing GCS url: %v", err)
+		gcsLogger.Println(er.Error())
+		return "", er
+	}
+	return url, nil
+}
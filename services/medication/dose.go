@@ -0,0 +1,166 @@
+package medication
+
+import (
+	"sort"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+)
+
+// longest range of doses listed at once, in seconds
+const MAX_DOSE_RANGE = 31 * 24 * 60 * 60
+
+// custom reminder times of the regimen or default times of its schedule, nil when it isn't taken by the clock
+func DoseTimes(regimen model.MedicationRegimen) []string {
+	if _, ok := model.DEFAULT_DOSE_TIMES[regimen.Schedule]; !ok {
+		return nil
+	}
+	if len(regimen.ReminderTimes) > 0 {
+		return regimen.ReminderTimes
+	}
+	return model.DEFAULT_DOSE_TIMES[regimen.Schedule]
+}
+
+/*
+scheduled doses of the regimens in [from, to) oldest first, a regimen has doses from its start date
+until its end date, regimens with unknown start date are taken as started long ago
+*/
+func ScheduledDoses(regimens []model.MedicationRegimen, from int, to int, loc *time.Location) []model.ScheduledDose {
+	res := []model.ScheduledDose{}
+	if from >= to {
+		return res
+	}
+	start := time.Unix(int64(from), 0).In(loc)
+	end := time.Unix(int64(to), 0).In(loc)
+	for _, regimen := range regimens {
+		times := DoseTimes(regimen)
+		if len(times) == 0 {
+			continue
+		}
+		day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+		for !day.After(end) {
+			if dueOn(regimen, day, loc) {
+				for _, clock := range times {
+					at, ok := doseAt(day, clock, loc)
+					if !ok || at < from || at >= to || !activeAt(regimen, at) {
+						continue
+					}
+					res = append(res, model.ScheduledDose{
+						RegimenID:    regimen.ID,
+						MedicineName: regimen.MedicineName,
+						Dose:         regimen.Dose,
+						DoseUnit:     regimen.DoseUnit,
+						ScheduledAt:  at,
+					})
+				}
+			}
+			day = day.AddDate(0, 0, 1)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].ScheduledAt < res[j].ScheduledAt })
+	return res
+}
+
+// the patient can log a dose at this time, any past time for medicines without schedule
+func IsDoseTime(regimen model.MedicationRegimen, at int, loc *time.Location) bool {
+	if DoseTimes(regimen) == nil {
+		return activeAt(regimen, at)
+	}
+	for _, dose := range ScheduledDoses([]model.MedicationRegimen{regimen}, at, at+1, loc) {
+		if dose.ScheduledAt == at {
+			return true
+		}
+	}
+	return false
+}
+
+// attach logged status to the scheduled doses
+func WithStatus(doses []model.ScheduledDose, logged []model.MedicationDose) []model.ScheduledDose {
+	status := map[[2]int]*model.DoseStatus{}
+	for _, l := range logged {
+		status[[2]int{l.RegimenID, l.ScheduledAt}] = l.Status
+	}
+	for i := range doses {
+		doses[i].Status = status[[2]int{doses[i].RegimenID, doses[i].ScheduledAt}]
+	}
+	return doses
+}
+
+/*
+adherence of the weeks from monday of weekStart, doses after now are not expected yet,
+doses before the regimen was recorded are not expected either, a dose that is never logged counts as missed
+*/
+func WeeklyAdherence(regimens []model.MedicationRegimen, logged []model.MedicationDose, weekStart int, weeks int, now int, loc *time.Location) []model.WeeklyAdherence {
+	recordedAt := map[int]int{}
+	for _, regimen := range regimens {
+		recordedAt[regimen.ID] = regimen.CreateAt
+	}
+	t := time.Unix(int64(weekStart), 0).In(loc)
+	monday := time.Date(t.Year(), t.Month(), t.Day()-(int(t.Weekday())+6)%7, 0, 0, 0, 0, loc)
+	res := []model.WeeklyAdherence{}
+	for w := 0; w < weeks; w++ {
+		from := int(monday.AddDate(0, 0, 7*w).Unix())
+		to := int(monday.AddDate(0, 0, 7*(w+1)).Unix())
+		week := model.WeeklyAdherence{WeekStart: from}
+		doses := WithStatus(ScheduledDoses(regimens, from, min(to, now+1), loc), logged)
+		for _, dose := range doses {
+			if dose.ScheduledAt < recordedAt[dose.RegimenID] {
+				continue
+			}
+			week.Expected++
+			if dose.Status == nil {
+				continue
+			}
+			switch *dose.Status {
+			case model.DOSE_TAKEN:
+				week.Taken++
+			case model.DOSE_SKIPPED:
+				week.Skipped++
+			}
+		}
+		if week.Expected > 0 {
+			percent := float64(week.Taken) * 100 / float64(week.Expected)
+			week.Percent = &percent
+		}
+		res = append(res, week)
+	}
+	return res
+}
+
+func dueOn(regimen model.MedicationRegimen, day time.Time, loc *time.Location) bool {
+	switch regimen.Schedule {
+	case model.SCHEDULE_WEEKEND:
+		return day.Weekday() == time.Saturday || day.Weekday() == time.Sunday
+	case model.SCHEDULE_WEEKLY:
+		if regimen.StartDate == nil {
+			return day.Weekday() == time.Monday
+		}
+		return day.Weekday() == time.Unix(int64(*regimen.StartDate), 0).In(loc).Weekday()
+	case model.SCHEDULE_ALTERNATE:
+		first := time.Unix(0, 0).In(loc)
+		if regimen.StartDate != nil {
+			first = time.Unix(int64(*regimen.StartDate), 0).In(loc)
+		}
+		first = time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc)
+		// count calendar days, not hours, so daylight saving doesn't shift the parity
+		days := int(time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC).Sub(time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.UTC)).Hours() / 24)
+		return days%2 == 0
+	}
+	return true
+}
+
+// the regimen is taken at this time, from its start date until its end date
+func activeAt(regimen model.MedicationRegimen, at int) bool {
+	if regimen.EndDate != nil && at >= *regimen.EndDate {
+		return false
+	}
+	return regimen.StartDate == nil || at >= *regimen.StartDate
+}
+
+func doseAt(day time.Time, clock string, loc *time.Location) (int, bool) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, false
+	}
+	return int(time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, loc).Unix()), true
+}
@@ -0,0 +1,106 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+)
+
+// send due scheduled campaigns to their segments, called by worker
+func (n *service) SendDueCampaigns() error {
+	now := int(time.Now().Unix())
+	campaigns, err := n.Repo.ClaimDueCampaigns(now)
+	if err != nil {
+		NotiLogger.Printf("can't claim campaigns : %v\n", err.Error())
+		return err
+	}
+	for _, c := range campaigns {
+		if err := n.sendCampaign(c, now); err != nil {
+			NotiLogger.Printf("can't send campaign %v : %v\n", c.ID, err.Error())
+		}
+	}
+	return nil
+}
+
+/*
+save the campaign to inbox of every patient in the segment together with pushes of their devices,
+campaigns are pushed only, fallback rules are not applied to avoid mass email and sms,
+the campaign goes back to scheduled if anything fails before it is saved as sent
+*/
+func (n *service) sendCampaign(c model.Campaign, now int) error {
+	patientIds, err := n.Repo.GetSegmentPatientIds(c.Segment.Data(), now)
+	if err != nil {
+		n.rescheduleCampaign(c)
+		return err
+	}
+	prefs := map[int]model.NotificationPreference{}
+	devices := []model.Device{}
+	if len(patientIds) > 0 {
+		ids := []string{}
+		for _, id := range patientIds {
+			ids = append(ids, fmt.Sprint(id))
+		}
+		inPatients := repository.Criteria{QueryCriteria: repository.PATIENTID_IN, Value: strings.Join(ids, ",")}
+		prefList, err := n.Repo.GetAllNotificationPreference(inPatients)
+		if err != nil {
+			n.rescheduleCampaign(c)
+			return err
+		}
+		for _, p := range prefList {
+			prefs[p.PatientID] = p
+		}
+		devices, err = n.Repo.GetAllDevice(inPatients)
+		if err != nil {
+			n.rescheduleCampaign(c)
+			return err
+		}
+	}
+	campaignId := c.ID
+	notifications := []model.Notification{}
+	for _, id := range patientIds {
+		notification := model.NewNotification(id, model.CATEGORY_CONTENT, c.Title, c.Body, model.NotificationLink{})
+		notification.CampaignID = &campaignId
+		notifications = append(notifications, notification)
+	}
+	sent := c
+	sent.Status = model.CAMPAIGN_SENT
+	sent.RecipientCount = len(patientIds)
+	sent.SentAt = &now
+	var isDue []bool
+	created, err := n.Repo.SaveSentCampaign(sent, notifications, func(saved []model.Notification) []model.NotificationOutbox {
+		byPatient := map[int]model.Notification{}
+		for _, s := range saved {
+			byPatient[s.PatientID] = s
+		}
+		messages := []model.NotificationOutbox{}
+		for _, d := range devices {
+			pref := prefs[d.PatientId]
+			if d.ExpoToken == "" || pref.IsMuted(model.CATEGORY_CONTENT) {
+				continue
+			}
+			m := newOutboxMessage(d.PatientId, d.ID, d.ExpoToken, byPatient[d.PatientId])
+			m.CampaignID = &campaignId
+			m.NextAttemptAt = QuietUntil(pref, now)
+			messages = append(messages, m)
+		}
+		isDue = leaseDue(messages)
+		return messages
+	})
+	if err != nil {
+		n.rescheduleCampaign(c)
+		return err
+	}
+	NotiLogger.Printf("campaign %v saved to inbox of %v patients\n", c.ID, len(patientIds))
+	n.sendDue(created, isDue)
+	return nil
+}
+
+func (n *service) rescheduleCampaign(c model.Campaign) {
+	c.Status = model.CAMPAIGN_SCHEDULED
+	if err := n.Repo.UpdateCampaign(c); err != nil {
+		NotiLogger.Printf("can't reschedule campaign %v : %v\n", c.ID, err.Error())
+	}
+}
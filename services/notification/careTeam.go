@@ -0,0 +1,58 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/PhasitWo/duchenne-server/model"
+)
+
+/*
+tell the care team of the patient about a new question or appointment request,
+saved to the inbox of every doctor in the team, doctors have no push devices
+*/
+func (n *service) NotifyCareTeam(patientId int, link model.NotificationLink) error {
+	members, err := n.Repo.GetAllCareTeamMember(patientId)
+	if err != nil {
+		NotiLogger.Printf("can't get care team of patient %v : %v\n", patientId, err.Error())
+		return err
+	}
+	if len(members) == 0 {
+		return nil
+	}
+	patient, err := n.Repo.GetPatientById(patientId)
+	if err != nil {
+		NotiLogger.Printf("can't get patient %v : %v\n", patientId, err.Error())
+		return err
+	}
+	name := fullName(patient.FirstName, patient.MiddleName, patient.LastName)
+	var title, body string
+	switch link.Type {
+	case model.NOTIFICATION_QUESTION:
+		q, err := n.Repo.GetQuestion(link.ID)
+		if err != nil {
+			NotiLogger.Printf("can't get question %v : %v\n", link.ID, err.Error())
+			return err
+		}
+		title = fmt.Sprintf("คำถามใหม่จาก %s", name)
+		body = q.Topic
+	case model.NOTIFICATION_APPOINTMENT:
+		ap, err := n.Repo.GetAppointment(link.ID)
+		if err != nil {
+			NotiLogger.Printf("can't get appointment %v : %v\n", link.ID, err.Error())
+			return err
+		}
+		title = fmt.Sprintf("คำขอนัดหมายจาก %s", name)
+		body = fmt.Sprintf("วันที่ %s เวลา %s น.", formatDate(ap.Date, model.LANGUAGE_TH), formatClock(ap.Date))
+	default:
+		return fmt.Errorf("no care team notification for %q", link.Type)
+	}
+	notifications := make([]model.DoctorNotification, 0, len(members))
+	for _, member := range members {
+		notifications = append(notifications, model.NewDoctorNotification(member.DoctorID, patientId, title, body, link))
+	}
+	if err := n.Repo.CreateDoctorNotifications(notifications); err != nil {
+		NotiLogger.Printf("can't notify care team of patient %v : %v\n", patientId, err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PhasitWo/duchenne-server/model"
+)
+
+// a way to deliver outbox messages, messages are queued and retried the same way on every channel
+type Channel interface {
+	Name() model.NotificationChannel
+	// maximum messages in one Send call
+	BatchSize() int
+	// send messages of this channel, results are in the same order as messages
+	Send(messages []model.NotificationOutbox) []SendResult
+}
+
+// result of sending one outbox message
+type SendResult struct {
+	TicketID string // receipt id for channels with receipts i.e. Expo
+	Err      error
+	Retry    bool   // temporary error, send again later
+	Reason   string // recorded as last error, e.g. Expo error code
+	Detail   string // message from the provider
+}
+
+func sentResult(ticketId string) SendResult {
+	return SendResult{TicketID: ticketId}
+}
+
+func retryResult(err error) SendResult {
+	return SendResult{Err: err, Retry: true, Reason: err.Error()}
+}
+
+func failedResult(err error) SendResult {
+	return SendResult{Err: err, Reason: err.Error()}
+}
+
+// channel used when a category has no rule
+var defaultFallback = []model.NotificationChannel{model.CHANNEL_EXPO}
+
+/*
+parse fallback rules e.g. "appointment=expo,sms,email",
+channels are tried in order until the patient can be reached on one of them
+*/
+func ParseFallbackRules(rules []string) (map[model.NotificationCategory][]model.NotificationChannel, error) {
+	res := map[model.NotificationCategory][]model.NotificationChannel{}
+	for _, rule := range rules {
+		category, channels, ok := strings.Cut(rule, "=")
+		if !ok || channels == "" {
+			return nil, fmt.Errorf("invalid fallback rule %q", rule)
+		}
+		switch model.NotificationCategory(category) {
+		case model.CATEGORY_GENERAL, model.CATEGORY_APPOINTMENT, model.CATEGORY_REMINDER, model.CATEGORY_QUESTION, model.CATEGORY_CONTENT, model.CATEGORY_MEDICATION, model.CATEGORY_VACCINE, model.CATEGORY_ACCOUNT:
+		default:
+			return nil, fmt.Errorf("invalid category in fallback rule %q", rule)
+		}
+		list := []model.NotificationChannel{}
+		for _, ch := range strings.Split(channels, ",") {
+			switch model.NotificationChannel(ch) {
+			case model.CHANNEL_EXPO, model.CHANNEL_EMAIL, model.CHANNEL_SMS:
+				list = append(list, model.NotificationChannel(ch))
+			default:
+				return nil, fmt.Errorf("invalid channel in fallback rule %q", rule)
+			}
+		}
+		res[model.NotificationCategory(category)] = list
+	}
+	return res, nil
+}
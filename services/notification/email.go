@@ -0,0 +1,97 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // empty means no authentication
+	Password string
+	From     string
+}
+
+// send email through SMTP server
+type EmailChannel struct {
+	config SMTPConfig
+}
+
+func NewEmailChannel(config SMTPConfig) *EmailChannel {
+	return &EmailChannel{config: config}
+}
+
+func (c *EmailChannel) Name() model.NotificationChannel {
+	return model.CHANNEL_EMAIL
+}
+
+func (c *EmailChannel) BatchSize() int {
+	return 20
+}
+
+func (c *EmailChannel) Send(messages []model.NotificationOutbox) []SendResult {
+	results := make([]SendResult, len(messages))
+	addr := net.JoinHostPort(c.config.Host, strconv.Itoa(c.config.Port))
+	var auth smtp.Auth
+	if c.config.Username != "" {
+		auth = smtp.PlainAuth("", c.config.Username, c.config.Password, c.config.Host)
+	}
+	for i, m := range messages {
+		if m.Address == nil {
+			results[i] = failedResult(errors.New("missing email address"))
+			continue
+		}
+		to, err := mail.ParseAddress(*m.Address)
+		if err != nil {
+			results[i] = failedResult(fmt.Errorf("invalid email address : %w", err))
+			continue
+		}
+		err = smtp.SendMail(addr, auth, c.config.From, []string{to.Address}, buildEmail(c.config.From, to.Address, m.Title, m.Body))
+		if err != nil {
+			// 5xx means the server rejected this message permanently
+			var protoErr *textproto.Error
+			if errors.As(err, &protoErr) && protoErr.Code >= 500 {
+				results[i] = failedResult(err)
+			} else {
+				results[i] = retryResult(err)
+			}
+			continue
+		}
+		results[i] = sentResult("")
+	}
+	return results
+}
+
+// plain text email, subject and body are encoded for Thai characters
+func buildEmail(from string, to string, subject string, body string) []byte {
+	// line breaks in subject would start new headers
+	subject = strings.NewReplacer("\r", " ", "\n", " ").Replace(subject)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+	encoded := base64.StdEncoding.EncodeToString([]byte(body))
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+	return buf.Bytes()
+}
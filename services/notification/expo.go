@@ -0,0 +1,72 @@
+package notification
+
+import (
+	"errors"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	expo "github.com/PhasitWo/duchenne-server/services/notification/expo/exponent-server-sdk-golang-master/sdk"
+)
+
+// push to Expo tokens of registered devices
+type ExpoChannel struct {
+	config *expo.ClientConfig
+}
+
+func NewExpoChannel(config *expo.ClientConfig) *ExpoChannel {
+	return &ExpoChannel{config: config}
+}
+
+func (c *ExpoChannel) Name() model.NotificationChannel {
+	return model.CHANNEL_EXPO
+}
+
+func (c *ExpoChannel) BatchSize() int {
+	return MAX_MESSAGES_PER_REQUEST
+}
+
+func (c *ExpoChannel) Send(messages []model.NotificationOutbox) []SendResult {
+	results := make([]SendResult, len(messages))
+	pushMessages := []expo.PushMessage{}
+	for _, m := range messages {
+		pushMessages = append(pushMessages, expo.PushMessage{
+			To:       []expo.ExponentPushToken{expo.ExponentPushToken(m.ExpoToken)},
+			Title:    m.Title,
+			Body:     m.Body,
+			Sound:    "default",
+			Priority: expo.HighPriority,
+			Data:     m.Data.Data(),
+		})
+	}
+	client := expo.NewPushClient(c.config)
+	responses, err := client.PublishMultiple(pushMessages)
+	if err != nil {
+		NotiLogger.Printf("can't publish messages : %v\n", err.Error())
+		for i := range results {
+			results[i] = retryResult(err)
+		}
+		return results
+	}
+	// 1 message has 1 receiver, so responses are in the same order as messages
+	for i := range messages {
+		if i >= len(responses) {
+			results[i] = retryResult(errors.New("missing push ticket"))
+			continue
+		}
+		res := responses[i]
+		if err := res.ValidateResponse(); err != nil {
+			var rateErr *expo.MessageRateExceededError
+			if errors.As(err, &rateErr) {
+				results[i] = retryResult(err)
+			} else {
+				results[i] = SendResult{Err: err, Reason: ticketError(res), Detail: res.Message}
+			}
+			continue
+		}
+		results[i] = sentResult(res.ID)
+	}
+	return results
+}
+
+func (c *ExpoChannel) GetReceipts(ids []string) (map[string]expo.PushReceipt, error) {
+	return expo.NewPushClient(c.config).GetReceipts(ids)
+}
@@ -191,3 +191,30 @@ func NewPushServerError(message string, response *http.Response,
 func (e *PushServerError) Error() string {
 	return e.Message
 }
+
+// ReceiptResponse is the HTTP response returned from an Expo getReceipts request
+type ReceiptResponse struct {
+	Data   map[string]PushReceipt `json:"data"`
+	Errors []map[string]string    `json:"errors"`
+}
+
+// PushReceipt tells whether Expo delivered the message to Apple or Google
+type PushReceipt struct {
+	Status  string            `json:"status"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details"`
+}
+
+// ValidateReceipt returns an error if the receipt indicates that one occurred.
+func (r *PushReceipt) ValidateReceipt() error {
+	if r.Status == SuccessStatus {
+		return nil
+	}
+	err := &PushResponseError{
+		Response: &PushResponse{Status: r.Status, Message: r.Message, Details: r.Details},
+	}
+	if r.Details != nil && r.Details["error"] == ErrorDeviceNotRegistered {
+		return &DeviceNotRegisteredError{PushResponseError: *err}
+	}
+	return err
+}
@@ -165,3 +165,44 @@ func checkStatus(resp *http.Response) error {
 	}
 	return fmt.Errorf("invalid response (%d %s)", resp.StatusCode, resp.Status)
 }
+
+// GetReceipts fetches push receipts of the tickets returned from publishing
+// @param ids: ticket ids, up to 1000 ids per request
+// @return receipts keyed by ticket id, receipts that are not ready yet are omitted
+// @return error if the request failed
+func (c *PushClient) GetReceipts(ids []string) (map[string]PushReceipt, error) {
+	url := fmt.Sprintf("%s%s/push/getReceipts", c.host, c.apiURL)
+	jsonBytes, err := json.Marshal(map[string][]string{"ids": ids})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(jsonBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	if c.accessToken != "" {
+		req.Header.Add("Authorization", "Bearer "+c.accessToken)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	err = checkStatus(resp)
+	if err != nil {
+		return nil, err
+	}
+	var r *ReceiptResponse
+	err = json.NewDecoder(resp.Body).Decode(&r)
+	if err != nil {
+		return nil, err
+	}
+	if r.Errors != nil {
+		return nil, NewPushServerError("Invalid server response", resp, nil, r.Errors)
+	}
+	if r.Data == nil {
+		return map[string]PushReceipt{}, nil
+	}
+	return r.Data, nil
+}
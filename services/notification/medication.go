@@ -0,0 +1,67 @@
+package notification
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/medication"
+	"github.com/PhasitWo/duchenne-server/services/schedule"
+)
+
+// doses are reminded up to this long after their time, older ones are not reminded anymore
+const medicationReminderWindow = 30 * 60
+
+/*
+remind doses of active regimens that are due within the window, every reminder is recorded in the dose ledger
+first so it's sent only once even when runs overlap, doses the patient already logged are not reminded
+*/
+func (n *service) SendMedicationReminders() error {
+	regimens, err := n.Repo.GetRemindableMedicationRegimen()
+	if err != nil {
+		NotiLogger.Println("can't get medication regimens")
+		return err
+	}
+	regimenOf := map[int]model.MedicationRegimen{}
+	for _, regimen := range regimens {
+		regimenOf[regimen.ID] = regimen
+	}
+	now := int(time.Now().Unix())
+	sentCnt := 0
+	for _, dose := range medication.ScheduledDoses(regimens, now-medicationReminderWindow, now+1, schedule.Location()) {
+		regimen := regimenOf[dose.RegimenID]
+		remindAt := now
+		err := n.Repo.CreateMedicationDose(model.MedicationDose{
+			RegimenID:   regimen.ID,
+			PatientID:   regimen.PatientID,
+			ScheduledAt: dose.ScheduledAt,
+			RemindAt:    &remindAt,
+		})
+		if err != nil {
+			if !errors.Is(err, repository.ErrDuplicateEntry) {
+				NotiLogger.Printf("can't record reminder of regimen %v : %v\n", regimen.ID, err.Error())
+			}
+			continue
+		}
+		params := model.TemplateParams{
+			"medicineName": regimen.MedicineName,
+			"dose":         formatDose(regimen.Dose, regimen.DoseUnit),
+			"time":         formatClock(dose.ScheduledAt),
+		}
+		err = n.SendTemplateByPatientId(regimen.PatientID, model.TEMPLATE_MEDICATION_REMINDER, params, model.MedicationLink(regimen.ID))
+		if err != nil {
+			NotiLogger.Printf("can't send reminder of regimen %v : %v\n", regimen.ID, err.Error())
+			continue
+		}
+		sentCnt++
+	}
+	NotiLogger.Printf("sent %v medication reminders\n", sentCnt)
+	return nil
+}
+
+// e.g. "18 mg", "1.5 tablet"
+func formatDose(dose float64, unit model.DoseUnit) string {
+	return strconv.FormatFloat(dose, 'f', -1, 64) + " " + string(unit)
+}
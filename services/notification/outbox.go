@@ -0,0 +1,241 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/config"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	expo "github.com/PhasitWo/duchenne-server/services/notification/expo/exponent-server-sdk-golang-master/sdk"
+)
+
+const (
+	// 1 request can contain up to 100 messages, for safety purpose -> 1 request should contain only up to 80 messages
+	MAX_MESSAGES_PER_REQUEST = 80
+	// Expo accepts up to 1000 ids per receipt request
+	MAX_RECEIPTS_PER_REQUEST = 1000
+	// seconds that claimed messages are hidden from other workers
+	OUTBOX_LEASE = 5 * 60
+	// messages claimed in one worker run
+	OUTBOX_BATCH = 500
+	// receipts are usually ready within 15 minutes after sending
+	RECEIPT_DELAY = 15 * 60
+	// Expo keeps receipts for 24 hours
+	RECEIPT_EXPIRE = 24 * 60 * 60
+)
+
+// send due pending messages, called by worker
+func (n *service) ProcessOutbox() error {
+	messages, err := n.Repo.ClaimDueOutboxMessages(int(time.Now().Unix()), OUTBOX_LEASE, OUTBOX_BATCH)
+	if err != nil {
+		NotiLogger.Printf("can't claim outbox messages : %v\n", err.Error())
+		return err
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	NotiLogger.Printf("sending %v outbox messages\n", len(messages))
+	n.dispatch(messages)
+	return nil
+}
+
+// poll receipts of sent Expo messages, mark them delivered or failed
+func (n *service) CheckReceipts() error {
+	expoChannel, ok := n.channels[model.CHANNEL_EXPO].(*ExpoChannel)
+	if !ok {
+		return nil
+	}
+	now := int(time.Now().Unix())
+	messages, err := n.Repo.GetAllOutboxMessage(MAX_RECEIPTS_PER_REQUEST,
+		repository.Criteria{QueryCriteria: repository.STATUS, Value: model.OUTBOX_SENT},
+		repository.Criteria{QueryCriteria: repository.TICKETID_ISNOTNULL},
+		repository.Criteria{QueryCriteria: repository.SENTAT_LESSTHAN, Value: now - RECEIPT_DELAY},
+	)
+	if err != nil {
+		NotiLogger.Printf("can't get sent messages : %v\n", err.Error())
+		return err
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	ids := []string{}
+	for _, m := range messages {
+		ids = append(ids, *m.TicketID)
+	}
+	receipts, err := expoChannel.GetReceipts(ids)
+	if err != nil {
+		NotiLogger.Printf("can't get push receipts : %v\n", err.Error())
+		return err
+	}
+	for _, m := range messages {
+		receipt, ok := receipts[*m.TicketID]
+		if !ok {
+			// not ready yet, give up when Expo no longer keeps it
+			if *m.SentAt < now-RECEIPT_EXPIRE {
+				n.markFailed(m, "push receipt is not available")
+			}
+			continue
+		}
+		if err := receipt.ValidateReceipt(); err != nil {
+			n.markFailed(m, receiptError(receipt))
+			var notRegisteredErr *expo.DeviceNotRegisteredError
+			if errors.As(err, &notRegisteredErr) {
+				n.pruneDevice(m, receipt.Message)
+			}
+			continue
+		}
+		m.Status = model.OUTBOX_DELIVERED
+		m.DeliveredAt = &now
+		n.updateMessage(m)
+	}
+	return nil
+}
+
+// send messages in batches of their channel and record the result of every message
+func (n *service) dispatch(messages []model.NotificationOutbox) {
+	groups := map[model.NotificationChannel][]model.NotificationOutbox{}
+	order := []model.NotificationChannel{}
+	for _, m := range messages {
+		name := m.Channel
+		if name == "" {
+			name = model.CHANNEL_EXPO
+		}
+		if _, ok := groups[name]; !ok {
+			order = append(order, name)
+		}
+		groups[name] = append(groups[name], m)
+	}
+	for _, name := range order {
+		group := groups[name]
+		ch, ok := n.channels[name]
+		if !ok {
+			for _, m := range group {
+				n.markFailed(m, fmt.Sprintf("channel %v is not configured", name))
+			}
+			continue
+		}
+		for base := 0; base < len(group); base += ch.BatchSize() {
+			batch := group[base:min(base+ch.BatchSize(), len(group))]
+			results := ch.Send(batch)
+			for i, m := range batch {
+				n.applyResult(m, results[i])
+			}
+		}
+	}
+}
+
+func (n *service) applyResult(m model.NotificationOutbox, res SendResult) {
+	if res.Err == nil {
+		now := int(time.Now().Unix())
+		m.Status = model.OUTBOX_SENT
+		m.Attempts++
+		if res.TicketID != "" {
+			ticketId := res.TicketID
+			m.TicketID = &ticketId
+		}
+		m.SentAt = &now
+		m.LastError = nil
+		n.updateMessage(m)
+		return
+	}
+	if res.Retry {
+		n.retryLater(m, res.Err.Error())
+		return
+	}
+	n.markFailed(m, res.Reason)
+	if res.Reason == expo.ErrorDeviceNotRegistered {
+		n.pruneDevice(m, res.Detail)
+	}
+}
+
+func (n *service) retryLater(m model.NotificationOutbox, reason string) {
+	m.Attempts++
+	m.LastError = &reason
+	if m.Attempts >= config.AppConfig.OUTBOX_MAX_ATTEMPTS {
+		m.Status = model.OUTBOX_FAILED
+	} else {
+		m.Status = model.OUTBOX_PENDING
+		m.NextAttemptAt = int(time.Now().Unix()) + retryBackoff(m.Attempts)
+	}
+	n.updateMessage(m)
+}
+
+func (n *service) markFailed(m model.NotificationOutbox, reason string) {
+	if m.Status == model.OUTBOX_PENDING {
+		m.Attempts++
+	}
+	m.Status = model.OUTBOX_FAILED
+	m.LastError = &reason
+	n.updateMessage(m)
+}
+
+// delete the device whose token is permanently invalid and record why
+func (n *service) pruneDevice(m model.NotificationOutbox, detail string) {
+	if m.DeviceID == nil {
+		return
+	}
+	devices, err := n.Repo.GetAllDevice(repository.Criteria{QueryCriteria: repository.ID, Value: *m.DeviceID})
+	if err != nil {
+		NotiLogger.Printf("can't get device %v : %v\n", *m.DeviceID, err.Error())
+		return
+	}
+	// already logged out, or logged in again with a new token
+	if len(devices) == 0 || devices[0].ExpoToken != m.ExpoToken {
+		return
+	}
+	device := devices[0]
+	outboxId := m.ID
+	removal := model.DeviceRemoval{
+		PatientID:  device.PatientId,
+		DeviceID:   device.ID,
+		DeviceName: device.DeviceName,
+		ExpoToken:  device.ExpoToken,
+		Reason:     expo.ErrorDeviceNotRegistered,
+		OutboxID:   &outboxId,
+	}
+	if detail != "" {
+		removal.Detail = &detail
+	}
+	if err := n.Repo.CreateDeviceRemoval(removal); err != nil {
+		NotiLogger.Printf("can't record removal of device %v : %v\n", device.ID, err.Error())
+		return
+	}
+	if err := n.Repo.DeleteDevice(device.ID); err != nil {
+		NotiLogger.Printf("can't delete device %v : %v\n", device.ID, err.Error())
+		return
+	}
+	NotiLogger.Printf("removed device %v of patient %v : %v\n", device.ID, device.PatientId, expo.ErrorDeviceNotRegistered)
+}
+
+func (n *service) updateMessage(m model.NotificationOutbox) {
+	if err := n.Repo.UpdateOutboxMessage(m); err != nil {
+		NotiLogger.Printf("can't update outbox message %v : %v\n", m.ID, err.Error())
+	}
+}
+
+// 30s, 1m, 2m, 4m, ... up to 1 hour
+func retryBackoff(attempts int) int {
+	const maxBackoff = 60 * 60
+	backoff := 30
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
+
+// error code from Expo e.g. DeviceNotRegistered, fallback to message
+func ticketError(res expo.PushResponse) string {
+	if code := res.Details["error"]; code != "" {
+		return code
+	}
+	return res.Message
+}
+
+func receiptError(receipt expo.PushReceipt) string {
+	if code := receipt.Details["error"]; code != "" {
+		return code
+	}
+	return receipt.Message
+}
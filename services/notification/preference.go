@@ -0,0 +1,59 @@
+package notification
+
+import (
+	"errors"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/services/schedule"
+	"gorm.io/gorm"
+)
+
+// preference of the patient, default preference (nothing muted, no quiet hours) when the patient never set one
+func (n *service) getPreference(patientId int) model.NotificationPreference {
+	pref, err := n.Repo.GetNotificationPreference(patientId)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			NotiLogger.Printf("can't get notification preference of patient %v : %v\n", patientId, err.Error())
+		}
+		return model.NotificationPreference{PatientID: patientId}
+	}
+	return pref
+}
+
+// patient timezone, fallback to clinic timezone
+func PreferenceLocation(pref model.NotificationPreference) *time.Location {
+	if pref.Timezone != "" {
+		if loc, err := time.LoadLocation(pref.Timezone); err == nil {
+			return loc
+		}
+	}
+	return schedule.Location()
+}
+
+/*
+end of the current quiet hours as unix time, 0 when now is not in quiet hours,
+quiet hours may cross midnight e.g. 22:00 - 07:00
+*/
+func QuietUntil(pref model.NotificationPreference, now int) int {
+	if !pref.HasQuietHours() {
+		return 0
+	}
+	start, end := *pref.QuietStartMinute, *pref.QuietEndMinute
+	t := time.Unix(int64(now), 0).In(PreferenceLocation(pref))
+	minute := t.Hour()*60 + t.Minute()
+	day := t.Day()
+	if start < end {
+		if minute < start || minute >= end {
+			return 0
+		}
+	} else {
+		if minute < start && minute >= end {
+			return 0
+		}
+		if minute >= start {
+			day++ // ends tomorrow
+		}
+	}
+	return int(time.Date(t.Year(), t.Month(), day, 0, end, 0, 0, t.Location()).Unix())
+}
@@ -0,0 +1,123 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+)
+
+/*
+send due reminders of confirmed appointments from enabled reminder rules,
+every sent reminder is recorded in the ledger first so it's sent only once even when runs overlap
+*/
+func (n *service) SendReminders() error {
+	rules, err := n.Repo.GetAllReminderRule(repository.Criteria{QueryCriteria: repository.IS_ENABLED, Value: true})
+	if err != nil {
+		NotiLogger.Println("can't get reminder rules")
+		return err
+	}
+	if len(rules) == 0 {
+		NotiLogger.Println("no enabled reminder rules")
+		return nil
+	}
+	maxOffset := 0
+	for _, rule := range rules {
+		maxOffset = max(maxOffset, rule.OffsetMinutes)
+	}
+	now := int(time.Now().Unix())
+	aps, err := n.Repo.GetAllAppointment(-1, 0,
+		repository.Criteria{QueryCriteria: repository.STATUS_CONFIRMED},
+		repository.Criteria{QueryCriteria: repository.DATE_GREATERTHAN, Value: now},
+		repository.Criteria{QueryCriteria: repository.DATE_LESSTHAN, Value: now + maxOffset*60 + 1},
+	)
+	if err != nil {
+		NotiLogger.Println("can't get upcoming appointments")
+		return err
+	}
+	if len(aps) == 0 {
+		NotiLogger.Println("no upcoming appointments to remind")
+		return nil
+	}
+	// load ledger of these appointments
+	ids := []string{}
+	for _, ap := range aps {
+		ids = append(ids, fmt.Sprint(ap.ID))
+	}
+	logs, err := n.Repo.GetAllReminderLog(repository.Criteria{QueryCriteria: repository.APPOINTMENTID_IN, Value: strings.Join(ids, ",")})
+	if err != nil {
+		NotiLogger.Println("can't get reminder logs")
+		return err
+	}
+	logged := map[string]bool{}
+	for _, l := range logs {
+		logged[reminderKey(l.AppointmentID, l.AppointmentDate, l.RuleID)] = true
+	}
+	def, err := GetTemplateDefinition(model.TEMPLATE_APPOINTMENT_REMINDER)
+	if err != nil {
+		return err
+	}
+	sentCnt := 0
+	for _, ap := range aps {
+		send, skipped := planReminder(rules, ap.ID, ap.Date, now, logged)
+		for _, rule := range skipped {
+			err := n.Repo.CreateReminderLog(model.ReminderLog{AppointmentID: ap.ID, RuleID: rule.ID, AppointmentDate: ap.Date, Skipped: true})
+			if err != nil && !errors.Is(err, repository.ErrDuplicateEntry) {
+				NotiLogger.Printf("can't record skipped reminder of appointment %v : %v\n", ap.ID, err.Error())
+			}
+		}
+		if send == nil {
+			continue
+		}
+		// claim this reminder before sending, duplicate entry means another run already sent it
+		err := n.Repo.CreateReminderLog(model.ReminderLog{AppointmentID: ap.ID, RuleID: send.ID, AppointmentDate: ap.Date})
+		if err != nil {
+			if !errors.Is(err, repository.ErrDuplicateEntry) {
+				NotiLogger.Printf("can't record reminder of appointment %v : %v\n", ap.ID, err.Error())
+			}
+			continue
+		}
+		language := ap.Patient.Language
+		params := appointmentParams(ap.Appointment, ap.Doctor.Doctor, language)
+		params["remainingTime"] = formatRemainingTime(ap.Date, now, language)
+		title, body := n.renderTemplate(def, language, params)
+		if err := n.SendNotiByPatientId(ap.PatientID, def.Category, title, body, model.AppointmentLink(ap.ID)); err != nil {
+			NotiLogger.Printf("can't send reminder of appointment %v : %v\n", ap.ID, err.Error())
+			continue
+		}
+		sentCnt++
+	}
+	NotiLogger.Printf("sent %v reminders\n", sentCnt)
+	return nil
+}
+
+/*
+among due rules that are not in the ledger, only the closest one to the appointment is sent,
+the others are skipped so a late booking doesn't get every stage at once
+*/
+func planReminder(rules []model.ReminderRule, appointmentId int, date int, now int, logged map[string]bool) (send *model.ReminderRule, skipped []model.ReminderRule) {
+	for i := range rules {
+		rule := rules[i]
+		if date-rule.OffsetMinutes*60 > now || logged[reminderKey(appointmentId, date, rule.ID)] {
+			continue
+		}
+		if send == nil {
+			send = &rule
+			continue
+		}
+		if rule.OffsetMinutes < send.OffsetMinutes {
+			skipped = append(skipped, *send)
+			send = &rule
+		} else {
+			skipped = append(skipped, rule)
+		}
+	}
+	return send, skipped
+}
+
+func reminderKey(appointmentId int, date int, ruleId int) string {
+	return fmt.Sprintf("%d:%d:%d", appointmentId, date, ruleId)
+}
@@ -3,9 +3,7 @@ package notification
 import (
 	"database/sql"
 	"errors"
-	"fmt"
 	"log"
-	"math"
 	"os"
 	"strings"
 	"time"
@@ -14,215 +12,245 @@ import (
 	"github.com/PhasitWo/duchenne-server/model"
 	"github.com/PhasitWo/duchenne-server/repository"
 	expo "github.com/PhasitWo/duchenne-server/services/notification/expo/exponent-server-sdk-golang-master/sdk"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
 type INotificationService interface {
-	SendDailyNotifications(dayRange *int) error
-	SendNotiByPatientId(id int, title string, body string) error
+	SendReminders() error
+	SendMedicationReminders() error
+	SendVaccineReminders() error
+	SendNotiByPatientId(id int, category model.NotificationCategory, title string, body string, link model.NotificationLink) error
+	SendTemplateByPatientId(id int, key model.TemplateKey, params model.TemplateParams, link model.NotificationLink) error
+	NotifyCareTeam(patientId int, link model.NotificationLink) error
+	SendDueCampaigns() error
+	ProcessOutbox() error
+	CheckReceipts() error
 }
 
 type service struct {
-	Repo  repository.IRepo
-	sqldb *sql.DB
+	Repo     repository.IRepo
+	sqldb    *sql.DB
+	channels map[model.NotificationChannel]Channel
+	fallback map[model.NotificationCategory][]model.NotificationChannel
 }
 
 var NotiLogger = log.New(os.Stdout, "[NOTI] ", log.LstdFlags)
+
+// the patient can't be reached on any channel of the category
 var ErrDevicesNotFound = errors.New("error not found any devices")
 
+// service with channels from config, email and sms are enabled only when their server is set
 func NewService(db *gorm.DB) *service {
 	sqldb, err := db.DB()
 	if err != nil {
 		panic("can't get *sql.DB from gorm")
 	}
-	return &service{
-		Repo:  repository.New(db),
-		sqldb: sqldb,
+	channels := []Channel{NewExpoChannel(&expo.ClientConfig{
+		Host:        config.AppConfig.EXPO_HOST,
+		AccessToken: config.AppConfig.EXPO_ACCESS_TOKEN,
+	})}
+	if config.AppConfig.SMTP_HOST != "" {
+		channels = append(channels, NewEmailChannel(SMTPConfig{
+			Host:     config.AppConfig.SMTP_HOST,
+			Port:     config.AppConfig.SMTP_PORT,
+			Username: config.AppConfig.SMTP_USERNAME,
+			Password: config.AppConfig.SMTP_PASSWORD,
+			From:     config.AppConfig.SMTP_FROM,
+		}))
+	}
+	if config.AppConfig.SMS_GATEWAY_URL != "" {
+		channels = append(channels, NewSMSChannel(SMSConfig{
+			URL:   config.AppConfig.SMS_GATEWAY_URL,
+			Token: config.AppConfig.SMS_GATEWAY_TOKEN,
+		}))
 	}
+	return New(repository.New(db), sqldb, channels...)
 }
 
-func (n *service) SendNotiByPatientId(id int, title string, body string) error {
-	devices, err := n.Repo.GetAllDevice(repository.Criteria{QueryCriteria: repository.PATIENTID, Value: id})
+// constructor with explicit dependencies e.g. channels of fake servers in tests, fallback rules are read from config
+func New(repo repository.IRepo, sqldb *sql.DB, channels ...Channel) *service {
+	fallback, err := ParseFallbackRules(config.AppConfig.NOTIFICATION_FALLBACK)
 	if err != nil {
-		NotiLogger.Println("Error can't get devices to push notifications")
-		return err
+		NotiLogger.Printf("%v, push only\n", err.Error())
+		fallback = map[model.NotificationCategory][]model.NotificationChannel{}
 	}
-	if len(devices) == 0 {
-		NotiLogger.Println("Error no devices to push notifications")
-		return ErrDevicesNotFound
+	n := &service{
+		Repo:     repo,
+		sqldb:    sqldb,
+		channels: map[model.NotificationChannel]Channel{},
+		fallback: fallback,
 	}
-	msg := expo.PushMessage{To: []expo.ExponentPushToken{}, Title: title, Body: body, Sound: "default", Priority: expo.HighPriority}
-	for _, d := range devices {
-		msg.To = append(msg.To, expo.ExponentPushToken(d.ExpoToken))
+	for _, ch := range channels {
+		n.channels[ch.Name()] = ch
 	}
-	SendRequest([]expo.PushMessage{msg})
-	return nil
+	return n
 }
 
 /*
-send daily notification about upcoming appointments,
-day_range = nil will use default value from config
+persist the notification in the patient's inbox, then send it on the first channel of the category
+that can reach the patient e.g. sms when no device is registered,
+muted categories are not sent and messages during quiet hours are deferred
 */
-func (n *service) SendDailyNotifications(dayRange *int) error {
-	if dayRange == nil {
-		dayRange = &config.AppConfig.NOTIFY_IN_RANGE
-		NotiLogger.Println("using default day range from config")
-	}
-	res, err := queryDB(n.sqldb, *dayRange)
-	if err != nil {
-		msg := "can't query database"
-		NotiLogger.Println(msg)
-		return errors.New(msg)
-	}
-	if len(res) == 0 {
-		NotiLogger.Printf("no upcoming appointments in the next %v days\n", *dayRange)
+func (n *service) SendNotiByPatientId(id int, category model.NotificationCategory, title string, body string, link model.NotificationLink) error {
+	notification := n.createNotification(id, category, title, body, link)
+	pref := n.getPreference(id)
+	if pref.IsMuted(category) {
+		NotiLogger.Printf("patient %v muted %v notifications, skip push\n", id, category)
 		return nil
 	}
-	NotiLogger.Printf("preparing messages..\n")
-	// prepare messages
-	// 1 appointmemnt -> 1 message -- to --> multiple receivers
-	messagesPool := []expo.PushMessage{}
-	var newMessage expo.PushMessage
-	prior := -1
-	for index, elem := range res {
-		if elem.AppointmentId != prior {
-			// add prior new message to pool
-			if prior != -1 {
-				messagesPool = append(messagesPool, newMessage)
-			}
-			// create new message
-			title := "อย่าลืมนัดหมายของคุณ!"
-			body := formatRemainingTime(elem.Date, int(time.Now().Unix())) + " (" + formatThaiTime(elem.Date) + ")"
-			newMessage = expo.PushMessage{
-				To:       []expo.ExponentPushToken{expo.ExponentPushToken(elem.ExpoToken)},
-				Body:     body,
-				Sound:    "default",
-				Title:    title,
-				Priority: expo.HighPriority,
-			}
-		} else {
-			newMessage.To = append(newMessage.To, expo.ExponentPushToken(elem.ExpoToken))
+	var lastErr error
+	for _, ch := range n.channelsOf(category) {
+		messages, err := n.channelMessages(ch, id, notification)
+		if err != nil {
+			NotiLogger.Printf("can't get %v recipients of patient %v : %v\n", ch, id, err.Error())
+			lastErr = err
+			continue
 		}
-		// special case -> if this new message is the last message
-		if index == len(res)-1 {
-			messagesPool = append(messagesPool, newMessage)
+		if len(messages) == 0 {
+			continue
 		}
-		prior = elem.AppointmentId
+		deferUntil := QuietUntil(pref, int(time.Now().Unix()))
+		for i := range messages {
+			messages[i].NextAttemptAt = deferUntil
+		}
+		return n.enqueueAndSend(messages)
 	}
-
-	// 1 request can contain up to 100 messages, for safety purpose -> 1 request should contain only up to 80 messages
-	// divide len([]message) with 80 -> split up to multiple request
-	NotiLogger.Printf("splitting up messages to multiple request\n")
-	const MAX_MESSAGES_PER_REQUEST = 80
-	var messageCnt = float64(len(messagesPool))
-	var cnt float64 = math.Ceil(float64(messageCnt) / MAX_MESSAGES_PER_REQUEST)
-	for i := 0; i < int(cnt); i++ {
-		// calculate base and limit for slicing slice
-		base := float64(i * MAX_MESSAGES_PER_REQUEST)
-		limit := base + math.Min(messageCnt-base, MAX_MESSAGES_PER_REQUEST)
-
-		// send request
-		NotiLogger.Printf("sending request %v => messagesPool[%v:%v]\n", i, base, limit)
-		SendRequest(messagesPool[int(base):int(limit)])
+	if lastErr != nil {
+		return lastErr
 	}
-	return nil
+	NotiLogger.Println("Error no devices to push notifications")
+	return ErrDevicesNotFound
 }
 
-func SendRequest(messages []expo.PushMessage) {
-	client := expo.NewPushClient(nil)
-	// Publish message
-	responses, err := client.PublishMultiple(messages)
-
-	// Check errors
-	if err != nil && !strings.Contains(err.Error(), "mismatched response length") {
-		NotiLogger.Panic(err)
-	}
-	NotiLogger.Println("validating..")
-	// Validate responses
-	for index, response := range responses {
-		fmt.Printf("push ticket %v =>", index)
-		if response.ValidateResponse() != nil {
-			fmt.Println(response.PushMessage.To, "failed")
-		} else {
-			fmt.Println(response.PushMessage.To, "succeed")
+// configured channels of the category in fallback order
+func (n *service) channelsOf(category model.NotificationCategory) []model.NotificationChannel {
+	rule, ok := n.fallback[category]
+	if !ok {
+		rule = defaultFallback
+	}
+	res := []model.NotificationChannel{}
+	for _, ch := range rule {
+		if _, ok := n.channels[ch]; ok {
+			res = append(res, ch)
 		}
 	}
+	return res
 }
 
-var apmtQuery = `
-select appointments.id ,date, devices.id, devices.device_name , devices.expo_token, appointments.patient_id from appointments
-inner join devices on appointments.patient_id = devices.patient_id
-where devices.expo_token != "" AND appointments.approve_at IS NOT NULL AND appointments.date > ? AND appointments.date < ?
-order by appointments.id asc
-`
-
-func queryDB(db *sql.DB, dayRange int) ([]model.AppointmentDevice, error) {
-	now := int(time.Now().Unix())
-	limit := now + dayRange*24*60*60
-	rows, err := db.Query(apmtQuery, now, limit)
-	if err != nil {
-		NotiLogger.Println("queryDB : Can't query database")
-		NotiLogger.Println(err.Error())
-		return nil, err
-	}
-	defer rows.Close()
-	res := []model.AppointmentDevice{}
-	for rows.Next() {
-		var ad model.AppointmentDevice
-		if err := rows.Scan(
-			&ad.AppointmentId,
-			&ad.Date,
-			&ad.DeviceId,
-			&ad.DeviceName,
-			&ad.ExpoToken,
-			&ad.PatientId,
-		); err != nil {
-			fmt.Printf("queryDB : %v", err.Error())
+// messages to every address of the patient on the channel, empty when the patient has none
+func (n *service) channelMessages(ch model.NotificationChannel, patientId int, notification model.Notification) ([]model.NotificationOutbox, error) {
+	messages := []model.NotificationOutbox{}
+	if ch == model.CHANNEL_EXPO {
+		// caregiver devices get pushes of every linked patient, not only the active one
+		devices, err := n.Repo.GetAllDevice(repository.Criteria{QueryCriteria: repository.PATIENT_DEVICE, Value: patientId})
+		if err != nil {
 			return nil, err
 		}
-		res = append(res, ad)
+		for _, d := range devices {
+			if d.ExpoToken == "" {
+				continue
+			}
+			messages = append(messages, newOutboxMessage(patientId, d.ID, d.ExpoToken, notification))
+		}
+		return messages, nil
 	}
-	if err := rows.Err(); err != nil {
-		fmt.Printf("queryDB : %v", err.Error())
+	patient, err := n.Repo.GetPatientById(patientId)
+	if err != nil {
 		return nil, err
 	}
-	NotiLogger.Printf("now: %v, limit: %v (day range: %v)\n", now, limit, dayRange)
-	return res, nil
+	address := patient.Email
+	if ch == model.CHANNEL_SMS {
+		address = patient.Phone
+	}
+	if address == nil || strings.TrimSpace(*address) == "" {
+		return messages, nil
+	}
+	m := newOutboxMessage(patientId, 0, "", notification)
+	m.DeviceID = nil
+	m.Channel = ch
+	trimmed := strings.TrimSpace(*address)
+	m.Address = &trimmed
+	return append(messages, m), nil
 }
 
-func formatRemainingTime(dueTimestamp int, nowTimestamp int) string {
-	sec := (dueTimestamp - nowTimestamp)
-	minute := sec / 60
-	hour := minute / 60
-	day := hour / 24
-	baseStr := "คุณมีนัดหมายในอีก "
-	var output string
-	if minute == 0 {
-		output = "ไม่กี่นาที"
-	} else if hour == 0 {
-		output = fmt.Sprintf("%d นาที", minute)
-	} else if day == 0 {
-		output = fmt.Sprintf("%d ชั่วโมง %d นาที", hour, minute%60)
+/*
+save the notification to the inbox, the push still goes out if it can't be saved,
+in that case the returned notification has no id and the push has no notificationId
+*/
+func (n *service) createNotification(patientId int, category model.NotificationCategory, title string, body string, link model.NotificationLink) model.Notification {
+	notification := model.NewNotification(patientId, category, title, body, link)
+	id, err := n.Repo.CreateNotification(notification)
+	if err != nil {
+		NotiLogger.Printf("can't save notification of patient %v : %v\n", patientId, err.Error())
+		return notification
+	}
+	notification.ID = id
+	return notification
+}
+
+func newOutboxMessage(patientId int, deviceId int, expoToken string, notification model.Notification) model.NotificationOutbox {
+	m := model.NotificationOutbox{
+		PatientID: &patientId,
+		DeviceID:  &deviceId,
+		Channel:   model.CHANNEL_EXPO,
+		ExpoToken: expoToken,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Status:    model.OUTBOX_PENDING,
+	}
+	data := notification.PushData()
+	if notification.ID != 0 {
+		notificationId := notification.ID
+		m.NotificationID = &notificationId
 	} else {
-		output = fmt.Sprintf("%d วัน %d ชั่วโมง", day, hour%24)
+		delete(data, "notificationId")
 	}
-	return baseStr + output
+	m.Data = datatypes.NewJSONType(data)
+	return m
 }
 
-var thaiMonths = []string{
-	"", // index 0 is not used
-	"มกราคม", "กุมภาพันธ์", "มีนาคม", "เมษายน",
-	"พฤษภาคม", "มิถุนายน", "กรกฎาคม", "สิงหาคม",
-	"กันยายน", "ตุลาคม", "พฤศจิกายน", "ธันวาคม",
+/*
+persist messages before sending, so failed messages are retried by ProcessOutbox,
+messages with NextAttemptAt in the future (quiet hours) are left for ProcessOutbox
+*/
+func (n *service) enqueueAndSend(messages []model.NotificationOutbox) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	isDue := leaseDue(messages)
+	created, err := n.Repo.CreateOutboxMessages(messages)
+	if err != nil {
+		NotiLogger.Printf("can't enqueue messages : %v\n", err.Error())
+		return err
+	}
+	n.sendDue(created, isDue)
+	return nil
 }
 
-func formatThaiTime(dueTimestamp int) string {
-	t := time.Unix(int64(dueTimestamp), 0)
-	day := t.Day()
-	month := t.Month()
-	year := t.Year()
-	// format to thai
-	beYear := year + 543
-	thaiMonth := thaiMonths[int(month)]
-	return fmt.Sprintf("%d %s %d", day, thaiMonth, beYear)
+// lease the messages that are due now to this send, returns which ones are due
+func leaseDue(messages []model.NotificationOutbox) []bool {
+	now := int(time.Now().Unix())
+	isDue := make([]bool, len(messages))
+	for i := range messages {
+		if messages[i].NextAttemptAt <= now {
+			isDue[i] = true
+			// the worker only picks them up if this send doesn't finish within the lease
+			messages[i].NextAttemptAt = now + OUTBOX_LEASE
+		}
+	}
+	return isDue
+}
+
+func (n *service) sendDue(created []model.NotificationOutbox, isDue []bool) {
+	due := []model.NotificationOutbox{}
+	for i, m := range created {
+		if isDue[i] {
+			due = append(due, m)
+		}
+	}
+	if deferred := len(created) - len(due); deferred > 0 {
+		NotiLogger.Printf("deferred %v messages until quiet hours end\n", deferred)
+	}
+	n.dispatch(due)
 }
@@ -5,6 +5,7 @@
 package notification
 
 import (
+	"github.com/PhasitWo/duchenne-server/model"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -35,68 +36,250 @@ func (_m *MockService) EXPECT() *MockService_Expecter {
 	return &MockService_Expecter{mock: &_m.Mock}
 }
 
-// SendDailyNotifications provides a mock function for the type MockService
-func (_mock *MockService) SendDailyNotifications(dayRange *int) error {
-	ret := _mock.Called(dayRange)
+// CheckReceipts provides a mock function for the type MockService
+func (_mock *MockService) CheckReceipts() error {
+	ret := _mock.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for SendDailyNotifications")
+		panic("no return value specified for CheckReceipts")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(*int) error); ok {
-		r0 = returnFunc(dayRange)
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_SendDailyNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendDailyNotifications'
-type MockService_SendDailyNotifications_Call struct {
+// MockService_CheckReceipts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckReceipts'
+type MockService_CheckReceipts_Call struct {
 	*mock.Call
 }
 
-// SendDailyNotifications is a helper method to define mock.On call
-//   - dayRange *int
-func (_e *MockService_Expecter) SendDailyNotifications(dayRange interface{}) *MockService_SendDailyNotifications_Call {
-	return &MockService_SendDailyNotifications_Call{Call: _e.mock.On("SendDailyNotifications", dayRange)}
+// CheckReceipts is a helper method to define mock.On call
+func (_e *MockService_Expecter) CheckReceipts() *MockService_CheckReceipts_Call {
+	return &MockService_CheckReceipts_Call{Call: _e.mock.On("CheckReceipts")}
 }
 
-func (_c *MockService_SendDailyNotifications_Call) Run(run func(dayRange *int)) *MockService_SendDailyNotifications_Call {
+func (_c *MockService_CheckReceipts_Call) Run(run func()) *MockService_CheckReceipts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *int
+		run()
+	})
+	return _c
+}
+
+func (_c *MockService_CheckReceipts_Call) Return(err error) *MockService_CheckReceipts_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_CheckReceipts_Call) RunAndReturn(run func() error) *MockService_CheckReceipts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotifyCareTeam provides a mock function for the type MockService
+func (_mock *MockService) NotifyCareTeam(patientId int, link model.NotificationLink) error {
+	ret := _mock.Called(patientId, link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifyCareTeam")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, model.NotificationLink) error); ok {
+		r0 = returnFunc(patientId, link)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_NotifyCareTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotifyCareTeam'
+type MockService_NotifyCareTeam_Call struct {
+	*mock.Call
+}
+
+// NotifyCareTeam is a helper method to define mock.On call
+//   - patientId int
+//   - link model.NotificationLink
+func (_e *MockService_Expecter) NotifyCareTeam(patientId interface{}, link interface{}) *MockService_NotifyCareTeam_Call {
+	return &MockService_NotifyCareTeam_Call{Call: _e.mock.On("NotifyCareTeam", patientId, link)}
+}
+
+func (_c *MockService_NotifyCareTeam_Call) Run(run func(patientId int, link model.NotificationLink)) *MockService_NotifyCareTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
 		if args[0] != nil {
-			arg0 = args[0].(*int)
+			arg0 = args[0].(int)
+		}
+		var arg1 model.NotificationLink
+		if args[1] != nil {
+			arg1 = args[1].(model.NotificationLink)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_SendDailyNotifications_Call) Return(err error) *MockService_SendDailyNotifications_Call {
+func (_c *MockService_NotifyCareTeam_Call) Return(err error) *MockService_NotifyCareTeam_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_SendDailyNotifications_Call) RunAndReturn(run func(dayRange *int) error) *MockService_SendDailyNotifications_Call {
+func (_c *MockService_NotifyCareTeam_Call) RunAndReturn(run func(patientId int, link model.NotificationLink) error) *MockService_NotifyCareTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessOutbox provides a mock function for the type MockService
+func (_mock *MockService) ProcessOutbox() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessOutbox")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_ProcessOutbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessOutbox'
+type MockService_ProcessOutbox_Call struct {
+	*mock.Call
+}
+
+// ProcessOutbox is a helper method to define mock.On call
+func (_e *MockService_Expecter) ProcessOutbox() *MockService_ProcessOutbox_Call {
+	return &MockService_ProcessOutbox_Call{Call: _e.mock.On("ProcessOutbox")}
+}
+
+func (_c *MockService_ProcessOutbox_Call) Run(run func()) *MockService_ProcessOutbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockService_ProcessOutbox_Call) Return(err error) *MockService_ProcessOutbox_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_ProcessOutbox_Call) RunAndReturn(run func() error) *MockService_ProcessOutbox_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendDueCampaigns provides a mock function for the type MockService
+func (_mock *MockService) SendDueCampaigns() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendDueCampaigns")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_SendDueCampaigns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendDueCampaigns'
+type MockService_SendDueCampaigns_Call struct {
+	*mock.Call
+}
+
+// SendDueCampaigns is a helper method to define mock.On call
+func (_e *MockService_Expecter) SendDueCampaigns() *MockService_SendDueCampaigns_Call {
+	return &MockService_SendDueCampaigns_Call{Call: _e.mock.On("SendDueCampaigns")}
+}
+
+func (_c *MockService_SendDueCampaigns_Call) Run(run func()) *MockService_SendDueCampaigns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockService_SendDueCampaigns_Call) Return(err error) *MockService_SendDueCampaigns_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_SendDueCampaigns_Call) RunAndReturn(run func() error) *MockService_SendDueCampaigns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendMedicationReminders provides a mock function for the type MockService
+func (_mock *MockService) SendMedicationReminders() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMedicationReminders")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_SendMedicationReminders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMedicationReminders'
+type MockService_SendMedicationReminders_Call struct {
+	*mock.Call
+}
+
+// SendMedicationReminders is a helper method to define mock.On call
+func (_e *MockService_Expecter) SendMedicationReminders() *MockService_SendMedicationReminders_Call {
+	return &MockService_SendMedicationReminders_Call{Call: _e.mock.On("SendMedicationReminders")}
+}
+
+func (_c *MockService_SendMedicationReminders_Call) Run(run func()) *MockService_SendMedicationReminders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockService_SendMedicationReminders_Call) Return(err error) *MockService_SendMedicationReminders_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_SendMedicationReminders_Call) RunAndReturn(run func() error) *MockService_SendMedicationReminders_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // SendNotiByPatientId provides a mock function for the type MockService
-func (_mock *MockService) SendNotiByPatientId(id int, title string, body string) error {
-	ret := _mock.Called(id, title, body)
+func (_mock *MockService) SendNotiByPatientId(id int, category model.NotificationCategory, title string, body string, link model.NotificationLink) error {
+	ret := _mock.Called(id, category, title, body, link)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SendNotiByPatientId")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(int, string, string) error); ok {
-		r0 = returnFunc(id, title, body)
+	if returnFunc, ok := ret.Get(0).(func(int, model.NotificationCategory, string, string, model.NotificationLink) error); ok {
+		r0 = returnFunc(id, category, title, body, link)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -110,30 +293,42 @@ type MockService_SendNotiByPatientId_Call struct {
 
 // SendNotiByPatientId is a helper method to define mock.On call
 //   - id int
+//   - category model.NotificationCategory
 //   - title string
 //   - body string
-func (_e *MockService_Expecter) SendNotiByPatientId(id interface{}, title interface{}, body interface{}) *MockService_SendNotiByPatientId_Call {
-	return &MockService_SendNotiByPatientId_Call{Call: _e.mock.On("SendNotiByPatientId", id, title, body)}
+//   - link model.NotificationLink
+func (_e *MockService_Expecter) SendNotiByPatientId(id interface{}, category interface{}, title interface{}, body interface{}, link interface{}) *MockService_SendNotiByPatientId_Call {
+	return &MockService_SendNotiByPatientId_Call{Call: _e.mock.On("SendNotiByPatientId", id, category, title, body, link)}
 }
 
-func (_c *MockService_SendNotiByPatientId_Call) Run(run func(id int, title string, body string)) *MockService_SendNotiByPatientId_Call {
+func (_c *MockService_SendNotiByPatientId_Call) Run(run func(id int, category model.NotificationCategory, title string, body string, link model.NotificationLink)) *MockService_SendNotiByPatientId_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int
 		if args[0] != nil {
 			arg0 = args[0].(int)
 		}
-		var arg1 string
+		var arg1 model.NotificationCategory
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(model.NotificationCategory)
 		}
 		var arg2 string
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 model.NotificationLink
+		if args[4] != nil {
+			arg4 = args[4].(model.NotificationLink)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
@@ -144,7 +339,164 @@ func (_c *MockService_SendNotiByPatientId_Call) Return(err error) *MockService_S
 	return _c
 }
 
-func (_c *MockService_SendNotiByPatientId_Call) RunAndReturn(run func(id int, title string, body string) error) *MockService_SendNotiByPatientId_Call {
+func (_c *MockService_SendNotiByPatientId_Call) RunAndReturn(run func(id int, category model.NotificationCategory, title string, body string, link model.NotificationLink) error) *MockService_SendNotiByPatientId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendReminders provides a mock function for the type MockService
+func (_mock *MockService) SendReminders() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendReminders")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_SendReminders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendReminders'
+type MockService_SendReminders_Call struct {
+	*mock.Call
+}
+
+// SendReminders is a helper method to define mock.On call
+func (_e *MockService_Expecter) SendReminders() *MockService_SendReminders_Call {
+	return &MockService_SendReminders_Call{Call: _e.mock.On("SendReminders")}
+}
+
+func (_c *MockService_SendReminders_Call) Run(run func()) *MockService_SendReminders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockService_SendReminders_Call) Return(err error) *MockService_SendReminders_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_SendReminders_Call) RunAndReturn(run func() error) *MockService_SendReminders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendTemplateByPatientId provides a mock function for the type MockService
+func (_mock *MockService) SendTemplateByPatientId(id int, key model.TemplateKey, params model.TemplateParams, link model.NotificationLink) error {
+	ret := _mock.Called(id, key, params, link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendTemplateByPatientId")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, model.TemplateKey, model.TemplateParams, model.NotificationLink) error); ok {
+		r0 = returnFunc(id, key, params, link)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_SendTemplateByPatientId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendTemplateByPatientId'
+type MockService_SendTemplateByPatientId_Call struct {
+	*mock.Call
+}
+
+// SendTemplateByPatientId is a helper method to define mock.On call
+//   - id int
+//   - key model.TemplateKey
+//   - params model.TemplateParams
+//   - link model.NotificationLink
+func (_e *MockService_Expecter) SendTemplateByPatientId(id interface{}, key interface{}, params interface{}, link interface{}) *MockService_SendTemplateByPatientId_Call {
+	return &MockService_SendTemplateByPatientId_Call{Call: _e.mock.On("SendTemplateByPatientId", id, key, params, link)}
+}
+
+func (_c *MockService_SendTemplateByPatientId_Call) Run(run func(id int, key model.TemplateKey, params model.TemplateParams, link model.NotificationLink)) *MockService_SendTemplateByPatientId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 model.TemplateKey
+		if args[1] != nil {
+			arg1 = args[1].(model.TemplateKey)
+		}
+		var arg2 model.TemplateParams
+		if args[2] != nil {
+			arg2 = args[2].(model.TemplateParams)
+		}
+		var arg3 model.NotificationLink
+		if args[3] != nil {
+			arg3 = args[3].(model.NotificationLink)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_SendTemplateByPatientId_Call) Return(err error) *MockService_SendTemplateByPatientId_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_SendTemplateByPatientId_Call) RunAndReturn(run func(id int, key model.TemplateKey, params model.TemplateParams, link model.NotificationLink) error) *MockService_SendTemplateByPatientId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendVaccineReminders provides a mock function for the type MockService
+func (_mock *MockService) SendVaccineReminders() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendVaccineReminders")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_SendVaccineReminders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendVaccineReminders'
+type MockService_SendVaccineReminders_Call struct {
+	*mock.Call
+}
+
+// SendVaccineReminders is a helper method to define mock.On call
+func (_e *MockService_Expecter) SendVaccineReminders() *MockService_SendVaccineReminders_Call {
+	return &MockService_SendVaccineReminders_Call{Call: _e.mock.On("SendVaccineReminders")}
+}
+
+func (_c *MockService_SendVaccineReminders_Call) Run(run func()) *MockService_SendVaccineReminders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockService_SendVaccineReminders_Call) Return(err error) *MockService_SendVaccineReminders_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_SendVaccineReminders_Call) RunAndReturn(run func() error) *MockService_SendVaccineReminders_Call {
 	_c.Call.Return(run)
 	return _c
 }
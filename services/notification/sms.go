@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+)
+
+type SMSConfig struct {
+	URL   string // gateway endpoint that accepts {"to", "message"} JSON
+	Token string // sent as bearer token, empty means no authorization header
+}
+
+// send sms through HTTP gateway
+type SMSChannel struct {
+	config SMSConfig
+	client *http.Client
+}
+
+func NewSMSChannel(config SMSConfig) *SMSChannel {
+	return &SMSChannel{config: config, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+type smsRequest struct {
+	To      string `json:"to"`
+	Message string `json:"message"`
+}
+
+func (c *SMSChannel) Name() model.NotificationChannel {
+	return model.CHANNEL_SMS
+}
+
+func (c *SMSChannel) BatchSize() int {
+	return 20
+}
+
+func (c *SMSChannel) Send(messages []model.NotificationOutbox) []SendResult {
+	results := make([]SendResult, len(messages))
+	for i, m := range messages {
+		if m.Address == nil {
+			results[i] = failedResult(errors.New("missing phone number"))
+			continue
+		}
+		results[i] = c.send(*m.Address, m.Title+"\n"+m.Body)
+	}
+	return results
+}
+
+func (c *SMSChannel) send(phone string, message string) SendResult {
+	payload, err := json.Marshal(smsRequest{To: phone, Message: message})
+	if err != nil {
+		return failedResult(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, c.config.URL, bytes.NewReader(payload))
+	if err != nil {
+		return failedResult(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return retryResult(err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return sentResult("")
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return retryResult(fmt.Errorf("sms gateway responded %v", resp.StatusCode))
+	default:
+		return failedResult(fmt.Errorf("sms gateway responded %v", resp.StatusCode))
+	}
+}
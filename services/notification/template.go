@@ -0,0 +1,350 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/services/schedule"
+	"gorm.io/gorm"
+)
+
+// built-in template of a notification, admins can override its text per language
+type TemplateDefinition struct {
+	Key          model.TemplateKey
+	Category     model.NotificationCategory
+	Placeholders []string // allowed {{placeholder}} in title and body
+	Defaults     map[model.Language]model.TemplateText
+}
+
+var appointmentPlaceholders = []string{"doctorName", "date", "time"}
+
+func defaultText(th [2]string, en [2]string) map[model.Language]model.TemplateText {
+	return map[model.Language]model.TemplateText{
+		model.LANGUAGE_TH: {Language: model.LANGUAGE_TH, Title: th[0], Body: th[1], IsDefault: true},
+		model.LANGUAGE_EN: {Language: model.LANGUAGE_EN, Title: en[0], Body: en[1], IsDefault: true},
+	}
+}
+
+// every template sent by the system, in display order
+var TemplateDefinitions = []TemplateDefinition{
+	{
+		Key:          model.TEMPLATE_APPOINTMENT_CREATED,
+		Category:     model.CATEGORY_APPOINTMENT,
+		Placeholders: appointmentPlaceholders,
+		Defaults: defaultText(
+			[2]string{"คุณมีนัดหมายใหม่!", "ดูข้อมูลในแอปพลิเคชัน"},
+			[2]string{"You have a new appointment!", "See the details in the app"},
+		),
+	},
+	{
+		Key:          model.TEMPLATE_APPOINTMENT_UPDATED,
+		Category:     model.CATEGORY_APPOINTMENT,
+		Placeholders: appointmentPlaceholders,
+		Defaults: defaultText(
+			[2]string{"นัดหมายของคุณมีการเปลี่ยนแปลง!", "เช็คสถานะในแอปพลิเคชัน"},
+			[2]string{"Your appointment has changed!", "Check the status in the app"},
+		),
+	},
+	{
+		Key:          model.TEMPLATE_APPOINTMENT_APPROVED,
+		Category:     model.CATEGORY_APPOINTMENT,
+		Placeholders: appointmentPlaceholders,
+		Defaults: defaultText(
+			[2]string{"นัดหมายของคุณได้รับการยืนยันแล้ว!", "เช็คสถานะในแอปพลิเคชัน"},
+			[2]string{"Your appointment is confirmed!", "Check the status in the app"},
+		),
+	},
+	{
+		Key:          model.TEMPLATE_APPOINTMENT_REJECTED,
+		Category:     model.CATEGORY_APPOINTMENT,
+		Placeholders: append([]string{"reason"}, appointmentPlaceholders...),
+		Defaults: defaultText(
+			[2]string{"นัดหมายของคุณไม่ได้รับการยืนยัน", "{{reason}}"},
+			[2]string{"Your appointment was not confirmed", "{{reason}}"},
+		),
+	},
+	{
+		Key:          model.TEMPLATE_APPOINTMENT_CANCELLED,
+		Category:     model.CATEGORY_APPOINTMENT,
+		Placeholders: append([]string{"reason"}, appointmentPlaceholders...),
+		Defaults: defaultText(
+			[2]string{"นัดหมายของคุณถูกยกเลิก!", "เจ้าหน้าที่ยกเลิกนัดหมายของคุณ"},
+			[2]string{"Your appointment was cancelled!", "The staff cancelled your appointment"},
+		),
+	},
+	{
+		Key:          model.TEMPLATE_RESCHEDULE_ACCEPTED,
+		Category:     model.CATEGORY_APPOINTMENT,
+		Placeholders: appointmentPlaceholders,
+		Defaults: defaultText(
+			[2]string{"คำขอเลื่อนนัดหมายของคุณได้รับการอนุมัติแล้ว!", "เช็ควันนัดหมายใหม่ในแอปพลิเคชัน"},
+			[2]string{"Your reschedule request is approved!", "Check the new date in the app"},
+		),
+	},
+	{
+		Key:          model.TEMPLATE_RESCHEDULE_DECLINED,
+		Category:     model.CATEGORY_APPOINTMENT,
+		Placeholders: append([]string{"reason"}, appointmentPlaceholders...),
+		Defaults: defaultText(
+			[2]string{"คำขอเลื่อนนัดหมายของคุณไม่ได้รับการอนุมัติ", "{{reason}}"},
+			[2]string{"Your reschedule request was declined", "{{reason}}"},
+		),
+	},
+	{
+		Key:          model.TEMPLATE_APPOINTMENT_REMINDER,
+		Category:     model.CATEGORY_REMINDER,
+		Placeholders: append([]string{"remainingTime"}, appointmentPlaceholders...),
+		Defaults: defaultText(
+			[2]string{"อย่าลืมนัดหมายของคุณ!", "คุณมีนัดหมายในอีก {{remainingTime}} ({{date}})"},
+			[2]string{"Don't forget your appointment!", "Your appointment is in {{remainingTime}} ({{date}})"},
+		),
+	},
+	{
+		Key:          model.TEMPLATE_QUESTION_ANSWERED,
+		Category:     model.CATEGORY_QUESTION,
+		Placeholders: []string{"topic", "doctorName"},
+		Defaults: defaultText(
+			[2]string{"แพทย์ตอบคำถามของคุณแล้ว!", "ดูคำตอบในแอปพลิเคชัน"},
+			[2]string{"The doctor answered your question!", "See the answer in the app"},
+		),
+	},
+	{
+		Key:          model.TEMPLATE_QUESTION_MESSAGE,
+		Category:     model.CATEGORY_QUESTION,
+		Placeholders: []string{"topic", "doctorName"},
+		Defaults: defaultText(
+			[2]string{"มีข้อความใหม่ในคำถามของคุณ", "{{topic}}"},
+			[2]string{"New message on your question", "{{topic}}"},
+		),
+	},
+	{
+		Key:          model.TEMPLATE_MEDICATION_REMINDER,
+		Category:     model.CATEGORY_MEDICATION,
+		Placeholders: []string{"medicineName", "dose", "time"},
+		Defaults: defaultText(
+			[2]string{"ได้เวลาทานยาแล้ว", "{{medicineName}} {{dose}} เวลา {{time}} น."},
+			[2]string{"Time for your medicine", "{{medicineName}} {{dose}} at {{time}}"},
+		),
+	},
+	{
+		Key:          model.TEMPLATE_VACCINE_DUE,
+		Category:     model.CATEGORY_VACCINE,
+		Placeholders: []string{"vaccineName", "dueDate"},
+		Defaults: defaultText(
+			[2]string{"ถึงกำหนดรับวัคซีน", "{{vaccineName}} ครบกำหนดวันที่ {{dueDate}} ติดต่อโรงพยาบาลเพื่อนัดฉีดวัคซีน"},
+			[2]string{"Vaccine due", "{{vaccineName}} is due on {{dueDate}}, contact the clinic to get it"},
+		),
+	},
+	{
+		Key:          model.TEMPLATE_SIGNUP_APPROVED,
+		Category:     model.CATEGORY_ACCOUNT,
+		Placeholders: []string{"firstName", "hn"},
+		Defaults: defaultText(
+			[2]string{"บัญชีของคุณได้รับการยืนยันแล้ว!", "คุณ{{firstName}} สามารถเข้าสู่ระบบด้วย HN {{hn}} ได้แล้ว"},
+			[2]string{"Your account is verified!", "{{firstName}}, you can now log in with HN {{hn}}"},
+		),
+	},
+	{
+		Key:          model.TEMPLATE_SIGNUP_REJECTED,
+		Category:     model.CATEGORY_ACCOUNT,
+		Placeholders: []string{"firstName", "reason"},
+		Defaults: defaultText(
+			[2]string{"การสมัครสมาชิกของคุณไม่ได้รับการอนุมัติ", "{{reason}}"},
+			[2]string{"Your signup was not approved", "{{reason}}"},
+		),
+	},
+}
+
+// body when the rendered body is blank e.g. rejected without a reason
+var emptyBodyFallback = map[model.Language]string{
+	model.LANGUAGE_TH: "เช็คสถานะในแอปพลิเคชัน",
+	model.LANGUAGE_EN: "Check the status in the app",
+}
+
+var ErrTemplateNotFound = errors.New("template not found")
+
+func GetTemplateDefinition(key model.TemplateKey) (TemplateDefinition, error) {
+	for _, def := range TemplateDefinitions {
+		if def.Key == key {
+			return def, nil
+		}
+	}
+	return TemplateDefinition{}, ErrTemplateNotFound
+}
+
+var placeholderPattern = regexp.MustCompile(`{{\s*([^{}]*?)\s*}}`)
+
+// check that the text only uses placeholders of the template
+func (def TemplateDefinition) Validate(text string) error {
+	for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+		if !containsString(def.Placeholders, match[1]) {
+			return fmt.Errorf("unknown placeholder {{%v}}, allowed placeholders are %v", match[1], strings.Join(def.Placeholders, ", "))
+		}
+	}
+	return nil
+}
+
+// replace placeholders with values, placeholders without value become empty
+func renderText(text string, values model.TemplateParams) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(s string) string {
+		name := placeholderPattern.FindStringSubmatch(s)[1]
+		return values[name]
+	})
+}
+
+/*
+render a template in the language, text is taken from admin's override,
+then built-in text of the language, then built-in thai text
+*/
+func (n *service) renderTemplate(def TemplateDefinition, language model.Language, values model.TemplateParams) (title string, body string) {
+	if !language.IsValid() {
+		language = model.LANGUAGE_TH
+	}
+	text, ok := def.Defaults[language]
+	if !ok {
+		text = def.Defaults[model.LANGUAGE_TH]
+	}
+	override, err := n.Repo.GetNotificationTemplate(def.Key, language)
+	if err == nil {
+		text = model.TemplateText{Title: override.Title, Body: override.Body}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		NotiLogger.Printf("can't get template %v (%v), use default : %v\n", def.Key, language, err.Error())
+	}
+	title = strings.TrimSpace(renderText(text.Title, values))
+	body = strings.TrimSpace(renderText(text.Body, values))
+	if body == "" {
+		body = emptyBodyFallback[language]
+	}
+	return title, body
+}
+
+/*
+render the template in the patient's language and send it, placeholders of the linked appointment or question
+are filled from the database unless the caller already gives them
+*/
+func (n *service) SendTemplateByPatientId(id int, key model.TemplateKey, params model.TemplateParams, link model.NotificationLink) error {
+	def, err := GetTemplateDefinition(key)
+	if err != nil {
+		NotiLogger.Printf("can't send template %v : %v\n", key, err.Error())
+		return err
+	}
+	values := model.TemplateParams{}
+	language := n.linkParams(id, link, values)
+	for k, v := range params {
+		values[k] = v
+	}
+	title, body := n.renderTemplate(def, language, values)
+	return n.SendNotiByPatientId(id, def.Category, title, body, link)
+}
+
+// fill placeholders of the linked appointment or question, return language of the patient
+func (n *service) linkParams(patientId int, link model.NotificationLink, values model.TemplateParams) model.Language {
+	switch link.Type {
+	case model.NOTIFICATION_APPOINTMENT:
+		ap, err := n.Repo.GetAppointment(link.ID)
+		if err == nil && ap.PatientID == patientId {
+			language := ap.Patient.Language
+			for k, v := range appointmentParams(ap.Appointment, ap.Doctor.Doctor, language) {
+				values[k] = v
+			}
+			return language
+		}
+	case model.NOTIFICATION_QUESTION:
+		q, err := n.Repo.GetQuestion(link.ID)
+		if err == nil && q.PatientID == patientId {
+			values["topic"] = q.Topic
+			if q.DoctorID != nil {
+				values["doctorName"] = fullName(q.Doctor.FirstName, q.Doctor.MiddleName, q.Doctor.LastName)
+			}
+			return q.Patient.Language
+		}
+	}
+	patient, err := n.Repo.GetPatientById(patientId)
+	if err != nil {
+		NotiLogger.Printf("can't get language of patient %v, use thai : %v\n", patientId, err.Error())
+		return model.LANGUAGE_TH
+	}
+	return patient.Language
+}
+
+func appointmentParams(ap model.Appointment, doctor model.Doctor, language model.Language) model.TemplateParams {
+	return model.TemplateParams{
+		"doctorName": fullName(doctor.FirstName, doctor.MiddleName, doctor.LastName),
+		"date":       formatDate(ap.Date, language),
+		"time":       formatClock(ap.Date),
+	}
+}
+
+func fullName(firstName string, middleName *string, lastName string) string {
+	if middleName != nil && *middleName != "" {
+		return firstName + " " + *middleName + " " + lastName
+	}
+	return firstName + " " + lastName
+}
+
+func containsString(list []string, s string) bool {
+	for _, elem := range list {
+		if elem == s {
+			return true
+		}
+	}
+	return false
+}
+
+// time left before the appointment e.g. "2 วัน 3 ชั่วโมง", "2 days 3 hours"
+func formatRemainingTime(dueTimestamp int, nowTimestamp int, language model.Language) string {
+	sec := (dueTimestamp - nowTimestamp)
+	minute := sec / 60
+	hour := minute / 60
+	day := hour / 24
+	if language == model.LANGUAGE_EN {
+		if minute == 0 {
+			return "a few minutes"
+		} else if hour == 0 {
+			return englishUnit(minute, "minute")
+		} else if day == 0 {
+			return englishUnit(hour, "hour") + " " + englishUnit(minute%60, "minute")
+		}
+		return englishUnit(day, "day") + " " + englishUnit(hour%24, "hour")
+	}
+	if minute == 0 {
+		return "ไม่กี่นาที"
+	} else if hour == 0 {
+		return fmt.Sprintf("%d นาที", minute)
+	} else if day == 0 {
+		return fmt.Sprintf("%d ชั่วโมง %d นาที", hour, minute%60)
+	}
+	return fmt.Sprintf("%d วัน %d ชั่วโมง", day, hour%24)
+}
+
+func englishUnit(value int, unit string) string {
+	if value == 1 {
+		return fmt.Sprintf("%d %s", value, unit)
+	}
+	return fmt.Sprintf("%d %ss", value, unit)
+}
+
+var thaiMonths = []string{
+	"", // index 0 is not used
+	"มกราคม", "กุมภาพันธ์", "มีนาคม", "เมษายน",
+	"พฤษภาคม", "มิถุนายน", "กรกฎาคม", "สิงหาคม",
+	"กันยายน", "ตุลาคม", "พฤศจิกายน", "ธันวาคม",
+}
+
+// date in clinic timezone, thai uses buddhist era e.g. "5 มกราคม 2568", "5 January 2025"
+func formatDate(timestamp int, language model.Language) string {
+	t := time.Unix(int64(timestamp), 0).In(schedule.Location())
+	if language == model.LANGUAGE_EN {
+		return fmt.Sprintf("%d %s %d", t.Day(), t.Month().String(), t.Year())
+	}
+	return fmt.Sprintf("%d %s %d", t.Day(), thaiMonths[int(t.Month())], t.Year()+543)
+}
+
+// time of day in clinic timezone e.g. "14:30"
+func formatClock(timestamp int) string {
+	return time.Unix(int64(timestamp), 0).In(schedule.Location()).Format("15:04")
+}
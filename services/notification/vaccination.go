@@ -0,0 +1,74 @@
+package notification
+
+import (
+	"errors"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/schedule"
+	"github.com/PhasitWo/duchenne-server/services/vaccination"
+)
+
+/*
+remind patients of vaccine doses that became due, live vaccines on high dose steroids are left for the doctor,
+every reminder is recorded in the ledger first so each dose is reminded only once
+*/
+func (n *service) SendVaccineReminders() error {
+	rules, err := n.Repo.GetAllVaccineRule(repository.Criteria{QueryCriteria: repository.IS_ENABLED, Value: true})
+	if err != nil {
+		NotiLogger.Println("can't get vaccine rules")
+		return err
+	}
+	if len(rules) == 0 {
+		NotiLogger.Println("no enabled vaccine rules")
+		return nil
+	}
+	patients, err := n.Repo.GetAllPatient(-1, 0, repository.Criteria{QueryCriteria: repository.VERIFICATION, Value: model.SIGNUP_APPROVED})
+	if err != nil {
+		NotiLogger.Println("can't get patients")
+		return err
+	}
+	def, err := GetTemplateDefinition(model.TEMPLATE_VACCINE_DUE)
+	if err != nil {
+		return err
+	}
+	now := int(time.Now().Unix())
+	sentCnt := 0
+	for _, patient := range patients {
+		records, err := n.Repo.GetAllVaccination(patient.ID)
+		if err != nil {
+			NotiLogger.Printf("can't get vaccinations of patient %v : %v\n", patient.ID, err.Error())
+			continue
+		}
+		regimens, err := n.Repo.GetAllMedicationRegimen(patient.ID)
+		if err != nil {
+			NotiLogger.Printf("can't get medication of patient %v : %v\n", patient.ID, err.Error())
+			continue
+		}
+		for _, due := range vaccination.DueVaccines(patient, rules, records, regimens, now, 0, schedule.Location()) {
+			if due.Contraindicated {
+				continue
+			}
+			err := n.Repo.CreateVaccineReminderLog(model.VaccineReminderLog{PatientID: patient.ID, VaccineID: due.VaccineID, DoseNumber: due.DoseNumber})
+			if err != nil {
+				if !errors.Is(err, repository.ErrDuplicateEntry) {
+					NotiLogger.Printf("can't record vaccine reminder of patient %v : %v\n", patient.ID, err.Error())
+				}
+				continue
+			}
+			params := model.TemplateParams{
+				"vaccineName": due.VaccineName,
+				"dueDate":     formatDate(due.DueAt, patient.Language),
+			}
+			title, body := n.renderTemplate(def, patient.Language, params)
+			if err := n.SendNotiByPatientId(patient.ID, def.Category, title, body, model.VaccineLink(due.VaccineID)); err != nil {
+				NotiLogger.Printf("can't send vaccine reminder of patient %v : %v\n", patient.ID, err.Error())
+				continue
+			}
+			sentCnt++
+		}
+	}
+	NotiLogger.Printf("sent %v vaccine reminders\n", sentCnt)
+	return nil
+}
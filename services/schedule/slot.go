@@ -0,0 +1,84 @@
+package schedule
+
+import (
+	"sort"
+	"time"
+	_ "time/tzdata" // embed timezone database for containers without zoneinfo
+
+	"github.com/PhasitWo/duchenne-server/config"
+	"github.com/PhasitWo/duchenne-server/model"
+)
+
+// maximum range of slots that can be requested at once
+const MAX_SLOT_RANGE = 31 * 24 * 60 * 60
+
+// clinic timezone from config, fallback to GMT+7
+func Location() *time.Location {
+	if config.AppConfig.CLINIC_TIMEZONE == "" {
+		return time.FixedZone("GMT+7", 7*60*60)
+	}
+	loc, err := time.LoadLocation(config.AppConfig.CLINIC_TIMEZONE)
+	if err != nil {
+		return time.FixedZone("GMT+7", 7*60*60)
+	}
+	return loc
+}
+
+/*
+generate free slots in [from, to) from weekly schedules,
+slots that overlap any exception or booked appointment are skipped
+*/
+func GenerateSlots(schedules []model.DoctorSchedule, exceptions []model.ScheduleException, booked []model.Slot, from int, to int, loc *time.Location) []model.Slot {
+	res := []model.Slot{}
+	if from >= to {
+		return res
+	}
+	start := time.Unix(int64(from), 0).In(loc)
+	end := time.Unix(int64(to), 0).In(loc)
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	for !day.After(end) {
+		for _, s := range schedules {
+			if s.Weekday != int(day.Weekday()) || s.SlotMinutes <= 0 {
+				continue
+			}
+			for minute := s.StartMinute; minute+s.SlotMinutes <= s.EndMinute; minute += s.SlotMinutes {
+				slotStart := int(time.Date(day.Year(), day.Month(), day.Day(), 0, minute, 0, 0, loc).Unix())
+				slotEnd := slotStart + s.SlotMinutes*60
+				if slotStart < from || slotEnd > to {
+					continue
+				}
+				if isBlocked(slotStart, slotEnd, exceptions, booked) {
+					continue
+				}
+				res = append(res, model.Slot{Start: slotStart, End: slotEnd})
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Start < res[j].Start })
+	return res
+}
+
+// find a free slot that starts exactly at date
+func FindSlot(slots []model.Slot, date int) (model.Slot, bool) {
+	for _, s := range slots {
+		if s.Start == date {
+			return s, true
+		}
+	}
+	return model.Slot{}, false
+}
+
+func isBlocked(slotStart int, slotEnd int, exceptions []model.ScheduleException, booked []model.Slot) bool {
+	for _, e := range exceptions {
+		if e.StartAt < slotEnd && e.EndAt > slotStart {
+			return true
+		}
+	}
+	for _, b := range booked {
+		if b.Start < slotEnd && b.End > slotStart {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package vaccination
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+)
+
+// prednisone mg a day from which steroids are high dose, live vaccines are not given
+const HIGH_DOSE_PREDNISONE_MG = 20
+
+// live vaccines wait this long after high dose steroids are stopped, in seconds
+const STEROID_WASHOUT = 30 * 24 * 60 * 60
+
+// prednisone equivalent per mg of steroids, matched by medicine name,
+// longest names first so methylprednisolone isn't taken as prednisolone
+var steroids = []struct {
+	name  string
+	ratio float64
+}{
+	{"methylprednisolone", 1.25},
+	{"prednisolone", 1},
+	{"prednisone", 1},
+	{"deflazacort", 5.0 / 6},
+}
+
+/*
+next dose of every enabled rule that is overdue or due before now + within, oldest first,
+steroid only rules apply to patients who take steroids now
+*/
+func DueVaccines(patient model.Patient, rules []model.VaccineRule, records []model.Vaccination, regimens []model.MedicationRegimen, now int, within int, loc *time.Location) []model.DueVaccine {
+	res := []model.DueVaccine{}
+	steroid := TakingSteroid(regimens, now)
+	highDose := OnHighDoseSteroid(regimens, patient.Weight, now)
+	birth := time.Unix(int64(patient.BirthDate), 0).In(loc)
+	for _, rule := range rules {
+		if !rule.Enabled || (rule.SteroidOnly && !steroid) {
+			continue
+		}
+		given := []int{}
+		for _, record := range records {
+			if record.VaccineID != nil && *record.VaccineID == rule.VaccineID {
+				given = append(given, record.VaccinatedAt)
+			}
+		}
+		dose, dueAt, ok := nextDose(rule, given, birth, loc)
+		if !ok || dueAt > now+within {
+			continue
+		}
+		status := model.VACCINE_UPCOMING
+		if dueAt <= now {
+			status = model.VACCINE_OVERDUE
+		}
+		res = append(res, model.DueVaccine{
+			VaccineID:       rule.VaccineID,
+			VaccineName:     rule.Vaccine.Name,
+			DoseNumber:      dose,
+			DueAt:           dueAt,
+			Status:          status,
+			Contraindicated: rule.Vaccine.Live && highDose,
+		})
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].DueAt < res[j].DueAt })
+	return res
+}
+
+// the patient takes any steroid at the time
+func TakingSteroid(regimens []model.MedicationRegimen, at int) bool {
+	for _, regimen := range regimens {
+		if _, ok := steroidRatio(regimen.MedicineName); ok && takenAt(regimen, at, 0) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+the patient takes at least HIGH_DOSE_PREDNISONE_MG a day or 2 mg/kg a day of prednisone equivalent,
+or stopped it within the washout, a steroid with unknown dose counts as high dose so a doctor checks it
+*/
+func OnHighDoseSteroid(regimens []model.MedicationRegimen, weight *float32, at int) bool {
+	for _, regimen := range regimens {
+		ratio, ok := steroidRatio(regimen.MedicineName)
+		if !ok || !takenAt(regimen, at, STEROID_WASHOUT) {
+			continue
+		}
+		if regimen.DoseUnit != model.DOSE_MG || regimen.Dose <= 0 {
+			return true
+		}
+		// intermittent schedules give the whole dose on the dosing day
+		perDay := max(len(model.DEFAULT_DOSE_TIMES[regimen.Schedule]), 1)
+		mg := regimen.Dose * ratio * float64(perDay)
+		if mg >= HIGH_DOSE_PREDNISONE_MG || (weight != nil && *weight > 0 && mg/float64(*weight) >= 2) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+the first dose is due at the minimum age, the next ones an interval after the latest given dose
+but not before the minimum age, no more dose when the series is complete
+*/
+func nextDose(rule model.VaccineRule, given []int, birth time.Time, loc *time.Location) (dose int, dueAt int, ok bool) {
+	if rule.Doses > 0 && len(given) >= rule.Doses {
+		return 0, 0, false
+	}
+	due := birth.AddDate(0, rule.MinAgeMonths, 0)
+	if len(given) > 0 {
+		if rule.IntervalMonths == 0 {
+			return 0, 0, false
+		}
+		last := given[0]
+		for _, at := range given {
+			last = max(last, at)
+		}
+		if next := time.Unix(int64(last), 0).In(loc).AddDate(0, rule.IntervalMonths, 0); next.After(due) {
+			due = next
+		}
+	}
+	return len(given) + 1, int(due.Unix()), true
+}
+
+func steroidRatio(medicineName string) (float64, bool) {
+	name := strings.ToLower(medicineName)
+	for _, steroid := range steroids {
+		if strings.Contains(name, steroid.name) {
+			return steroid.ratio, true
+		}
+	}
+	return 0, false
+}
+
+// the regimen is taken at the time or ended less than grace seconds before it
+func takenAt(regimen model.MedicationRegimen, at int, grace int) bool {
+	if regimen.StartDate != nil && at < *regimen.StartDate {
+		return false
+	}
+	return regimen.EndDate == nil || at < *regimen.EndDate+grace
+}
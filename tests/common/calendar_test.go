@@ -0,0 +1,170 @@
+package common_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/auth"
+	"github.com/PhasitWo/duchenne-server/config"
+	"github.com/PhasitWo/duchenne-server/handlers/common"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetDoctorCalendarFeed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.AppConfig.CALENDAR_FEED_KEY = "TEST_KEY"
+	t.Run("invalidToken", func(t *testing.T) {
+		commonH := common.CommonHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/abc.ics", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:token", commonH.GetDoctorCalendarFeed)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 401, recorder.Code)
+	})
+	t.Run("patientToken", func(t *testing.T) {
+		token, err := auth.GenerateCalendarFeedToken(auth.CALENDAR_PATIENT, 1, 0)
+		assert.NoError(t, err)
+		commonH := common.CommonHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/"+token+".ics", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:token", commonH.GetDoctorCalendarFeed)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 401, recorder.Code)
+	})
+	t.Run("rotatedToken", func(t *testing.T) {
+		token, err := auth.GenerateCalendarFeedToken(auth.CALENDAR_DOCTOR, 3, 0)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetCalendarFeedVersion("doctor", 3).Return(1, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/"+token+".ics", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:token", commonH.GetDoctorCalendarFeed)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 401, recorder.Code)
+	})
+	t.Run("internalError", func(t *testing.T) {
+		token, err := auth.GenerateCalendarFeedToken(auth.CALENDAR_DOCTOR, 3, 0)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetCalendarFeedVersion("doctor", 3).Return(0, nil)
+		repo.EXPECT().GetAllAppointment(-1, 0, ownerCriteria(repository.DOCTORID, 3)).Return(nil, errors.New("err"))
+
+		req := httptest.NewRequest(http.MethodGet, "/"+token+".ics", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:token", commonH.GetDoctorCalendarFeed)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 500, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		token, err := auth.GenerateCalendarFeedToken(auth.CALENDAR_DOCTOR, 3, 0)
+		assert.NoError(t, err)
+		date := int(time.Date(2030, 1, 2, 3, 0, 0, 0, time.UTC).Unix())
+		aps := []model.SafeAppointment{
+			{Appointment: model.Appointment{ID: 10, Date: date, Duration: 30, CreateAt: 100, UpdateAt: 100, Status: model.APPROVED, Patient: model.Patient{FirstName: "สมชาย", LastName: "ใจดี", Hn: "HN1"}}},
+			{Appointment: model.Appointment{ID: 11, Date: date, Duration: 30, CreateAt: 100, UpdateAt: 160, Status: model.CANCELLED_BY_STAFF}},
+		}
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetCalendarFeedVersion("doctor", 3).Return(0, nil)
+		repo.EXPECT().GetAllAppointment(-1, 0, ownerCriteria(repository.DOCTORID, 3)).Return(aps, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/"+token+".ics", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:token", commonH.GetDoctorCalendarFeed)
+		router.ServeHTTP(recorder, req)
+
+		body := recorder.Body.String()
+		assert.Equal(t, 200, recorder.Code)
+		assert.True(t, strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/calendar"))
+		assert.Contains(t, body, "METHOD:PUBLISH\r\n")
+		assert.Contains(t, body, "UID:appointment-10@duchenne-server\r\n")
+		assert.Contains(t, body, "DTSTART:20300102T030000Z\r\nDTEND:20300102T033000Z\r\n")
+		assert.Contains(t, body, "SUMMARY:นัดหมาย สมชาย ใจดี (HN HN1)\r\n")
+		assert.Contains(t, body, "UID:appointment-11@duchenne-server\r\n")
+		assert.Contains(t, body, "SEQUENCE:60\r\nSTATUS:CANCELLED\r\n")
+	})
+}
+
+func TestGetPatientCalendarFeed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.AppConfig.CALENDAR_FEED_KEY = "TEST_KEY"
+	t.Run("doctorToken", func(t *testing.T) {
+		token, err := auth.GenerateCalendarFeedToken(auth.CALENDAR_DOCTOR, 1, 0)
+		assert.NoError(t, err)
+		commonH := common.CommonHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/"+token+".ics", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:token", commonH.GetPatientCalendarFeed)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 401, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		token, err := auth.GenerateCalendarFeedToken(auth.CALENDAR_PATIENT, 5, 0)
+		assert.NoError(t, err)
+		aps := []model.SafeAppointment{{Appointment: model.Appointment{ID: 10, Date: 2000000000, Duration: 30, Status: model.REQUESTED}}}
+		aps[0].Doctor.FirstName = "Somsak"
+		aps[0].Doctor.LastName = "Rakdee"
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetCalendarFeedVersion("patient", 5).Return(0, nil)
+		repo.EXPECT().GetAllAppointment(-1, 0, ownerCriteria(repository.PATIENTID, 5)).Return(aps, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/"+token, nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:token", commonH.GetPatientCalendarFeed)
+		router.ServeHTTP(recorder, req)
+
+		body := recorder.Body.String()
+		assert.Equal(t, 200, recorder.Code)
+		assert.Contains(t, body, "SUMMARY:นัดหมายกับ Somsak Rakdee\r\n")
+		assert.Contains(t, body, "STATUS:TENTATIVE\r\n")
+	})
+}
+
+// match criteria list that starts with the owner of the feed
+func ownerCriteria(column repository.ColumnCriteria, id int) any {
+	return mock.MatchedBy(func(criteria []repository.Criteria) bool {
+		return len(criteria) > 0 && criteria[0] == repository.Criteria{QueryCriteria: column, Value: id}
+	})
+}
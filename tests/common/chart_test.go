@@ -0,0 +1,128 @@
+package common_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/handlers/common"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGetChart(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	birth := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(years int, months int) int { return int(birth.AddDate(years, months, 0).Unix()) }
+	measurements := []model.Measurement{
+		{ID: 1, Type: model.MEASURE_SIX_MINUTE_WALK, Value: 420, MeasuredAt: at(7, 0)},
+		{ID: 2, Type: model.MEASURE_WEIGHT, Value: 22, MeasuredAt: at(7, 0)},
+		{ID: 3, Type: model.MEASURE_SIX_MINUTE_WALK, Value: 390, MeasuredAt: at(8, 0)},
+		{ID: 4, Type: model.MEASURE_SIX_MINUTE_WALK, Value: 340, MeasuredAt: at(9, 0)},
+		{ID: 5, Type: model.MEASURE_WEIGHT, Value: 22.5, MeasuredAt: at(7, 1)},
+	}
+	t.Run("invalidType", func(t *testing.T) {
+		commonH := common.CommonHandler{Repo: repository.NewMockRepo(t)}
+
+		req := httptest.NewRequest(http.MethodGet, "/1?type=iq", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, commonH.GetDoctorChart)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("patientNotFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodGet, "/1", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, commonH.GetDoctorChart)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("notInCareTeam", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetCareTeamMember(1, 3).Return(model.CareTeamMember{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound)).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/1", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3); ctx.Set("doctorRole", model.USER) }, commonH.GetDoctorChart)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1, BirthDate: int(birth.Unix())}, nil)
+		repo.EXPECT().GetAllMeasurement(1).Return(measurements, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/?reference=true", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, commonH.GetPatientChart)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		var charts []model.MeasurementChart
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &charts))
+		assert.Len(t, charts, 2)
+		// weight first in MEASUREMENT_TYPES order, a month apart is too short for a rate
+		weight := charts[0]
+		assert.Equal(t, model.MEASURE_WEIGHT, weight.Type)
+		assert.InDelta(t, 7.0, weight.Points[0].AgeYears, 0.01)
+		assert.Nil(t, weight.RatePerYear)
+		assert.Len(t, weight.Reference, 7)
+		assert.Equal(t, 50, weight.Reference[3].Percentile)
+		// reference rows of age 7 and 8 cover the patient's points
+		assert.Len(t, weight.Reference[3].Points, 2)
+		assert.Equal(t, 7.0, weight.Reference[3].Points[0].AgeYears)
+		assert.Equal(t, 22.9, weight.Reference[3].Points[0].Value)
+		walk := charts[1]
+		assert.Equal(t, model.MEASURE_SIX_MINUTE_WALK, walk.Type)
+		assert.Len(t, walk.Points, 3)
+		assert.InDelta(t, -40.0, *walk.RatePerYear, 0.1)
+		// no reference for the walk test
+		assert.Nil(t, walk.Reference)
+	})
+	t.Run("filterType", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1, BirthDate: int(birth.Unix())}, nil)
+		repo.EXPECT().GetAllMeasurement(1, []repository.Criteria{{QueryCriteria: repository.MEASURED_AFTER, Value: 99}}).Return(measurements, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/1?type=sixMinuteWalk&type=nsaa&from=100", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, commonH.GetDoctorChart)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		var charts []model.MeasurementChart
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &charts))
+		assert.Len(t, charts, 1)
+		assert.Equal(t, model.MEASURE_SIX_MINUTE_WALK, charts[0].Type)
+	})
+}
@@ -0,0 +1,225 @@
+package common_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/config"
+	"github.com/PhasitWo/duchenne-server/handlers/common"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	cloudstorage "github.com/PhasitWo/duchenne-server/services/cloud-storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// multipart request with the content as field 'file'
+func attachmentRequest(t *testing.T, fileName string, content []byte) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", fileName)
+	assert.NoError(t, err)
+	_, err = part.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	req := httptest.NewRequest(http.MethodPost, "/15", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestUploadQuestionAttachment(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.AppConfig.MAX_ATTACHMENT_SIZE_MB = 1
+	ownQuestion := model.SafeQuestion{Question: model.Question{ID: 15, PatientID: 1}}
+	t.Run("notOwner", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion("15").Return(model.SafeQuestion{Question: model.Question{ID: 15, PatientID: 2}}, nil)
+
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, commonH.UploadQuestionAttachment)
+		router.ServeHTTP(recorder, attachmentRequest(t, "rash.png", pngHeader))
+
+		assert.Equal(t, 401, recorder.Code)
+	})
+	t.Run("notFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion("15").Return(model.SafeQuestion{}, fmt.Errorf("exec : %w", gorm.ErrRecordNotFound))
+
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, commonH.UploadQuestionAttachment)
+		router.ServeHTTP(recorder, attachmentRequest(t, "rash.png", pngHeader))
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("invalidContentType", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion("15").Return(ownQuestion, nil)
+
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		// the name says png but the content is a script
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, commonH.UploadQuestionAttachment)
+		router.ServeHTTP(recorder, attachmentRequest(t, "rash.png", []byte("<html><script>alert(1)</script></html>")))
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("tooLarge", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion("15").Return(ownQuestion, nil)
+
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		content := append(pngHeader, bytes.Repeat([]byte{0}, 1024*1024)...)
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, commonH.UploadQuestionAttachment)
+		router.ServeHTTP(recorder, attachmentRequest(t, "rash.png", content))
+
+		assert.Equal(t, 413, recorder.Code)
+	})
+	t.Run("dbErrorDeletesFile", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		storage := cloudstorage.NewMockService(t)
+		commonH := common.CommonHandler{Repo: repo, CloudStorageService: storage}
+
+		var objectName string
+		repo.EXPECT().GetQuestion("15").Return(ownQuestion, nil)
+		storage.EXPECT().UploadPrivateFile(mock.Anything, mock.Anything, "image/png").RunAndReturn(func(_ *multipart.FileHeader, name string, _ string) error {
+			objectName = name
+			return nil
+		}).Once()
+		repo.EXPECT().CreateQuestionAttachment(mock.Anything).Return(-1, errors.New("err"))
+		storage.EXPECT().DeletePrivateFile(mock.Anything).RunAndReturn(func(name string) error {
+			assert.Equal(t, objectName, name)
+			return nil
+		}).Once()
+
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, commonH.UploadQuestionAttachment)
+		router.ServeHTTP(recorder, attachmentRequest(t, "rash.png", pngHeader))
+
+		assert.Equal(t, 500, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		storage := cloudstorage.NewMockService(t)
+		commonH := common.CommonHandler{Repo: repo, CloudStorageService: storage}
+
+		repo.EXPECT().GetQuestion("15").Return(ownQuestion, nil)
+		storage.EXPECT().UploadPrivateFile(mock.Anything, mock.MatchedBy(func(name string) bool {
+			return strings.HasPrefix(name, "questions/15/") && strings.HasSuffix(name, ".png")
+		}), "image/png").Return(nil).Once()
+		repo.EXPECT().CreateQuestionAttachment(mock.MatchedBy(func(a model.QuestionAttachment) bool {
+			return a.QuestionID == 15 && a.UploaderType == model.ACTOR_PATIENT && a.UploaderID == 1 &&
+				a.FileName == "rash.png" && a.ContentType == "image/png" && a.Size == int64(len(pngHeader))
+		})).Return(4, nil).Once()
+
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, commonH.UploadQuestionAttachment)
+		router.ServeHTTP(recorder, attachmentRequest(t, "rash.png", pngHeader))
+
+		assert.Equal(t, 201, recorder.Code)
+		assert.JSONEq(t, `{"id":4}`, recorder.Body.String())
+	})
+}
+
+func TestGetQuestionAttachmentURL(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.AppConfig.ATTACHMENT_URL_TTL = 300
+	t.Run("patientNotOwner", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion("15").Return(model.SafeQuestion{Question: model.Question{ID: 15, PatientID: 2}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/15/4", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id/:attachmentId", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, commonH.GetPatientQuestionAttachmentURL)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 401, recorder.Code)
+	})
+	t.Run("attachmentNotFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion("15").Return(model.SafeQuestion{Question: model.Question{ID: 15, PatientID: 1}}, nil)
+		repo.EXPECT().GetQuestionAttachment("15", "4").Return(model.QuestionAttachment{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodGet, "/15/4", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id/:attachmentId", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, commonH.GetDoctorQuestionAttachmentURL)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("doctorNotInCareTeam", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion("15").Return(model.SafeQuestion{Question: model.Question{ID: 15, PatientID: 1}}, nil)
+		repo.EXPECT().GetCareTeamMember(1, 3).Return(model.CareTeamMember{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound)).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/15/4", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id/:attachmentId", func(ctx *gin.Context) { ctx.Set("doctorId", 3); ctx.Set("doctorRole", model.USER) }, commonH.GetDoctorQuestionAttachmentURL)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		storage := cloudstorage.NewMockService(t)
+		commonH := common.CommonHandler{Repo: repo, CloudStorageService: storage}
+
+		repo.EXPECT().GetQuestion("15").Return(model.SafeQuestion{Question: model.Question{ID: 15, PatientID: 1}}, nil)
+		repo.EXPECT().GetQuestionAttachment("15", "4").Return(model.QuestionAttachment{ID: 4, QuestionID: 15, ObjectName: "questions/15/1.png"}, nil)
+		storage.EXPECT().SignedURL("questions/15/1.png", mock.MatchedBy(func(expires time.Time) bool {
+			ttl := time.Until(expires)
+			return ttl > 290*time.Second && ttl <= 300*time.Second
+		})).Return("https://storage.googleapis.com/signed", nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/15/4", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id/:attachmentId", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, commonH.GetPatientQuestionAttachmentURL)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"url":"https://storage.googleapis.com/signed"`)
+	})
+}
@@ -0,0 +1,101 @@
+package common_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/handlers/common"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGetDueVaccine(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	enabled := []repository.Criteria{{QueryCriteria: repository.IS_ENABLED, Value: true}}
+	t.Run("invalidWithin", func(t *testing.T) {
+		commonH := common.CommonHandler{Repo: repository.NewMockRepo(t)}
+
+		req := httptest.NewRequest(http.MethodGet, "/1?within=400", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, commonH.GetDoctorDueVaccine)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("patientNotFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodGet, "/1", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, commonH.GetDoctorDueVaccine)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("notInCareTeam", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		repo.EXPECT().GetCareTeamMember(1, 3).Return(model.CareTeamMember{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound)).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/1", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3); ctx.Set("doctorRole", model.USER) }, commonH.GetDoctorDueVaccine)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		commonH := common.CommonHandler{Repo: repo}
+
+		now := time.Now()
+		flu := 1
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1, BirthDate: int(now.AddDate(-8, 0, 0).Unix())}, nil)
+		repo.EXPECT().GetAllVaccineRule(enabled).Return([]model.VaccineRule{
+			{VaccineID: 1, Vaccine: model.Vaccine{ID: 1, Name: "Influenza"}, MinAgeMonths: 6, IntervalMonths: 12, Enabled: true},
+			{VaccineID: 3, Vaccine: model.Vaccine{ID: 3, Name: "MMR", Live: true}, MinAgeMonths: 9, Doses: 2, IntervalMonths: 9, Enabled: true},
+		}, nil)
+		repo.EXPECT().GetAllVaccination(1).Return([]model.Vaccination{
+			{VaccineID: &flu, VaccinatedAt: int(now.AddDate(0, -11, 0).Unix())},
+		}, nil)
+		start := int(now.AddDate(-1, 0, 0).Unix())
+		repo.EXPECT().GetAllMedicationRegimen(1).Return([]model.MedicationRegimen{
+			{MedicineName: "Prednisolone", Dose: 25, DoseUnit: model.DOSE_MG, Schedule: model.SCHEDULE_ONCE_DAILY, StartDate: &start},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, commonH.GetPatientDueVaccine)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		var due []model.DueVaccine
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &due))
+		assert.Len(t, due, 2)
+		// mmr was never given and can't be given on high dose steroids
+		assert.Equal(t, 3, due[0].VaccineID)
+		assert.Equal(t, model.VACCINE_OVERDUE, due[0].Status)
+		assert.True(t, due[0].Contraindicated)
+		assert.Equal(t, 1, due[1].VaccineID)
+		assert.Equal(t, model.VACCINE_UPCOMING, due[1].Status)
+	})
+}
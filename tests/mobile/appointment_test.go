@@ -13,6 +13,8 @@ import (
 	"github.com/PhasitWo/duchenne-server/handlers/mobile"
 	"github.com/PhasitWo/duchenne-server/model"
 	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/notification"
+	"github.com/PhasitWo/duchenne-server/services/schedule"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -215,9 +217,114 @@ func TestCreateAppointment(t *testing.T) {
 
 		assert.Equal(t, 422, recorder.Code)
 	})
+	t.Run("doctorNotFound", func(t *testing.T) {
+		input := model.PatientCreateAppointmentRequest{
+			Date:     slotDate(),
+			DoctorId: 10,
+		}
+		rawInput, err := json.Marshal(&input)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		mockErr := fmt.Errorf("wrap : %w", gorm.ErrRecordNotFound)
+		repo.EXPECT().GetDoctorById(10).Return(model.Doctor{}, mockErr)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawInput))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.CreateAppointment)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("doctorCannotBeAppointed", func(t *testing.T) {
+		input := model.PatientCreateAppointmentRequest{
+			Date:     slotDate(),
+			DoctorId: 10,
+		}
+		rawInput, err := json.Marshal(&input)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetDoctorById(10).Return(model.Doctor{ID: 10, CanBeAppointed: false}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawInput))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.CreateAppointment)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("notMatchFreeSlot", func(t *testing.T) {
+		input := model.PatientCreateAppointmentRequest{
+			Date:     slotDate(),
+			DoctorId: 10,
+		}
+		rawInput, err := json.Marshal(&input)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetDoctorById(10).Return(model.Doctor{ID: 10, CanBeAppointed: true}, nil)
+		repo.EXPECT().GetDoctorSchedule(10).Return(scheduleAt(input.Date), nil)
+		repo.EXPECT().GetAllScheduleException(mock.Anything, mock.Anything, mock.Anything).Return([]model.ScheduleException{}, nil)
+		// the slot is already booked
+		repo.EXPECT().GetAllAppointment(-1, 0, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+			[]model.SafeAppointment{{Appointment: model.Appointment{Date: input.Date, Duration: 30}}}, nil,
+		)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawInput))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.CreateAppointment)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("conflict", func(t *testing.T) {
+		input := model.PatientCreateAppointmentRequest{
+			Date:     slotDate(),
+			DoctorId: 10,
+		}
+		rawInput, err := json.Marshal(&input)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		mockFreeSlot(repo, 10, input.Date)
+		// another patient takes the slot, then this patient has no right to see its id
+		conflictErr := &repository.ErrAppointmentConflict{Appointment: model.Appointment{ID: 7, Date: input.Date, Duration: 30, DoctorID: 10, PatientID: 2}}
+		repo.EXPECT().CreateAppointment(mock.Anything).Return(-1, fmt.Errorf("exec : %w", conflictErr))
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawInput))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.CreateAppointment)
+		router.ServeHTTP(recorder, req)
+
+		expectRespBody, err := json.Marshal(gin.H{
+			"error":    "appointment overlaps another appointment",
+			"conflict": model.AppointmentConflict{Date: input.Date, Duration: 30, DoctorID: 10},
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 409, recorder.Code)
+		assert.Equal(t, expectRespBody, recorder.Body.Bytes())
+	})
 	t.Run("internalError", func(t *testing.T) {
 		input := model.PatientCreateAppointmentRequest{
-			Date:     int(time.Now().Add(2 * time.Hour).Unix()),
+			Date:     slotDate(),
 			DoctorId: 10,
 		}
 		rawInput, err := json.Marshal(&input)
@@ -226,6 +333,7 @@ func TestCreateAppointment(t *testing.T) {
 		repo := repository.NewMockRepo(t)
 		mobileH := mobile.MobileHandler{Repo: repo}
 
+		mockFreeSlot(repo, 10, input.Date)
 		repo.EXPECT().CreateAppointment(mock.Anything).Return(-1, errors.New("err"))
 
 		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawInput))
@@ -239,23 +347,28 @@ func TestCreateAppointment(t *testing.T) {
 	})
 	t.Run("success", func(t *testing.T) {
 		input := model.PatientCreateAppointmentRequest{
-			Date:     int(time.Now().Add(2 * time.Hour).Unix()),
+			Date:     slotDate(),
 			DoctorId: 10,
 		}
 		rawInput, err := json.Marshal(&input)
 		assert.NoError(t, err)
 		// setup mock
 		repo := repository.NewMockRepo(t)
-		mobileH := mobile.MobileHandler{Repo: repo}
+		noti := notification.NewMockService(t)
+		mobileH := mobile.MobileHandler{Repo: repo, NotiService: noti}
 
+		mockFreeSlot(repo, 10, input.Date)
 		repo.EXPECT().CreateAppointment(
 			model.Appointment{
 				Date:      input.Date,
+				Duration:  30,
 				PatientID: 1,
 				DoctorID:  input.DoctorId,
+				Status:    model.REQUESTED,
 				ApproveAt: nil,
 			},
 		).Return(22, nil)
+		noti.EXPECT().NotifyCareTeam(1, model.AppointmentLink(22)).Return(nil).Maybe()
 
 		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawInput))
 		recorder := httptest.NewRecorder()
@@ -338,13 +451,13 @@ func TestDeleteAppointment(t *testing.T) {
 		assert.Equal(t, 401, recorder.Code)
 	})
 	t.Run("internalError", func(t *testing.T) {
-		apm := model.SafeAppointment{Appointment: model.Appointment{Patient: model.Patient{ID: 1}}}
+		apm := model.SafeAppointment{Appointment: model.Appointment{ID: 10, Status: model.REQUESTED, Patient: model.Patient{ID: 1}}}
 		// setup mock
 		repo := repository.NewMockRepo(t)
 		mobileH := mobile.MobileHandler{Repo: repo}
 
 		repo.EXPECT().GetAppointment("10").Return(apm, nil)
-		repo.EXPECT().DeleteAppointment("10").Return(errors.New("err"))
+		repo.EXPECT().ChangeAppointmentStatus(10, mock.Anything).Return(errors.New("err"))
 
 		req := httptest.NewRequest(http.MethodDelete, "/10", nil)
 		recorder := httptest.NewRecorder()
@@ -355,14 +468,38 @@ func TestDeleteAppointment(t *testing.T) {
 
 		assert.Equal(t, 500, recorder.Code)
 	})
+	t.Run("invalidTransition", func(t *testing.T) {
+		apm := model.SafeAppointment{Appointment: model.Appointment{ID: 10, Status: model.COMPLETED, Patient: model.Patient{ID: 1}}}
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		mockErr := fmt.Errorf("exec : %w", repository.ErrInvalidStatusTransition)
+		repo.EXPECT().GetAppointment("10").Return(apm, nil)
+		repo.EXPECT().ChangeAppointmentStatus(10, mock.Anything).Return(mockErr)
+
+		req := httptest.NewRequest(http.MethodDelete, "/10", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.DELETE("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.DeleteAppointment)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
 	t.Run("success", func(t *testing.T) {
-		apm := model.SafeAppointment{Appointment: model.Appointment{Patient: model.Patient{ID: 1}}}
+		apm := model.SafeAppointment{Appointment: model.Appointment{ID: 10, Status: model.REQUESTED, Patient: model.Patient{ID: 1}}}
+		patientId := 1
 		// setup mock
 		repo := repository.NewMockRepo(t)
 		mobileH := mobile.MobileHandler{Repo: repo}
 
 		repo.EXPECT().GetAppointment("10").Return(apm, nil)
-		repo.EXPECT().DeleteAppointment("10").Return(nil)
+		repo.EXPECT().ChangeAppointmentStatus(10, model.AppointmentStatusChange{
+			Status:    model.CANCELLED_BY_PATIENT,
+			ActorType: model.ACTOR_PATIENT,
+			ActorID:   &patientId,
+		}).Return(nil)
 
 		req := httptest.NewRequest(http.MethodDelete, "/10", nil)
 		recorder := httptest.NewRecorder()
@@ -374,3 +511,186 @@ func TestDeleteAppointment(t *testing.T) {
 		assert.Equal(t, 204, recorder.Code)
 	})
 }
+
+// appointment date in the future that is aligned to a minute
+func slotDate() int {
+	return int(time.Now().Add(2 * time.Hour).Truncate(time.Minute).Unix())
+}
+
+// weekly schedule that has a 30-minute slot starting at date
+func scheduleAt(date int) []model.DoctorSchedule {
+	t := time.Unix(int64(date), 0).In(schedule.Location())
+	start := t.Hour()*60 + t.Minute()
+	return []model.DoctorSchedule{{DoctorID: 10, Weekday: int(t.Weekday()), StartMinute: start, EndMinute: start + 30, SlotMinutes: 30}}
+}
+
+func mockFreeSlot(repo *repository.MockRepo, doctorId int, date int) {
+	repo.EXPECT().GetDoctorById(doctorId).Return(model.Doctor{ID: doctorId, CanBeAppointed: true}, nil)
+	repo.EXPECT().GetDoctorSchedule(doctorId).Return(scheduleAt(date), nil)
+	repo.EXPECT().GetAllScheduleException(mock.Anything, mock.Anything, mock.Anything).Return([]model.ScheduleException{}, nil)
+	repo.EXPECT().GetAllAppointment(-1, 0, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]model.SafeAppointment{}, nil)
+}
+
+func TestRequestReschedule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("bindingError", func(t *testing.T) {
+		mobileH := mobile.MobileHandler{}
+
+		req := httptest.NewRequest(http.MethodPost, "/10/reschedule", bytes.NewReader([]byte(`{}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id/reschedule", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.RequestReschedule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		input := model.PatientRescheduleRequest{Date: slotDate()}
+		rawInput, err := json.Marshal(&input)
+		assert.NoError(t, err)
+		apm := model.SafeAppointment{Appointment: model.Appointment{ID: 10, Status: model.APPROVED, Patient: model.Patient{ID: 22}}}
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetAppointment(10).Return(apm, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/10/reschedule", bytes.NewReader(rawInput))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id/reschedule", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.RequestReschedule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 401, recorder.Code)
+	})
+	t.Run("inactiveAppointment", func(t *testing.T) {
+		input := model.PatientRescheduleRequest{Date: slotDate()}
+		rawInput, err := json.Marshal(&input)
+		assert.NoError(t, err)
+		apm := model.SafeAppointment{Appointment: model.Appointment{ID: 10, Status: model.CANCELLED_BY_STAFF, Patient: model.Patient{ID: 1}}}
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetAppointment(10).Return(apm, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/10/reschedule", bytes.NewReader(rawInput))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id/reschedule", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.RequestReschedule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("pendingRequestExists", func(t *testing.T) {
+		input := model.PatientRescheduleRequest{Date: slotDate()}
+		rawInput, err := json.Marshal(&input)
+		assert.NoError(t, err)
+		apm := model.SafeAppointment{Appointment: model.Appointment{ID: 10, DoctorID: 10, Date: input.Date - 3600, Status: model.APPROVED, Patient: model.Patient{ID: 1}}}
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetAppointment(10).Return(apm, nil)
+		mockFreeSlot(repo, 10, input.Date)
+		repo.EXPECT().CreateRescheduleRequest(mock.Anything).Return(-1, fmt.Errorf("exec : %w", repository.ErrDuplicateEntry))
+
+		req := httptest.NewRequest(http.MethodPost, "/10/reschedule", bytes.NewReader(rawInput))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id/reschedule", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.RequestReschedule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		reason := "busy"
+		input := model.PatientRescheduleRequest{Date: slotDate(), Reason: &reason}
+		rawInput, err := json.Marshal(&input)
+		assert.NoError(t, err)
+		apm := model.SafeAppointment{Appointment: model.Appointment{ID: 10, DoctorID: 10, Date: input.Date - 3600, Status: model.APPROVED, Patient: model.Patient{ID: 1}}}
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetAppointment(10).Return(apm, nil)
+		mockFreeSlot(repo, 10, input.Date)
+		repo.EXPECT().CreateRescheduleRequest(model.RescheduleRequest{AppointmentID: 10, ProposedDate: input.Date, Reason: &reason}).Return(3, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/10/reschedule", bytes.NewReader(rawInput))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id/reschedule", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.RequestReschedule)
+		router.ServeHTTP(recorder, req)
+
+		expectRespBody, err := json.Marshal(gin.H{"id": 3})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 201, recorder.Code)
+		assert.Equal(t, expectRespBody, recorder.Body.Bytes())
+	})
+}
+
+func TestGetAppointmentICS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("unauthorized", func(t *testing.T) {
+		apm := model.SafeAppointment{Appointment: model.Appointment{ID: 10, Patient: model.Patient{ID: 22}}}
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetAppointment("10").Return(apm, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/10", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetAppointmentICS)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 401, recorder.Code)
+	})
+	t.Run("approved", func(t *testing.T) {
+		apm := model.SafeAppointment{Appointment: model.Appointment{ID: 10, Date: 2000000000, Duration: 30, Status: model.APPROVED, Patient: model.Patient{ID: 1}}}
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetAppointment("10").Return(apm, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/10", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetAppointmentICS)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "METHOD:PUBLISH\r\n")
+		assert.Contains(t, recorder.Body.String(), "UID:appointment-10@duchenne-server\r\n")
+	})
+	t.Run("cancelled", func(t *testing.T) {
+		apm := model.SafeAppointment{Appointment: model.Appointment{ID: 10, Date: 2000000000, Duration: 30, Status: model.CANCELLED_BY_PATIENT, Patient: model.Patient{ID: 1}}}
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetAppointment("10").Return(apm, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/10", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetAppointmentICS)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "METHOD:CANCEL\r\n")
+		assert.Contains(t, recorder.Body.String(), "STATUS:CANCELLED\r\n")
+	})
+}
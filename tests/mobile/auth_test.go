@@ -9,6 +9,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/PhasitWo/duchenne-server/auth"
+	"github.com/PhasitWo/duchenne-server/config"
 	"github.com/PhasitWo/duchenne-server/handlers/mobile"
 	"github.com/PhasitWo/duchenne-server/model"
 	"github.com/PhasitWo/duchenne-server/repository"
@@ -357,3 +359,138 @@ func TestLogin(t *testing.T) {
 		assert.Equal(t, 500, recorder.Code)
 	})
 }
+
+func TestRefreshVerification(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hashed, err := auth.HashPassword("password1")
+	assert.NoError(t, err)
+	reason := "HN not found"
+	testCases := []struct {
+		name     string
+		patient  model.Patient
+		expected int
+	}{
+		{name: "pending", patient: model.Patient{ID: 1, Password: hashed, VerificationStatus: model.SIGNUP_PENDING}, expected: 403},
+		{name: "rejected", patient: model.Patient{ID: 1, Password: hashed, VerificationStatus: model.SIGNUP_REJECTED, RejectReason: &reason}, expected: 403},
+		{name: "unverified", patient: model.Patient{ID: 1, Password: hashed, VerificationStatus: model.SIGNUP_APPROVED}, expected: 403},
+		{name: "approved", patient: model.Patient{ID: 1, Password: hashed, VerificationStatus: model.SIGNUP_APPROVED, Verified: true}, expected: 200},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := repository.NewMockRepo(t)
+			mobileH := mobile.MobileHandler{Repo: repo}
+
+			repo.EXPECT().GetPatientByNID("1234567890123").Return(tc.patient, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"nid":"1234567890123","password":"password1"}`)))
+			recorder := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(recorder)
+
+			router.POST("/", mobileH.Refresh)
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected, recorder.Code)
+		})
+	}
+}
+
+func TestSignup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	signup := func(code string) []byte {
+		input := gin.H{
+			"nid":       "1234567890123",
+			"password":  "password1",
+			"hn":        "HN001",
+			"firstName": "Somchai",
+			"lastName":  "Jaidee",
+			"phone":     "0812345678",
+			"pin":       "123456",
+		}
+		if code != "" {
+			input["invitationCode"] = code
+		}
+		rawInput, err := json.Marshal(&input)
+		assert.NoError(t, err)
+		return rawInput
+	}
+	pending := mock.MatchedBy(func(p model.Patient) bool {
+		return !p.Verified && p.VerificationStatus == model.SIGNUP_PENDING
+	})
+	t.Run("invitationRequired", func(t *testing.T) {
+		config.AppConfig.REQUIRE_INVITATION = true
+		defer func() { config.AppConfig.REQUIRE_INVITATION = false }()
+		mobileH := mobile.MobileHandler{}
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(signup("")))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", mobileH.Signup)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("codeOfAnotherHn", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		hn := "HN999"
+		repo.EXPECT().GetInvitationCode("ABCD2345").Return(model.InvitationCode{ID: 4, Hn: &hn}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(signup("ABCD2345")))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", mobileH.Signup)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("codeTakenMeanwhile", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetInvitationCode("ABCD2345").Return(model.InvitationCode{ID: 4}, nil)
+		repo.EXPECT().CreatePatientWithInvitation(pending, 4).Return(-1, fmt.Errorf("exec : %w", repository.ErrInvitationUsed))
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(signup("ABCD2345")))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", mobileH.Signup)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("pendingWithCode", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetInvitationCode("ABCD2345").Return(model.InvitationCode{ID: 4}, nil)
+		repo.EXPECT().CreatePatientWithInvitation(pending, 4).Return(7, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(signup("ABCD2345")))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", mobileH.Signup)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+	})
+	t.Run("pendingWithoutCode", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().CreatePatient(pending).Return(7, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(signup("")))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", mobileH.Signup)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+	})
+}
@@ -0,0 +1,274 @@
+package mobile_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PhasitWo/duchenne-server/auth"
+	"github.com/PhasitWo/duchenne-server/handlers/mobile"
+	"github.com/PhasitWo/duchenne-server/middleware"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCaregiverRefresh(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hashed, err := auth.HashPassword("secret")
+	assert.NoError(t, err)
+	testCases := []struct {
+		name     string
+		password string
+		mockErr  error
+		expected int
+	}{
+		{name: "notFound", password: "secret", mockErr: fmt.Errorf("wrap : %w", gorm.ErrRecordNotFound), expected: 404},
+		{name: "invalidCredential", password: "wrong", expected: 401},
+		{name: "success", password: "secret", expected: 200},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rawInput, err := json.Marshal(gin.H{"nid": "1234567890123", "password": tc.password})
+			assert.NoError(t, err)
+			// setup mock
+			repo := repository.NewMockRepo(t)
+			mobileH := mobile.MobileHandler{Repo: repo}
+
+			repo.EXPECT().GetCaregiverByNID("1234567890123").Return(model.Caregiver{ID: 3, Password: hashed}, tc.mockErr)
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawInput))
+			recorder := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(recorder)
+
+			router.POST("/", mobileH.CaregiverRefresh)
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected, recorder.Code)
+		})
+	}
+}
+
+func TestSwitchPatient(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("notCaregiver", func(t *testing.T) {
+		mobileH := mobile.MobileHandler{}
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"patientId":2}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("patientId", 1); ctx.Set("deviceId", 5) }, mobileH.SwitchPatient)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 403, recorder.Code)
+	})
+	t.Run("notLinked", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetCaregiverLink(3, 2).Return(model.CaregiverLink{}, fmt.Errorf("wrap : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"patientId":2}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("caregiverId", 3); ctx.Set("deviceId", 5) }, mobileH.SwitchPatient)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		link := model.CaregiverLink{ID: 7, CaregiverID: 3, PatientID: 2, Permissions: []model.CaregiverPermission{model.CAREGIVER_QUESTION}}
+		repo.EXPECT().GetCaregiverLink(3, 2).Return(link, nil)
+		repo.EXPECT().UpdateDevice(model.Device{ID: 5, PatientId: 2}).Return(nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"patientId":2}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("caregiverId", 3); ctx.Set("deviceId", 5) }, mobileH.SwitchPatient)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		var body map[string]any
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.EqualValues(t, 2, body["patientId"])
+		assert.NotEmpty(t, body["accessToken"])
+	})
+}
+
+func TestLinkCaregiver(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	input := `{"nid":"1234567890123","relationship":"parent","permissions":["question"]}`
+	t.Run("caregiverNotFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetCaregiverByNID("1234567890123").Return(model.Caregiver{}, fmt.Errorf("wrap : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(input)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.LinkCaregiver)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetCaregiverByNID("1234567890123").Return(model.Caregiver{ID: 3}, nil)
+		repo.EXPECT().CreateCaregiverLink(mock.Anything).Return(-1, fmt.Errorf("exec : %w", repository.ErrDuplicateEntry))
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(input)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.LinkCaregiver)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetCaregiverByNID("1234567890123").Return(model.Caregiver{ID: 3}, nil)
+		repo.EXPECT().CreateCaregiverLink(model.CaregiverLink{
+			CaregiverID:  3,
+			PatientID:    1,
+			Relationship: model.RELATIONSHIP_PARENT,
+			Permissions:  []model.CaregiverPermission{model.CAREGIVER_QUESTION},
+		}).Return(7, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(input)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.LinkCaregiver)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+	})
+}
+
+func TestMobilePermissionMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name     string
+		claims   *auth.PatientAccessClaims
+		expected int
+	}{
+		{name: "patient", claims: &auth.PatientAccessClaims{PatientId: 1}, expected: 200},
+		{name: "caregiverWithPermission", claims: &auth.PatientAccessClaims{PatientId: 1, CaregiverId: 3, Permissions: []model.CaregiverPermission{model.CAREGIVER_QUESTION}}, expected: 200},
+		{name: "caregiverWithoutPermission", claims: &auth.PatientAccessClaims{PatientId: 1, CaregiverId: 3, Permissions: []model.CaregiverPermission{model.CAREGIVER_APPOINTMENT}}, expected: 403},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			recorder := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(recorder)
+
+			router.POST("/",
+				func(ctx *gin.Context) { ctx.Set("claims", tc.claims) },
+				middleware.MobilePermissionMiddleware(model.CAREGIVER_QUESTION),
+				func(ctx *gin.Context) { ctx.Status(http.StatusOK) },
+			)
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected, recorder.Code)
+		})
+	}
+}
+
+func TestMobilePatientOnlyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("caregiver", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/",
+			func(ctx *gin.Context) { ctx.Set("caregiverId", 3) },
+			middleware.MobilePatientOnlyMiddleware,
+			func(ctx *gin.Context) { ctx.Status(http.StatusOK) },
+		)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 403, recorder.Code)
+	})
+	t.Run("patient", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/",
+			func(ctx *gin.Context) { ctx.Set("patientId", 1) },
+			middleware.MobilePatientOnlyMiddleware,
+			func(ctx *gin.Context) { ctx.Status(http.StatusOK) },
+		)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestCaregiverLinkMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	caregiverClaims := func() *auth.PatientAccessClaims {
+		return &auth.PatientAccessClaims{PatientId: 2, DeviceId: 5, CaregiverId: 3, Permissions: []model.CaregiverPermission{model.CAREGIVER_QUESTION}}
+	}
+	deviceCriteria := []repository.Criteria{
+		{QueryCriteria: repository.ID, Value: 5},
+		{QueryCriteria: repository.CAREGIVERID, Value: 3},
+		{QueryCriteria: repository.PATIENTID, Value: 2},
+	}
+	serve := func(repo repository.IRepo, claims *auth.PatientAccessClaims) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/",
+			func(ctx *gin.Context) { ctx.Set("claims", claims) },
+			middleware.CaregiverLinkMiddleware(repo),
+			func(ctx *gin.Context) { ctx.Status(http.StatusOK) },
+		)
+		router.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+	t.Run("patient", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		assert.Equal(t, 200, serve(repo, &auth.PatientAccessClaims{PatientId: 1, DeviceId: 5}))
+	})
+	t.Run("unlinked", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		repo.EXPECT().GetCaregiverLink(3, 2).Return(model.CaregiverLink{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+		assert.Equal(t, 401, serve(repo, caregiverClaims()))
+	})
+	t.Run("deviceRemoved", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		repo.EXPECT().GetCaregiverLink(3, 2).Return(model.CaregiverLink{ID: 7}, nil)
+		repo.EXPECT().GetAllDevice(deviceCriteria).Return([]model.Device{}, nil)
+		assert.Equal(t, 401, serve(repo, caregiverClaims()))
+	})
+	t.Run("permissionsFromLink", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		repo.EXPECT().GetCaregiverLink(3, 2).Return(model.CaregiverLink{ID: 7, Permissions: []model.CaregiverPermission{}}, nil)
+		repo.EXPECT().GetAllDevice(deviceCriteria).Return([]model.Device{{ID: 5}}, nil)
+		claims := caregiverClaims()
+		assert.Equal(t, 200, serve(repo, claims))
+		assert.False(t, claims.Can(model.CAREGIVER_QUESTION))
+	})
+}
@@ -15,6 +15,10 @@ import (
 
 func TestGetAllDevice(t *testing.T) {
 	gin.SetMode(gin.TestMode)
+	patientDevices := []repository.Criteria{
+		{QueryCriteria: repository.PATIENTID, Value: 1},
+		{QueryCriteria: repository.CAREGIVERID_ISNULL},
+	}
 	t.Run("noPatientIdFromAuthMiddleware", func(t *testing.T) {
 		// setup mock
 		mobileH := mobile.MobileHandler{}
@@ -33,7 +37,7 @@ func TestGetAllDevice(t *testing.T) {
 		repo := repository.NewMockRepo(t)
 		mobileH := mobile.MobileHandler{Repo: repo}
 
-		repo.EXPECT().GetAllDevice(repository.Criteria{QueryCriteria: repository.PATIENTID, Value: 1}).Return([]model.Device{}, errors.New("err"))
+		repo.EXPECT().GetAllDevice(patientDevices).Return([]model.Device{}, errors.New("err"))
 
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		recorder := httptest.NewRecorder()
@@ -49,7 +53,7 @@ func TestGetAllDevice(t *testing.T) {
 		repo := repository.NewMockRepo(t)
 		mobileH := mobile.MobileHandler{Repo: repo}
 
-		repo.EXPECT().GetAllDevice(repository.Criteria{QueryCriteria: repository.PATIENTID, Value: 1}).Return([]model.Device{}, nil)
+		repo.EXPECT().GetAllDevice(patientDevices).Return([]model.Device{}, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		recorder := httptest.NewRecorder()
@@ -58,6 +62,54 @@ func TestGetAllDevice(t *testing.T) {
 		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetAllDevice)
 		router.ServeHTTP(recorder, req)
 
+		assert.Equal(t, 200, recorder.Code)
+	})
+	t.Run("mixedDevices", func(t *testing.T) {
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		caregiverId := 7
+		stored := []model.Device{
+			{ID: 1, PatientId: 1, DeviceName: "patient phone"},
+			{ID: 2, PatientId: 1, DeviceName: "caregiver phone", CaregiverID: &caregiverId},
+		}
+		// filter like the database would
+		repo.EXPECT().GetAllDevice(patientDevices).RunAndReturn(func(criteria ...repository.Criteria) ([]model.Device, error) {
+			res := []model.Device{}
+			for _, d := range stored {
+				if d.CaregiverID == nil {
+					res = append(res, d)
+				}
+			}
+			return res, nil
+		}).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetAllDevice)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "patient phone")
+		assert.NotContains(t, recorder.Body.String(), "caregiver phone")
+	})
+	t.Run("caregiverDevices", func(t *testing.T) {
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetAllDevice([]repository.Criteria{{QueryCriteria: repository.CAREGIVERID, Value: 7}}).Return([]model.Device{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1); ctx.Set("caregiverId", 7) }, mobileH.GetAllDevice)
+		router.ServeHTTP(recorder, req)
+
 		assert.Equal(t, 200, recorder.Code)
 	})
 }
@@ -0,0 +1,60 @@
+package mobile_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PhasitWo/duchenne-server/handlers/mobile"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMeasurementTrend(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("invalidType", func(t *testing.T) {
+		mobileH := mobile.MobileHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/?type=glucose", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetMeasurementTrend)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetAllMeasurement(1).Return([]model.Measurement{
+			{ID: 1, Type: model.MEASURE_NSAA, Value: 24, MeasuredAt: 100},
+			{ID: 2, Type: model.MEASURE_WEIGHT, Value: 30, MeasuredAt: 100},
+			{ID: 3, Type: model.MEASURE_NSAA, Value: 21, MeasuredAt: 200},
+		}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetMeasurementTrend)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		var res []model.MeasurementTrend
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+		// weight comes before nsaa
+		assert.Len(t, res, 2)
+		assert.Equal(t, model.MEASURE_WEIGHT, res[0].Type)
+		assert.Nil(t, res[0].Change)
+		assert.Equal(t, model.MEASURE_NSAA, res[1].Type)
+		assert.Equal(t, "score", res[1].Unit)
+		assert.Len(t, res[1].Points, 2)
+		assert.Equal(t, 3, res[1].Latest.ID)
+		assert.Equal(t, -3.0, *res[1].Change)
+	})
+}
@@ -0,0 +1,224 @@
+package mobile_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/config"
+	"github.com/PhasitWo/duchenne-server/handlers/mobile"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestGetActiveMedication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("internalError", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetAllMedicationRegimen(1, []repository.Criteria{{QueryCriteria: repository.ENDDATE_ISNULL}}).Return(nil, errors.New("err"))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetActiveMedication)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 500, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetAllMedicationRegimen(1, []repository.Criteria{{QueryCriteria: repository.ENDDATE_ISNULL}}).Return([]model.MedicationRegimen{{ID: 4}}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetActiveMedication)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestGetMedicationDoses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.AppConfig.CLINIC_TIMEZONE = "Asia/Bangkok"
+	loc, _ := time.LoadLocation("Asia/Bangkok")
+	from := int(time.Date(2025, 3, 3, 0, 0, 0, 0, loc).Unix())
+	to := from + 24*60*60
+	t.Run("invalidRange", func(t *testing.T) {
+		mobileH := mobile.MobileHandler{Repo: repository.NewMockRepo(t)}
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/?from=%d&to=%d", from, from+32*24*60*60), nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetMedicationDoses)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		taken := model.DOSE_TAKEN
+		repo.EXPECT().GetAllMedicationRegimen(1).Return([]model.MedicationRegimen{
+			{ID: 4, MedicineName: "Deflazacort", Schedule: model.SCHEDULE_TWICE_DAILY},
+		}, nil).Once()
+		repo.EXPECT().GetAllMedicationDose(1, from, to).Return([]model.MedicationDose{
+			{RegimenID: 4, ScheduledAt: from + 8*60*60, Status: &taken},
+		}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/?from=%d&to=%d", from, to), nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetMedicationDoses)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.JSONEq(t, fmt.Sprintf(`[
+			{"regimenId":4,"medicineName":"Deflazacort","dose":0,"doseUnit":"","scheduledAt":%d,"status":"taken"},
+			{"regimenId":4,"medicineName":"Deflazacort","dose":0,"doseUnit":"","scheduledAt":%d,"status":null}
+		]`, from+8*60*60, from+20*60*60), recorder.Body.String())
+	})
+}
+
+func TestLogMedicationDose(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.AppConfig.CLINIC_TIMEZONE = "Asia/Bangkok"
+	loc, _ := time.LoadLocation("Asia/Bangkok")
+	dose := int(time.Date(2025, 3, 3, 8, 0, 0, 0, loc).Unix())
+	regimen := model.MedicationRegimen{ID: 4, PatientID: 1, Schedule: model.SCHEDULE_ONCE_DAILY}
+	t.Run("notOwner", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetMedicationRegimen(1, 4).Return(model.MedicationRegimen{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+
+		reqBody := []byte(fmt.Sprintf(`{"scheduledAt":%d,"status":"taken"}`, dose))
+		req := httptest.NewRequest(http.MethodPost, "/4", bytes.NewBuffer(reqBody))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.LogMedicationDose)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("notDoseTime", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetMedicationRegimen(1, 4).Return(regimen, nil)
+
+		reqBody := []byte(fmt.Sprintf(`{"scheduledAt":%d,"status":"taken"}`, dose+60*60))
+		req := httptest.NewRequest(http.MethodPost, "/4", bytes.NewBuffer(reqBody))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.LogMedicationDose)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("futureDose", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetMedicationRegimen(1, 4).Return(regimen, nil)
+
+		reqBody := []byte(fmt.Sprintf(`{"scheduledAt":%d,"status":"taken"}`, time.Now().AddDate(0, 0, 2).Unix()))
+		req := httptest.NewRequest(http.MethodPost, "/4", bytes.NewBuffer(reqBody))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.LogMedicationDose)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetMedicationRegimen(1, 4).Return(regimen, nil)
+		repo.EXPECT().LogMedicationDose(mock.MatchedBy(func(d model.MedicationDose) bool {
+			return d.RegimenID == 4 && d.PatientID == 1 && d.ScheduledAt == dose && *d.Status == model.DOSE_SKIPPED && d.LogAt != nil
+		})).Return(nil).Once()
+
+		reqBody := []byte(fmt.Sprintf(`{"scheduledAt":%d,"status":"skipped"}`, dose))
+		req := httptest.NewRequest(http.MethodPost, "/4", bytes.NewBuffer(reqBody))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.LogMedicationDose)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestUpdateMedicationReminder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("invalidTime", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetMedicationRegimen(1, 4).Return(model.MedicationRegimen{ID: 4, Schedule: model.SCHEDULE_ONCE_DAILY}, nil)
+
+		req := httptest.NewRequest(http.MethodPut, "/4", bytes.NewBufferString(`{"disabled":false,"times":["25:00"]}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.UpdateMedicationReminder)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("asNeeded", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetMedicationRegimen(1, 4).Return(model.MedicationRegimen{ID: 4, Schedule: model.SCHEDULE_AS_NEEDED}, nil)
+
+		req := httptest.NewRequest(http.MethodPut, "/4", bytes.NewBufferString(`{"disabled":false,"times":["09:00"]}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.UpdateMedicationReminder)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetMedicationRegimen(1, 4).Return(model.MedicationRegimen{ID: 4, Schedule: model.SCHEDULE_TWICE_DAILY}, nil)
+		repo.EXPECT().UpdateMedicationReminder(4, false, []string{"07:30", "19:30"}).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/4", bytes.NewBufferString(`{"disabled":false,"times":["07:30","19:30"]}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.UpdateMedicationReminder)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
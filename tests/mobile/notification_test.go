@@ -0,0 +1,275 @@
+package mobile_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PhasitWo/duchenne-server/handlers/mobile"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestGetAllNotification(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("noPatientIdFromAuthMiddleware", func(t *testing.T) {
+		mobileH := mobile.MobileHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", mobileH.GetAllNotification)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 500, recorder.Code)
+	})
+	t.Run("badPaging", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		req := httptest.NewRequest(http.MethodGet, "/?limit=abc", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetAllNotification)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("internalError", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetAllNotification(100, 0, []repository.Criteria{{QueryCriteria: repository.PATIENTID, Value: 1}}).Return(nil, errors.New("err"))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetAllNotification)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 500, recorder.Code)
+	})
+	t.Run("unread", func(t *testing.T) {
+		appointmentId := 3
+		notifications := []model.Notification{{ID: 2, PatientID: 1, Title: "title", Body: "body", Type: model.NOTIFICATION_APPOINTMENT, RefID: &appointmentId}}
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetAllNotification(10, 20, []repository.Criteria{
+			{QueryCriteria: repository.PATIENTID, Value: 1},
+			{QueryCriteria: repository.READAT_ISNULL},
+		}).Return(notifications, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/?limit=10&offset=20&unread=true", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetAllNotification)
+		router.ServeHTTP(recorder, req)
+
+		expectRespBody, err := json.Marshal(notifications)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, expectRespBody, recorder.Body.Bytes())
+	})
+}
+
+func TestGetUnreadNotificationCount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("internalError", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().CountNotification([]repository.Criteria{
+			{QueryCriteria: repository.PATIENTID, Value: 1},
+			{QueryCriteria: repository.READAT_ISNULL},
+		}).Return(0, errors.New("err"))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetUnreadNotificationCount)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 500, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().CountNotification([]repository.Criteria{
+			{QueryCriteria: repository.PATIENTID, Value: 1},
+			{QueryCriteria: repository.READAT_ISNULL},
+		}).Return(4, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetUnreadNotificationCount)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.JSONEq(t, `{"count":4}`, recorder.Body.String())
+	})
+}
+
+func TestReadNotification(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("notFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().ReadNotification(1, "2").Return(fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodPut, "/2", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.ReadNotification)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().ReadNotification(1, "2").Return(nil)
+
+		req := httptest.NewRequest(http.MethodPut, "/2", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.ReadNotification)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 204, recorder.Code)
+	})
+}
+
+func TestReadAllNotification(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("internalError", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().ReadAllNotification(1).Return(0, errors.New("err"))
+
+		req := httptest.NewRequest(http.MethodPut, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.ReadAllNotification)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 500, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().ReadAllNotification(1).Return(3, nil)
+
+		req := httptest.NewRequest(http.MethodPut, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.ReadAllNotification)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 204, recorder.Code)
+	})
+}
+
+func TestGetNotificationPreference(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("default", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetNotificationPreference(1).Return(model.NotificationPreference{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetNotificationPreference)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.JSONEq(t, `{"mutedCategories":[],"quietStartMinute":null,"quietEndMinute":null,"timezone":"","updateAt":0}`, recorder.Body.String())
+	})
+	t.Run("internalError", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetNotificationPreference(1).Return(model.NotificationPreference{}, errors.New("err"))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.GetNotificationPreference)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 500, recorder.Code)
+	})
+}
+
+func TestUpdateNotificationPreference(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	badInputs := []string{
+		`{"mutedCategories":["unknown"]}`,
+		`{"quietStartMinute":1320}`,
+		`{"quietStartMinute":1320,"quietEndMinute":1440}`,
+		`{"timezone":"Mars/Olympus"}`,
+	}
+	for _, input := range badInputs {
+		t.Run("badInput", func(t *testing.T) {
+			repo := repository.NewMockRepo(t)
+			mobileH := mobile.MobileHandler{Repo: repo}
+
+			req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(input))
+			recorder := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(recorder)
+
+			router.PUT("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.UpdateNotificationPreference)
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, 400, recorder.Code, input)
+		})
+	}
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().UpsertNotificationPreference(mock.MatchedBy(func(p model.NotificationPreference) bool {
+			return p.PatientID == 1 && p.IsMuted(model.CATEGORY_CONTENT) && !p.IsMuted(model.CATEGORY_APPOINTMENT) &&
+				*p.QuietStartMinute == 1320 && *p.QuietEndMinute == 420 && p.Timezone == "Asia/Bangkok"
+		})).Return(nil).Once()
+
+		input := `{"mutedCategories":["content"],"quietStartMinute":1320,"quietEndMinute":420,"timezone":"Asia/Bangkok"}`
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.UpdateNotificationPreference)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 204, recorder.Code)
+	})
+}
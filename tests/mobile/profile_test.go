@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/PhasitWo/duchenne-server/handlers/mobile"
@@ -81,3 +82,35 @@ func TestGetProfile(t *testing.T) {
 		assert.Equal(t, 200, recorder.Code)
 	})
 }
+
+func TestUpdateLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("unsupportedLanguage", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"language":"jp"}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.UpdateLanguage)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().UpdatePatientLanguage(1, model.LANGUAGE_EN).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"language":"en"}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.UpdateLanguage)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
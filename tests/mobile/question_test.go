@@ -13,6 +13,7 @@ import (
 	"github.com/PhasitWo/duchenne-server/handlers/mobile"
 	"github.com/PhasitWo/duchenne-server/model"
 	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/notification"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -273,9 +274,11 @@ func TestCreateQuestion(t *testing.T) {
 		assert.NoError(t, err)
 		// setup mock
 		repo := repository.NewMockRepo(t)
-		mobileH := mobile.MobileHandler{Repo: repo}
+		noti := notification.NewMockService(t)
+		mobileH := mobile.MobileHandler{Repo: repo, NotiService: noti}
 
 		repo.EXPECT().CreateQuestion(1, input.Topic, input.Question, mock.Anything).Return(55, nil)
+		noti.EXPECT().NotifyCareTeam(1, model.QuestionLink(55)).Return(nil).Maybe()
 
 		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawInput))
 		recorder := httptest.NewRecorder()
@@ -392,3 +395,114 @@ func TestDeleteQuestion(t *testing.T) {
 		assert.Equal(t, 204, recorder.Code)
 	})
 }
+
+func TestCreateQuestionMessage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("notOwner", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion("15").Return(model.SafeQuestion{Question: model.Question{ID: 15, PatientID: 2}}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/15", strings.NewReader(`{"message":"more detail"}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.CreateQuestionMessage)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 401, recorder.Code)
+	})
+	t.Run("closed", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion("15").Return(model.SafeQuestion{Question: model.Question{ID: 15, PatientID: 1}}, nil)
+		repo.EXPECT().CreateQuestionMessage(mock.Anything).Return(-1, fmt.Errorf("exec : %w", repository.ErrQuestionClosed))
+
+		req := httptest.NewRequest(http.MethodPost, "/15", strings.NewReader(`{"message":"more detail"}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.CreateQuestionMessage)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion("15").Return(model.SafeQuestion{Question: model.Question{ID: 15, PatientID: 1}}, nil)
+		repo.EXPECT().CreateQuestionMessage(model.QuestionMessage{
+			QuestionID: 15,
+			AuthorType: model.ACTOR_PATIENT,
+			AuthorID:   1,
+			Message:    "more detail",
+		}).Return(7, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/15", strings.NewReader(`{"message":"more detail"}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.CreateQuestionMessage)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+		assert.JSONEq(t, `{"id":7}`, recorder.Body.String())
+	})
+}
+
+func TestReadQuestion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := repository.NewMockRepo(t)
+	mobileH := mobile.MobileHandler{Repo: repo}
+
+	repo.EXPECT().GetQuestion("15").Return(model.SafeQuestion{Question: model.Question{ID: 15, PatientID: 1}}, nil)
+	repo.EXPECT().ReadQuestion(15, model.ACTOR_PATIENT).Return(nil).Once()
+
+	req := httptest.NewRequest(http.MethodPut, "/15", nil)
+	recorder := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(recorder)
+
+	router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.ReadQuestion)
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, 200, recorder.Code)
+}
+
+func TestCloseQuestion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("close", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion("15").Return(model.SafeQuestion{Question: model.Question{ID: 15, PatientID: 1}}, nil)
+		repo.EXPECT().SetQuestionClosed(15, true).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/15", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.CloseQuestion)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+	t.Run("reopen", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion("15").Return(model.SafeQuestion{Question: model.Question{ID: 15, PatientID: 1}}, nil)
+		repo.EXPECT().SetQuestionClosed(15, false).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/15", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("patientId", 1) }, mobileH.ReopenQuestion)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
@@ -0,0 +1,119 @@
+package mobile_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/handlers/mobile"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestGetDoctorSlots(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("invalidRange", func(t *testing.T) {
+		mobileH := mobile.MobileHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/1/slots?from=200&to=100", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id/slots", mobileH.GetDoctorSlots)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("notFound", func(t *testing.T) {
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		mockErr := fmt.Errorf("wrap : %w", gorm.ErrRecordNotFound)
+		repo.EXPECT().GetDoctorById(1).Return(model.Doctor{}, mockErr)
+
+		req := httptest.NewRequest(http.MethodGet, "/1/slots", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id/slots", mobileH.GetDoctorSlots)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("doctorCannotBeAppointed", func(t *testing.T) {
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetDoctorById(1).Return(model.Doctor{ID: 1, CanBeAppointed: false}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/1/slots", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id/slots", mobileH.GetDoctorSlots)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		date := slotDate()
+		from := date - 60
+		to := date + 24*60*60
+		exceptionAt := date + 7*24*60*60 // outside the range
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetDoctorById(10).Return(model.Doctor{ID: 10, CanBeAppointed: true}, nil)
+		repo.EXPECT().GetDoctorSchedule(10).Return(scheduleAt(date), nil)
+		repo.EXPECT().GetAllScheduleException(mock.Anything, mock.Anything, mock.Anything).Return(
+			[]model.ScheduleException{{StartAt: exceptionAt, EndAt: exceptionAt + 60}}, nil,
+		)
+		repo.EXPECT().GetAllAppointment(-1, 0, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]model.SafeAppointment{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/10/slots?from=%d&to=%d", from, to), nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id/slots", mobileH.GetDoctorSlots)
+		router.ServeHTTP(recorder, req)
+
+		expectRespBody, err := json.Marshal([]model.Slot{{Start: date, End: date + 30*60}})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, expectRespBody, recorder.Body.Bytes())
+	})
+	t.Run("holiday", func(t *testing.T) {
+		date := slotDate()
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		mobileH := mobile.MobileHandler{Repo: repo}
+
+		repo.EXPECT().GetDoctorById(10).Return(model.Doctor{ID: 10, CanBeAppointed: true}, nil)
+		repo.EXPECT().GetDoctorSchedule(10).Return(scheduleAt(date), nil)
+		repo.EXPECT().GetAllScheduleException(mock.Anything, mock.Anything, mock.Anything).Return(
+			[]model.ScheduleException{{StartAt: date - 60, EndAt: date + 60}}, nil,
+		)
+		repo.EXPECT().GetAllAppointment(-1, 0, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]model.SafeAppointment{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/10/slots?to=%d", int(time.Now().Add(24*time.Hour).Unix())), nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id/slots", mobileH.GetDoctorSlots)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "[]", recorder.Body.String())
+	})
+}
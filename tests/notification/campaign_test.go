@@ -0,0 +1,83 @@
+package notification_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/notification"
+	expo "github.com/PhasitWo/duchenne-server/services/notification/expo/exponent-server-sdk-golang-master/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/datatypes"
+)
+
+func TestSendDueCampaigns(t *testing.T) {
+	campaign := model.Campaign{ID: 3, Title: "title", Body: "body", Status: model.CAMPAIGN_SENDING, Segment: datatypes.NewJSONType(model.CampaignSegment{})}
+	t.Run("segmentError", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		repo.EXPECT().ClaimDueCampaigns(mock.Anything).Return([]model.Campaign{campaign}, nil)
+		repo.EXPECT().GetSegmentPatientIds(model.CampaignSegment{}, mock.Anything).Return(nil, errors.New("err"))
+		// back to scheduled for the next run
+		repo.EXPECT().UpdateCampaign(mock.MatchedBy(func(c model.Campaign) bool {
+			return c.ID == 3 && c.Status == model.CAMPAIGN_SCHEDULED
+		})).Return(nil).Once()
+
+		assert.NoError(t, service.SendDueCampaigns())
+	})
+	t.Run("success", func(t *testing.T) {
+		server := fakeExpoServer(t, []expo.PushResponse{{ID: "ticket-1", Status: "ok"}}, nil)
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{Host: server.URL}))
+
+		repo.EXPECT().ClaimDueCampaigns(mock.Anything).Return([]model.Campaign{campaign}, nil)
+		repo.EXPECT().GetSegmentPatientIds(model.CampaignSegment{}, mock.Anything).Return([]int{1, 2}, nil)
+		inPatients := []repository.Criteria{{QueryCriteria: repository.PATIENTID_IN, Value: "1,2"}}
+		// patient 2 muted announcements
+		repo.EXPECT().GetAllNotificationPreference(inPatients).Return([]model.NotificationPreference{
+			{PatientID: 2, MutedCategories: []model.NotificationCategory{model.CATEGORY_CONTENT}},
+		}, nil)
+		repo.EXPECT().GetAllDevice(inPatients).Return([]model.Device{
+			{ID: 5, PatientId: 1, ExpoToken: "ExponentPushToken[a]"},
+			{ID: 6, PatientId: 2, ExpoToken: "ExponentPushToken[b]"},
+		}, nil)
+		repo.EXPECT().SaveSentCampaign(mock.MatchedBy(func(c model.Campaign) bool {
+			return c.Status == model.CAMPAIGN_SENT && c.RecipientCount == 2 && c.SentAt != nil
+		}), mock.MatchedBy(func(n []model.Notification) bool {
+			return len(n) == 2 && n[0].PatientID == 1 && *n[0].CampaignID == 3 && n[1].Category == model.CATEGORY_CONTENT
+		}), mock.Anything).RunAndReturn(func(c model.Campaign, n []model.Notification, build func([]model.Notification) []model.NotificationOutbox) ([]model.NotificationOutbox, error) {
+			n[0].ID, n[1].ID = 10, 11
+			m := build(n)
+			assert.Len(t, m, 1)
+			assert.Equal(t, 5, *m[0].DeviceID)
+			assert.Equal(t, 3, *m[0].CampaignID)
+			assert.Equal(t, 10, *m[0].NotificationID)
+			m[0].ID = 1
+			return m, nil
+		}).Once()
+		repo.EXPECT().UpdateOutboxMessage(mock.MatchedBy(func(m model.NotificationOutbox) bool {
+			return m.Status == model.OUTBOX_SENT
+		})).Return(nil).Once()
+
+		assert.NoError(t, service.SendDueCampaigns())
+	})
+	t.Run("saveError", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		repo.EXPECT().ClaimDueCampaigns(mock.Anything).Return([]model.Campaign{campaign}, nil)
+		repo.EXPECT().GetSegmentPatientIds(model.CampaignSegment{}, mock.Anything).Return([]int{1}, nil)
+		repo.EXPECT().GetAllNotificationPreference(mock.Anything).Return(nil, nil)
+		repo.EXPECT().GetAllDevice(mock.Anything).Return([]model.Device{{ID: 5, PatientId: 1, ExpoToken: "ExponentPushToken[a]"}}, nil)
+		repo.EXPECT().SaveSentCampaign(mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("err")).Once()
+		// nothing was saved, back to scheduled for the next run
+		repo.EXPECT().UpdateCampaign(mock.MatchedBy(func(c model.Campaign) bool {
+			return c.ID == 3 && c.Status == model.CAMPAIGN_SCHEDULED && c.SentAt == nil
+		})).Return(nil).Once()
+
+		assert.NoError(t, service.SendDueCampaigns())
+	})
+}
@@ -0,0 +1,56 @@
+package notification_test
+
+import (
+	"testing"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/notification"
+	expo "github.com/PhasitWo/duchenne-server/services/notification/expo/exponent-server-sdk-golang-master/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifyCareTeam(t *testing.T) {
+	members := []model.SafeCareTeamMember{
+		{CareTeamMember: model.CareTeamMember{ID: 1, PatientID: 1, DoctorID: 3, Role: model.CARE_NEUROLOGIST}},
+		{CareTeamMember: model.CareTeamMember{ID: 2, PatientID: 1, DoctorID: 4, Role: model.CARE_COORDINATOR}},
+	}
+	patient := model.Patient{ID: 1, FirstName: "John", LastName: "Doe"}
+	t.Run("question", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		repo.EXPECT().GetAllCareTeamMember(1).Return(members, nil).Once()
+		repo.EXPECT().GetPatientById(1).Return(patient, nil).Once()
+		repo.EXPECT().GetQuestion(9).Return(model.SafeQuestion{Question: model.Question{ID: 9, Topic: "cough at night"}}, nil).Once()
+		repo.EXPECT().CreateDoctorNotifications([]model.DoctorNotification{
+			model.NewDoctorNotification(3, 1, "คำถามใหม่จาก John Doe", "cough at night", model.QuestionLink(9)),
+			model.NewDoctorNotification(4, 1, "คำถามใหม่จาก John Doe", "cough at night", model.QuestionLink(9)),
+		}).Return(nil).Once()
+
+		assert.NoError(t, service.NotifyCareTeam(1, model.QuestionLink(9)))
+	})
+	t.Run("appointment", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		// 5 January 2025 14:30 in Bangkok
+		ap := model.SafeAppointment{Appointment: model.Appointment{ID: 7, Date: 1736062200}}
+		repo.EXPECT().GetAllCareTeamMember(1).Return(members[:1], nil).Once()
+		repo.EXPECT().GetPatientById(1).Return(patient, nil).Once()
+		repo.EXPECT().GetAppointment(7).Return(ap, nil).Once()
+		repo.EXPECT().CreateDoctorNotifications([]model.DoctorNotification{
+			model.NewDoctorNotification(3, 1, "คำขอนัดหมายจาก John Doe", "วันที่ 5 มกราคม 2568 เวลา 14:30 น.", model.AppointmentLink(7)),
+		}).Return(nil).Once()
+
+		assert.NoError(t, service.NotifyCareTeam(1, model.AppointmentLink(7)))
+	})
+	t.Run("noCareTeam", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		repo.EXPECT().GetAllCareTeamMember(1).Return([]model.SafeCareTeamMember{}, nil).Once()
+
+		assert.NoError(t, service.NotifyCareTeam(1, model.QuestionLink(9)))
+	})
+}
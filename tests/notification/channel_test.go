@@ -0,0 +1,292 @@
+package notification_test
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/PhasitWo/duchenne-server/config"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/notification"
+	expo "github.com/PhasitWo/duchenne-server/services/notification/expo/exponent-server-sdk-golang-master/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakeMail struct {
+	From string
+	To   []string
+	Data string
+}
+
+// minimal SMTP server that accepts every mail, or rejects recipients with rcptCode
+type fakeSMTPServer struct {
+	listener net.Listener
+	rcptCode int
+	mu       sync.Mutex
+	mails    []fakeMail
+}
+
+func newFakeSMTPServer(t *testing.T, rcptCode int) *fakeSMTPServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	s := &fakeSMTPServer{listener: l, rcptCode: rcptCode}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { l.Close() })
+	return s
+}
+
+func (s *fakeSMTPServer) config() notification.SMTPConfig {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return notification.SMTPConfig{Host: host, Port: p, From: "clinic@example.com"}
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { fmt.Fprintf(conn, "%s\r\n", line) }
+	reply("220 fake smtp")
+	mail := fakeMail{}
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 fake")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			mail.From = strings.Trim(strings.TrimSpace(line)[len("MAIL FROM:"):], "<>")
+			reply("250 ok")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			if s.rcptCode != 0 {
+				reply(fmt.Sprintf("%d rejected", s.rcptCode))
+				continue
+			}
+			mail.To = append(mail.To, strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>"))
+			reply("250 ok")
+		case cmd == "DATA":
+			reply("354 go ahead")
+			data := strings.Builder{}
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			mail.Data = data.String()
+			s.mu.Lock()
+			s.mails = append(s.mails, mail)
+			s.mu.Unlock()
+			reply("250 queued")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func emailMessage(address string) model.NotificationOutbox {
+	return model.NotificationOutbox{ID: 1, Channel: model.CHANNEL_EMAIL, Address: &address, Title: "นัดหมายของคุณถูกยกเลิก!", Body: "เจ้าหน้าที่ยกเลิกนัดหมายของคุณ"}
+}
+
+func TestEmailChannel(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		server := newFakeSMTPServer(t, 0)
+		ch := notification.NewEmailChannel(server.config())
+
+		results := ch.Send([]model.NotificationOutbox{emailMessage("parent@example.com")})
+
+		assert.Len(t, results, 1)
+		assert.NoError(t, results[0].Err)
+		assert.Len(t, server.mails, 1)
+		mail := server.mails[0]
+		assert.Equal(t, "clinic@example.com", mail.From)
+		assert.Equal(t, []string{"parent@example.com"}, mail.To)
+		// Thai subject and body survive encoding
+		header, body, _ := strings.Cut(mail.Data, "\r\n\r\n")
+		for _, line := range strings.Split(header, "\r\n") {
+			if subject, ok := strings.CutPrefix(line, "Subject: "); ok {
+				decoded, err := new(mime.WordDecoder).DecodeHeader(subject)
+				assert.NoError(t, err)
+				assert.Equal(t, "นัดหมายของคุณถูกยกเลิก!", decoded)
+			}
+		}
+		decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(body, "\r\n", ""))
+		assert.NoError(t, err)
+		assert.Equal(t, "เจ้าหน้าที่ยกเลิกนัดหมายของคุณ", string(decoded))
+	})
+	t.Run("invalidAddress", func(t *testing.T) {
+		server := newFakeSMTPServer(t, 0)
+		ch := notification.NewEmailChannel(server.config())
+
+		results := ch.Send([]model.NotificationOutbox{emailMessage("not an email\r\nBcc: x@example.com")})
+
+		assert.Error(t, results[0].Err)
+		assert.False(t, results[0].Retry)
+		assert.Empty(t, server.mails)
+	})
+	t.Run("rejected", func(t *testing.T) {
+		server := newFakeSMTPServer(t, 550)
+		ch := notification.NewEmailChannel(server.config())
+
+		results := ch.Send([]model.NotificationOutbox{emailMessage("parent@example.com")})
+
+		assert.Error(t, results[0].Err)
+		assert.False(t, results[0].Retry)
+	})
+	t.Run("temporaryError", func(t *testing.T) {
+		server := newFakeSMTPServer(t, 451)
+		ch := notification.NewEmailChannel(server.config())
+
+		results := ch.Send([]model.NotificationOutbox{emailMessage("parent@example.com")})
+
+		assert.Error(t, results[0].Err)
+		assert.True(t, results[0].Retry)
+	})
+}
+
+func fakeSMSGateway(t *testing.T, status int, received *[]map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer sms-token", r.Header.Get("Authorization"))
+		body := map[string]string{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		*received = append(*received, body)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSMSChannel(t *testing.T) {
+	phone := "0812345678"
+	message := model.NotificationOutbox{ID: 1, Channel: model.CHANNEL_SMS, Address: &phone, Title: "title", Body: "body"}
+	testCases := []struct {
+		name   string
+		status int
+		ok     bool
+		retry  bool
+	}{
+		{"success", http.StatusOK, true, false},
+		{"throttled", http.StatusTooManyRequests, false, true},
+		{"gatewayError", http.StatusBadGateway, false, true},
+		{"rejected", http.StatusBadRequest, false, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			received := []map[string]string{}
+			server := fakeSMSGateway(t, tc.status, &received)
+			ch := notification.NewSMSChannel(notification.SMSConfig{URL: server.URL, Token: "sms-token"})
+
+			results := ch.Send([]model.NotificationOutbox{message})
+
+			assert.Equal(t, []map[string]string{{"to": phone, "message": "title\nbody"}}, received)
+			assert.Equal(t, tc.ok, results[0].Err == nil)
+			assert.Equal(t, tc.retry, results[0].Retry)
+		})
+	}
+}
+
+func TestParseFallbackRules(t *testing.T) {
+	rules, err := notification.ParseFallbackRules([]string{"appointment=expo,sms,email", "content=expo", "medication=expo,sms", "vaccine=expo,email"})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.NotificationChannel{model.CHANNEL_EXPO, model.CHANNEL_SMS}, rules[model.CATEGORY_MEDICATION])
+	assert.Equal(t, []model.NotificationChannel{model.CHANNEL_EXPO, model.CHANNEL_EMAIL}, rules[model.CATEGORY_VACCINE])
+	assert.Equal(t, []model.NotificationChannel{model.CHANNEL_EXPO, model.CHANNEL_SMS, model.CHANNEL_EMAIL}, rules[model.CATEGORY_APPOINTMENT])
+	assert.Equal(t, []model.NotificationChannel{model.CHANNEL_EXPO}, rules[model.CATEGORY_CONTENT])
+
+	for _, bad := range []string{"appointment", "unknown=expo", "appointment=fax", "appointment="} {
+		_, err := notification.ParseFallbackRules([]string{bad})
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestSendNotiByPatientIdFallback(t *testing.T) {
+	config.AppConfig.OUTBOX_MAX_ATTEMPTS = 5
+	config.AppConfig.NOTIFICATION_FALLBACK = []string{"appointment=expo,sms,email"}
+	t.Cleanup(func() { config.AppConfig.NOTIFICATION_FALLBACK = nil })
+	phone := "0812345678"
+	t.Run("smsWhenNoDevice", func(t *testing.T) {
+		received := []map[string]string{}
+		gateway := fakeSMSGateway(t, http.StatusOK, &received)
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil,
+			notification.NewExpoChannel(&expo.ClientConfig{}),
+			notification.NewSMSChannel(notification.SMSConfig{URL: gateway.URL, Token: "sms-token"}),
+		)
+
+		repo.EXPECT().CreateNotification(mock.Anything).Return(1, nil).Once()
+		repo.EXPECT().GetNotificationPreference(1).Return(model.NotificationPreference{PatientID: 1}, nil)
+		repo.EXPECT().GetAllDevice(mock.Anything).Return([]model.Device{}, nil)
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1, Phone: &phone}, nil).Once()
+		repo.EXPECT().CreateOutboxMessages(mock.MatchedBy(func(m []model.NotificationOutbox) bool {
+			return len(m) == 1 && m[0].Channel == model.CHANNEL_SMS && *m[0].Address == phone && m[0].DeviceID == nil
+		})).RunAndReturn(func(m []model.NotificationOutbox) ([]model.NotificationOutbox, error) {
+			m[0].ID = 1
+			return m, nil
+		}).Once()
+		repo.EXPECT().UpdateOutboxMessage(mock.MatchedBy(func(m model.NotificationOutbox) bool {
+			return m.ID == 1 && m.Status == model.OUTBOX_SENT && m.TicketID == nil
+		})).Return(nil).Once()
+
+		err := service.SendNotiByPatientId(1, model.CATEGORY_APPOINTMENT, "title", "body", model.AppointmentLink(2))
+		assert.NoError(t, err)
+		assert.Len(t, received, 1)
+	})
+	t.Run("skipUnconfiguredChannel", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		// sms and email are not configured
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		repo.EXPECT().CreateNotification(mock.Anything).Return(1, nil).Once()
+		repo.EXPECT().GetNotificationPreference(1).Return(model.NotificationPreference{PatientID: 1}, nil)
+		repo.EXPECT().GetAllDevice(mock.Anything).Return([]model.Device{}, nil)
+
+		err := service.SendNotiByPatientId(1, model.CATEGORY_APPOINTMENT, "title", "body", model.AppointmentLink(2))
+		assert.ErrorIs(t, err, notification.ErrDevicesNotFound)
+	})
+	t.Run("noContact", func(t *testing.T) {
+		server := newFakeSMTPServer(t, 0)
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil,
+			notification.NewExpoChannel(&expo.ClientConfig{}),
+			notification.NewSMSChannel(notification.SMSConfig{URL: "http://127.0.0.1:0"}),
+			notification.NewEmailChannel(server.config()),
+		)
+
+		repo.EXPECT().CreateNotification(mock.Anything).Return(1, nil).Once()
+		repo.EXPECT().GetNotificationPreference(1).Return(model.NotificationPreference{PatientID: 1}, nil)
+		repo.EXPECT().GetAllDevice(mock.Anything).Return([]model.Device{}, nil)
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1}, nil).Twice()
+
+		err := service.SendNotiByPatientId(1, model.CATEGORY_APPOINTMENT, "title", "body", model.AppointmentLink(2))
+		assert.ErrorIs(t, err, notification.ErrDevicesNotFound)
+		assert.Empty(t, server.mails)
+	})
+}
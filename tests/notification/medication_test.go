@@ -0,0 +1,146 @@
+package notification_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/config"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/medication"
+	"github.com/PhasitWo/duchenne-server/services/notification"
+	expo "github.com/PhasitWo/duchenne-server/services/notification/expo/exponent-server-sdk-golang-master/sdk"
+	"github.com/PhasitWo/duchenne-server/services/schedule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func TestScheduledDoses(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Bangkok")
+	assert.NoError(t, err)
+	at := func(day int, hour int) int { return int(time.Date(2025, 3, day, hour, 0, 0, 0, loc).Unix()) }
+	start := at(3, 0) // monday
+	t.Run("twiceDaily", func(t *testing.T) {
+		regimens := []model.MedicationRegimen{{ID: 1, Schedule: model.SCHEDULE_TWICE_DAILY, StartDate: &start}}
+		doses := medication.ScheduledDoses(regimens, at(3, 0), at(5, 0), loc)
+		assert.Len(t, doses, 4)
+		assert.Equal(t, at(3, 8), doses[0].ScheduledAt)
+		assert.Equal(t, at(4, 20), doses[3].ScheduledAt)
+	})
+	t.Run("customTimesAndEndDate", func(t *testing.T) {
+		end := at(4, 12)
+		regimens := []model.MedicationRegimen{{ID: 1, Schedule: model.SCHEDULE_ONCE_DAILY, StartDate: &start, EndDate: &end, ReminderTimes: datatypes.NewJSONSlice([]string{"10:00"})}}
+		doses := medication.ScheduledDoses(regimens, at(1, 0), at(8, 0), loc)
+		assert.Len(t, doses, 2)
+		assert.Equal(t, at(3, 10), doses[0].ScheduledAt)
+		assert.Equal(t, at(4, 10), doses[1].ScheduledAt)
+	})
+	t.Run("alternateDayAndWeekly", func(t *testing.T) {
+		regimens := []model.MedicationRegimen{
+			{ID: 1, Schedule: model.SCHEDULE_ALTERNATE, StartDate: &start},
+			{ID: 2, Schedule: model.SCHEDULE_WEEKLY, StartDate: &start},
+			{ID: 3, Schedule: model.SCHEDULE_AS_NEEDED, StartDate: &start},
+		}
+		doses := medication.ScheduledDoses(regimens, at(3, 0), at(10, 0), loc)
+		// alternate day on 3, 5, 7, 9 and weekly on monday 3
+		assert.Len(t, doses, 5)
+		assert.Equal(t, 2, doses[1].RegimenID)
+	})
+	t.Run("isDoseTime", func(t *testing.T) {
+		regimen := model.MedicationRegimen{ID: 1, Schedule: model.SCHEDULE_ONCE_DAILY, StartDate: &start}
+		assert.True(t, medication.IsDoseTime(regimen, at(4, 8), loc))
+		assert.False(t, medication.IsDoseTime(regimen, at(4, 9), loc))
+		assert.False(t, medication.IsDoseTime(regimen, at(2, 8), loc))
+		asNeeded := model.MedicationRegimen{ID: 2, Schedule: model.SCHEDULE_AS_NEEDED, StartDate: &start}
+		assert.True(t, medication.IsDoseTime(asNeeded, at(4, 9)+17, loc))
+	})
+}
+
+func TestWeeklyAdherence(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Bangkok")
+	assert.NoError(t, err)
+	at := func(day int, hour int) int { return int(time.Date(2025, 3, day, hour, 0, 0, 0, loc).Unix()) }
+	start := at(3, 0) // monday
+	regimens := []model.MedicationRegimen{{ID: 1, Schedule: model.SCHEDULE_ONCE_DAILY, StartDate: &start}}
+	taken, skipped := model.DOSE_TAKEN, model.DOSE_SKIPPED
+	logged := []model.MedicationDose{
+		{RegimenID: 1, ScheduledAt: at(3, 8), Status: &taken},
+		{RegimenID: 1, ScheduledAt: at(4, 8), Status: &taken},
+		{RegimenID: 1, ScheduledAt: at(5, 8), Status: &skipped},
+		{RegimenID: 1, ScheduledAt: at(10, 8)}, // reminded but never logged
+	}
+	// wednesday of the week before, until tuesday noon of the second week
+	weekStart := int(time.Date(2025, 2, 26, 0, 0, 0, 0, loc).Unix())
+	weeks := medication.WeeklyAdherence(regimens, logged, weekStart, 3, at(11, 12), loc)
+	assert.Len(t, weeks, 3)
+	// before the regimen started
+	assert.Equal(t, 0, weeks[0].Expected)
+	assert.Nil(t, weeks[0].Percent)
+	assert.Equal(t, at(3, 0), weeks[1].WeekStart)
+	assert.Equal(t, 7, weeks[1].Expected)
+	assert.Equal(t, 2, weeks[1].Taken)
+	assert.Equal(t, 1, weeks[1].Skipped)
+	assert.InDelta(t, 28.57, *weeks[1].Percent, 0.01)
+	// only monday and tuesday are due
+	assert.Equal(t, 2, weeks[2].Expected)
+	assert.Equal(t, 0.0, *weeks[2].Percent)
+	t.Run("recordedAfterStart", func(t *testing.T) {
+		// entered on thursday noon with the start date of monday
+		regimens := []model.MedicationRegimen{{ID: 1, Schedule: model.SCHEDULE_ONCE_DAILY, StartDate: &start, CreateAt: at(6, 12)}}
+		weeks := medication.WeeklyAdherence(regimens, nil, start, 1, at(9, 23), loc)
+		assert.Equal(t, 3, weeks[0].Expected)
+	})
+}
+
+func TestSendMedicationReminders(t *testing.T) {
+	config.AppConfig.CLINIC_TIMEZONE = "Asia/Bangkok"
+	// a dose at the current minute is in the reminder window
+	now := time.Now().In(schedule.Location())
+	regimen := model.MedicationRegimen{
+		ID:            4,
+		PatientID:     1,
+		MedicineName:  "Deflazacort",
+		Dose:          18,
+		DoseUnit:      model.DOSE_MG,
+		Schedule:      model.SCHEDULE_ONCE_DAILY,
+		ReminderTimes: datatypes.NewJSONSlice([]string{now.Format("15:04")}),
+	}
+	scheduledAt := int(time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), 0, 0, schedule.Location()).Unix())
+	t.Run("alreadyReminded", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		repo.EXPECT().GetRemindableMedicationRegimen().Return([]model.MedicationRegimen{regimen}, nil)
+		repo.EXPECT().CreateMedicationDose(mock.Anything).Return(fmt.Errorf("exec : %w", repository.ErrDuplicateEntry)).Once()
+
+		assert.NoError(t, service.SendMedicationReminders())
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		repo.EXPECT().GetRemindableMedicationRegimen().Return([]model.MedicationRegimen{regimen}, nil)
+		repo.EXPECT().CreateMedicationDose(mock.MatchedBy(func(d model.MedicationDose) bool {
+			return d.RegimenID == 4 && d.PatientID == 1 && d.ScheduledAt == scheduledAt && d.RemindAt != nil && d.Status == nil
+		})).Return(nil).Once()
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1, Language: model.LANGUAGE_EN}, nil).Once()
+		repo.EXPECT().GetNotificationTemplate(model.TEMPLATE_MEDICATION_REMINDER, model.LANGUAGE_EN).Return(model.NotificationTemplate{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound)).Once()
+		var saved model.Notification
+		repo.EXPECT().CreateNotification(mock.Anything).RunAndReturn(func(n model.Notification) (int, error) {
+			saved = n
+			return 1, nil
+		}).Once()
+		// muted, saved to the inbox only
+		repo.EXPECT().GetNotificationPreference(mock.Anything).Return(model.NotificationPreference{
+			MutedCategories: []model.NotificationCategory{model.CATEGORY_MEDICATION},
+		}, nil)
+
+		assert.NoError(t, service.SendMedicationReminders())
+		assert.Equal(t, "Time for your medicine", saved.Title)
+		assert.Equal(t, fmt.Sprintf("Deflazacort 18 mg at %v", now.Format("15:04")), saved.Body)
+		assert.Equal(t, model.CATEGORY_MEDICATION, saved.Category)
+	})
+}
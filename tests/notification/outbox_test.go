@@ -0,0 +1,246 @@
+package notification_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/config"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/notification"
+	expo "github.com/PhasitWo/duchenne-server/services/notification/expo/exponent-server-sdk-golang-master/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// local Expo server that replies push tickets and receipts in order
+func fakeExpoServer(t *testing.T, tickets []expo.PushResponse, receipts map[string]expo.PushReceipt) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case expo.DefaultBaseAPIURL + "/push/send":
+			if tickets == nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": tickets})
+		case expo.DefaultBaseAPIURL + "/push/getReceipts":
+			json.NewEncoder(w).Encode(map[string]any{"data": receipts})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSendNotiByPatientId(t *testing.T) {
+	config.AppConfig.OUTBOX_MAX_ATTEMPTS = 5
+	t.Run("noDevice", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		// still saved to the inbox
+		repo.EXPECT().CreateNotification(mock.MatchedBy(func(n model.Notification) bool {
+			return n.PatientID == 1 && n.Type == model.NOTIFICATION_GENERAL && n.RefID == nil
+		})).Return(1, nil).Once()
+		repo.EXPECT().GetNotificationPreference(1).Return(model.NotificationPreference{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+		repo.EXPECT().GetAllDevice(mock.Anything).Return([]model.Device{{ID: 1, ExpoToken: ""}}, nil)
+
+		err := service.SendNotiByPatientId(1, model.CATEGORY_GENERAL, "title", "body", model.NotificationLink{})
+		assert.ErrorIs(t, err, notification.ErrDevicesNotFound)
+	})
+	t.Run("enqueueError", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		repo.EXPECT().CreateNotification(mock.Anything).Return(1, nil).Once()
+		repo.EXPECT().GetNotificationPreference(1).Return(model.NotificationPreference{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+		repo.EXPECT().GetAllDevice(mock.Anything).Return([]model.Device{{ID: 1, ExpoToken: "ExponentPushToken[a]"}}, nil)
+		repo.EXPECT().CreateOutboxMessages(mock.Anything).Return(nil, errors.New("err"))
+
+		err := service.SendNotiByPatientId(1, model.CATEGORY_GENERAL, "title", "body", model.NotificationLink{})
+		assert.Error(t, err)
+	})
+	t.Run("success", func(t *testing.T) {
+		server := fakeExpoServer(t, []expo.PushResponse{
+			{ID: "ticket-1", Status: "ok"},
+			{Status: "error", Message: "rate exceeded", Details: map[string]string{"error": expo.ErrorMessageRateExceeded}},
+		}, nil)
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{Host: server.URL}))
+
+		repo.EXPECT().CreateNotification(mock.MatchedBy(func(n model.Notification) bool {
+			return n.PatientID == 1 && n.Type == model.NOTIFICATION_APPOINTMENT && *n.RefID == 3
+		})).Return(9, nil).Once()
+		repo.EXPECT().GetNotificationPreference(1).Return(model.NotificationPreference{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+		repo.EXPECT().GetAllDevice(mock.Anything).Return([]model.Device{
+			{ID: 1, ExpoToken: "ExponentPushToken[a]"},
+			{ID: 2, ExpoToken: "ExponentPushToken[b]"},
+		}, nil)
+		repo.EXPECT().CreateOutboxMessages(mock.Anything).RunAndReturn(func(messages []model.NotificationOutbox) ([]model.NotificationOutbox, error) {
+			assert.Len(t, messages, 2)
+			for i := range messages {
+				assert.Equal(t, model.OUTBOX_PENDING, messages[i].Status)
+				assert.Equal(t, 9, *messages[i].NotificationID)
+				// deep link to the appointment
+				assert.Equal(t, map[string]string{"notificationId": "9", "type": "appointment", "id": "3"}, messages[i].Data.Data())
+				messages[i].ID = i + 1
+			}
+			return messages, nil
+		})
+		// first message is accepted by Expo
+		repo.EXPECT().UpdateOutboxMessage(mock.MatchedBy(func(m model.NotificationOutbox) bool {
+			return m.ID == 1 && m.Status == model.OUTBOX_SENT && *m.TicketID == "ticket-1" && m.Attempts == 1
+		})).Return(nil).Once()
+		// second message is throttled, retry later
+		repo.EXPECT().UpdateOutboxMessage(mock.MatchedBy(func(m model.NotificationOutbox) bool {
+			return m.ID == 2 && m.Status == model.OUTBOX_PENDING && m.Attempts == 1 && m.NextAttemptAt > int(time.Now().Unix())
+		})).Return(nil).Once()
+
+		err := service.SendNotiByPatientId(1, model.CATEGORY_GENERAL, "title", "body", model.AppointmentLink(3))
+		assert.NoError(t, err)
+	})
+}
+
+func TestProcessOutbox(t *testing.T) {
+	config.AppConfig.OUTBOX_MAX_ATTEMPTS = 5
+	t.Run("claimError", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		repo.EXPECT().ClaimDueOutboxMessages(mock.Anything, notification.OUTBOX_LEASE, notification.OUTBOX_BATCH).Return(nil, errors.New("err"))
+
+		assert.Error(t, service.ProcessOutbox())
+	})
+	t.Run("transportError", func(t *testing.T) {
+		server := fakeExpoServer(t, nil, nil) // always 500
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{Host: server.URL}))
+
+		repo.EXPECT().ClaimDueOutboxMessages(mock.Anything, notification.OUTBOX_LEASE, notification.OUTBOX_BATCH).Return([]model.NotificationOutbox{
+			{ID: 1, ExpoToken: "ExponentPushToken[a]", Status: model.OUTBOX_PENDING, Attempts: 0},
+			{ID: 2, ExpoToken: "ExponentPushToken[b]", Status: model.OUTBOX_PENDING, Attempts: 4},
+		}, nil)
+		repo.EXPECT().UpdateOutboxMessage(mock.MatchedBy(func(m model.NotificationOutbox) bool {
+			return m.ID == 1 && m.Status == model.OUTBOX_PENDING && m.Attempts == 1 && m.LastError != nil
+		})).Return(nil).Once()
+		// the last attempt gives up
+		repo.EXPECT().UpdateOutboxMessage(mock.MatchedBy(func(m model.NotificationOutbox) bool {
+			return m.ID == 2 && m.Status == model.OUTBOX_FAILED && m.Attempts == 5
+		})).Return(nil).Once()
+
+		assert.NoError(t, service.ProcessOutbox())
+	})
+	t.Run("deviceNotRegistered", func(t *testing.T) {
+		server := fakeExpoServer(t, []expo.PushResponse{
+			{Status: "error", Message: "not registered", Details: map[string]string{"error": expo.ErrorDeviceNotRegistered}},
+		}, nil)
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{Host: server.URL}))
+
+		repo.EXPECT().ClaimDueOutboxMessages(mock.Anything, notification.OUTBOX_LEASE, notification.OUTBOX_BATCH).Return([]model.NotificationOutbox{
+			{ID: 1, ExpoToken: "ExponentPushToken[a]", Status: model.OUTBOX_PENDING},
+		}, nil)
+		repo.EXPECT().UpdateOutboxMessage(mock.MatchedBy(func(m model.NotificationOutbox) bool {
+			return m.ID == 1 && m.Status == model.OUTBOX_FAILED && *m.LastError == expo.ErrorDeviceNotRegistered
+		})).Return(nil).Once()
+
+		assert.NoError(t, service.ProcessOutbox())
+	})
+}
+
+func TestCheckReceipts(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		server := fakeExpoServer(t, nil, map[string]expo.PushReceipt{
+			"ticket-1": {Status: "ok"},
+			"ticket-2": {Status: "error", Message: "not registered", Details: map[string]string{"error": expo.ErrorDeviceNotRegistered}},
+		})
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{Host: server.URL}))
+
+		ticket1, ticket2, ticket3 := "ticket-1", "ticket-2", "ticket-3"
+		sentAt := int(time.Now().Add(-time.Hour).Unix())
+		repo.EXPECT().GetAllOutboxMessage(notification.MAX_RECEIPTS_PER_REQUEST, mock.Anything, mock.Anything, mock.Anything).Return([]model.NotificationOutbox{
+			{ID: 1, Status: model.OUTBOX_SENT, TicketID: &ticket1, SentAt: &sentAt},
+			{ID: 2, Status: model.OUTBOX_SENT, TicketID: &ticket2, SentAt: &sentAt},
+			{ID: 3, Status: model.OUTBOX_SENT, TicketID: &ticket3, SentAt: &sentAt}, // not ready yet
+		}, nil)
+		repo.EXPECT().UpdateOutboxMessage(mock.MatchedBy(func(m model.NotificationOutbox) bool {
+			return m.ID == 1 && m.Status == model.OUTBOX_DELIVERED && m.DeliveredAt != nil
+		})).Return(nil).Once()
+		repo.EXPECT().UpdateOutboxMessage(mock.MatchedBy(func(m model.NotificationOutbox) bool {
+			return m.ID == 2 && m.Status == model.OUTBOX_FAILED && *m.LastError == expo.ErrorDeviceNotRegistered
+		})).Return(nil).Once()
+
+		assert.NoError(t, service.CheckReceipts())
+	})
+}
+
+func TestPruneDevice(t *testing.T) {
+	config.AppConfig.OUTBOX_MAX_ATTEMPTS = 5
+	notRegistered := expo.PushResponse{Status: "error", Message: "not a valid push token", Details: map[string]string{"error": expo.ErrorDeviceNotRegistered}}
+	deviceId := 2
+	t.Run("ticket", func(t *testing.T) {
+		server := fakeExpoServer(t, []expo.PushResponse{notRegistered}, nil)
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{Host: server.URL}))
+
+		repo.EXPECT().ClaimDueOutboxMessages(mock.Anything, notification.OUTBOX_LEASE, notification.OUTBOX_BATCH).Return([]model.NotificationOutbox{
+			{ID: 1, DeviceID: &deviceId, ExpoToken: "ExponentPushToken[a]", Status: model.OUTBOX_PENDING},
+		}, nil)
+		repo.EXPECT().UpdateOutboxMessage(mock.Anything).Return(nil).Once()
+		repo.EXPECT().GetAllDevice([]repository.Criteria{{QueryCriteria: repository.ID, Value: deviceId}}).Return([]model.Device{
+			{ID: deviceId, DeviceName: "phone", ExpoToken: "ExponentPushToken[a]", PatientId: 7},
+		}, nil)
+		repo.EXPECT().CreateDeviceRemoval(mock.MatchedBy(func(r model.DeviceRemoval) bool {
+			return r.PatientID == 7 && r.DeviceID == deviceId && r.Reason == expo.ErrorDeviceNotRegistered &&
+				*r.Detail == notRegistered.Message && *r.OutboxID == 1
+		})).Return(nil).Once()
+		repo.EXPECT().DeleteDevice(deviceId).Return(nil).Once()
+
+		assert.NoError(t, service.ProcessOutbox())
+	})
+	t.Run("receipt", func(t *testing.T) {
+		server := fakeExpoServer(t, nil, map[string]expo.PushReceipt{
+			"ticket-1": {Status: notRegistered.Status, Message: notRegistered.Message, Details: notRegistered.Details},
+		})
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{Host: server.URL}))
+
+		ticket := "ticket-1"
+		sentAt := int(time.Now().Add(-time.Hour).Unix())
+		repo.EXPECT().GetAllOutboxMessage(notification.MAX_RECEIPTS_PER_REQUEST, mock.Anything, mock.Anything, mock.Anything).Return([]model.NotificationOutbox{
+			{ID: 1, DeviceID: &deviceId, ExpoToken: "ExponentPushToken[a]", Status: model.OUTBOX_SENT, TicketID: &ticket, SentAt: &sentAt},
+		}, nil)
+		repo.EXPECT().UpdateOutboxMessage(mock.Anything).Return(nil).Once()
+		repo.EXPECT().GetAllDevice([]repository.Criteria{{QueryCriteria: repository.ID, Value: deviceId}}).Return([]model.Device{
+			{ID: deviceId, DeviceName: "phone", ExpoToken: "ExponentPushToken[a]", PatientId: 7},
+		}, nil)
+		repo.EXPECT().CreateDeviceRemoval(mock.Anything).Return(nil).Once()
+		repo.EXPECT().DeleteDevice(deviceId).Return(nil).Once()
+
+		assert.NoError(t, service.CheckReceipts())
+	})
+	t.Run("tokenChanged", func(t *testing.T) {
+		server := fakeExpoServer(t, []expo.PushResponse{notRegistered}, nil)
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{Host: server.URL}))
+
+		repo.EXPECT().ClaimDueOutboxMessages(mock.Anything, notification.OUTBOX_LEASE, notification.OUTBOX_BATCH).Return([]model.NotificationOutbox{
+			{ID: 1, DeviceID: &deviceId, ExpoToken: "ExponentPushToken[a]", Status: model.OUTBOX_PENDING},
+		}, nil)
+		repo.EXPECT().UpdateOutboxMessage(mock.Anything).Return(nil).Once()
+		// same device id, but the token is no longer the dead one
+		repo.EXPECT().GetAllDevice([]repository.Criteria{{QueryCriteria: repository.ID, Value: deviceId}}).Return([]model.Device{
+			{ID: deviceId, ExpoToken: "ExponentPushToken[b]", PatientId: 7},
+		}, nil)
+
+		assert.NoError(t, service.ProcessOutbox())
+	})
+}
@@ -0,0 +1,93 @@
+package notification_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/notification"
+	expo "github.com/PhasitWo/duchenne-server/services/notification/expo/exponent-server-sdk-golang-master/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func quietHours(start int, end int) model.NotificationPreference {
+	return model.NotificationPreference{QuietStartMinute: &start, QuietEndMinute: &end, Timezone: "Asia/Bangkok"}
+}
+
+func TestQuietUntil(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Bangkok")
+	assert.NoError(t, err)
+	at := func(day int, hour int, minute int) int {
+		return int(time.Date(2025, 3, day, hour, minute, 0, 0, loc).Unix())
+	}
+	t.Run("noQuietHours", func(t *testing.T) {
+		assert.Equal(t, 0, notification.QuietUntil(model.NotificationPreference{}, at(10, 23, 0)))
+	})
+	t.Run("sameDay", func(t *testing.T) {
+		pref := quietHours(12*60, 13*60)
+		assert.Equal(t, at(10, 13, 0), notification.QuietUntil(pref, at(10, 12, 30)))
+		assert.Equal(t, 0, notification.QuietUntil(pref, at(10, 13, 0)))
+		assert.Equal(t, 0, notification.QuietUntil(pref, at(10, 11, 59)))
+	})
+	t.Run("acrossMidnight", func(t *testing.T) {
+		pref := quietHours(22*60, 7*60)
+		assert.Equal(t, at(11, 7, 0), notification.QuietUntil(pref, at(10, 22, 0)))
+		assert.Equal(t, at(11, 7, 0), notification.QuietUntil(pref, at(11, 3, 0)))
+		assert.Equal(t, 0, notification.QuietUntil(pref, at(11, 7, 0)))
+		assert.Equal(t, 0, notification.QuietUntil(pref, at(10, 21, 59)))
+	})
+	t.Run("patientTimezone", func(t *testing.T) {
+		pref := quietHours(22*60, 7*60)
+		pref.Timezone = "Europe/London"
+		// 23:00 in Bangkok is 16:00 in London
+		assert.Equal(t, 0, notification.QuietUntil(pref, at(10, 23, 0)))
+	})
+}
+
+func TestSendNotiByPatientIdPreference(t *testing.T) {
+	t.Run("muted", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		// saved to the inbox but not pushed
+		repo.EXPECT().CreateNotification(mock.MatchedBy(func(n model.Notification) bool {
+			return n.Category == model.CATEGORY_CONTENT
+		})).Return(1, nil).Once()
+		repo.EXPECT().GetNotificationPreference(1).Return(model.NotificationPreference{
+			PatientID:       1,
+			MutedCategories: []model.NotificationCategory{model.CATEGORY_CONTENT},
+		}, nil)
+
+		err := service.SendNotiByPatientId(1, model.CATEGORY_CONTENT, "title", "body", model.NotificationLink{})
+		assert.NoError(t, err)
+	})
+	t.Run("quietHours", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		now := time.Now()
+		// quiet hours from 1 hour ago to 1 hour later in patient timezone
+		loc, err := time.LoadLocation("Asia/Bangkok")
+		assert.NoError(t, err)
+		local := now.In(loc)
+		minute := local.Hour()*60 + local.Minute()
+		pref := quietHours((minute+23*60)%(24*60), (minute+60)%(24*60))
+		quietEnd := notification.QuietUntil(pref, int(now.Unix()))
+		assert.Greater(t, quietEnd, int(now.Unix()))
+
+		repo.EXPECT().CreateNotification(mock.Anything).Return(1, nil).Once()
+		repo.EXPECT().GetNotificationPreference(1).Return(pref, nil)
+		repo.EXPECT().GetAllDevice(mock.Anything).Return([]model.Device{{ID: 1, ExpoToken: "ExponentPushToken[a]"}}, nil)
+		// deferred to the end of quiet hours, nothing is sent now
+		repo.EXPECT().CreateOutboxMessages(mock.MatchedBy(func(messages []model.NotificationOutbox) bool {
+			return len(messages) == 1 && messages[0].NextAttemptAt == quietEnd && messages[0].Status == model.OUTBOX_PENDING
+		})).RunAndReturn(func(messages []model.NotificationOutbox) ([]model.NotificationOutbox, error) {
+			return messages, nil
+		}).Once()
+
+		err = service.SendNotiByPatientId(1, model.CATEGORY_APPOINTMENT, "title", "body", model.NotificationLink{})
+		assert.NoError(t, err)
+	})
+}
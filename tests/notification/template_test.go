@@ -0,0 +1,121 @@
+package notification_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/config"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/notification"
+	expo "github.com/PhasitWo/duchenne-server/services/notification/expo/exponent-server-sdk-golang-master/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// service that only saves to the inbox, every category is muted so nothing is pushed
+func templateService(t *testing.T) (*repository.MockRepo, notification.INotificationService, *model.Notification) {
+	repo := repository.NewMockRepo(t)
+	service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+	saved := &model.Notification{}
+	repo.EXPECT().CreateNotification(mock.Anything).RunAndReturn(func(n model.Notification) (int, error) {
+		*saved = n
+		return 1, nil
+	}).Once()
+	repo.EXPECT().GetNotificationPreference(mock.Anything).Return(model.NotificationPreference{
+		MutedCategories: []model.NotificationCategory{model.CATEGORY_APPOINTMENT, model.CATEGORY_QUESTION},
+	}, nil)
+	return repo, service, saved
+}
+
+func TestSendTemplateByPatientId(t *testing.T) {
+	config.AppConfig.CLINIC_TIMEZONE = "Asia/Bangkok"
+	loc, err := time.LoadLocation("Asia/Bangkok")
+	assert.NoError(t, err)
+	date := int(time.Date(2025, 1, 5, 14, 30, 0, 0, loc).Unix())
+	appointment := func(language model.Language) model.SafeAppointment {
+		return model.SafeAppointment{
+			Appointment: model.Appointment{ID: 3, PatientID: 1, Date: date, Patient: model.Patient{ID: 1, Language: language}},
+			Doctor:      model.TrimDoctor{Doctor: model.Doctor{FirstName: "John", LastName: "Doe"}},
+		}
+	}
+	t.Run("englishPatient", func(t *testing.T) {
+		repo, service, saved := templateService(t)
+		repo.EXPECT().GetAppointment(3).Return(appointment(model.LANGUAGE_EN), nil).Once()
+		repo.EXPECT().GetNotificationTemplate(model.TEMPLATE_APPOINTMENT_REJECTED, model.LANGUAGE_EN).Return(model.NotificationTemplate{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound)).Once()
+
+		err := service.SendTemplateByPatientId(1, model.TEMPLATE_APPOINTMENT_REJECTED, model.TemplateParams{"reason": "doctor is on leave"}, model.AppointmentLink(3))
+		assert.NoError(t, err)
+		assert.Equal(t, "Your appointment was not confirmed", saved.Title)
+		assert.Equal(t, "doctor is on leave", saved.Body)
+		assert.Equal(t, model.CATEGORY_APPOINTMENT, saved.Category)
+	})
+	t.Run("override", func(t *testing.T) {
+		repo, service, saved := templateService(t)
+		repo.EXPECT().GetAppointment(3).Return(appointment(model.LANGUAGE_TH), nil).Once()
+		repo.EXPECT().GetNotificationTemplate(model.TEMPLATE_APPOINTMENT_APPROVED, model.LANGUAGE_TH).Return(model.NotificationTemplate{
+			Title: "นัดหมายกับ {{doctorName}}",
+			Body:  "วันที่ {{ date }} เวลา {{time}}",
+		}, nil).Once()
+
+		err := service.SendTemplateByPatientId(1, model.TEMPLATE_APPOINTMENT_APPROVED, nil, model.AppointmentLink(3))
+		assert.NoError(t, err)
+		assert.Equal(t, "นัดหมายกับ John Doe", saved.Title)
+		assert.Equal(t, "วันที่ 5 มกราคม 2568 เวลา 14:30", saved.Body)
+	})
+	t.Run("emptyReason", func(t *testing.T) {
+		// the appointment is gone, language is read from the patient
+		repo, service, saved := templateService(t)
+		repo.EXPECT().GetAppointment(3).Return(model.SafeAppointment{}, fmt.Errorf("exec : %w", gorm.ErrRecordNotFound)).Once()
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1, Language: model.LANGUAGE_EN}, nil).Once()
+		repo.EXPECT().GetNotificationTemplate(model.TEMPLATE_RESCHEDULE_DECLINED, model.LANGUAGE_EN).Return(model.NotificationTemplate{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound)).Once()
+
+		err := service.SendTemplateByPatientId(1, model.TEMPLATE_RESCHEDULE_DECLINED, nil, model.AppointmentLink(3))
+		assert.NoError(t, err)
+		assert.Equal(t, "Your reschedule request was declined", saved.Title)
+		assert.Equal(t, "Check the status in the app", saved.Body)
+	})
+	t.Run("question", func(t *testing.T) {
+		repo, service, saved := templateService(t)
+		doctorId := 2
+		repo.EXPECT().GetQuestion(4).Return(model.SafeQuestion{
+			Question: model.Question{ID: 4, Topic: "Diet", PatientID: 1, DoctorID: &doctorId, Patient: model.Patient{Language: model.LANGUAGE_TH}},
+			Doctor:   model.TrimDoctor{Doctor: model.Doctor{FirstName: "John", LastName: "Doe"}},
+		}, nil).Once()
+		repo.EXPECT().GetNotificationTemplate(model.TEMPLATE_QUESTION_ANSWERED, model.LANGUAGE_TH).Return(model.NotificationTemplate{
+			Title: "{{doctorName}} ตอบคำถาม",
+			Body:  "{{topic}}",
+		}, nil).Once()
+
+		err := service.SendTemplateByPatientId(1, model.TEMPLATE_QUESTION_ANSWERED, nil, model.QuestionLink(4))
+		assert.NoError(t, err)
+		assert.Equal(t, "John Doe ตอบคำถาม", saved.Title)
+		assert.Equal(t, "Diet", saved.Body)
+		assert.Equal(t, model.CATEGORY_QUESTION, saved.Category)
+	})
+	t.Run("unknownTemplate", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		err := service.SendTemplateByPatientId(1, "unknown", nil, model.NotificationLink{})
+		assert.ErrorIs(t, err, notification.ErrTemplateNotFound)
+	})
+}
+
+func TestTemplateDefinitionValidate(t *testing.T) {
+	def, err := notification.GetTemplateDefinition(model.TEMPLATE_APPOINTMENT_REMINDER)
+	assert.NoError(t, err)
+	assert.NoError(t, def.Validate("{{remainingTime}} {{date}} {{ time }} {{doctorName}}"))
+	assert.Error(t, def.Validate("{{reason}}"))
+	// every built-in text is valid and exists in every language
+	for _, def := range notification.TemplateDefinitions {
+		for _, language := range model.SUPPORTED_LANGUAGES {
+			text, ok := def.Defaults[language]
+			assert.True(t, ok, "%v has no %v text", def.Key, language)
+			assert.NoError(t, def.Validate(text.Title))
+			assert.NoError(t, def.Validate(text.Body))
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package notification_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/PhasitWo/duchenne-server/config"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/notification"
+	expo "github.com/PhasitWo/duchenne-server/services/notification/expo/exponent-server-sdk-golang-master/sdk"
+	"github.com/PhasitWo/duchenne-server/services/vaccination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestDueVaccines(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Bangkok")
+	assert.NoError(t, err)
+	date := func(year int, month time.Month, day int) int {
+		return int(time.Date(year, month, day, 0, 0, 0, 0, loc).Unix())
+	}
+	patient := model.Patient{ID: 1, BirthDate: date(2018, 1, 10)}
+	now := date(2025, 3, 1)
+	influenza := model.VaccineRule{VaccineID: 1, Vaccine: model.Vaccine{ID: 1, Name: "Influenza"}, MinAgeMonths: 6, IntervalMonths: 12, Enabled: true}
+	pcv := model.VaccineRule{VaccineID: 2, Vaccine: model.Vaccine{ID: 2, Name: "PCV13"}, MinAgeMonths: 24, Doses: 1, SteroidOnly: true, Enabled: true}
+	mmr := model.VaccineRule{VaccineID: 3, Vaccine: model.Vaccine{ID: 3, Name: "MMR", Live: true}, MinAgeMonths: 9, Doses: 2, IntervalMonths: 9, Enabled: true}
+	rules := []model.VaccineRule{influenza, pcv, mmr}
+	flu, measles := 1, 3
+	records := []model.Vaccination{
+		{VaccineID: &measles, VaccinatedAt: date(2018, 10, 10)},
+		{VaccineID: &flu, VaccinatedAt: date(2024, 4, 1)},
+		{VaccineID: &measles, VaccinatedAt: date(2019, 7, 10)},
+		{VaccineName: "unknown"}, // migrated without catalog
+	}
+	start := date(2024, 1, 1)
+	deflazacort := model.MedicationRegimen{MedicineName: "Deflazacort", Dose: 18, DoseUnit: model.DOSE_MG, Schedule: model.SCHEDULE_ONCE_DAILY, StartDate: &start}
+	t.Run("noSteroid", func(t *testing.T) {
+		due := vaccination.DueVaccines(patient, rules, records, nil, now, 60*24*60*60, loc)
+		// mmr series is complete, pcv is for steroid only
+		assert.Len(t, due, 1)
+		assert.Equal(t, 1, due[0].VaccineID)
+		assert.Equal(t, 2, due[0].DoseNumber)
+		assert.Equal(t, date(2025, 4, 1), due[0].DueAt)
+		assert.Equal(t, model.VACCINE_UPCOMING, due[0].Status)
+	})
+	t.Run("steroid", func(t *testing.T) {
+		due := vaccination.DueVaccines(patient, rules, records, []model.MedicationRegimen{deflazacort}, now, 0, loc)
+		assert.Len(t, due, 1)
+		assert.Equal(t, 2, due[0].VaccineID)
+		assert.Equal(t, date(2020, 1, 10), due[0].DueAt)
+		assert.Equal(t, model.VACCINE_OVERDUE, due[0].Status)
+	})
+	t.Run("liveContraindicated", func(t *testing.T) {
+		due := vaccination.DueVaccines(patient, []model.VaccineRule{mmr}, nil, []model.MedicationRegimen{deflazacort}, now, 0, loc)
+		assert.Len(t, due, 1)
+		assert.Equal(t, 1, due[0].DoseNumber)
+		// 18 mg of deflazacort is 15 mg of prednisone, high dose for a 7 kg child only
+		assert.False(t, due[0].Contraindicated)
+		weight := float32(7)
+		assert.True(t, vaccination.OnHighDoseSteroid([]model.MedicationRegimen{deflazacort}, &weight, now))
+	})
+}
+
+func TestOnHighDoseSteroid(t *testing.T) {
+	now := int(time.Now().Unix())
+	ended := now - 10*24*60*60
+	longAgo := now - 60*24*60*60
+	cases := []struct {
+		name     string
+		regimen  model.MedicationRegimen
+		highDose bool
+	}{
+		{"twiceDaily", model.MedicationRegimen{MedicineName: "Prednisolone", Dose: 10, DoseUnit: model.DOSE_MG, Schedule: model.SCHEDULE_TWICE_DAILY}, true},
+		{"lowDose", model.MedicationRegimen{MedicineName: "prednisolone 5 mg", Dose: 5, DoseUnit: model.DOSE_MG, Schedule: model.SCHEDULE_ONCE_DAILY}, false},
+		{"unknownDose", model.MedicationRegimen{MedicineName: "Prednisone", Schedule: model.SCHEDULE_OTHER}, true},
+		{"washout", model.MedicationRegimen{MedicineName: "Prednisone", Dose: 30, DoseUnit: model.DOSE_MG, Schedule: model.SCHEDULE_ONCE_DAILY, EndDate: &ended}, true},
+		{"stopped", model.MedicationRegimen{MedicineName: "Prednisone", Dose: 30, DoseUnit: model.DOSE_MG, Schedule: model.SCHEDULE_ONCE_DAILY, EndDate: &longAgo}, false},
+		{"notSteroid", model.MedicationRegimen{MedicineName: "Enalapril", Dose: 50, DoseUnit: model.DOSE_MG, Schedule: model.SCHEDULE_ONCE_DAILY}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.highDose, vaccination.OnHighDoseSteroid([]model.MedicationRegimen{c.regimen}, nil, now))
+		})
+	}
+}
+
+func TestSendVaccineReminders(t *testing.T) {
+	config.AppConfig.CLINIC_TIMEZONE = "Asia/Bangkok"
+	enabled := []repository.Criteria{{QueryCriteria: repository.IS_ENABLED, Value: true}}
+	now := time.Now()
+	rules := []model.VaccineRule{
+		{VaccineID: 1, Vaccine: model.Vaccine{ID: 1, Name: "Influenza"}, MinAgeMonths: 6, IntervalMonths: 12, Enabled: true},
+		{VaccineID: 3, Vaccine: model.Vaccine{ID: 3, Name: "MMR", Live: true}, MinAgeMonths: 9, Doses: 2, IntervalMonths: 9, Enabled: true},
+	}
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		flu := 1
+		repo.EXPECT().GetAllVaccineRule(enabled).Return(rules, nil)
+		repo.EXPECT().GetAllPatient(-1, 0, []repository.Criteria{{QueryCriteria: repository.VERIFICATION, Value: model.SIGNUP_APPROVED}}).Return([]model.Patient{
+			{ID: 1, Language: model.LANGUAGE_EN, BirthDate: int(now.AddDate(-8, 0, 0).Unix())},
+			{ID: 2, BirthDate: int(now.AddDate(-8, 0, 0).Unix())},
+		}, nil)
+		// influenza is due since yesterday, mmr is done
+		mmr := 3
+		repo.EXPECT().GetAllVaccination(1).Return([]model.Vaccination{
+			{VaccineID: &flu, VaccinatedAt: int(now.AddDate(-1, 0, -1).Unix())},
+			{VaccineID: &mmr, VaccinatedAt: int(now.AddDate(-7, 0, 0).Unix())},
+			{VaccineID: &mmr, VaccinatedAt: int(now.AddDate(-6, 0, 0).Unix())},
+		}, nil)
+		// became due long ago and was reminded back then
+		repo.EXPECT().GetAllVaccination(2).Return([]model.Vaccination{}, nil)
+		repo.EXPECT().GetAllMedicationRegimen(mock.Anything).Return([]model.MedicationRegimen{}, nil)
+		repo.EXPECT().CreateVaccineReminderLog(model.VaccineReminderLog{PatientID: 1, VaccineID: 1, DoseNumber: 2}).Return(nil).Once()
+		repo.EXPECT().CreateVaccineReminderLog(mock.MatchedBy(func(l model.VaccineReminderLog) bool { return l.PatientID == 2 })).
+			Return(fmt.Errorf("exec : %w", repository.ErrDuplicateEntry)).Twice()
+		repo.EXPECT().GetNotificationTemplate(model.TEMPLATE_VACCINE_DUE, model.LANGUAGE_EN).Return(model.NotificationTemplate{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound)).Once()
+		var saved model.Notification
+		repo.EXPECT().CreateNotification(mock.Anything).RunAndReturn(func(n model.Notification) (int, error) {
+			saved = n
+			return 1, nil
+		}).Once()
+		repo.EXPECT().GetNotificationPreference(1).Return(model.NotificationPreference{
+			MutedCategories: []model.NotificationCategory{model.CATEGORY_VACCINE},
+		}, nil)
+
+		assert.NoError(t, service.SendVaccineReminders())
+		assert.Equal(t, "Vaccine due", saved.Title)
+		assert.Contains(t, saved.Body, "Influenza is due on")
+		assert.Equal(t, model.CATEGORY_VACCINE, saved.Category)
+	})
+	t.Run("alreadyReminded", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		flu := 1
+		repo.EXPECT().GetAllVaccineRule(enabled).Return(rules[:1], nil)
+		repo.EXPECT().GetAllPatient(-1, 0, []repository.Criteria{{QueryCriteria: repository.VERIFICATION, Value: model.SIGNUP_APPROVED}}).Return([]model.Patient{{ID: 1, BirthDate: int(now.AddDate(-8, 0, 0).Unix())}}, nil)
+		repo.EXPECT().GetAllVaccination(1).Return([]model.Vaccination{
+			{VaccineID: &flu, VaccinatedAt: int(now.AddDate(-1, 0, -1).Unix())},
+		}, nil)
+		repo.EXPECT().GetAllMedicationRegimen(1).Return([]model.MedicationRegimen{}, nil)
+		repo.EXPECT().CreateVaccineReminderLog(mock.Anything).Return(fmt.Errorf("exec : %w", repository.ErrDuplicateEntry)).Once()
+
+		assert.NoError(t, service.SendVaccineReminders())
+	})
+	t.Run("longOverdue", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		service := notification.New(repo, nil, notification.NewExpoChannel(&expo.ClientConfig{}))
+
+		// the yearly dose was missed two years ago and never reminded
+		flu := 1
+		repo.EXPECT().GetAllVaccineRule(enabled).Return(rules[:1], nil)
+		repo.EXPECT().GetAllPatient(-1, 0, []repository.Criteria{{QueryCriteria: repository.VERIFICATION, Value: model.SIGNUP_APPROVED}}).Return([]model.Patient{{ID: 1, Language: model.LANGUAGE_EN, BirthDate: int(now.AddDate(-8, 0, 0).Unix())}}, nil)
+		repo.EXPECT().GetAllVaccination(1).Return([]model.Vaccination{
+			{VaccineID: &flu, VaccinatedAt: int(now.AddDate(-3, 0, 0).Unix())},
+		}, nil)
+		repo.EXPECT().GetAllMedicationRegimen(1).Return([]model.MedicationRegimen{}, nil)
+		repo.EXPECT().CreateVaccineReminderLog(model.VaccineReminderLog{PatientID: 1, VaccineID: 1, DoseNumber: 2}).Return(nil).Once()
+		repo.EXPECT().GetNotificationTemplate(model.TEMPLATE_VACCINE_DUE, model.LANGUAGE_EN).Return(model.NotificationTemplate{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound)).Once()
+		repo.EXPECT().CreateNotification(mock.Anything).Return(1, nil).Once()
+		repo.EXPECT().GetNotificationPreference(1).Return(model.NotificationPreference{
+			MutedCategories: []model.NotificationCategory{model.CATEGORY_VACCINE},
+		}, nil)
+
+		assert.NoError(t, service.SendVaccineReminders())
+	})
+}
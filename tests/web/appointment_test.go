@@ -34,13 +34,15 @@ func TestGetAllAppointment(t *testing.T) {
 		repo := repository.NewMockRepo(t)
 		webH := web.WebHandler{Repo: repo}
 
-		repo.EXPECT().GetAllAppointment(9999, 0).Return([]model.SafeAppointment{}, nil)
+		repo.EXPECT().GetAllAppointment(9999, 0, []repository.Criteria{
+			{QueryCriteria: repository.MY_PATIENTID, Value: 3},
+		}).Return([]model.SafeAppointment{}, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.GET("/", webH.GetAllAppointment)
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.GetAllAppointment)
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 200, recorder.Code)
@@ -58,6 +60,19 @@ func TestGetAllAppointment(t *testing.T) {
 
 		assert.Equal(t, 400, recorder.Code)
 	})
+	t.Run("invalidScopeQueryParam", func(t *testing.T) {
+		// setup mock
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/?scope=brabra", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", webH.GetAllAppointment)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
 	t.Run("invalidLimitQueryParam", func(t *testing.T) {
 		// setup mock
 		webH := web.WebHandler{}
@@ -78,11 +93,11 @@ func TestGetAllAppointment(t *testing.T) {
 
 		repo.EXPECT().GetAllAppointment(9999, 0).Return([]model.SafeAppointment{}, errors.New("some internal error"))
 
-		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req := httptest.NewRequest(http.MethodGet, "/?scope=all", nil)
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.GET("/", webH.GetAllAppointment)
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.GetAllAppointment)
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 500, recorder.Code)
@@ -105,6 +120,7 @@ func TestGetOneAppointment(t *testing.T) {
 		rr := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(rr)
 		c.Params = gin.Params{gin.Param{Key: "id", Value: id}}
+		c.Set("doctorRole", model.ADMIN)
 
 		webH.GetAppointment(c)
 
@@ -165,7 +181,7 @@ func TestCreateAppointment(t *testing.T) {
 		webH := web.WebHandler{Repo: repo, NotiService: noti}
 
 		repo.EXPECT().CreateAppointment(mock.Anything).Return(1, nil).Once()
-		noti.EXPECT().SendNotiByPatientId(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe() // go routine
+		noti.EXPECT().SendTemplateByPatientId(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe() // go routine
 
 		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(input))
 		recorder := httptest.NewRecorder()
@@ -176,6 +192,45 @@ func TestCreateAppointment(t *testing.T) {
 
 		assert.Equal(t, 201, recorder.Code)
 	})
+	t.Run("conflict", func(t *testing.T) {
+		apmReq := model.CreateAppointmentRequest{
+			Date:      int(time.Now().Add(60 * time.Minute).Unix()),
+			Duration:  60,
+			PatientId: 1,
+			DoctorId:  1,
+			Approve:   false,
+		}
+		input, err := json.Marshal(&apmReq)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		conflictErr := &repository.ErrAppointmentConflict{Appointment: model.Appointment{ID: 7, Date: apmReq.Date + 1800, Duration: 30, DoctorID: 1, PatientID: 2}}
+		repo.EXPECT().CreateAppointment(model.Appointment{
+			Date:      apmReq.Date,
+			Duration:  60,
+			PatientID: 1,
+			DoctorID:  1,
+			Status:    model.REQUESTED,
+		}).Return(-1, fmt.Errorf("exec : %w", conflictErr)).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", webH.CreateAppointment)
+		router.ServeHTTP(recorder, req)
+
+		expectRespBody, err := json.Marshal(gin.H{
+			"error":    "appointment overlaps another appointment of this doctor or patient",
+			"conflict": model.AppointmentConflict{ID: 7, Date: apmReq.Date + 1800, Duration: 30, DoctorID: 1, PatientID: 2},
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 409, recorder.Code)
+		assert.Equal(t, expectRespBody, recorder.Body.Bytes())
+	})
 	t.Run("bindingError", func(t *testing.T) {
 		apmReq := model.CreateAppointmentRequest{
 			Date:      int(time.Now().Add(60 * time.Minute).Unix()),
@@ -219,6 +274,119 @@ func TestCreateAppointment(t *testing.T) {
 
 		assert.Equal(t, 422, recorder.Code)
 	})
+	t.Run("notFound", func(t *testing.T) {
+		apmReq := model.CreateAppointmentRequest{
+			Date:      int(time.Now().Add(60 * time.Minute).Unix()),
+			PatientId: 1,
+			DoctorId:  1,
+			Approve:   false,
+		}
+		input, err := json.Marshal(&apmReq)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		mockErr := fmt.Errorf("wrap : %w", gorm.ErrRecordNotFound)
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{}, mockErr).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", webH.UpdateAppointment)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("inactiveAppointment", func(t *testing.T) {
+		apmReq := model.CreateAppointmentRequest{
+			Date:      int(time.Now().Add(60 * time.Minute).Unix()),
+			PatientId: 1,
+			DoctorId:  1,
+			Approve:   false,
+		}
+		input, err := json.Marshal(&apmReq)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, Status: model.CANCELLED_BY_PATIENT}}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", webH.UpdateAppointment)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("conflict", func(t *testing.T) {
+		apmReq := model.CreateAppointmentRequest{
+			Date:      int(time.Now().Add(60 * time.Minute).Unix()),
+			PatientId: 1,
+			DoctorId:  1,
+			Approve:   false,
+		}
+		input, err := json.Marshal(&apmReq)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		g, repoTX, _ := mockTx(t, repo)
+		webH := web.WebHandler{Repo: repo, DBConn: g}
+
+		conflictErr := &repository.ErrAppointmentConflict{Appointment: model.Appointment{ID: 7, Date: apmReq.Date, Duration: 30, DoctorID: 1, PatientID: 2}}
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, Date: apmReq.Date, Duration: 45, Status: model.REQUESTED}}, nil).Once()
+		// keep the current duration when it's not specified
+		repoTX.EXPECT().UpdateAppointment(model.Appointment{ID: 1, Date: apmReq.Date, Duration: 45, PatientID: 1, DoctorID: 1}).Return(fmt.Errorf("exec : %w", conflictErr)).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", webH.UpdateAppointment)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("reschedule", func(t *testing.T) {
+		apmReq := model.CreateAppointmentRequest{
+			Date:      int(time.Now().Add(60 * time.Minute).Unix()),
+			PatientId: 1,
+			DoctorId:  1,
+			Approve:   true,
+		}
+		input, err := json.Marshal(&apmReq)
+		assert.NoError(t, err)
+		doctorId := 5
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		noti := notification.NewMockService(t)
+		g, repoTX, tx := mockTx(t, repo)
+		webH := web.WebHandler{Repo: repo, DBConn: g, NotiService: noti}
+
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, Date: apmReq.Date + 60, Status: model.APPROVED}}, nil).Once()
+		repoTX.EXPECT().ChangeAppointmentStatus(1, model.AppointmentStatusChange{
+			Status:    model.RESCHEDULED,
+			Date:      &apmReq.Date,
+			ActorType: model.ACTOR_DOCTOR,
+			ActorID:   &doctorId,
+		}).Return(nil).Once()
+		repoTX.EXPECT().UpdateAppointment(mock.Anything).Return(nil).Once()
+		noti.EXPECT().SendTemplateByPatientId(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe() // go routine
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 5) }, webH.UpdateAppointment)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.True(t, tx.committed)
+	})
 	t.Run("internalError", func(t *testing.T) {
 		apmReq := model.CreateAppointmentRequest{
 			Date:      int(time.Now().Add(60 * time.Minute).Unix()),
@@ -245,6 +413,34 @@ func TestCreateAppointment(t *testing.T) {
 	})
 }
 
+// connection of a mocked transaction that records how it ends
+type fakeTx struct {
+	gorm.ConnPool
+	committed  bool
+	rolledBack bool
+}
+
+func (f *fakeTx) Commit() error {
+	f.committed = true
+	return nil
+}
+
+func (f *fakeTx) Rollback() error {
+	f.rolledBack = true
+	return nil
+}
+
+// begin a mocked transaction, queries in the transaction go to the returned repo
+func mockTx(t *testing.T, repo *repository.MockRepo) (*repository.MockGorm, *repository.MockRepo, *fakeTx) {
+	conn := &fakeTx{}
+	tx := &gorm.DB{Config: &gorm.Config{}, Statement: &gorm.Statement{ConnPool: conn}}
+	g := repository.NewMockGorm(t)
+	g.EXPECT().Begin().Return(tx)
+	repoTX := repository.NewMockRepo(t)
+	repo.EXPECT().New(tx).Return(repoTX)
+	return g, repoTX, conn
+}
+
 func TestUpdateAppointment(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		apmReq := model.CreateAppointmentRequest{
@@ -258,10 +454,12 @@ func TestUpdateAppointment(t *testing.T) {
 		// setup mock
 		repo := repository.NewMockRepo(t)
 		noti := notification.NewMockService(t)
-		webH := web.WebHandler{Repo: repo, NotiService: noti}
+		g, repoTX, tx := mockTx(t, repo)
+		webH := web.WebHandler{Repo: repo, DBConn: g, NotiService: noti}
 
-		repo.EXPECT().UpdateAppointment(mock.Anything).Return(nil).Once()
-		noti.EXPECT().SendNotiByPatientId(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe() // go routine
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, Date: apmReq.Date, Status: model.REQUESTED}}, nil).Once()
+		repoTX.EXPECT().UpdateAppointment(mock.Anything).Return(nil).Once()
+		noti.EXPECT().SendTemplateByPatientId(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe() // go routine
 
 		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(input))
 		recorder := httptest.NewRecorder()
@@ -271,6 +469,7 @@ func TestUpdateAppointment(t *testing.T) {
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 200, recorder.Code)
+		assert.True(t, tx.committed)
 	})
 	t.Run("bindingError", func(t *testing.T) {
 		apmReq := model.CreateAppointmentRequest{
@@ -315,7 +514,7 @@ func TestUpdateAppointment(t *testing.T) {
 
 		assert.Equal(t, 422, recorder.Code)
 	})
-	t.Run("internalError", func(t *testing.T) {
+	t.Run("notFound", func(t *testing.T) {
 		apmReq := model.CreateAppointmentRequest{
 			Date:      int(time.Now().Add(60 * time.Minute).Unix()),
 			PatientId: 1,
@@ -328,7 +527,94 @@ func TestUpdateAppointment(t *testing.T) {
 		repo := repository.NewMockRepo(t)
 		webH := web.WebHandler{Repo: repo}
 
-		repo.EXPECT().UpdateAppointment(mock.Anything).Return(errors.New("some internal err")).Once()
+		mockErr := fmt.Errorf("wrap : %w", gorm.ErrRecordNotFound)
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{}, mockErr).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", webH.UpdateAppointment)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("inactiveAppointment", func(t *testing.T) {
+		apmReq := model.CreateAppointmentRequest{
+			Date:      int(time.Now().Add(60 * time.Minute).Unix()),
+			PatientId: 1,
+			DoctorId:  1,
+			Approve:   false,
+		}
+		input, err := json.Marshal(&apmReq)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, Status: model.CANCELLED_BY_PATIENT}}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", webH.UpdateAppointment)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("reschedule", func(t *testing.T) {
+		apmReq := model.CreateAppointmentRequest{
+			Date:      int(time.Now().Add(60 * time.Minute).Unix()),
+			PatientId: 1,
+			DoctorId:  1,
+			Approve:   true,
+		}
+		input, err := json.Marshal(&apmReq)
+		assert.NoError(t, err)
+		doctorId := 5
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		noti := notification.NewMockService(t)
+		g, repoTX, tx := mockTx(t, repo)
+		webH := web.WebHandler{Repo: repo, DBConn: g, NotiService: noti}
+
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, Date: apmReq.Date + 60, Status: model.APPROVED}}, nil).Once()
+		repoTX.EXPECT().ChangeAppointmentStatus(1, model.AppointmentStatusChange{
+			Status:    model.RESCHEDULED,
+			Date:      &apmReq.Date,
+			ActorType: model.ACTOR_DOCTOR,
+			ActorID:   &doctorId,
+		}).Return(nil).Once()
+		repoTX.EXPECT().UpdateAppointment(mock.Anything).Return(nil).Once()
+		noti.EXPECT().SendTemplateByPatientId(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe() // go routine
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 5) }, webH.UpdateAppointment)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.True(t, tx.committed)
+	})
+	t.Run("internalError", func(t *testing.T) {
+		apmReq := model.CreateAppointmentRequest{
+			Date:      int(time.Now().Add(60 * time.Minute).Unix()),
+			PatientId: 1,
+			DoctorId:  1,
+			Approve:   false,
+		}
+		input, err := json.Marshal(&apmReq)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		g, repoTX, _ := mockTx(t, repo)
+		webH := web.WebHandler{Repo: repo, DBConn: g}
+
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, Date: apmReq.Date, Status: model.REQUESTED}}, nil).Once()
+		repoTX.EXPECT().UpdateAppointment(mock.Anything).Return(errors.New("some internal err")).Once()
 
 		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(input))
 		recorder := httptest.NewRecorder()
@@ -339,14 +625,64 @@ func TestUpdateAppointment(t *testing.T) {
 
 		assert.Equal(t, 500, recorder.Code)
 	})
+	t.Run("rescheduleUpdateError", func(t *testing.T) {
+		apmReq := model.CreateAppointmentRequest{
+			Date:      int(time.Now().Add(60 * time.Minute).Unix()),
+			PatientId: 1,
+			DoctorId:  1,
+		}
+		input, err := json.Marshal(&apmReq)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		g, repoTX, tx := mockTx(t, repo)
+		webH := web.WebHandler{Repo: repo, DBConn: g}
+
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, Date: apmReq.Date + 60, Status: model.APPROVED}}, nil).Once()
+		repoTX.EXPECT().ChangeAppointmentStatus(1, mock.Anything).Return(nil).Once()
+		repoTX.EXPECT().UpdateAppointment(mock.Anything).Return(errors.New("some internal err")).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 5) }, webH.UpdateAppointment)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 500, recorder.Code)
+		// the status change is not kept without the update
+		assert.True(t, tx.rolledBack)
+		assert.False(t, tx.committed)
+	})
 }
 
 func TestDeleteAppointment(t *testing.T) {
+	t.Run("withReason", func(t *testing.T) {
+		reason := "doctor is on leave"
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		noti := notification.NewMockService(t)
+		webH := web.WebHandler{Repo: repo, NotiService: noti}
+
+		repo.EXPECT().GetAppointment("1").Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, PatientID: 2, Status: model.APPROVED}}, nil).Once()
+		repo.EXPECT().ChangeAppointmentStatus(1, mock.Anything).Return(nil).Once()
+		noti.EXPECT().SendTemplateByPatientId(2, model.TEMPLATE_APPOINTMENT_CANCELLED, model.TemplateParams{"reason": reason}, model.AppointmentLink(1)).Return(nil).Maybe() // go routine
+
+		req := httptest.NewRequest(http.MethodDelete, "/1?reason=doctor+is+on+leave", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.DELETE("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 1) }, webH.DeleteAppointment)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 204, recorder.Code)
+	})
 	t.Run("success", func(t *testing.T) {
 		apm := model.SafeAppointment{
 			Appointment: model.Appointment{
-				ID: 1,
+				ID:        1,
 				PatientID: 2,
+				Status:    model.APPROVED,
 			},
 		}
 		// setup mock
@@ -355,14 +691,14 @@ func TestDeleteAppointment(t *testing.T) {
 		webH := web.WebHandler{Repo: repo, NotiService: noti}
 
 		repo.EXPECT().GetAppointment("1").Return(apm, nil).Once()
-		repo.EXPECT().DeleteAppointment("1").Return(nil).Once()
-		noti.EXPECT().SendNotiByPatientId(2, mock.Anything, mock.Anything).Return(nil).Maybe() // go routine
+		repo.EXPECT().ChangeAppointmentStatus(1, mock.Anything).Return(nil).Once()
+		noti.EXPECT().SendTemplateByPatientId(2, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe() // go routine
 
 		req := httptest.NewRequest(http.MethodDelete, "/1", nil)
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.DELETE("/:id", webH.DeleteAppointment)
+		router.DELETE("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 1) }, webH.DeleteAppointment)
 		router.ServeHTTP(recorder, req) // work around for ctx.Status() not setting status code when testing
 
 		assert.Equal(t, 204, recorder.Code)
@@ -404,8 +740,9 @@ func TestDeleteAppointment(t *testing.T) {
 	t.Run("deleteInternalError", func(t *testing.T) {
 		apm := model.SafeAppointment{
 			Appointment: model.Appointment{
-				ID: 1,
+				ID:        1,
 				PatientID: 2,
+				Status:    model.APPROVED,
 			},
 		}
 		// setup mock
@@ -413,15 +750,284 @@ func TestDeleteAppointment(t *testing.T) {
 		webH := web.WebHandler{Repo: repo}
 
 		repo.EXPECT().GetAppointment("1").Return(apm, nil).Once()
-		repo.EXPECT().DeleteAppointment("1").Return(errors.New("some internal error")).Once()
+		repo.EXPECT().ChangeAppointmentStatus(1, mock.Anything).Return(errors.New("some internal error")).Once()
 
 		req := httptest.NewRequest(http.MethodDelete, "/1", nil)
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.DELETE("/:id", webH.DeleteAppointment)
+		router.DELETE("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 1) }, webH.DeleteAppointment)
 		router.ServeHTTP(recorder, req) // work around for ctx.Status() not setting status code when testing
 
 		assert.Equal(t, 500, recorder.Code)
 	})
 }
+
+func TestUpdateAppointmentStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("bindingError", func(t *testing.T) {
+		input, err := json.Marshal(gin.H{"status": "requested"})
+		assert.NoError(t, err)
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", webH.UpdateAppointmentStatus)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("rejectWithoutReason", func(t *testing.T) {
+		input, err := json.Marshal(model.UpdateAppointmentStatusRequest{Status: model.REJECTED})
+		assert.NoError(t, err)
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", webH.UpdateAppointmentStatus)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("notFound", func(t *testing.T) {
+		input, err := json.Marshal(model.UpdateAppointmentStatusRequest{Status: model.APPROVED})
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		mockErr := fmt.Errorf("wrap : %w", gorm.ErrRecordNotFound)
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{}, mockErr).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", webH.UpdateAppointmentStatus)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("invalidTransition", func(t *testing.T) {
+		input, err := json.Marshal(model.UpdateAppointmentStatusRequest{Status: model.APPROVED})
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		mockErr := fmt.Errorf("exec : %w", repository.ErrInvalidStatusTransition)
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, Status: model.COMPLETED}}, nil).Once()
+		repo.EXPECT().ChangeAppointmentStatus(1, mock.Anything).Return(mockErr).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 1) }, webH.UpdateAppointmentStatus)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		reason := "doctor is not available"
+		input, err := json.Marshal(model.UpdateAppointmentStatusRequest{Status: model.REJECTED, Reason: &reason})
+		assert.NoError(t, err)
+		doctorId := 1
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		noti := notification.NewMockService(t)
+		webH := web.WebHandler{Repo: repo, NotiService: noti}
+
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, PatientID: 2, Status: model.REQUESTED}}, nil).Once()
+		repo.EXPECT().ChangeAppointmentStatus(1, model.AppointmentStatusChange{
+			Status:    model.REJECTED,
+			Reason:    &reason,
+			ActorType: model.ACTOR_DOCTOR,
+			ActorID:   &doctorId,
+		}).Return(nil).Once()
+		noti.EXPECT().SendTemplateByPatientId(2, model.TEMPLATE_APPOINTMENT_REJECTED, model.TemplateParams{"reason": reason}, model.AppointmentLink(1)).Return(nil).Maybe() // go routine
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 1) }, webH.UpdateAppointmentStatus)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestGetAppointmentHistory(t *testing.T) {
+	t.Run("notInCareTeam", func(t *testing.T) {
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAppointment("1").Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, PatientID: 2}}, nil).Once()
+		repo.EXPECT().GetCareTeamMember(2, 3).Return(model.CareTeamMember{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound)).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/1", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3); ctx.Set("doctorRole", model.USER) }, webH.GetAppointmentHistory)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAppointment("1").Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, PatientID: 2}}, nil).Once()
+		repo.EXPECT().GetAppointmentHistory(1).Return([]model.AppointmentHistory{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/1", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.GetAppointmentHistory)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestGetAppointmentReport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("atoiError", func(t *testing.T) {
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/?from=abc", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", webH.GetAppointmentReport)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		counts := []model.AppointmentStatusCount{{Status: model.COMPLETED, Count: 8}, {Status: model.NO_SHOW, Count: 2}}
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAppointmentStatusCount([]repository.Criteria{
+			{QueryCriteria: repository.DOCTORID, Value: 3},
+			{QueryCriteria: repository.DATE_GREATERTHAN, Value: 100},
+		}).Return(counts, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/?doctorId=3&from=100", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", webH.GetAppointmentReport)
+		router.ServeHTTP(recorder, req)
+
+		expectRespBody, err := json.Marshal(counts)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, expectRespBody, recorder.Body.Bytes())
+	})
+}
+
+func TestResolveRescheduleRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("atoiError", func(t *testing.T) {
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodPut, "/abc", bytes.NewReader([]byte(`{"accept":true}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", webH.ResolveRescheduleRequest)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("noPendingRequest", func(t *testing.T) {
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		mockErr := fmt.Errorf("exec : %w", gorm.ErrRecordNotFound)
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, PatientID: 2, Status: model.APPROVED}}, nil).Once()
+		repo.EXPECT().ResolveRescheduleRequest(1, true, (*string)(nil), 5).Return(model.RescheduleRequest{}, mockErr).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader([]byte(`{"accept":true}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 5) }, webH.ResolveRescheduleRequest)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("conflict", func(t *testing.T) {
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		conflictErr := &repository.ErrAppointmentConflict{Appointment: model.Appointment{ID: 7, Date: 1000, Duration: 30, DoctorID: 1, PatientID: 3}}
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, PatientID: 2, Status: model.APPROVED}}, nil).Once()
+		repo.EXPECT().ResolveRescheduleRequest(1, true, (*string)(nil), 5).Return(model.RescheduleRequest{}, fmt.Errorf("exec : %w", conflictErr)).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader([]byte(`{"accept":true}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 5) }, webH.ResolveRescheduleRequest)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("accept", func(t *testing.T) {
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		noti := notification.NewMockService(t)
+		webH := web.WebHandler{Repo: repo, NotiService: noti}
+
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, PatientID: 2, Status: model.APPROVED}}, nil).Once()
+		repo.EXPECT().ResolveRescheduleRequest(1, true, (*string)(nil), 5).Return(model.RescheduleRequest{ID: 3, Status: model.RESCHEDULE_ACCEPTED}, nil).Once()
+		noti.EXPECT().SendTemplateByPatientId(2, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe() // go routine
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader([]byte(`{"accept":true}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 5) }, webH.ResolveRescheduleRequest)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+	t.Run("decline", func(t *testing.T) {
+		reason := "doctor is on leave"
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		noti := notification.NewMockService(t)
+		webH := web.WebHandler{Repo: repo, NotiService: noti}
+
+		repo.EXPECT().GetAppointment(1).Return(model.SafeAppointment{Appointment: model.Appointment{ID: 1, PatientID: 2, Status: model.APPROVED}}, nil).Once()
+		repo.EXPECT().ResolveRescheduleRequest(1, false, &reason, 5).Return(model.RescheduleRequest{ID: 3, Status: model.RESCHEDULE_DECLINED}, nil).Once()
+		noti.EXPECT().SendTemplateByPatientId(2, model.TEMPLATE_RESCHEDULE_DECLINED, model.TemplateParams{"reason": reason}, model.AppointmentLink(1)).Return(nil).Maybe() // go routine
+
+		input, err := json.Marshal(model.ResolveRescheduleRequest{Accept: false, Reason: &reason})
+		assert.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 5) }, webH.ResolveRescheduleRequest)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
@@ -0,0 +1,187 @@
+package web_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PhasitWo/duchenne-server/handlers/web"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/notification"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestPreviewCampaign(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("badAgeRange", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"segment":{"minAge":10,"maxAge":5}}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", webH.PreviewCampaign)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetSegmentPatientIds(mock.MatchedBy(func(s model.CampaignSegment) bool {
+			return *s.DoctorID == 2 && *s.MinAge == 5 && s.MaxAge == nil && s.UpcomingDays == nil
+		}), mock.Anything).Return([]int{1, 3, 4}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"segment":{"doctorId":2,"minAge":5}}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", webH.PreviewCampaign)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.JSONEq(t, `{"count":3}`, recorder.Body.String())
+	})
+}
+
+func TestCreateCampaign(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("badInput", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"title":"","body":"body"}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("doctorId", 1) }, webH.CreateCampaign)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("scheduled", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		noti := notification.NewMockService(t)
+		webH := web.WebHandler{Repo: repo, NotiService: noti}
+
+		repo.EXPECT().CreateCampaign(mock.MatchedBy(func(c model.Campaign) bool {
+			return c.Title == "title" && c.ScheduledAt == 4102444800 && c.Status == model.CAMPAIGN_SCHEDULED &&
+				c.CreateByID == 1 && *c.Segment.Data().UpcomingDays == 7
+		})).Return(5, nil).Once()
+
+		input := `{"title":"title","body":"body","segment":{"upcomingDays":7},"scheduledAt":4102444800}`
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("doctorId", 1) }, webH.CreateCampaign)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+		assert.JSONEq(t, `{"id":5}`, recorder.Body.String())
+	})
+	t.Run("sendNow", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		noti := notification.NewMockService(t)
+		webH := web.WebHandler{Repo: repo, NotiService: noti}
+
+		repo.EXPECT().CreateCampaign(mock.Anything).Return(5, nil).Once()
+		noti.EXPECT().SendDueCampaigns().Return(nil).Maybe() // go routine
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"title":"title","body":"body"}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("doctorId", 1) }, webH.CreateCampaign)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+	})
+}
+
+func TestGetCampaignStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("notFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetCampaign(1).Return(model.Campaign{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodGet, "/1", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", webH.GetCampaignStats)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetCampaign(1).Return(model.Campaign{ID: 1, Status: model.CAMPAIGN_SENT, RecipientCount: 10}, nil)
+		repo.EXPECT().GetCampaignStats(1).Return(model.CampaignStats{
+			ReadCount: 4,
+			Outbox:    []model.OutboxStatusCount{{Status: model.OUTBOX_DELIVERED, Count: 7}, {Status: model.OUTBOX_FAILED, Count: 1}},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/1", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", webH.GetCampaignStats)
+		router.ServeHTTP(recorder, req)
+
+		expectRespBody, err := json.Marshal(model.CampaignStats{
+			RecipientCount: 10,
+			ReadCount:      4,
+			Outbox:         []model.OutboxStatusCount{{Status: model.OUTBOX_DELIVERED, Count: 7}, {Status: model.OUTBOX_FAILED, Count: 1}},
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, expectRespBody, recorder.Body.Bytes())
+	})
+}
+
+func TestCancelCampaign(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"success", nil, 204},
+		{"notFound", fmt.Errorf("exec : %w", gorm.ErrRecordNotFound), 404},
+		{"alreadySent", fmt.Errorf("exec : %w", repository.ErrInvalidStatusTransition), 409},
+		{"internalError", errors.New("err"), 500},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := repository.NewMockRepo(t)
+			webH := web.WebHandler{Repo: repo}
+
+			repo.EXPECT().CancelCampaign(1).Return(tc.err)
+
+			req := httptest.NewRequest(http.MethodDelete, "/1", nil)
+			recorder := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(recorder)
+
+			router.DELETE("/:id", webH.CancelCampaign)
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected, recorder.Code)
+		})
+	}
+}
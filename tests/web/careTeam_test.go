@@ -0,0 +1,310 @@
+package web_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PhasitWo/duchenne-server/handlers/web"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAddPatientCareTeamMember(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	body := `{"doctorId":3,"role":"neurologist"}`
+	t.Run("invalidRole", func(t *testing.T) {
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewReader([]byte(`{"doctorId":3,"role":"surgeon"}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", webH.AddPatientCareTeamMember)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("patientNotFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound)).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewReader([]byte(body)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", webH.AddPatientCareTeamMember)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("signupPending", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1, VerificationStatus: model.SIGNUP_PENDING}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewReader([]byte(body)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", webH.AddPatientCareTeamMember)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("alreadyInTeam", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1, VerificationStatus: model.SIGNUP_APPROVED}, nil).Once()
+		repo.EXPECT().CreateCareTeamMember(model.CareTeamMember{PatientID: 1, DoctorID: 3, Role: model.CARE_NEUROLOGIST}).
+			Return(-1, fmt.Errorf("exec : %w", repository.ErrDuplicateEntry)).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewReader([]byte(body)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", webH.AddPatientCareTeamMember)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("doctorNotFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1, VerificationStatus: model.SIGNUP_APPROVED}, nil).Once()
+		repo.EXPECT().CreateCareTeamMember(model.CareTeamMember{PatientID: 1, DoctorID: 3, Role: model.CARE_NEUROLOGIST}).
+			Return(-1, fmt.Errorf("exec : %w", repository.ErrForeignKeyFail)).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewReader([]byte(body)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", webH.AddPatientCareTeamMember)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1, VerificationStatus: model.SIGNUP_APPROVED}, nil).Once()
+		repo.EXPECT().CreateCareTeamMember(model.CareTeamMember{PatientID: 1, DoctorID: 3, Role: model.CARE_NEUROLOGIST}).Return(5, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewReader([]byte(body)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", webH.AddPatientCareTeamMember)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+		assert.JSONEq(t, `{"id":5}`, recorder.Body.String())
+	})
+}
+
+func TestUpdatePatientCareTeamMember(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("notInTeam", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetCareTeamMember("1", "3").Return(model.CareTeamMember{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound)).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/1/3", bytes.NewReader([]byte(`{"role":"coordinator"}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id/:doctorId", webH.UpdatePatientCareTeamMember)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		member := model.CareTeamMember{ID: 5, PatientID: 1, DoctorID: 3, Role: model.CARE_NEUROLOGIST}
+		repo.EXPECT().GetCareTeamMember("1", "3").Return(member, nil).Once()
+		member.Role = model.CARE_COORDINATOR
+		repo.EXPECT().UpdateCareTeamMember(member).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/1/3", bytes.NewReader([]byte(`{"role":"coordinator"}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id/:doctorId", webH.UpdatePatientCareTeamMember)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestRemovePatientCareTeamMember(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := repository.NewMockRepo(t)
+	webH := web.WebHandler{Repo: repo}
+
+	repo.EXPECT().GetCareTeamMember("1", "3").Return(model.CareTeamMember{ID: 5, PatientID: 1, DoctorID: 3}, nil).Once()
+	repo.EXPECT().DeleteCareTeamMember(5).Return(nil).Once()
+
+	req := httptest.NewRequest(http.MethodDelete, "/1/3", nil)
+	recorder := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(recorder)
+
+	router.DELETE("/:id/:doctorId", webH.RemovePatientCareTeamMember)
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, 204, recorder.Code)
+}
+
+func TestGetAllDoctorNotification(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := repository.NewMockRepo(t)
+	webH := web.WebHandler{Repo: repo}
+
+	repo.EXPECT().GetAllDoctorNotification(100, 0, []repository.Criteria{
+		{QueryCriteria: repository.DOCTORID, Value: 3},
+		{QueryCriteria: repository.READAT_ISNULL},
+	}).Return([]model.DoctorNotification{model.NewDoctorNotification(3, 1, "title", "body", model.QuestionLink(9))}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/?limit=100&unread=true", nil)
+	recorder := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(recorder)
+
+	router.GET("/", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.GetAllDoctorNotification)
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, 200, recorder.Code)
+}
+
+func TestReadDoctorNotification(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := repository.NewMockRepo(t)
+	webH := web.WebHandler{Repo: repo}
+
+	// notification of another doctor
+	repo.EXPECT().ReadDoctorNotification(3, "7").Return(fmt.Errorf("query : %w", gorm.ErrRecordNotFound)).Once()
+
+	req := httptest.NewRequest(http.MethodPut, "/7", nil)
+	recorder := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(recorder)
+
+	router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.ReadDoctorNotification)
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, 404, recorder.Code)
+}
+
+func TestMyPatientEnforcement(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	asUser := func(ctx *gin.Context) { ctx.Set("doctorId", 3); ctx.Set("doctorRole", model.USER) }
+	t.Run("userCannotListAll", func(t *testing.T) {
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/?scope=all", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", asUser, webH.GetAllPatient)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 403, recorder.Code)
+	})
+	t.Run("userNotInCareTeam", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById("1").Return(model.Patient{ID: 1}, nil).Once()
+		repo.EXPECT().GetCareTeamMember(1, 3).Return(model.CareTeamMember{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound)).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/1", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", asUser, webH.GetPatient)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("userInCareTeam", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion("5").Return(model.SafeQuestion{Question: model.Question{ID: 5, PatientID: 1}}, nil).Once()
+		repo.EXPECT().GetCareTeamMember(1, 3).Return(model.CareTeamMember{ID: 2}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/5", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", asUser, webH.GetQuestion)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+	repo := repository.NewMockRepo(t)
+	webH := web.WebHandler{Repo: repo}
+	patientRoutes := []struct {
+		name    string
+		handler gin.HandlerFunc
+	}{
+		{name: "measurement", handler: webH.GetAllPatientMeasurement},
+		{name: "medication", handler: webH.GetAllPatientMedication},
+		{name: "adherence", handler: webH.GetPatientAdherence},
+		{name: "vaccination", handler: webH.GetAllPatientVaccination},
+		{name: "careTeam", handler: webH.GetPatientCareTeam},
+		{name: "caregiver", handler: webH.GetPatientCaregiver},
+		{name: "deviceRemoval", handler: webH.GetPatientDeviceRemoval},
+	}
+	for _, tc := range patientRoutes {
+		t.Run("userNotInCareTeam/"+tc.name, func(t *testing.T) {
+			repo.EXPECT().GetCareTeamMember(1, 3).Return(model.CareTeamMember{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound)).Once()
+
+			req := httptest.NewRequest(http.MethodGet, "/1", nil)
+			recorder := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(recorder)
+
+			router.GET("/:id", asUser, tc.handler)
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, 404, recorder.Code)
+		})
+	}
+	questionRoutes := []struct {
+		name    string
+		body    string
+		handler gin.HandlerFunc
+	}{
+		{name: "answer", body: `{"answer":"rest more"}`, handler: webH.AnswerQuestion},
+		{name: "message", body: `{"message":"how is the pain today?"}`, handler: webH.CreateQuestionMessage},
+		{name: "read", handler: webH.ReadQuestion},
+		{name: "close", handler: webH.CloseQuestion},
+	}
+	for _, tc := range questionRoutes {
+		t.Run("userNotInCareTeam/"+tc.name, func(t *testing.T) {
+			repo.EXPECT().GetQuestion(5).Return(model.SafeQuestion{Question: model.Question{ID: 5, PatientID: 1}}, nil).Maybe()
+			repo.EXPECT().GetQuestion("5").Return(model.SafeQuestion{Question: model.Question{ID: 5, PatientID: 1}}, nil).Maybe()
+			repo.EXPECT().GetCareTeamMember(1, 3).Return(model.CareTeamMember{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound)).Once()
+
+			req := httptest.NewRequest(http.MethodPut, "/5", bytes.NewReader([]byte(tc.body)))
+			recorder := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(recorder)
+
+			router.PUT("/:id", asUser, tc.handler)
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, 404, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package web_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PhasitWo/duchenne-server/handlers/web"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestGetAllPatientMeasurement(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("invalidType", func(t *testing.T) {
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/1?type=glucose", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.GetAllPatientMeasurement)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAllMeasurement(1, []repository.Criteria{
+			{QueryCriteria: repository.TYPE, Value: "nsaa"},
+			{QueryCriteria: repository.MEASURED_AFTER, Value: 99},
+		}).Return([]model.Measurement{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/1?type=nsaa&from=100", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.GetAllPatientMeasurement)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestCreatePatientMeasurement(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("wrongUnit", func(t *testing.T) {
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewReader([]byte(`{"type":"weight","value":70,"unit":"lb","measuredAt":1700000000}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.CreatePatientMeasurement)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("patientNotFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{}, fmt.Errorf("exec : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewReader([]byte(`{"type":"nsaa","value":22,"measuredAt":1700000000}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.CreatePatientMeasurement)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		note := "after steroid"
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1}, nil)
+		repo.EXPECT().CreateMeasurement(model.Measurement{
+			PatientID:  1,
+			Type:       model.MEASURE_NSAA,
+			Value:      22,
+			Unit:       "score",
+			MeasuredAt: 1700000000,
+			DoctorID:   3,
+			Note:       &note,
+		}).Return(7, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewReader([]byte(`{"type":"nsaa","value":22,"measuredAt":1700000000,"note":"after steroid"}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.CreatePatientMeasurement)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+		assert.JSONEq(t, `{"id":7}`, recorder.Body.String())
+	})
+}
+
+func TestUpdatePatientMeasurement(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("notFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetMeasurement("1", "7").Return(model.Measurement{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodPut, "/1/7", bytes.NewReader([]byte(`{"value":71,"measuredAt":1700000000}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id/:measurementId", webH.UpdatePatientMeasurement)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetMeasurement("1", "7").Return(model.Measurement{ID: 7, PatientID: 1, Type: model.MEASURE_WEIGHT, Value: 70, Unit: "kg", MeasuredAt: 1600000000, DoctorID: 4}, nil)
+		repo.EXPECT().UpdateMeasurement(model.Measurement{ID: 7, PatientID: 1, Type: model.MEASURE_WEIGHT, Value: 71, Unit: "kg", MeasuredAt: 1700000000, DoctorID: 4}).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/1/7", bytes.NewReader([]byte(`{"value":71,"measuredAt":1700000000}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id/:measurementId", webH.UpdatePatientMeasurement)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestDeletePatientMeasurement(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := repository.NewMockRepo(t)
+	webH := web.WebHandler{Repo: repo}
+
+	measurement := model.Measurement{ID: 7, PatientID: 1, Type: model.MEASURE_HEIGHT}
+	repo.EXPECT().GetMeasurement("1", "7").Return(measurement, nil)
+	repo.EXPECT().DeleteMeasurement(measurement).Return(nil).Once()
+
+	req := httptest.NewRequest(http.MethodDelete, "/1/7", nil)
+	recorder := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(recorder)
+
+	router.DELETE("/:id/:measurementId", webH.DeletePatientMeasurement)
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, 204, recorder.Code)
+}
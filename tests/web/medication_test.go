@@ -0,0 +1,255 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PhasitWo/duchenne-server/handlers/web"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestGetAllPatientMedication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("invalidStatus", func(t *testing.T) {
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/1?status=paused", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.GetAllPatientMedication)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("active", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAllMedicationRegimen(1, []repository.Criteria{{QueryCriteria: repository.ENDDATE_ISNULL}}).Return([]model.MedicationRegimen{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/1?status=active", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.GetAllPatientMedication)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestCreatePatientMedication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("invalidUnit", func(t *testing.T) {
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewReader([]byte(`{"medicineName":"deflazacort","dose":18,"doseUnit":"spoon","schedule":"onceDaily","startDate":1700000000}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.CreatePatientMedication)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		startDate := 1700000000
+		prescriberId := 3
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1}, nil)
+		repo.EXPECT().CreateMedicationRegimen(model.MedicationRegimen{
+			PatientID:    1,
+			MedicineName: "deflazacort",
+			Dose:         18,
+			DoseUnit:     model.DOSE_MG,
+			Schedule:     model.SCHEDULE_ONCE_DAILY,
+			StartDate:    &startDate,
+			PrescriberID: &prescriberId,
+		}).Return(4, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewReader([]byte(`{"medicineName":"deflazacort","dose":18,"doseUnit":"mg","schedule":"onceDaily","startDate":1700000000}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.CreatePatientMedication)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+		assert.JSONEq(t, `{"id":4}`, recorder.Body.String())
+	})
+}
+
+func TestChangePatientMedication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	startDate := 1700000000
+	current := model.MedicationRegimen{ID: 4, PatientID: 1, MedicineName: "prednisolone", Dose: 20, DoseUnit: model.DOSE_MG, StartDate: &startDate}
+	body := `{"dose":15,"doseUnit":"mg","schedule":"onceDaily","startDate":1710000000,"reason":"weight gain"}`
+	t.Run("notFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetMedicationRegimen("1", "4").Return(model.MedicationRegimen{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodPost, "/1/4", bytes.NewReader([]byte(body)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id/:regimenId", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.ChangePatientMedication)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("beforeCurrentStart", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetMedicationRegimen("1", "4").Return(current, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/1/4", bytes.NewReader([]byte(`{"dose":15,"doseUnit":"mg","schedule":"onceDaily","startDate":1600000000,"reason":"weight gain"}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id/:regimenId", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.ChangePatientMedication)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("ended", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetMedicationRegimen("1", "4").Return(current, nil)
+		repo.EXPECT().ChangeMedicationRegimen(4, mock.Anything).Return(-1, fmt.Errorf("exec : %w", repository.ErrRegimenEnded))
+
+		req := httptest.NewRequest(http.MethodPost, "/1/4", bytes.NewReader([]byte(body)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id/:regimenId", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.ChangePatientMedication)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetMedicationRegimen("1", "4").Return(current, nil)
+		repo.EXPECT().ChangeMedicationRegimen(4, mock.MatchedBy(func(next model.MedicationRegimen) bool {
+			return next.PatientID == 1 && next.MedicineName == "prednisolone" && next.Dose == 15 &&
+				*next.StartDate == 1710000000 && *next.Reason == "weight gain" && *next.PrescriberID == 3
+		})).Return(5, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/1/4", bytes.NewReader([]byte(body)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id/:regimenId", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.ChangePatientMedication)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+		assert.JSONEq(t, `{"id":5}`, recorder.Body.String())
+	})
+}
+
+func TestStopPatientMedication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := repository.NewMockRepo(t)
+	webH := web.WebHandler{Repo: repo}
+
+	repo.EXPECT().GetMedicationRegimen("1", "4").Return(model.MedicationRegimen{ID: 4, PatientID: 1}, nil)
+	repo.EXPECT().StopMedicationRegimen(4, 1710000000, "side effects").Return(nil).Once()
+
+	req := httptest.NewRequest(http.MethodPut, "/1/4", bytes.NewReader([]byte(`{"endDate":1710000000,"reason":"side effects"}`)))
+	recorder := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(recorder)
+
+	router.PUT("/:id/:regimenId", webH.StopPatientMedication)
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, 200, recorder.Code)
+}
+
+func TestGetPatientAdherence(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("invalidWeeks", func(t *testing.T) {
+		webH := web.WebHandler{Repo: repository.NewMockRepo(t)}
+
+		req := httptest.NewRequest(http.MethodGet, "/1?weeks=60", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.GetPatientAdherence)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAllMedicationRegimen(1).Return([]model.MedicationRegimen{
+			{ID: 4, Schedule: model.SCHEDULE_ONCE_DAILY},
+		}, nil).Once()
+		repo.EXPECT().GetAllMedicationDose(1, mock.Anything, mock.Anything).Return([]model.MedicationDose{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/1?weeks=4", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.GetPatientAdherence)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		var weeks []model.WeeklyAdherence
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &weeks))
+		assert.Len(t, weeks, 4)
+		// nothing logged
+		assert.Equal(t, 7, weeks[0].Expected)
+		assert.Equal(t, 0.0, *weeks[0].Percent)
+	})
+}
+
+func TestDeletePatientMedication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name     string
+		mockErr  error
+		expected int
+	}{
+		{name: "replacedByLaterRegimen", mockErr: fmt.Errorf("exec : %w", repository.ErrRegimenReplaced), expected: 409},
+		{name: "hasLoggedDoses", mockErr: fmt.Errorf("exec : %w", repository.ErrRegimenHasDoses), expected: 409},
+		{name: "success", expected: 204},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := repository.NewMockRepo(t)
+			webH := web.WebHandler{Repo: repo}
+
+			regimen := model.MedicationRegimen{ID: 4, PatientID: 1}
+			repo.EXPECT().GetMedicationRegimen("1", "4").Return(regimen, nil).Once()
+			repo.EXPECT().DeleteMedicationRegimen(regimen).Return(tc.mockErr).Once()
+
+			req := httptest.NewRequest(http.MethodDelete, "/1/4", nil)
+			recorder := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(recorder)
+
+			router.DELETE("/:id/:regimenId", webH.DeletePatientMedication)
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expected, recorder.Code)
+		})
+	}
+}
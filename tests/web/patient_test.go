@@ -37,7 +37,7 @@ func TestGetOnPatient(t *testing.T) {
 		expectRespBody, err := json.Marshal(&patient)
 		assert.NoError(t, err)
 
-		router.GET("/:id", webH.GetPatient)
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.GetPatient)
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 200, recorder.Code)
@@ -85,13 +85,16 @@ func TestGetAllPatient(t *testing.T) {
 		repo := repository.NewMockRepo(t)
 		webH := web.WebHandler{Repo: repo}
 
-		repo.EXPECT().GetAllPatient(mock.Anything, mock.Anything).Return([]model.Patient{}, nil).Once()
+		repo.EXPECT().GetAllPatient(mock.Anything, mock.Anything, []repository.Criteria{
+			{QueryCriteria: repository.VERIFICATION, Value: model.SIGNUP_APPROVED},
+			{QueryCriteria: repository.MY_PATIENT, Value: 3},
+		}).Return([]model.Patient{}, nil).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.GET("/", webH.GetAllPatient)
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.GetAllPatient)
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 200, recorder.Code)
@@ -101,13 +104,15 @@ func TestGetAllPatient(t *testing.T) {
 		repo := repository.NewMockRepo(t)
 		webH := web.WebHandler{Repo: repo}
 
-		repo.EXPECT().GetAllPatient(mock.Anything, mock.Anything).Return([]model.Patient{}, errors.New("some internal error")).Once()
+		repo.EXPECT().GetAllPatient(mock.Anything, mock.Anything, []repository.Criteria{
+			{QueryCriteria: repository.VERIFICATION, Value: model.SIGNUP_APPROVED},
+		}).Return([]model.Patient{}, errors.New("some internal error")).Once()
 
-		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req := httptest.NewRequest(http.MethodGet, "/?scope=all", nil)
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.GET("/", webH.GetAllPatient)
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.GetAllPatient)
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 500, recorder.Code)
@@ -497,254 +502,73 @@ func TestDeletePatient(t *testing.T) {
 	})
 }
 func TestUpdatePatientVaccineHistory(t *testing.T) {
-	t.Run("bindingError", func(t *testing.T) {
-		input := model.UpdateVaccineHistoryRequest{
-			Data: []model.VaccineHistory{{Id: "test", VaccineName: "hello", VaccineAt: 0}}, // error require
-		}
-		rawInput, err := json.Marshal(&input)
-		assert.NoError(t, err)
-		// setup mock
-		webH := web.WebHandler{}
-
-		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(rawInput))
-		recorder := httptest.NewRecorder()
-		_, router := gin.CreateTestContext(recorder)
-
-		router.PUT("/:id", webH.UpdatePatientVaccineHistory)
-		router.ServeHTTP(recorder, req)
-
-		assert.Equal(t, 400, recorder.Code)
-	})
-	t.Run("atoiError", func(t *testing.T) {
-		input := model.UpdateVaccineHistoryRequest{
-			Data: []model.VaccineHistory{{Id: "test", VaccineName: "hello", VaccineAt: 123}},
-		}
-		rawInput, err := json.Marshal(&input)
-		assert.NoError(t, err)
-		// setup mock
-		webH := web.WebHandler{}
-
-		req := httptest.NewRequest(http.MethodPut, "/asd", bytes.NewReader(rawInput))
-		recorder := httptest.NewRecorder()
-		_, router := gin.CreateTestContext(recorder)
-
-		router.PUT("/:id", webH.UpdatePatientVaccineHistory)
-		router.ServeHTTP(recorder, req)
-
-		assert.Equal(t, 400, recorder.Code)
-	})
-	t.Run("atoiError", func(t *testing.T) {
-		input := model.UpdateVaccineHistoryRequest{
-			Data: []model.VaccineHistory{{Id: "test", VaccineName: "hello", VaccineAt: 123}},
-		}
-		rawInput, err := json.Marshal(&input)
-		assert.NoError(t, err)
-		// setup mock
-		repo := repository.NewMockRepo(t)
-		webH := web.WebHandler{Repo: repo}
-
-		mockErr := fmt.Errorf("wrap : %w", gorm.ErrRecordNotFound)
-		repo.EXPECT().GetPatientById(1).Return(model.Patient{}, mockErr).Once()
-
-		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(rawInput))
-		recorder := httptest.NewRecorder()
-		_, router := gin.CreateTestContext(recorder)
-
-		router.PUT("/:id", webH.UpdatePatientVaccineHistory)
-		router.ServeHTTP(recorder, req)
-
-		assert.Equal(t, 404, recorder.Code)
-	})
-	t.Run("getPatientInternalError", func(t *testing.T) {
-		input := model.UpdateVaccineHistoryRequest{
-			Data: []model.VaccineHistory{{Id: "test", VaccineName: "hello", VaccineAt: 123}},
-		}
-		rawInput, err := json.Marshal(&input)
-		assert.NoError(t, err)
-		// setup mock
-		repo := repository.NewMockRepo(t)
-		webH := web.WebHandler{Repo: repo}
-
-		mockErr := fmt.Errorf("wrap : %w", errors.New("some internal error"))
-		repo.EXPECT().GetPatientById(1).Return(model.Patient{}, mockErr).Once()
-
-		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(rawInput))
-		recorder := httptest.NewRecorder()
-		_, router := gin.CreateTestContext(recorder)
-
-		router.PUT("/:id", webH.UpdatePatientVaccineHistory)
-		router.ServeHTTP(recorder, req)
-
-		assert.Equal(t, 500, recorder.Code)
-	})
-	t.Run("updateInternalError", func(t *testing.T) {
-		input := model.UpdateVaccineHistoryRequest{
-			Data: []model.VaccineHistory{{Id: "test", VaccineName: "hello", VaccineAt: 123}},
-		}
-		rawInput, err := json.Marshal(&input)
-		assert.NoError(t, err)
-		// setup mock
-		repo := repository.NewMockRepo(t)
-		webH := web.WebHandler{Repo: repo}
-
-		repo.EXPECT().GetPatientById(1).Return(model.Patient{}, nil).Once()
-		repo.EXPECT().UpdatePatientVaccineHistory(1, input.Data).Return(errors.New("some internal error")).Once()
-
-		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(rawInput))
-		recorder := httptest.NewRecorder()
-		_, router := gin.CreateTestContext(recorder)
-
-		router.PUT("/:id", webH.UpdatePatientVaccineHistory)
-		router.ServeHTTP(recorder, req)
-
-		assert.Equal(t, 500, recorder.Code)
-	})
-	t.Run("success", func(t *testing.T) {
-		input := model.UpdateVaccineHistoryRequest{
-			Data: []model.VaccineHistory{{Id: "test", VaccineName: "hello", VaccineAt: 123}},
-		}
-		rawInput, err := json.Marshal(&input)
-		assert.NoError(t, err)
+	t.Run("gone", func(t *testing.T) {
 		// setup mock
 		repo := repository.NewMockRepo(t)
 		webH := web.WebHandler{Repo: repo}
 
-		repo.EXPECT().GetPatientById(1).Return(model.Patient{}, nil).Once()
-		repo.EXPECT().UpdatePatientVaccineHistory(1, input.Data).Return(nil).Once()
-
-		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(rawInput))
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader([]byte(`{"data":[{"id":"1","vaccineName":"hello","vaccineAt":1700000000}]}`)))
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
 		router.PUT("/:id", webH.UpdatePatientVaccineHistory)
 		router.ServeHTTP(recorder, req)
 
-		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, 410, recorder.Code)
 	})
 }
-func TestUpdatePatientMedicine(t *testing.T) {
-	t.Run("bindingError", func(t *testing.T) {
-		input := model.UpdateMedicineRequest{
-			Data: []model.Medicine{{Id: "", MedicineName: "hello"}}, // error require
-		}
-		rawInput, err := json.Marshal(&input)
-		assert.NoError(t, err)
-		// setup mock
-		webH := web.WebHandler{}
-
-		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(rawInput))
-		recorder := httptest.NewRecorder()
-		_, router := gin.CreateTestContext(recorder)
-
-		router.PUT("/:id", webH.UpdatePatientMedicine)
-		router.ServeHTTP(recorder, req)
-
-		assert.Equal(t, 400, recorder.Code)
-	})
-	t.Run("atoiError", func(t *testing.T) {
-		input := model.UpdateMedicineRequest{
-			Data: []model.Medicine{{Id: "test", MedicineName: "hello"}}, // error require
-		}
-		rawInput, err := json.Marshal(&input)
-		assert.NoError(t, err)
-		// setup mock
-		webH := web.WebHandler{}
-
-		req := httptest.NewRequest(http.MethodPut, "/asd", bytes.NewReader(rawInput))
-		recorder := httptest.NewRecorder()
-		_, router := gin.CreateTestContext(recorder)
 
-		router.PUT("/:id", webH.UpdatePatientMedicine)
-		router.ServeHTTP(recorder, req)
-
-		assert.Equal(t, 400, recorder.Code)
-	})
-	t.Run("notFound", func(t *testing.T) {
-		input := model.UpdateMedicineRequest{
-			Data: []model.Medicine{{Id: "test", MedicineName: "hello"}}, // error require
-		}
-		rawInput, err := json.Marshal(&input)
-		assert.NoError(t, err)
+func TestUpdatePatientMedicine(t *testing.T) {
+	t.Run("gone", func(t *testing.T) {
 		// setup mock
 		repo := repository.NewMockRepo(t)
 		webH := web.WebHandler{Repo: repo}
 
-		mockErr := fmt.Errorf("wrap : %w", gorm.ErrRecordNotFound)
-		repo.EXPECT().GetPatientById(1).Return(model.Patient{}, mockErr).Once()
-
-		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(rawInput))
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader([]byte(`{"data":[{"id":"1","medicineName":"hello"}]}`)))
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
 		router.PUT("/:id", webH.UpdatePatientMedicine)
 		router.ServeHTTP(recorder, req)
 
-		assert.Equal(t, 404, recorder.Code)
+		assert.Equal(t, 410, recorder.Code)
 	})
-	t.Run("getPatientInternalError", func(t *testing.T) {
-		input := model.UpdateMedicineRequest{
-			Data: []model.Medicine{{Id: "test", MedicineName: "hello"}}, // error require
-		}
-		rawInput, err := json.Marshal(&input)
-		assert.NoError(t, err)
-		// setup mock
-		repo := repository.NewMockRepo(t)
-		webH := web.WebHandler{Repo: repo}
-
-		mockErr := fmt.Errorf("wrap : %w", errors.New("some internal error"))
-		repo.EXPECT().GetPatientById(1).Return(model.Patient{}, mockErr).Once()
-
-		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(rawInput))
-		recorder := httptest.NewRecorder()
-		_, router := gin.CreateTestContext(recorder)
-
-		router.PUT("/:id", webH.UpdatePatientMedicine)
-		router.ServeHTTP(recorder, req)
+}
 
-		assert.Equal(t, 500, recorder.Code)
-	})
-	t.Run("updateInternalError", func(t *testing.T) {
-		input := model.UpdateMedicineRequest{
-			Data: []model.Medicine{{Id: "test", MedicineName: "hello"}}, // error require
-		}
-		rawInput, err := json.Marshal(&input)
-		assert.NoError(t, err)
-		// setup mock
+func TestGetPatientDeviceRemoval(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("badId", func(t *testing.T) {
 		repo := repository.NewMockRepo(t)
 		webH := web.WebHandler{Repo: repo}
 
-		repo.EXPECT().GetPatientById(1).Return(model.Patient{}, nil).Once()
-		repo.EXPECT().UpdatePatientMedicine(1, input.Data).Return(errors.New("some internal error")).Once()
-
-		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(rawInput))
+		req := httptest.NewRequest(http.MethodGet, "/abc", nil)
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.PUT("/:id", webH.UpdatePatientMedicine)
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.GetPatientDeviceRemoval)
 		router.ServeHTTP(recorder, req)
 
-		assert.Equal(t, 500, recorder.Code)
+		assert.Equal(t, 400, recorder.Code)
 	})
 	t.Run("success", func(t *testing.T) {
-		input := model.UpdateMedicineRequest{
-			Data: []model.Medicine{{Id: "test", MedicineName: "hello"}}, // error require
-		}
-		rawInput, err := json.Marshal(&input)
-		assert.NoError(t, err)
-		// setup mock
+		detail := "not a valid push token"
+		removals := []model.DeviceRemoval{{ID: 1, PatientID: 1, DeviceID: 2, DeviceName: "phone", ExpoToken: "ExponentPushToken[a]", Reason: "DeviceNotRegistered", Detail: &detail}}
 		repo := repository.NewMockRepo(t)
 		webH := web.WebHandler{Repo: repo}
 
-		repo.EXPECT().GetPatientById(1).Return(model.Patient{}, nil).Once()
-		repo.EXPECT().UpdatePatientMedicine(1, input.Data).Return(nil).Once()
+		repo.EXPECT().GetAllDeviceRemoval([]repository.Criteria{{QueryCriteria: repository.PATIENTID, Value: 1}}).Return(removals, nil)
 
-		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(rawInput))
+		req := httptest.NewRequest(http.MethodGet, "/1", nil)
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.PUT("/:id", webH.UpdatePatientMedicine)
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.GetPatientDeviceRemoval)
 		router.ServeHTTP(recorder, req)
 
+		expectRespBody, err := json.Marshal(removals)
+		assert.NoError(t, err)
+
 		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, expectRespBody, recorder.Body.Bytes())
 	})
 }
@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/PhasitWo/duchenne-server/auth"
+	"github.com/PhasitWo/duchenne-server/config"
 	"github.com/PhasitWo/duchenne-server/handlers/web"
 	"github.com/PhasitWo/duchenne-server/model"
 	"github.com/PhasitWo/duchenne-server/repository"
@@ -282,3 +285,45 @@ func TestUpdateProfile(t *testing.T) {
 		assert.Equal(t, 200, recorder.Code)
 	})
 }
+
+func TestRotateCalendarFeed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.AppConfig.CALENDAR_FEED_KEY = "TEST_KEY"
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().RotateCalendarFeed("doctor", 3).Return(2, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.RotateCalendarFeed)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		var res struct{ Url string }
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+		token := strings.TrimSuffix(strings.TrimPrefix(res.Url, "/calendar/doctor/"), ".ics")
+		ownerId, version, err := auth.ParseCalendarFeedToken(token, auth.CALENDAR_DOCTOR)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, ownerId)
+		assert.Equal(t, 2, version)
+	})
+	t.Run("internalError", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().RotateCalendarFeed("doctor", 3).Return(0, errors.New("err")).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.RotateCalendarFeed)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 500, recorder.Code)
+	})
+}
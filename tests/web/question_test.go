@@ -8,7 +8,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/PhasitWo/duchenne-server/config"
 	"github.com/PhasitWo/duchenne-server/handlers/web"
 	"github.com/PhasitWo/duchenne-server/model"
 	"github.com/PhasitWo/duchenne-server/services/notification"
@@ -65,11 +67,11 @@ func TestGetAllQuestion(t *testing.T) {
 
 		repo.EXPECT().GetAllQuestion(9999, 0).Return([]model.QuestionTopic{}, errors.New("some internal error"))
 
-		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req := httptest.NewRequest(http.MethodGet, "/?scope=all", nil)
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.GET("/", webH.GetAllQuestion)
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.GetAllQuestion)
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 500, recorder.Code)
@@ -79,13 +81,15 @@ func TestGetAllQuestion(t *testing.T) {
 		repo := repository.NewMockRepo(t)
 		webH := web.WebHandler{Repo: repo}
 
-		repo.EXPECT().GetAllQuestion(9999, 0).Return([]model.QuestionTopic{}, nil)
+		repo.EXPECT().GetAllQuestion(9999, 0, []repository.Criteria{
+			{QueryCriteria: repository.MY_PATIENTID, Value: 3},
+		}).Return([]model.QuestionTopic{}, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.GET("/", webH.GetAllQuestion)
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.GetAllQuestion)
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 200, recorder.Code)
@@ -138,7 +142,7 @@ func TestGetOneQuestion(t *testing.T) {
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.GET("/:id", webH.GetQuestion)
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.GetQuestion)
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 200, recorder.Code)
@@ -154,7 +158,7 @@ func TestAnswerQuestion(t *testing.T) {
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 1) }, webH.AnswerQuestion)
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 1); ctx.Set("doctorRole", model.ADMIN) }, webH.AnswerQuestion)
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 400, recorder.Code)
@@ -171,7 +175,7 @@ func TestAnswerQuestion(t *testing.T) {
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.POST("/:id", webH.AnswerQuestion)
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.AnswerQuestion)
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 404, recorder.Code)
@@ -188,7 +192,7 @@ func TestAnswerQuestion(t *testing.T) {
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.POST("/:id", webH.AnswerQuestion)
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.AnswerQuestion)
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 500, recorder.Code)
@@ -208,7 +212,7 @@ func TestAnswerQuestion(t *testing.T) {
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.POST("/:id", webH.AnswerQuestion)
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.AnswerQuestion)
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 409, recorder.Code)
@@ -232,7 +236,7 @@ func TestAnswerQuestion(t *testing.T) {
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.POST("/:id", webH.AnswerQuestion)
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.AnswerQuestion)
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 400, recorder.Code)
@@ -256,7 +260,7 @@ func TestAnswerQuestion(t *testing.T) {
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.POST("/:id", webH.AnswerQuestion)
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.AnswerQuestion)
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 500, recorder.Code)
@@ -281,7 +285,7 @@ func TestAnswerQuestion(t *testing.T) {
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 1) }, webH.AnswerQuestion)
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 1); ctx.Set("doctorRole", model.ADMIN) }, webH.AnswerQuestion)
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 500, recorder.Code)
@@ -302,13 +306,313 @@ func TestAnswerQuestion(t *testing.T) {
 
 		repo.EXPECT().GetQuestion("1").Return(question, nil).Once()
 		repo.EXPECT().UpdateQuestionAnswer(question.ID, input.Answer, 1).Return(nil).Once()
-		noti.EXPECT().SendNotiByPatientId(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		noti.EXPECT().SendTemplateByPatientId(question.PatientID, model.TEMPLATE_QUESTION_ANSWERED, model.TemplateParams(nil), model.QuestionLink(question.ID)).Return(nil).Maybe()
 
 		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewReader(rawInput))
 		recorder := httptest.NewRecorder()
 		_, router := gin.CreateTestContext(recorder)
 
-		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 1) }, webH.AnswerQuestion)
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 1); ctx.Set("doctorRole", model.ADMIN) }, webH.AnswerQuestion)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestCreateQuestionMessage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("notFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion(15).Return(model.SafeQuestion{}, fmt.Errorf("exec : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodPost, "/15", bytes.NewReader([]byte(`{"message":"please send a photo"}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3); ctx.Set("doctorRole", model.ADMIN) }, webH.CreateQuestionMessage)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("closed", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion(15).Return(model.SafeQuestion{Question: model.Question{ID: 15, PatientID: 1}}, nil)
+		repo.EXPECT().CreateQuestionMessage(mock.Anything).Return(-1, fmt.Errorf("exec : %w", repository.ErrQuestionClosed))
+
+		req := httptest.NewRequest(http.MethodPost, "/15", bytes.NewReader([]byte(`{"message":"please send a photo"}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3); ctx.Set("doctorRole", model.ADMIN) }, webH.CreateQuestionMessage)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		noti := notification.NewMockService(t)
+		webH := web.WebHandler{Repo: repo, NotiService: noti}
+
+		repo.EXPECT().GetQuestion(15).Return(model.SafeQuestion{Question: model.Question{ID: 15, PatientID: 1}}, nil)
+		repo.EXPECT().CreateQuestionMessage(model.QuestionMessage{
+			QuestionID: 15,
+			AuthorType: model.ACTOR_DOCTOR,
+			AuthorID:   3,
+			Message:    "please send a photo",
+		}).Return(8, nil).Once()
+		noti.EXPECT().SendTemplateByPatientId(1, model.TEMPLATE_QUESTION_MESSAGE, model.TemplateParams(nil), model.QuestionLink(15)).Return(nil).Maybe() // go routine
+
+		req := httptest.NewRequest(http.MethodPost, "/15", bytes.NewReader([]byte(`{"message":"please send a photo"}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3); ctx.Set("doctorRole", model.ADMIN) }, webH.CreateQuestionMessage)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+		assert.JSONEq(t, `{"id":8}`, recorder.Body.String())
+	})
+}
+
+func TestReadQuestion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := repository.NewMockRepo(t)
+	webH := web.WebHandler{Repo: repo}
+
+	repo.EXPECT().GetQuestion(15).Return(model.SafeQuestion{Question: model.Question{ID: 15}}, nil)
+	repo.EXPECT().ReadQuestion(15, model.ACTOR_DOCTOR).Return(nil).Once()
+
+	req := httptest.NewRequest(http.MethodPut, "/15", nil)
+	recorder := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(recorder)
+
+	router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.ReadQuestion)
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, 200, recorder.Code)
+}
+
+func TestCloseQuestion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("notFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion(15).Return(model.SafeQuestion{}, fmt.Errorf("exec : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodPut, "/15", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.CloseQuestion)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("reopen", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion(15).Return(model.SafeQuestion{Question: model.Question{ID: 15}}, nil)
+		repo.EXPECT().SetQuestionClosed(15, false).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/15", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.ReopenQuestion)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestQuestionTriageFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.AppConfig.QUESTION_SLA_HOURS = 24
+	t.Run("invalidCategory", func(t *testing.T) {
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/?category=dental", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", webH.GetAllQuestion)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("myUrgent", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAllQuestion(100, 0, []repository.Criteria{
+			{QueryCriteria: repository.PRIORITY, Value: "urgent"},
+			{QueryCriteria: repository.ASSIGNEEID, Value: 3},
+			{QueryCriteria: repository.MY_PATIENTID, Value: 3},
+		}).Return([]model.QuestionTopic{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/?priority=urgent&assigneeId=me", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.GetAllQuestion)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+	t.Run("slaBreached", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		now := int(time.Now().Unix())
+		late := now - 25*60*60
+		recent := now - 60*60
+		repo.EXPECT().GetAllQuestion(100, 0, mock.Anything).Return([]model.QuestionTopic{
+			{ID: 1, AwaitingSince: &late},
+			{ID: 2, AwaitingSince: &recent},
+			{ID: 3},
+		}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/?overdue=true&scope=all", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("doctorRole", model.ADMIN) }, webH.GetAllQuestion)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		var res []model.QuestionTopic
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+		assert.True(t, res[0].SLABreached)
+		assert.False(t, res[1].SLABreached)
+		assert.False(t, res[2].SLABreached)
+	})
+}
+
+func TestGetQuestionQueue(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := repository.NewMockRepo(t)
+	webH := web.WebHandler{Repo: repo}
+
+	repo.EXPECT().GetQuestionQueue(3, 100, 0).Return([]model.QuestionTopic{}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	recorder := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(recorder)
+
+	router.GET("/", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.GetQuestionQueue)
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, 200, recorder.Code)
+}
+
+func TestAnswerAssignedQuestion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := repository.NewMockRepo(t)
+	webH := web.WebHandler{Repo: repo}
+
+	assigneeId := 4
+	repo.EXPECT().GetQuestion("15").Return(model.SafeQuestion{Question: model.Question{ID: 15, AssigneeID: &assigneeId}}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/15", bytes.NewReader([]byte(`{"answer":"rest well"}`)))
+	recorder := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(recorder)
+
+	router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3); ctx.Set("doctorRole", model.ADMIN) }, webH.AnswerQuestion)
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, 403, recorder.Code)
+}
+
+func TestAssignQuestion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("assigneeNotFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion(15).Return(model.SafeQuestion{Question: model.Question{ID: 15}}, nil)
+		repo.EXPECT().GetDoctorById(4).Return(model.Doctor{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodPut, "/15", bytes.NewReader([]byte(`{"assigneeId":4}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", webH.AssignQuestion)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("unassign", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion(15).Return(model.SafeQuestion{Question: model.Question{ID: 15}}, nil)
+		repo.EXPECT().AssignQuestion(15, (*int)(nil)).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/15", bytes.NewReader([]byte(`{"assigneeId":null}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", webH.AssignQuestion)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestTriageQuestion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := repository.NewMockRepo(t)
+	webH := web.WebHandler{Repo: repo}
+
+	repo.EXPECT().GetQuestion(15).Return(model.SafeQuestion{Question: model.Question{ID: 15}}, nil)
+	repo.EXPECT().UpdateQuestionTriage(15, model.QUESTION_RESPIRATORY, model.PRIORITY_URGENT).Return(nil).Once()
+
+	req := httptest.NewRequest(http.MethodPut, "/15", bytes.NewReader([]byte(`{"category":"respiratory","priority":"urgent"}`)))
+	recorder := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(recorder)
+
+	router.PUT("/:id", webH.TriageQuestion)
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, 200, recorder.Code)
+}
+
+func TestClaimQuestion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("takenByOther", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion(15).Return(model.SafeQuestion{Question: model.Question{ID: 15}}, nil)
+		repo.EXPECT().ClaimQuestion(15, 3).Return(fmt.Errorf("exec : %w", repository.ErrQuestionAssigned))
+
+		req := httptest.NewRequest(http.MethodPut, "/15", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.ClaimQuestion)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("release", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetQuestion(15).Return(model.SafeQuestion{Question: model.Question{ID: 15}}, nil)
+		repo.EXPECT().ReleaseQuestion(15, 3).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/15", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.ReleaseQuestion)
 		router.ServeHTTP(recorder, req)
 
 		assert.Equal(t, 200, recorder.Code)
@@ -0,0 +1,221 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PhasitWo/duchenne-server/config"
+	"github.com/PhasitWo/duchenne-server/handlers/web"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/notification"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllReminderRule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("internalError", func(t *testing.T) {
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAllReminderRule().Return(nil, errors.New("err"))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", webH.GetAllReminderRule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 500, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		rules := []model.ReminderRule{{ID: 1, Name: "1 day", OffsetMinutes: 1440, Enabled: true}}
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAllReminderRule().Return(rules, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", webH.GetAllReminderRule)
+		router.ServeHTTP(recorder, req)
+
+		expectRespBody, err := json.Marshal(rules)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, expectRespBody, recorder.Body.Bytes())
+	})
+}
+
+func TestCreateReminderRule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("bindingError", func(t *testing.T) {
+		input, err := json.Marshal(model.ReminderRuleRequest{Name: "too close", OffsetMinutes: 1})
+		assert.NoError(t, err)
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", webH.CreateReminderRule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		input, err := json.Marshal(model.ReminderRuleRequest{Name: "2 hours", OffsetMinutes: 120, Enabled: true})
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().CreateReminderRule(model.ReminderRule{Name: "2 hours", OffsetMinutes: 120, Enabled: true}).Return(-1, fmt.Errorf("exec : %w", repository.ErrDuplicateEntry))
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", webH.CreateReminderRule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		input, err := json.Marshal(model.ReminderRuleRequest{Name: "2 hours", OffsetMinutes: 120, Enabled: true})
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().CreateReminderRule(model.ReminderRule{Name: "2 hours", OffsetMinutes: 120, Enabled: true}).Return(4, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", webH.CreateReminderRule)
+		router.ServeHTTP(recorder, req)
+
+		expectRespBody, err := json.Marshal(gin.H{"id": 4})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 201, recorder.Code)
+		assert.Equal(t, expectRespBody, recorder.Body.Bytes())
+	})
+}
+
+func TestUpdateReminderRule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("atoiError", func(t *testing.T) {
+		input, err := json.Marshal(model.ReminderRuleRequest{Name: "1 day", OffsetMinutes: 1440})
+		assert.NoError(t, err)
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodPut, "/abc", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", webH.UpdateReminderRule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		input, err := json.Marshal(model.ReminderRuleRequest{Name: "1 day", OffsetMinutes: 1440, Enabled: false})
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().UpdateReminderRule(model.ReminderRule{ID: 2, Name: "1 day", OffsetMinutes: 1440, Enabled: false}).Return(nil)
+
+		req := httptest.NewRequest(http.MethodPut, "/2", bytes.NewReader(input))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", webH.UpdateReminderRule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestDeleteReminderRule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("success", func(t *testing.T) {
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().DeleteReminderRule("2").Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/2", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.DELETE("/:id", webH.DeleteReminderRule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 204, recorder.Code)
+	})
+}
+
+func TestSendReminders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.AppConfig.NOTIFY_SECRET = "TEST_SECRET"
+	t.Run("invalidSecret", func(t *testing.T) {
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodPost, "/?secret=wrong", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", webH.SendReminders)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 401, recorder.Code)
+	})
+	t.Run("internalError", func(t *testing.T) {
+		noti := notification.NewMockService(t)
+		webH := web.WebHandler{NotiService: noti}
+
+		noti.EXPECT().SendReminders().Return(errors.New("err"))
+
+		req := httptest.NewRequest(http.MethodPost, "/?secret=TEST_SECRET", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", webH.SendReminders)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 500, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		noti := notification.NewMockService(t)
+		webH := web.WebHandler{NotiService: noti}
+
+		noti.EXPECT().SendReminders().Return(nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/?secret=TEST_SECRET", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", webH.SendReminders)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
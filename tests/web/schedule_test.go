@@ -0,0 +1,198 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PhasitWo/duchenne-server/handlers/web"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestGetDoctorSchedule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("atoiError", func(t *testing.T) {
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/abc", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", webH.GetDoctorSchedule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("internalError", func(t *testing.T) {
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetDoctorSchedule(1).Return([]model.DoctorSchedule{}, errors.New("err"))
+
+		req := httptest.NewRequest(http.MethodGet, "/1", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", webH.GetDoctorSchedule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 500, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		schedules := []model.DoctorSchedule{{ID: 1, DoctorID: 1, Weekday: 1, StartMinute: 540, EndMinute: 720, SlotMinutes: 30}}
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetDoctorSchedule(1).Return(schedules, nil)
+		repo.EXPECT().GetAllScheduleException(mock.Anything, mock.Anything).Return([]model.ScheduleException{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/1", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", webH.GetDoctorSchedule)
+		router.ServeHTTP(recorder, req)
+
+		expectRespBody, err := json.Marshal(model.DoctorScheduleResponse{Schedules: schedules, Exceptions: []model.ScheduleException{}})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, expectRespBody, recorder.Body.Bytes())
+	})
+}
+
+func TestUpdateDoctorSchedule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	validInput := model.UpdateDoctorScheduleRequest{
+		Data: []model.DoctorScheduleInput{{Weekday: 1, StartMinute: 540, EndMinute: 720, SlotMinutes: 30}},
+	}
+	t.Run("bindingError", func(t *testing.T) {
+		input := model.UpdateDoctorScheduleRequest{
+			Data: []model.DoctorScheduleInput{{Weekday: 1, StartMinute: 720, EndMinute: 540, SlotMinutes: 30}}, // end before start
+		}
+		rawInput, err := json.Marshal(&input)
+		assert.NoError(t, err)
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(rawInput))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", webH.UpdateDoctorSchedule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("notFound", func(t *testing.T) {
+		rawInput, err := json.Marshal(&validInput)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		mockErr := fmt.Errorf("wrap : %w", gorm.ErrRecordNotFound)
+		repo.EXPECT().GetDoctorById(1).Return(model.Doctor{}, mockErr)
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(rawInput))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", webH.UpdateDoctorSchedule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		rawInput, err := json.Marshal(&validInput)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetDoctorById(1).Return(model.Doctor{ID: 1}, nil)
+		repo.EXPECT().ReplaceDoctorSchedule(1, []model.DoctorSchedule{{DoctorID: 1, Weekday: 1, StartMinute: 540, EndMinute: 720, SlotMinutes: 30}}).Return(nil)
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader(rawInput))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", webH.UpdateDoctorSchedule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestCreateScheduleException(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("bindingError", func(t *testing.T) {
+		input := model.CreateScheduleExceptionRequest{StartAt: 200, EndAt: 100}
+		rawInput, err := json.Marshal(&input)
+		assert.NoError(t, err)
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawInput))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", webH.CreateScheduleException)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("invalidDoctorId", func(t *testing.T) {
+		doctorId := 5
+		input := model.CreateScheduleExceptionRequest{DoctorId: &doctorId, StartAt: 100, EndAt: 200}
+		rawInput, err := json.Marshal(&input)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		mockErr := fmt.Errorf("wrap : %w", gorm.ErrRecordNotFound)
+		repo.EXPECT().GetDoctorById(5).Return(model.Doctor{}, mockErr)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawInput))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", webH.CreateScheduleException)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("clinicHoliday", func(t *testing.T) {
+		input := model.CreateScheduleExceptionRequest{StartAt: 100, EndAt: 200}
+		rawInput, err := json.Marshal(&input)
+		assert.NoError(t, err)
+		// setup mock
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().CreateScheduleException(model.ScheduleException{StartAt: 100, EndAt: 200}).Return(3, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rawInput))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", webH.CreateScheduleException)
+		router.ServeHTTP(recorder, req)
+
+		expectRespBody, err := json.Marshal(gin.H{"id": 3})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 201, recorder.Code)
+		assert.Equal(t, expectRespBody, recorder.Body.Bytes())
+	})
+}
@@ -0,0 +1,190 @@
+package web_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PhasitWo/duchenne-server/handlers/web"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/notification"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestGetAllSignup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("invalidStatus", func(t *testing.T) {
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/?status=unknown", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", webH.GetAllSignup)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("pendingByDefault", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAllPatient(mock.Anything, mock.Anything, []repository.Criteria{{QueryCriteria: repository.VERIFICATION, Value: model.SIGNUP_PENDING}}).
+			Return([]model.Patient{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", webH.GetAllSignup)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestApproveSignup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setDoctor := func(ctx *gin.Context) { ctx.Set("doctorId", 9) }
+	body := `{"hn":"HN001"}`
+	t.Run("bindingError", func(t *testing.T) {
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader([]byte(`{}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", setDoctor, webH.ApproveSignup)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("notFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById("1").Return(model.Patient{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound)).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader([]byte(body)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", setDoctor, webH.ApproveSignup)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("alreadyReviewed", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById("1").Return(model.Patient{ID: 1, VerificationStatus: model.SIGNUP_REJECTED}, nil).Once()
+		repo.EXPECT().ApproveSignup(1, "HN001", 9).Return(fmt.Errorf("exec : %w", repository.ErrInvalidStatusTransition)).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader([]byte(body)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", setDoctor, webH.ApproveSignup)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("duplicateHn", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById("1").Return(model.Patient{ID: 1, VerificationStatus: model.SIGNUP_PENDING}, nil).Once()
+		repo.EXPECT().ApproveSignup(1, "HN001", 9).Return(fmt.Errorf("exec : %w", repository.ErrDuplicateEntry)).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader([]byte(body)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", setDoctor, webH.ApproveSignup)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		noti := notification.NewMockService(t)
+		webH := web.WebHandler{Repo: repo, NotiService: noti}
+
+		repo.EXPECT().GetPatientById("1").Return(model.Patient{ID: 1, FirstName: "Somchai", VerificationStatus: model.SIGNUP_PENDING}, nil).Once()
+		repo.EXPECT().ApproveSignup(1, "HN001", 9).Return(nil).Once()
+		noti.EXPECT().SendTemplateByPatientId(1, model.TEMPLATE_SIGNUP_APPROVED, mock.Anything, mock.Anything).Return(nil).Maybe() // go routine
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader([]byte(body)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", setDoctor, webH.ApproveSignup)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestRejectSignup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setDoctor := func(ctx *gin.Context) { ctx.Set("doctorId", 9) }
+	t.Run("noReason", func(t *testing.T) {
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader([]byte(`{}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", setDoctor, webH.RejectSignup)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		noti := notification.NewMockService(t)
+		webH := web.WebHandler{Repo: repo, NotiService: noti}
+
+		repo.EXPECT().GetPatientById("1").Return(model.Patient{ID: 1, VerificationStatus: model.SIGNUP_PENDING}, nil).Once()
+		repo.EXPECT().RejectSignup(1, "HN not found", 9).Return(nil).Once()
+		noti.EXPECT().SendTemplateByPatientId(1, model.TEMPLATE_SIGNUP_REJECTED, mock.Anything, mock.Anything).Return(nil).Maybe() // go routine
+
+		req := httptest.NewRequest(http.MethodPut, "/1", bytes.NewReader([]byte(`{"reason":"HN not found"}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", setDoctor, webH.RejectSignup)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestCreateInvitationCode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("retryOnCollision", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().CreateInvitationCode(mock.Anything).Return(-1, fmt.Errorf("exec : %w", repository.ErrDuplicateEntry)).Once()
+		repo.EXPECT().CreateInvitationCode(mock.MatchedBy(func(i model.InvitationCode) bool {
+			return len(i.Code) == 8 && i.CreatedByID == 9 && i.Hn != nil && *i.Hn == "HN001"
+		})).Return(4, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"hn":"HN001"}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("doctorId", 9) }, webH.CreateInvitationCode)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+	})
+}
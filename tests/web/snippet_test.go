@@ -0,0 +1,203 @@
+package web_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PhasitWo/duchenne-server/handlers/web"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/notification"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestGetAllAnswerSnippet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("invalidScope", func(t *testing.T) {
+		webH := web.WebHandler{}
+
+		req := httptest.NewRequest(http.MethodGet, "/?scope=everyone", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.GetAllAnswerSnippet)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+	})
+	t.Run("searchMine", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAllAnswerSnippet(100, 0, "steroid", []repository.Criteria{
+			{QueryCriteria: repository.SNIPPET_VISIBLE, Value: 3},
+			{QueryCriteria: repository.OWNERID, Value: 3},
+		}).Return([]model.AnswerSnippet{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/?scope=mine&search=steroid", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.GetAllAnswerSnippet)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestGetAnswerSnippet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("personalOfOther", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAnswerSnippet("5").Return(model.AnswerSnippet{ID: 5, OwnerID: 4}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/5", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.GetAnswerSnippet)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("sharedOfOther", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAnswerSnippet("5").Return(model.AnswerSnippet{ID: 5, OwnerID: 4, Shared: true}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/5", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.GetAnswerSnippet)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestCreateAnswerSnippet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("contentNotFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetContent(9).Return(model.Content{}, fmt.Errorf("exec : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"title":"moon face","body":"it goes away","contentId":9}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.CreateAnswerSnippet)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		contentId := 9
+		repo.EXPECT().GetContent(9).Return(model.Content{ID: 9}, nil)
+		repo.EXPECT().CreateAnswerSnippet(model.AnswerSnippet{
+			Title:     "moon face",
+			Body:      "it goes away",
+			Shared:    true,
+			OwnerID:   3,
+			ContentID: &contentId,
+		}).Return(5, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"title":"moon face","body":"it goes away","shared":true,"contentId":9}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.CreateAnswerSnippet)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+		assert.JSONEq(t, `{"id":5}`, recorder.Body.String())
+	})
+}
+
+func TestUpdateAnswerSnippet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("notOwner", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAnswerSnippet(5).Return(model.AnswerSnippet{ID: 5, OwnerID: 4, Shared: true}, nil)
+
+		req := httptest.NewRequest(http.MethodPut, "/5", bytes.NewReader([]byte(`{"title":"moon face","body":"it goes away"}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.UpdateAnswerSnippet)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 403, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAnswerSnippet(5).Return(model.AnswerSnippet{ID: 5, OwnerID: 3}, nil)
+		repo.EXPECT().UpdateAnswerSnippet(model.AnswerSnippet{ID: 5, Title: "moon face", Body: "it goes away"}).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/5", bytes.NewReader([]byte(`{"title":"moon face","body":"it goes away"}`)))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.UpdateAnswerSnippet)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestDeleteAnswerSnippet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := repository.NewMockRepo(t)
+	webH := web.WebHandler{Repo: repo}
+
+	repo.EXPECT().GetAnswerSnippet(5).Return(model.AnswerSnippet{ID: 5, OwnerID: 3}, nil)
+	repo.EXPECT().DeleteAnswerSnippet(5).Return(nil).Once()
+
+	req := httptest.NewRequest(http.MethodDelete, "/5", nil)
+	recorder := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(recorder)
+
+	router.DELETE("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3) }, webH.DeleteAnswerSnippet)
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, 204, recorder.Code)
+}
+
+func TestAnswerWithSnippet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := repository.NewMockRepo(t)
+	noti := notification.NewMockService(t)
+	webH := web.WebHandler{Repo: repo, NotiService: noti}
+
+	repo.EXPECT().GetQuestion(15).Return(model.SafeQuestion{Question: model.Question{ID: 15, PatientID: 1}}, nil)
+	repo.EXPECT().CreateQuestionMessage(mock.Anything).Return(8, nil).Once()
+	repo.EXPECT().IncreaseSnippetUsage(5).Return(nil).Once()
+	noti.EXPECT().SendTemplateByPatientId(1, model.TEMPLATE_QUESTION_MESSAGE, model.TemplateParams(nil), model.QuestionLink(15)).Return(nil).Maybe() // go routine
+
+	req := httptest.NewRequest(http.MethodPost, "/15", bytes.NewReader([]byte(`{"message":"it goes away","snippetId":5}`)))
+	recorder := httptest.NewRecorder()
+	_, router := gin.CreateTestContext(recorder)
+
+	router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 3); ctx.Set("doctorRole", model.ADMIN) }, webH.CreateQuestionMessage)
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, 201, recorder.Code)
+}
@@ -0,0 +1,143 @@
+package web_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PhasitWo/duchenne-server/handlers/web"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/PhasitWo/duchenne-server/services/notification"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllNotificationTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("mergeOverride", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAllNotificationTemplate().Return([]model.NotificationTemplate{
+			{Key: model.TEMPLATE_APPOINTMENT_CREATED, Language: model.LANGUAGE_EN, Title: "New appointment", Body: "with {{doctorName}}"},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", webH.GetAllNotificationTemplate)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		var res []model.NotificationTemplateResponse
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+		assert.Len(t, res, len(notification.TemplateDefinitions))
+		assert.Equal(t, model.TEMPLATE_APPOINTMENT_CREATED, res[0].Key)
+		assert.Equal(t, []model.TemplateText{
+			{Language: model.LANGUAGE_TH, Title: "คุณมีนัดหมายใหม่!", Body: "ดูข้อมูลในแอปพลิเคชัน", IsDefault: true},
+			{Language: model.LANGUAGE_EN, Title: "New appointment", Body: "with {{doctorName}}"},
+		}, res[0].Texts)
+	})
+	t.Run("dbError", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetAllNotificationTemplate().Return(nil, errors.New("err"))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.GET("/", webH.GetAllNotificationTemplate)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 500, recorder.Code)
+	})
+}
+
+func TestUpdateNotificationTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name string
+		path string
+		body string
+		code int
+	}{
+		{"unknownKey", "/unknown/th", `{"title":"a","body":"b"}`, 404},
+		{"badLanguage", "/appointment_created/jp", `{"title":"a","body":"b"}`, 400},
+		{"badInput", "/appointment_created/th", `{"title":"a"}`, 400},
+		{"unknownPlaceholder", "/appointment_created/th", `{"title":"a","body":"{{reason}}"}`, 422},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := repository.NewMockRepo(t)
+			webH := web.WebHandler{Repo: repo}
+
+			req := httptest.NewRequest(http.MethodPut, tc.path, strings.NewReader(tc.body))
+			recorder := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(recorder)
+
+			router.PUT("/:key/:language", webH.UpdateNotificationTemplate)
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.code, recorder.Code)
+		})
+	}
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().UpsertNotificationTemplate(model.NotificationTemplate{
+			Key:      model.TEMPLATE_APPOINTMENT_REJECTED,
+			Language: model.LANGUAGE_EN,
+			Title:    "Appointment on {{date}} declined",
+			Body:     "{{reason}}",
+		}).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/appointment_rejected/en", strings.NewReader(`{"title":"Appointment on {{date}} declined","body":"{{reason}}"}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.PUT("/:key/:language", webH.UpdateNotificationTemplate)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
+
+func TestResetNotificationTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("unknownKey", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		req := httptest.NewRequest(http.MethodDelete, "/unknown/th", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.DELETE("/:key/:language", webH.ResetNotificationTemplate)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().DeleteNotificationTemplate(model.TEMPLATE_QUESTION_ANSWERED, model.LANGUAGE_TH).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/question_answered/th", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.DELETE("/:key/:language", webH.ResetNotificationTemplate)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 204, recorder.Code)
+	})
+}
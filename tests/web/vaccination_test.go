@@ -0,0 +1,226 @@
+package web_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PhasitWo/duchenne-server/handlers/web"
+	"github.com/PhasitWo/duchenne-server/model"
+	"github.com/PhasitWo/duchenne-server/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestDeleteVaccine(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("usedByRule", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().DeleteVaccine("1").Return(fmt.Errorf("exec : %w", repository.ErrForeignKeyFail))
+
+		req := httptest.NewRequest(http.MethodDelete, "/1", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.DELETE("/:id", webH.DeleteVaccine)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+}
+
+func TestCreateVaccineRule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("missingInterval", func(t *testing.T) {
+		webH := web.WebHandler{Repo: repository.NewMockRepo(t)}
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"vaccineId":1,"minAgeMonths":6,"doses":0,"enabled":true}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", webH.CreateVaccineRule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("vaccineNotFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().CreateVaccineRule(mock.Anything).Return(-1, fmt.Errorf("exec : %w", repository.ErrForeignKeyFail))
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"vaccineId":9,"minAgeMonths":6,"doses":1,"enabled":true}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", webH.CreateVaccineRule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().CreateVaccineRule(mock.Anything).Return(-1, fmt.Errorf("exec : %w", repository.ErrDuplicateEntry))
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"vaccineId":1,"minAgeMonths":6,"doses":0,"intervalMonths":12,"enabled":true}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", webH.CreateVaccineRule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 409, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().CreateVaccineRule(model.VaccineRule{VaccineID: 1, MinAgeMonths: 6, IntervalMonths: 12, Enabled: true}).Return(2, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"vaccineId":1,"minAgeMonths":6,"doses":0,"intervalMonths":12,"enabled":true}`))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/", webH.CreateVaccineRule)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+		assert.JSONEq(t, `{"id":2}`, recorder.Body.String())
+	})
+}
+
+func TestCreatePatientVaccination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reqBody := `{"vaccineId":1,"vaccinatedAt":1740787200,"location":"Siriraj"}`
+	t.Run("patientNotFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewBufferString(reqBody))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 5) }, webH.CreatePatientVaccination)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("vaccineNotFound", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1}, nil)
+		repo.EXPECT().GetVaccine(1).Return(model.Vaccine{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewBufferString(reqBody))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 5) }, webH.CreatePatientVaccination)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	steroid := []model.MedicationRegimen{{MedicineName: "prednisolone", Dose: 30, DoseUnit: model.DOSE_MG, Schedule: model.SCHEDULE_ONCE_DAILY}}
+	t.Run("liveOnHighDoseSteroid", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1}, nil)
+		repo.EXPECT().GetVaccine(1).Return(model.Vaccine{ID: 1, Name: "MMR", Live: true}, nil)
+		repo.EXPECT().GetAllMedicationRegimen(1).Return(steroid, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewBufferString(reqBody))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 5) }, webH.CreatePatientVaccination)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 422, recorder.Code)
+	})
+	t.Run("liveWithOverride", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1}, nil)
+		repo.EXPECT().GetVaccine(1).Return(model.Vaccine{ID: 1, Name: "MMR", Live: true}, nil)
+		repo.EXPECT().CreateVaccination(mock.Anything).Return(7, nil).Once()
+
+		body := `{"vaccineId":1,"vaccinatedAt":1740787200,"steroidOverride":true}`
+		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewBufferString(body))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 5) }, webH.CreatePatientVaccination)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetPatientById(1).Return(model.Patient{ID: 1}, nil)
+		repo.EXPECT().GetVaccine(1).Return(model.Vaccine{ID: 1, Name: "Influenza"}, nil)
+		repo.EXPECT().CreateVaccination(mock.MatchedBy(func(v model.Vaccination) bool {
+			return v.PatientID == 1 && *v.VaccineID == 1 && v.VaccineName == "Influenza" && v.VaccinatedAt == 1740787200 &&
+				*v.Location == "Siriraj" && *v.DoctorID == 5
+		})).Return(7, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/1", bytes.NewBufferString(reqBody))
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.POST("/:id", func(ctx *gin.Context) { ctx.Set("doctorId", 5) }, webH.CreatePatientVaccination)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+		assert.JSONEq(t, `{"id":7}`, recorder.Body.String())
+	})
+}
+
+func TestDeletePatientVaccination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("otherPatient", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetVaccination("1", "7").Return(model.Vaccination{}, fmt.Errorf("query : %w", gorm.ErrRecordNotFound))
+
+		req := httptest.NewRequest(http.MethodDelete, "/1/7", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.DELETE("/:id/:vaccinationId", webH.DeletePatientVaccination)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		repo := repository.NewMockRepo(t)
+		webH := web.WebHandler{Repo: repo}
+
+		repo.EXPECT().GetVaccination("1", "7").Return(model.Vaccination{ID: 7, PatientID: 1}, nil)
+		repo.EXPECT().DeleteVaccination(7).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/1/7", nil)
+		recorder := httptest.NewRecorder()
+		_, router := gin.CreateTestContext(recorder)
+
+		router.DELETE("/:id/:vaccinationId", webH.DeletePatientVaccination)
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 204, recorder.Code)
+	})
+}